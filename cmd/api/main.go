@@ -4,25 +4,43 @@ import (
 	"log"
 	"strings"
 
+	"github.com/alanloffler/go-calth-api/internal/api_key"
+	"github.com/alanloffler/go-calth-api/internal/audit"
 	"github.com/alanloffler/go-calth-api/internal/auth"
 	blocked_day "github.com/alanloffler/go-calth-api/internal/blocked-day"
 	"github.com/alanloffler/go-calth-api/internal/business"
 	"github.com/alanloffler/go-calth-api/internal/business_role_permission"
+	"github.com/alanloffler/go-calth-api/internal/clinical_record"
+	"github.com/alanloffler/go-calth-api/internal/common/encryption"
+	"github.com/alanloffler/go-calth-api/internal/common/throttle"
 	"github.com/alanloffler/go-calth-api/internal/config"
 	"github.com/alanloffler/go-calth-api/internal/database"
 	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
 	"github.com/alanloffler/go-calth-api/internal/event"
+	"github.com/alanloffler/go-calth-api/internal/fhir"
 	"github.com/alanloffler/go-calth-api/internal/health"
+	"github.com/alanloffler/go-calth-api/internal/invitation"
 	"github.com/alanloffler/go-calth-api/internal/medical_history"
+	"github.com/alanloffler/go-calth-api/internal/medical_history_template"
 	"github.com/alanloffler/go-calth-api/internal/middleware"
+	"github.com/alanloffler/go-calth-api/internal/patient_import"
+	"github.com/alanloffler/go-calth-api/internal/patient_merge"
+	"github.com/alanloffler/go-calth-api/internal/patient_summary"
 	"github.com/alanloffler/go-calth-api/internal/permission"
+	"github.com/alanloffler/go-calth-api/internal/portal"
+	"github.com/alanloffler/go-calth-api/internal/prescription"
 	"github.com/alanloffler/go-calth-api/internal/role"
+	"github.com/alanloffler/go-calth-api/internal/session"
 	"github.com/alanloffler/go-calth-api/internal/setting"
+	"github.com/alanloffler/go-calth-api/internal/storage"
+	"github.com/alanloffler/go-calth-api/internal/two_factor"
 	"github.com/alanloffler/go-calth-api/internal/user"
+	"github.com/alanloffler/go-calth-api/internal/vital_sign"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/hibiken/asynq"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
 )
 
 func main() {
@@ -53,9 +71,30 @@ func main() {
 	redisClient := asynq.NewClient(asynq.RedisClientOpt{Addr: redisArr})
 	defer redisClient.Close()
 
+	rdb := redis.NewClient(&redis.Options{Addr: redisArr})
+	defer rdb.Close()
+
+	var loginGuard *throttle.Guard = throttle.NewGuard(rdb, cfg)
+
+	// File storage
+	var store storage.Storage
+	store, err = storage.New(cfg)
+
+	if err != nil {
+		log.Fatal("Failed to initialize storage:", err)
+	}
+
 	// sqlc queries
 	var queries *sqlc.Queries = sqlc.New(pool)
 
+	// Clinical data encryption
+	var keys *encryption.Keyring
+	keys, err = encryption.NewKeyring(cfg.EncryptionMasterKey, queries)
+
+	if err != nil {
+		log.Fatal("Failed to initialize encryption:", err)
+	}
+
 	// Gin router
 	var router *gin.Engine = gin.Default()
 	router.SetTrustedProxies(nil)
@@ -75,19 +114,33 @@ func main() {
 
 	// Protected routes
 	protected := router.Group("/")
-	protected.Use(middleware.AuthMiddleware(authService))
+	protected.Use(middleware.AuthMiddleware(authService, queries), middleware.ImpersonationAuditMiddleware(queries))
+	api_key.RegisterRoutes(protected, queries)
+	audit.RegisterRoutes(protected, queries)
 	blocked_day.RegisterRoutes(protected, queries)
+	clinical_record.RegisterRoutes(protected, queries, store, keys, redisClient)
 	event.RegisterRoutes(protected, queries, pool, redisClient)
-	medical_history.RegisterRoutes(protected, queries)
+	patient_import.RegisterRoutes(protected, queries, store, keys, redisClient, cfg)
+	patient_merge.RegisterRoutes(protected, queries, pool)
+	patient_summary.RegisterRoutes(protected, queries)
+	vital_sign.RegisterRoutes(protected, queries, pool)
+	fhir.RegisterRoutes(protected, queries, keys)
+	medical_history_template.RegisterRoutes(protected, queries)
+	medical_history.RegisterRoutes(protected, queries, pool, store, keys, cfg)
 	permission.RegisterRoutes(protected, queries)
+	portal.RegisterRoutes(protected, queries, pool, keys, cfg)
+	prescription.RegisterRoutes(protected, queries, pool)
 	business_role_permission.RegisterRoutes(protected, queries)
 	role.RegisterRoutes(protected, queries, pool)
+	session.RegisterRoutes(protected, queries)
 	setting.RegisterRoutes(protected, queries)
-	user.RegisterRoutes(protected, queries, pool)
+	two_factor.RegisterRoutes(protected, queries, pool, keys)
+	user.RegisterRoutes(protected, queries, pool, keys, redisClient, loginGuard, cfg)
 
 	// Mixed routes (public/protected)
-	auth.RegisterRoutes(router, protected, queries, cfg)
-	business.RegisterRoutes(router, protected, queries, pool, redisClient, cfg.AppDomain)
+	auth.RegisterRoutes(router, protected, queries, pool, redisClient, keys, loginGuard, cfg)
+	invitation.RegisterRoutes(router, protected, queries, pool, redisClient, cfg)
+	business.RegisterRoutes(router, protected, queries, pool, redisClient, keys, cfg)
 
 	// Public routes
 	health.RegisterRoutes(router, pool)
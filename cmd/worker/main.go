@@ -6,10 +6,22 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"time"
 
+	"github.com/alanloffler/go-calth-api/internal/business"
+	"github.com/alanloffler/go-calth-api/internal/clinical_record"
+	"github.com/alanloffler/go-calth-api/internal/common/encryption"
+	"github.com/alanloffler/go-calth-api/internal/config"
+	"github.com/alanloffler/go-calth-api/internal/database"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
 	"github.com/alanloffler/go-calth-api/internal/email"
+	"github.com/alanloffler/go-calth-api/internal/patient_import"
 	"github.com/alanloffler/go-calth-api/internal/queue"
+	"github.com/alanloffler/go-calth-api/internal/storage"
+	"github.com/google/uuid"
 	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/joho/godotenv"
 )
 
@@ -33,6 +45,29 @@ func main() {
 
 	emailSvc := email.NewSendGridService(apiKey, fromEmail, fromName)
 
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal("Failed to load configuration:", err)
+	}
+
+	pool, err := database.Connect(cfg.DatabaseURL)
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+	defer pool.Close()
+
+	queries := sqlc.New(pool)
+
+	store, err := storage.New(cfg)
+	if err != nil {
+		log.Fatal("Failed to initialize storage:", err)
+	}
+
+	keys, err := encryption.NewKeyring(cfg.EncryptionMasterKey, queries)
+	if err != nil {
+		log.Fatal("Failed to initialize encryption:", err)
+	}
+
 	srv := asynq.NewServer(
 		asynq.RedisClientOpt{Addr: redisAddr},
 		asynq.Config{Concurrency: 10},
@@ -41,6 +76,30 @@ func main() {
 	mux := asynq.NewServeMux()
 	mux.HandleFunc("email:business_created", handleBusinessCreated(emailSvc))
 	mux.HandleFunc("email:event_created", handleEventCreated(emailSvc))
+	mux.HandleFunc("email:password_reset", handlePasswordReset(emailSvc))
+	mux.HandleFunc("email:invitation", handleInvitation(emailSvc))
+	mux.HandleFunc("email:email_verification", handleEmailVerification(emailSvc))
+	mux.HandleFunc("clinical_record:export", handleClinicalRecordExport(queries, keys, store))
+	mux.HandleFunc("encryption:reencrypt", handleBusinessReencryption(queries, keys))
+	mux.HandleFunc("search:backfill", handleSearchIndexBackfill(queries, keys))
+	mux.HandleFunc("patient_import:process", handlePatientImport(pool, keys, store))
+	mux.HandleFunc("session:cleanup", handleSessionCleanup(queries))
+
+	scheduler := asynq.NewScheduler(asynq.RedisClientOpt{Addr: redisAddr}, nil)
+	if _, err := scheduler.Register("@hourly", asynq.NewTask("session:cleanup", nil)); err != nil {
+		log.Fatal("Failed to schedule session cleanup:", err)
+	}
+	if err := scheduler.Start(); err != nil {
+		log.Fatal("Failed to start scheduler:", err)
+	}
+	defer scheduler.Shutdown()
+
+	queueClient := asynq.NewClient(asynq.RedisClientOpt{Addr: redisAddr})
+	defer queueClient.Close()
+
+	if err := enqueueSearchIndexBackfills(context.Background(), queries, queueClient); err != nil {
+		log.Println("Failed to queue search index backfill:", err)
+	}
 
 	if err := srv.Run(mux); err != nil {
 		log.Fatal(err)
@@ -83,3 +142,155 @@ func handleEventCreated(emailSvc *email.SendGridService) asynq.HandlerFunc {
 		return nil
 	}
 }
+
+func handlePasswordReset(emailSvc *email.SendGridService) asynq.HandlerFunc {
+	return func(ctx context.Context, t *asynq.Task) error {
+		var payload queue.PasswordResetPayload
+		if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+			return fmt.Errorf("unmarshal password_reset payload: %w", err)
+		}
+
+		return emailSvc.SendPasswordReset(payload.Email, payload.CompanyName, payload.FullName, payload.ResetLink, payload.ExpiresIn)
+	}
+}
+
+func handleInvitation(emailSvc *email.SendGridService) asynq.HandlerFunc {
+	return func(ctx context.Context, t *asynq.Task) error {
+		var payload queue.InvitationPayload
+		if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+			return fmt.Errorf("unmarshal invitation payload: %w", err)
+		}
+
+		return emailSvc.SendInvitation(payload.Email, payload.CompanyName, payload.InviterName, payload.RoleName, payload.InviteLink, payload.ExpiresIn)
+	}
+}
+
+func handleEmailVerification(emailSvc *email.SendGridService) asynq.HandlerFunc {
+	return func(ctx context.Context, t *asynq.Task) error {
+		var payload queue.EmailVerificationPayload
+		if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+			return fmt.Errorf("unmarshal email_verification payload: %w", err)
+		}
+
+		return emailSvc.SendEmailVerification(payload.Email, payload.CompanyName, payload.FullName, payload.VerifyLink, payload.ExpiresIn)
+	}
+}
+
+func handleClinicalRecordExport(q *sqlc.Queries, keys *encryption.Keyring, store storage.Storage) asynq.HandlerFunc {
+	return func(ctx context.Context, t *asynq.Task) error {
+		var payload queue.ClinicalRecordExportPayload
+		if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+			return fmt.Errorf("unmarshal clinical_record_export payload: %w", err)
+		}
+
+		var businessID, exportID pgtype.UUID
+		if err := businessID.Scan(payload.BusinessID); err != nil {
+			return fmt.Errorf("invalid business id: %w", err)
+		}
+		if err := exportID.Scan(payload.ExportID); err != nil {
+			return fmt.Errorf("invalid export id: %w", err)
+		}
+
+		return clinical_record.ProcessExport(ctx, q, keys, store, businessID, exportID)
+	}
+}
+
+func handleBusinessReencryption(q *sqlc.Queries, keys *encryption.Keyring) asynq.HandlerFunc {
+	return func(ctx context.Context, t *asynq.Task) error {
+		var payload queue.BusinessReencryptionPayload
+		if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+			return fmt.Errorf("unmarshal business_reencryption payload: %w", err)
+		}
+
+		var businessID pgtype.UUID
+		if err := businessID.Scan(payload.BusinessID); err != nil {
+			return fmt.Errorf("invalid business id: %w", err)
+		}
+
+		return business.ReencryptData(ctx, q, keys, businessID)
+	}
+}
+
+func handleSearchIndexBackfill(q *sqlc.Queries, keys *encryption.Keyring) asynq.HandlerFunc {
+	return func(ctx context.Context, t *asynq.Task) error {
+		var payload queue.SearchIndexBackfillPayload
+		if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+			return fmt.Errorf("unmarshal search_index_backfill payload: %w", err)
+		}
+
+		var businessID pgtype.UUID
+		if err := businessID.Scan(payload.BusinessID); err != nil {
+			return fmt.Errorf("invalid business id: %w", err)
+		}
+
+		return business.BackfillSearchIndex(ctx, q, keys, businessID)
+	}
+}
+
+// enqueueSearchIndexBackfills queues the search index backfill of every business
+// with medical histories that are not indexed yet, so histories written before
+// search existed become searchable after a deploy without waiting for a key
+// rotation.
+func enqueueSearchIndexBackfills(ctx context.Context, q *sqlc.Queries, client *asynq.Client) error {
+	businessIDs, err := q.ListBusinessesWithoutSearchTokens(ctx)
+	if err != nil {
+		return fmt.Errorf("list businesses without search tokens: %w", err)
+	}
+
+	for _, businessID := range businessIDs {
+		if err := queue.EnqueueSearchIndexBackfill(client, queue.SearchIndexBackfillPayload{
+			BusinessID: uuid.UUID(businessID.Bytes).String(),
+		}); err != nil {
+			return err
+		}
+	}
+
+	if len(businessIDs) > 0 {
+		log.Printf("[worker] queued search index backfill for %d businesses", len(businessIDs))
+	}
+	return nil
+}
+
+func handlePatientImport(pool *pgxpool.Pool, keys *encryption.Keyring, store storage.Storage) asynq.HandlerFunc {
+	return func(ctx context.Context, t *asynq.Task) error {
+		var payload queue.PatientImportPayload
+		if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+			return fmt.Errorf("unmarshal patient_import payload: %w", err)
+		}
+
+		var businessID, importID pgtype.UUID
+		if err := businessID.Scan(payload.BusinessID); err != nil {
+			return fmt.Errorf("invalid business id: %w", err)
+		}
+		if err := importID.Scan(payload.ImportID); err != nil {
+			return fmt.Errorf("invalid import id: %w", err)
+		}
+
+		return patient_import.ProcessImport(ctx, pool, keys, store, businessID, importID)
+	}
+}
+
+// loginAttemptRetention is how long failed logins are kept for review.
+const loginAttemptRetention = 90 * 24 * time.Hour
+
+func handleSessionCleanup(q *sqlc.Queries) asynq.HandlerFunc {
+	return func(ctx context.Context, t *asynq.Task) error {
+		deleted, err := q.DeleteExpiredUserSessions(ctx)
+		if err != nil {
+			return fmt.Errorf("delete expired sessions: %w", err)
+		}
+
+		challenges, err := q.DeleteExpiredTwoFactorChallenges(ctx)
+		if err != nil {
+			return fmt.Errorf("delete expired two-factor challenges: %w", err)
+		}
+
+		attempts, err := q.DeleteLoginAttemptsBefore(ctx, pgtype.Timestamptz{Time: time.Now().Add(-loginAttemptRetention), Valid: true})
+		if err != nil {
+			return fmt.Errorf("delete old login attempts: %w", err)
+		}
+
+		log.Printf("[worker] deleted %d expired sessions, %d two-factor challenges and %d login attempts", deleted, challenges, attempts)
+		return nil
+	}
+}
@@ -0,0 +1,214 @@
+package api_key
+
+import (
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/alanloffler/go-calth-api/internal/common/apikey"
+	"github.com/alanloffler/go-calth-api/internal/common/ctxkeys"
+	"github.com/alanloffler/go-calth-api/internal/common/response"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// An API key acts as the user who created it, limited to its scopes. Scopes
+// are checked against the creator's role on every request, so a key loses the
+// permissions its creator loses.
+type APIKeyHandler struct {
+	repo *APIKeyRepository
+}
+
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" binding:"required,min=3,max=100"`
+	Scopes    []string   `json:"scopes" binding:"required,min=1,dive,required"`
+	ExpiresAt *time.Time `json:"expiresAt"`
+}
+
+type APIKeyResponse struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	Prefix     string   `json:"prefix"`
+	Scopes     []string `json:"scopes"`
+	CreatedBy  string   `json:"createdBy"`
+	ExpiresAt  *string  `json:"expiresAt"`
+	LastUsedAt *string  `json:"lastUsedAt"`
+	RevokedAt  *string  `json:"revokedAt"`
+	CreatedAt  string   `json:"createdAt"`
+}
+
+// CreatedAPIKeyResponse is the only response carrying the key itself.
+type CreatedAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
+
+func NewAPIKeyHandler(repo *APIKeyRepository) *APIKeyHandler {
+	return &APIKeyHandler{repo: repo}
+}
+
+func (h *APIKeyHandler) GetAll(c *gin.Context) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	keys, err := h.repo.GetAll(c.Request.Context(), businessID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener las claves de API", err))
+		return
+	}
+
+	result := make([]APIKeyResponse, len(keys))
+	for i, k := range keys {
+		result[i] = toAPIKeyResponse(k)
+	}
+
+	c.JSON(http.StatusOK, response.Success("Claves de API encontradas", &result))
+}
+
+// Create issues a key for the caller. Scopes must be permissions the caller's
+// role holds; the key is shown once and only its hash is kept.
+func (h *APIKeyHandler) Create(c *gin.Context) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	userID, ok := ctxkeys.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	roleID, ok := ctxkeys.RoleID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	var req CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Error de validación de datos", err))
+		return
+	}
+
+	var expiresAt pgtype.Timestamptz
+	if req.ExpiresAt != nil {
+		if !req.ExpiresAt.After(time.Now()) {
+			c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "La fecha de expiración debe ser futura"))
+			return
+		}
+		expiresAt = pgtype.Timestamptz{Time: *req.ExpiresAt, Valid: true}
+	}
+
+	ctx := c.Request.Context()
+
+	permissions, err := h.repo.GetPermissions(ctx, sqlc.ListEffectivePermissionsParams{BusinessID: businessID, RoleID: roleID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al verificar permisos", err))
+		return
+	}
+
+	held := make(map[string]bool, len(permissions))
+	for _, p := range permissions {
+		held[p.ActionKey] = p.IsEffective
+	}
+
+	scopes := normalizeScopes(req.Scopes)
+	for _, scope := range scopes {
+		effective, exists := held[scope]
+		if !exists {
+			c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Permiso desconocido: "+scope))
+			return
+		}
+		if !effective {
+			c.JSON(http.StatusForbidden, response.Error(http.StatusForbidden, "No puede otorgar permisos que no posee: "+scope))
+			return
+		}
+	}
+
+	key, prefix, hash, err := apikey.Generate()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al generar la clave de API", err))
+		return
+	}
+
+	created, err := h.repo.Create(ctx, sqlc.CreateAPIKeyParams{
+		BusinessID: businessID,
+		UserID:     userID,
+		Name:       req.Name,
+		Prefix:     prefix,
+		KeyHash:    hash,
+		Scopes:     scopes,
+		ExpiresAt:  expiresAt,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al crear la clave de API", err))
+		return
+	}
+
+	result := CreatedAPIKeyResponse{APIKeyResponse: toAPIKeyResponse(created), Key: key}
+	c.JSON(http.StatusCreated, response.Success("Clave de API creada, guárdela ahora porque no volverá a mostrarse", &result))
+}
+
+// Revoke disables a key immediately. The row is kept to show when it was
+// last used.
+func (h *APIKeyHandler) Revoke(c *gin.Context) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(c.Param("id")); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID inválido", err))
+		return
+	}
+
+	revoked, err := h.repo.Revoke(c.Request.Context(), sqlc.RevokeAPIKeyParams{ID: id, BusinessID: businessID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al revocar la clave de API", err))
+		return
+	}
+	if revoked == 0 {
+		c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Clave de API no encontrada o ya revocada"))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Success[any]("Clave de API revocada", nil))
+}
+
+// normalizeScopes sorts scopes and drops duplicates.
+func normalizeScopes(scopes []string) []string {
+	result := slices.Clone(scopes)
+	slices.Sort(result)
+	return slices.Compact(result)
+}
+
+func toAPIKeyResponse(k sqlc.ApiKey) APIKeyResponse {
+	return APIKeyResponse{
+		ID:         uuid.UUID(k.ID.Bytes).String(),
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		Scopes:     k.Scopes,
+		CreatedBy:  uuid.UUID(k.UserID.Bytes).String(),
+		ExpiresAt:  optionalTime(k.ExpiresAt),
+		LastUsedAt: optionalTime(k.LastUsedAt),
+		RevokedAt:  optionalTime(k.RevokedAt),
+		CreatedAt:  k.CreatedAt.Time.Format(time.RFC3339),
+	}
+}
+
+func optionalTime(t pgtype.Timestamptz) *string {
+	if !t.Valid {
+		return nil
+	}
+	s := t.Time.Format(time.RFC3339)
+	return &s
+}
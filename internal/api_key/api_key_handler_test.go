@@ -0,0 +1,41 @@
+package api_key
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeScopes(t *testing.T) {
+	scopes := []string{"events-view", "patients-view", "events-view"}
+
+	assert.Equal(t, []string{"events-view", "patients-view"}, normalizeScopes(scopes))
+	assert.Equal(t, []string{"events-view", "patients-view", "events-view"}, scopes)
+}
+
+func TestToAPIKeyResponse(t *testing.T) {
+	at := pgtype.Timestamptz{Time: time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC), Valid: true}
+	k := sqlc.ApiKey{
+		ID:        pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		UserID:    pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		Name:      "Facturación",
+		Prefix:    "calth_abc123",
+		KeyHash:   "secret",
+		Scopes:    []string{"events-view"},
+		ExpiresAt: at,
+		CreatedAt: at,
+	}
+
+	got := toAPIKeyResponse(k)
+	assert.Equal(t, uuid.UUID(k.ID.Bytes).String(), got.ID)
+	assert.Equal(t, uuid.UUID(k.UserID.Bytes).String(), got.CreatedBy)
+	require.NotNil(t, got.ExpiresAt)
+	assert.Equal(t, "2026-03-10T12:00:00Z", *got.ExpiresAt)
+	assert.Nil(t, got.LastUsedAt)
+	assert.Nil(t, got.RevokedAt)
+}
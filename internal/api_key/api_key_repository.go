@@ -0,0 +1,32 @@
+package api_key
+
+import (
+	"context"
+
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type APIKeyRepository struct {
+	q *sqlc.Queries
+}
+
+func NewAPIKeyRepository(q *sqlc.Queries) *APIKeyRepository {
+	return &APIKeyRepository{q: q}
+}
+
+func (r *APIKeyRepository) Create(ctx context.Context, arg sqlc.CreateAPIKeyParams) (sqlc.ApiKey, error) {
+	return r.q.CreateAPIKey(ctx, arg)
+}
+
+func (r *APIKeyRepository) GetAll(ctx context.Context, businessID pgtype.UUID) ([]sqlc.ApiKey, error) {
+	return r.q.ListAPIKeys(ctx, businessID)
+}
+
+func (r *APIKeyRepository) Revoke(ctx context.Context, arg sqlc.RevokeAPIKeyParams) (int64, error) {
+	return r.q.RevokeAPIKey(ctx, arg)
+}
+
+func (r *APIKeyRepository) GetPermissions(ctx context.Context, arg sqlc.ListEffectivePermissionsParams) ([]sqlc.ListEffectivePermissionsRow, error) {
+	return r.q.ListEffectivePermissions(ctx, arg)
+}
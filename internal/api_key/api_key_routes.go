@@ -0,0 +1,17 @@
+package api_key
+
+import (
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/alanloffler/go-calth-api/internal/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(router *gin.RouterGroup, q *sqlc.Queries) {
+	var repo *APIKeyRepository = NewAPIKeyRepository(q)
+	var handler *APIKeyHandler = NewAPIKeyHandler(repo)
+	var apiKeys *gin.RouterGroup = router.Group("/api-keys", middleware.AccountOwnerMiddleware())
+
+	apiKeys.GET("", middleware.PermissionMiddleware(q, "api-keys-view"), handler.GetAll)
+	apiKeys.POST("", middleware.PermissionMiddleware(q, "api-keys-create"), handler.Create)
+	apiKeys.DELETE("/:id", middleware.PermissionMiddleware(q, "api-keys-delete"), handler.Revoke)
+}
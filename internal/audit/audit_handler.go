@@ -0,0 +1,269 @@
+package audit
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/alanloffler/go-calth-api/internal/common/ctxkeys"
+	"github.com/alanloffler/go-calth-api/internal/common/response"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const maxAccessLogLimit = 100
+
+type AuditHandler struct {
+	repo *AuditRepository
+}
+
+func NewAuditHandler(repo *AuditRepository) *AuditHandler {
+	return &AuditHandler{repo: repo}
+}
+
+type PersonResponse struct {
+	ID        string `json:"id"`
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+}
+
+type AccessLogResponse struct {
+	ID             string         `json:"id"`
+	User           PersonResponse `json:"user"`
+	Role           string         `json:"role"`
+	Patient        PersonResponse `json:"patient"`
+	Resource       string         `json:"resource"`
+	ResourceID     string         `json:"resourceId"`
+	Method         string         `json:"method"`
+	Path           string         `json:"path"`
+	StatusCode     int32          `json:"statusCode"`
+	IPAddress      string         `json:"ipAddress"`
+	UserAgent      string         `json:"userAgent"`
+	CreatedAt      string         `json:"createdAt"`
+	ImpersonatorID string         `json:"impersonatorId"`
+}
+
+type PatientAccessSummary struct {
+	User        PersonResponse `json:"user"`
+	Role        string         `json:"role"`
+	AccessCount int32          `json:"accessCount"`
+	FirstAccess string         `json:"firstAccess"`
+	LastAccess  string         `json:"lastAccess"`
+}
+
+type PatientAccessReport struct {
+	PatientID string                 `json:"patientId"`
+	Accessors []PatientAccessSummary `json:"accessors"`
+	Recent    []AccessLogResponse    `json:"recent"`
+}
+
+func uuidString(id pgtype.UUID) string {
+	if !id.Valid {
+		return ""
+	}
+	return uuid.UUID(id.Bytes).String()
+}
+
+func toAccessLogResponse(l sqlc.GetAccessAuditLogsRow) AccessLogResponse {
+	return AccessLogResponse{
+		ID: uuidString(l.ID),
+		User: PersonResponse{
+			ID:        uuidString(l.UserID),
+			FirstName: l.FirstName.String,
+			LastName:  l.LastName.String,
+		},
+		Role: l.RoleName.String,
+		Patient: PersonResponse{
+			ID:        uuidString(l.PatientID),
+			FirstName: l.FirstName_2.String,
+			LastName:  l.LastName_2.String,
+		},
+		Resource:       l.Resource,
+		ResourceID:     uuidString(l.ResourceID),
+		Method:         l.Method,
+		Path:           l.Path,
+		StatusCode:     l.StatusCode,
+		IPAddress:      l.IpAddress,
+		UserAgent:      l.UserAgent,
+		CreatedAt:      l.CreatedAt.Time.Format(time.RFC3339),
+		ImpersonatorID: uuidString(l.ImpersonatorID),
+	}
+}
+
+// parsePage reads the limit and page query parameters. It writes the error
+// response and returns false when they are invalid.
+func parsePage(c *gin.Context) (limit, offset int32, ok bool) {
+	limit = 20
+	if limitStr := c.Query("limit"); limitStr != "" {
+		parsedLimit, err := strconv.ParseInt(limitStr, 10, 32)
+		if err != nil || parsedLimit < 1 || parsedLimit > maxAccessLogLimit {
+			c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Límite inválido", err))
+			return 0, 0, false
+		}
+		limit = int32(parsedLimit)
+	}
+
+	pageIndex := int32(1)
+	if pageStr := c.Query("page"); pageStr != "" {
+		parsedPage, err := strconv.ParseInt(pageStr, 10, 32)
+		if err != nil || parsedPage < 1 {
+			c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Página inválida", err))
+			return 0, 0, false
+		}
+		pageIndex = int32(parsedPage)
+	}
+
+	return limit, (pageIndex - 1) * limit, true
+}
+
+func (h *AuditHandler) GetAccessLogs(c *gin.Context) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	params := sqlc.GetAccessAuditLogsParams{
+		BusinessID: businessID,
+	}
+
+	params.QueryLimit, params.QueryOffset, ok = parsePage(c)
+	if !ok {
+		return
+	}
+
+	if userIDStr := c.Query("userId"); userIDStr != "" {
+		if err := params.UserID.Scan(userIDStr); err != nil {
+			c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID de usuario inválido", err))
+			return
+		}
+	}
+
+	if patientIDStr := c.Query("patientId"); patientIDStr != "" {
+		if err := params.PatientID.Scan(patientIDStr); err != nil {
+			c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID de paciente inválido", err))
+			return
+		}
+	}
+
+	if resource := c.Query("resource"); resource != "" {
+		params.Resource = pgtype.Text{String: resource, Valid: true}
+	}
+
+	if c.Query("from") != "" || c.Query("to") != "" {
+		loc, err := time.LoadLocation("America/Argentina/Buenos_Aires")
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error de zona horaria", err))
+			return
+		}
+
+		if fromStr := c.Query("from"); fromStr != "" {
+			from, err := time.ParseInLocation("2006-01-02", fromStr, loc)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de fecha inválido", err))
+				return
+			}
+			params.DateFrom = pgtype.Timestamptz{Time: from, Valid: true}
+		}
+
+		if toStr := c.Query("to"); toStr != "" {
+			to, err := time.ParseInLocation("2006-01-02", toStr, loc)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de fecha inválido", err))
+				return
+			}
+			// The upper bound is exclusive, so the whole "to" day is included.
+			params.DateTo = pgtype.Timestamptz{Time: to.AddDate(0, 0, 1), Valid: true}
+		}
+	}
+
+	total, err := h.repo.CountAccessLogs(c.Request.Context(), sqlc.CountAccessAuditLogsParams{
+		BusinessID: params.BusinessID,
+		UserID:     params.UserID,
+		PatientID:  params.PatientID,
+		Resource:   params.Resource,
+		DateFrom:   params.DateFrom,
+		DateTo:     params.DateTo,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al contar registros de auditoría", err))
+		return
+	}
+
+	logs, err := h.repo.GetAccessLogs(c.Request.Context(), params)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener registros de auditoría", err))
+		return
+	}
+
+	items := make([]AccessLogResponse, len(logs))
+	for i, l := range logs {
+		items[i] = toAccessLogResponse(l)
+	}
+
+	result := response.PaginatedData[AccessLogResponse]{
+		Result: items,
+		Total:  total,
+	}
+	c.JSON(http.StatusOK, response.Success("Registros de auditoría encontrados", &result))
+}
+
+func (h *AuditHandler) GetPatientAccessReport(c *gin.Context) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	var patientID pgtype.UUID
+	if err := patientID.Scan(c.Param("patient_id")); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID inválido", err))
+		return
+	}
+
+	summary, err := h.repo.GetPatientAccessSummary(c.Request.Context(), sqlc.GetPatientAccessSummaryParams{
+		BusinessID: businessID,
+		PatientID:  patientID,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener el reporte de accesos", err))
+		return
+	}
+
+	recent, err := h.repo.GetAccessLogs(c.Request.Context(), sqlc.GetAccessAuditLogsParams{
+		BusinessID:  businessID,
+		PatientID:   patientID,
+		QueryLimit:  maxAccessLogLimit,
+		QueryOffset: 0,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener el reporte de accesos", err))
+		return
+	}
+
+	report := PatientAccessReport{
+		PatientID: uuidString(patientID),
+		Accessors: make([]PatientAccessSummary, len(summary)),
+		Recent:    make([]AccessLogResponse, len(recent)),
+	}
+	for i, s := range summary {
+		report.Accessors[i] = PatientAccessSummary{
+			User: PersonResponse{
+				ID:        uuidString(s.UserID),
+				FirstName: s.FirstName.String,
+				LastName:  s.LastName.String,
+			},
+			Role:        s.RoleName.String,
+			AccessCount: s.AccessCount,
+			FirstAccess: s.FirstAccess.Time.Format(time.RFC3339),
+			LastAccess:  s.LastAccess.Time.Format(time.RFC3339),
+		}
+	}
+	for i, l := range recent {
+		report.Recent[i] = toAccessLogResponse(l)
+	}
+
+	c.JSON(http.StatusOK, response.Success("Reporte de accesos encontrado", &report))
+}
@@ -0,0 +1,43 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+)
+
+type AuditRepository struct {
+	q *sqlc.Queries
+}
+
+func NewAuditRepository(q *sqlc.Queries) *AuditRepository {
+	return &AuditRepository{q: q}
+}
+
+func (r *AuditRepository) GetAccessLogs(ctx context.Context, arg sqlc.GetAccessAuditLogsParams) ([]sqlc.GetAccessAuditLogsRow, error) {
+	return r.q.GetAccessAuditLogs(ctx, arg)
+}
+
+func (r *AuditRepository) CountAccessLogs(ctx context.Context, arg sqlc.CountAccessAuditLogsParams) (int32, error) {
+	return r.q.CountAccessAuditLogs(ctx, arg)
+}
+
+func (r *AuditRepository) GetPatientAccessSummary(ctx context.Context, arg sqlc.GetPatientAccessSummaryParams) ([]sqlc.GetPatientAccessSummaryRow, error) {
+	return r.q.GetPatientAccessSummary(ctx, arg)
+}
+
+func (r *AuditRepository) GetSecurityEvents(ctx context.Context, arg sqlc.GetSecurityEventsParams) ([]sqlc.SecurityEvent, error) {
+	return r.q.GetSecurityEvents(ctx, arg)
+}
+
+func (r *AuditRepository) CountSecurityEvents(ctx context.Context, arg sqlc.CountSecurityEventsParams) (int32, error) {
+	return r.q.CountSecurityEvents(ctx, arg)
+}
+
+func (r *AuditRepository) GetLoginAttempts(ctx context.Context, arg sqlc.GetLoginAttemptsParams) ([]sqlc.LoginAttempt, error) {
+	return r.q.GetLoginAttempts(ctx, arg)
+}
+
+func (r *AuditRepository) CountLoginAttempts(ctx context.Context, arg sqlc.CountLoginAttemptsParams) (int32, error) {
+	return r.q.CountLoginAttempts(ctx, arg)
+}
@@ -0,0 +1,18 @@
+package audit
+
+import (
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/alanloffler/go-calth-api/internal/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(router *gin.RouterGroup, q *sqlc.Queries) {
+	var repo *AuditRepository = NewAuditRepository(q)
+	var handler *AuditHandler = NewAuditHandler(repo)
+	var audit *gin.RouterGroup = router.Group("/audit")
+
+	audit.GET("/access", middleware.PermissionMiddleware(q, "audit-view"), handler.GetAccessLogs)
+	audit.GET("/access/patient/:patient_id", middleware.PermissionMiddleware(q, "audit-view"), handler.GetPatientAccessReport)
+	audit.GET("/login-attempts", middleware.PermissionMiddleware(q, "audit-view"), handler.GetLoginAttempts)
+	audit.GET("/security", middleware.PermissionMiddleware(q, "audit-view"), handler.GetSecurityEvents)
+}
@@ -0,0 +1,154 @@
+package audit
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/alanloffler/go-calth-api/internal/common/ctxkeys"
+	"github.com/alanloffler/go-calth-api/internal/common/response"
+	"github.com/alanloffler/go-calth-api/internal/common/throttle"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type LoginAttemptResponse struct {
+	ID        string `json:"id"`
+	Email     string `json:"email"`
+	IPAddress string `json:"ipAddress"`
+	UserAgent string `json:"userAgent"`
+	Reason    string `json:"reason"`
+	CreatedAt string `json:"createdAt"`
+}
+
+type SecurityEventResponse struct {
+	ID             string `json:"id"`
+	UserID         string `json:"userId"`
+	SessionID      string `json:"sessionId"`
+	EventType      string `json:"eventType"`
+	IPAddress      string `json:"ipAddress"`
+	UserAgent      string `json:"userAgent"`
+	CreatedAt      string `json:"createdAt"`
+	ImpersonatorID string `json:"impersonatorId"`
+}
+
+func toSecurityEventResponse(e sqlc.SecurityEvent) SecurityEventResponse {
+	return SecurityEventResponse{
+		ID:             uuidString(e.ID),
+		UserID:         uuidString(e.UserID),
+		SessionID:      uuidString(e.SessionID),
+		EventType:      e.EventType,
+		IPAddress:      e.IpAddress,
+		UserAgent:      e.UserAgent,
+		CreatedAt:      e.CreatedAt.Time.Format(time.RFC3339),
+		ImpersonatorID: uuidString(e.ImpersonatorID),
+	}
+}
+
+// GetSecurityEvents lists the security events of the business, such as a
+// reused refresh token or a superadmin impersonating a user, newest first.
+func (h *AuditHandler) GetSecurityEvents(c *gin.Context) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	params := sqlc.GetSecurityEventsParams{
+		BusinessID: businessID,
+	}
+
+	params.QueryLimit, params.QueryOffset, ok = parsePage(c)
+	if !ok {
+		return
+	}
+
+	if userIDStr := c.Query("userId"); userIDStr != "" {
+		if err := params.UserID.Scan(userIDStr); err != nil {
+			c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID de usuario inválido", err))
+			return
+		}
+	}
+
+	total, err := h.repo.CountSecurityEvents(c.Request.Context(), sqlc.CountSecurityEventsParams{
+		BusinessID: params.BusinessID,
+		UserID:     params.UserID,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al contar eventos de seguridad", err))
+		return
+	}
+
+	events, err := h.repo.GetSecurityEvents(c.Request.Context(), params)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener eventos de seguridad", err))
+		return
+	}
+
+	items := make([]SecurityEventResponse, len(events))
+	for i, e := range events {
+		items[i] = toSecurityEventResponse(e)
+	}
+
+	result := response.PaginatedData[SecurityEventResponse]{
+		Result: items,
+		Total:  total,
+	}
+	c.JSON(http.StatusOK, response.Success("Eventos de seguridad encontrados", &result))
+}
+
+// GetLoginAttempts lists the failed logins of the business, newest first,
+// optionally for one email.
+func (h *AuditHandler) GetLoginAttempts(c *gin.Context) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	params := sqlc.GetLoginAttemptsParams{
+		BusinessID: businessID,
+	}
+
+	params.QueryLimit, params.QueryOffset, ok = parsePage(c)
+	if !ok {
+		return
+	}
+
+	if email := c.Query("email"); email != "" {
+		params.Email = pgtype.Text{String: throttle.NormalizeEmail(email), Valid: true}
+	}
+
+	total, err := h.repo.CountLoginAttempts(c.Request.Context(), sqlc.CountLoginAttemptsParams{
+		BusinessID: params.BusinessID,
+		Email:      params.Email,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al contar intentos de inicio de sesión", err))
+		return
+	}
+
+	attempts, err := h.repo.GetLoginAttempts(c.Request.Context(), params)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener intentos de inicio de sesión", err))
+		return
+	}
+
+	items := make([]LoginAttemptResponse, len(attempts))
+	for i, a := range attempts {
+		items[i] = LoginAttemptResponse{
+			ID:        uuidString(a.ID),
+			Email:     a.Email,
+			IPAddress: a.IpAddress,
+			UserAgent: a.UserAgent,
+			Reason:    a.Reason,
+			CreatedAt: a.CreatedAt.Time.Format(time.RFC3339),
+		}
+	}
+
+	result := response.PaginatedData[LoginAttemptResponse]{
+		Result: items,
+		Total:  total,
+	}
+	c.JSON(http.StatusOK, response.Success("Intentos de inicio de sesión encontrados", &result))
+}
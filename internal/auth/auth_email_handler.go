@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/alanloffler/go-calth-api/internal/common/response"
+	"github.com/alanloffler/go-calth-api/internal/common/token"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/alanloffler/go-calth-api/internal/email_verification"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+)
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type ResendVerificationRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// VerifyEmail marks the email of the user as verified with a token sent by the
+// email verification flow. The token is consumed.
+func (h *AuthHandler) VerifyEmail(c *gin.Context) {
+	var req VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Error de validación de datos", err))
+		return
+	}
+
+	business, ok := h.businessFromOrigin(c)
+	if !ok {
+		return
+	}
+
+	if _, err := h.repo.VerifyEmail(c.Request.Context(), sqlc.VerifyEmailParams{
+		BusinessID: business.ID,
+		TokenHash:  token.Hash(req.Token),
+	}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Enlace inválido o expirado"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al verificar el email", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Success[any]("Email verificado", nil))
+}
+
+// ResendVerification emails a new verification link to the user of the
+// business resolved from the Origin. The response is the same whether the
+// email is registered, already verified or over the resend limit.
+func (h *AuthHandler) ResendVerification(c *gin.Context) {
+	var req ResendVerificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Error de validación de datos", err))
+		return
+	}
+
+	business, ok := h.businessFromOrigin(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	accepted := response.Success[any]("Si el email está registrado y sin verificar, recibirás un enlace para verificarlo", nil)
+
+	user, err := h.repo.GetUserByEmail(ctx, sqlc.GetUserByEmailParams{BusinessID: business.ID, Email: req.Email})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusOK, accepted)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al buscar usuario", err))
+		return
+	}
+
+	if err := h.verification.Send(ctx, business.ID, user.ID); err != nil {
+		var limit *email_verification.ResendLimitError
+		if !errors.Is(err, email_verification.ErrAlreadyVerified) && !errors.As(err, &limit) {
+			c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al enviar el email de verificación", err))
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, accepted)
+}
@@ -2,17 +2,25 @@ package auth
 
 import (
 	"errors"
+	"log"
 	"net/http"
 	"strings"
 	"time"
 
 	"github.com/alanloffler/go-calth-api/internal/common/ctxkeys"
+	"github.com/alanloffler/go-calth-api/internal/common/mfa"
 	"github.com/alanloffler/go-calth-api/internal/common/response"
+	"github.com/alanloffler/go-calth-api/internal/common/throttle"
+	"github.com/alanloffler/go-calth-api/internal/common/token"
 	"github.com/alanloffler/go-calth-api/internal/config"
 	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/alanloffler/go-calth-api/internal/email_verification"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -35,29 +43,36 @@ type getMeRole struct {
 }
 
 type getMeResponse struct {
-	ID           pgtype.UUID        `json:"id"`
-	Ic           string             `json:"ic"`
-	UserName     string             `json:"userName"`
-	FirstName    string             `json:"firstName"`
-	LastName     string             `json:"lastName"`
-	Email        string             `json:"email"`
-	PhoneNumber  string             `json:"phoneNumber"`
-	RoleID       pgtype.UUID        `json:"roleId"`
-	BusinessID   pgtype.UUID        `json:"businessId"`
-	IsSuperAdmin bool               `json:"isSuperAdmin"`
-	CreatedAt    pgtype.Timestamptz `json:"createdAt"`
-	UpdatedAt    pgtype.Timestamptz `json:"updatedAt"`
-	Role         *getMeRole         `json:"role"`
+	ID              pgtype.UUID        `json:"id"`
+	Ic              string             `json:"ic"`
+	UserName        string             `json:"userName"`
+	FirstName       string             `json:"firstName"`
+	LastName        string             `json:"lastName"`
+	Email           string             `json:"email"`
+	EmailVerifiedAt pgtype.Timestamptz `json:"emailVerifiedAt"`
+	PhoneNumber     string             `json:"phoneNumber"`
+	RoleID          pgtype.UUID        `json:"roleId"`
+	BusinessID      pgtype.UUID        `json:"businessId"`
+	IsSuperAdmin    bool               `json:"isSuperAdmin"`
+	ImpersonatorID  pgtype.UUID        `json:"impersonatorId"`
+	CreatedAt       pgtype.Timestamptz `json:"createdAt"`
+	UpdatedAt       pgtype.Timestamptz `json:"updatedAt"`
+	Role            *getMeRole         `json:"role"`
 }
 
 type AuthHandler struct {
-	cfg     *config.Config
-	repo    *AuthRepository
-	service *AuthService
+	cfg          *config.Config
+	repo         *AuthRepository
+	service      *AuthService
+	pool         *pgxpool.Pool
+	queueClient  *asynq.Client
+	verification *email_verification.Sender
+	twoFactor    *mfa.Service
+	guard        *throttle.Guard
 }
 
-func NewAuthHandler(cfg *config.Config, repo *AuthRepository, service *AuthService) *AuthHandler {
-	return &AuthHandler{cfg: cfg, repo: repo, service: service}
+func NewAuthHandler(cfg *config.Config, repo *AuthRepository, service *AuthService, pool *pgxpool.Pool, queueClient *asynq.Client, verification *email_verification.Sender, twoFactor *mfa.Service, guard *throttle.Guard) *AuthHandler {
+	return &AuthHandler{cfg: cfg, repo: repo, service: service, pool: pool, queueClient: queueClient, verification: verification, twoFactor: twoFactor, guard: guard}
 }
 
 type LoginRequest struct {
@@ -72,25 +87,12 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	origin := c.GetHeader("Origin")
-	if origin == "" {
-		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Origen requerido"))
+	business, ok := h.businessFromOrigin(c)
+	if !ok {
 		return
 	}
 
-	slug, err := extractSubdomain(origin)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Subdominio inválido", err))
-		return
-	}
-
-	business, err := h.repo.GetBusinessBySlug(c.Request.Context(), slug)
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Negocio no encontrado"))
-			return
-		}
-		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al buscar negocio", err))
+	if !h.throttleLogin(c, business.ID, req.Email) {
 		return
 	}
 
@@ -111,7 +113,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		})
 		if err != nil {
 			if errors.Is(err, pgx.ErrNoRows) {
-				c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Credenciales inválidas", err))
+				h.loginFailed(c, business.ID, req.Email)
 				return
 			}
 			c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al buscar usuario", err))
@@ -123,23 +125,62 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
-		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Credenciales inválidas"))
+		h.loginFailed(c, business.ID, req.Email)
 		return
 	}
 
-	tokenPair, err := h.service.GenerateTokenPair(user.ID.String(), business.ID.String(), user.RoleID.String(), isSuperAdmin)
+	if err := h.guard.Succeed(c.Request.Context(), business.ID, req.Email); err != nil {
+		log.Printf("login throttle unavailable: %v", err)
+	}
+
+	if !isSuperAdmin && business.RequireVerifiedEmailLogin && !user.EmailVerifiedAt.Valid {
+		c.JSON(http.StatusForbidden, response.Error(http.StatusForbidden, "Debes verificar tu email antes de iniciar sesión"))
+		return
+	}
+
+	// With two-factor the tokens are only issued by VerifyTwoFactor.
+	status, err := h.twoFactor.Status(c.Request.Context(), user.ID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al generar tokens", err))
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener la verificación en dos pasos", err))
+		return
+	}
+	if status.EnabledAt.Valid || status.Required {
+		h.startTwoFactorChallenge(c, user.ID, business.ID, isSuperAdmin, !status.EnabledAt.Valid)
+		return
+	}
+
+	if !h.startSession(c, user.ID, business.ID, user.RoleID, isSuperAdmin, pgtype.UUID{}) {
 		return
 	}
 
-	_, err = h.repo.UpdateRefreshToken(c.Request.Context(), sqlc.UpdateRefreshTokenParams{
-		ID:           user.ID,
-		RefreshToken: pgtype.Text{String: tokenPair.RefreshToken, Valid: true},
+	c.JSON(http.StatusOK, response.Success[any]("Inicio de sesión exitoso", nil))
+}
+
+// startSession opens a session on this device and sets the token cookies.
+// impersonatorID is only valid when a superadmin starts acting as the user. It
+// writes the error response and returns false when it cannot.
+func (h *AuthHandler) startSession(c *gin.Context, userID, businessID, roleID pgtype.UUID, isSuperAdmin bool, impersonatorID pgtype.UUID) bool {
+	sessionID := uuid.New()
+
+	tokenPair, err := h.service.GenerateTokenPair(userID.String(), businessID.String(), roleID.String(), isSuperAdmin, sessionID.String(), impersonatorID.String())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al generar tokens", err))
+		return false
+	}
+
+	userAgent, ip := clientInfo(c)
+	_, err = h.repo.CreateSession(c.Request.Context(), sqlc.CreateUserSessionParams{
+		ID:         pgtype.UUID{Bytes: sessionID, Valid: true},
+		UserID:     userID,
+		BusinessID: businessID,
+		TokenHash:  token.Hash(tokenPair.RefreshToken),
+		UserAgent:  userAgent,
+		IpAddress:  ip,
+		ExpiresAt:  h.refreshExpiresAt(),
 	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al guardar token de refresco", err))
-		return
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al crear la sesión", err))
+		return false
 	}
 
 	accessMaxAge := parseDurationToSeconds(h.cfg.JwtAccessExpiry)
@@ -149,7 +190,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	c.SetCookie("access_token", tokenPair.AccessToken, accessMaxAge, "/", h.cfg.CookieDomain, h.cfg.CookieSecure, true)
 	c.SetCookie("refresh_token", tokenPair.RefreshToken, refreshMaxAge, "/", h.cfg.CookieDomain, h.cfg.CookieSecure, true)
 
-	c.JSON(http.StatusOK, response.Success[any]("Inicio de sesión exitoso", nil))
+	return true
 }
 
 func (h *AuthHandler) Logout(c *gin.Context) {
@@ -171,10 +212,13 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 		return
 	}
 
-	_, err = h.repo.ClearRefreshToken(c.Request.Context(), userID)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al cerrar sesión", err))
-		return
+	// Only this device is logged out; other sessions stay open.
+	var sessionID pgtype.UUID
+	if err := sessionID.Scan(claims.SessionID); err == nil {
+		if _, err := h.repo.DeleteSession(c.Request.Context(), sqlc.DeleteUserSessionParams{ID: sessionID, UserID: userID}); err != nil {
+			c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al cerrar sesión", err))
+			return
+		}
 	}
 
 	c.SetSameSite(http.SameSiteLaxMode)
@@ -209,13 +253,16 @@ func (h *AuthHandler) Refresh(c *gin.Context) {
 		return
 	}
 
-	// Look up the stored refresh token + role.
+	var sessionID pgtype.UUID
+	if err := sessionID.Scan(claims.SessionID); err != nil {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Token de refresco inválido"))
+		return
+	}
+
+	// Look up the role.
 	// Superadmin: ignore tenant scope (their home business may differ from the active one).
 	// Regular user: keep the (business_id, id) scope as before.
-	var (
-		storedRefreshToken pgtype.Text
-		roleID             pgtype.UUID
-	)
+	var roleID pgtype.UUID
 
 	if claims.IsSuperAdmin {
 		user, err := h.repo.GetUserByIDGlobal(c.Request.Context(), userID)
@@ -227,7 +274,6 @@ func (h *AuthHandler) Refresh(c *gin.Context) {
 			c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al buscar usuario", err))
 			return
 		}
-		storedRefreshToken = user.RefreshToken
 		roleID = user.RoleID
 	} else {
 		user, err := h.repo.GetUserByID(c.Request.Context(), sqlc.GetUserByIDParams{BusinessID: businessID, ID: userID})
@@ -239,13 +285,26 @@ func (h *AuthHandler) Refresh(c *gin.Context) {
 			c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al buscar usuario", err))
 			return
 		}
-		storedRefreshToken = user.RefreshToken
 		roleID = user.RoleID
 	}
 
-	if !storedRefreshToken.Valid || storedRefreshToken.String != refreshToken {
-		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Token de refresco inválido"))
-		return
+	// An impersonation lasts only while the impersonator is a superadmin.
+	if claims.ImpersonatorID != "" {
+		var impersonatorID pgtype.UUID
+		if err := impersonatorID.Scan(claims.ImpersonatorID); err != nil {
+			c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Token de refresco inválido"))
+			return
+		}
+
+		allowed, err := h.isSuperAdmin(c.Request.Context(), impersonatorID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al buscar usuario", err))
+			return
+		}
+		if !allowed {
+			c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Suplantación no autorizada"))
+			return
+		}
 	}
 
 	// Preserve the active tenant (businessID from current claims), NOT the user's home business.
@@ -254,20 +313,35 @@ func (h *AuthHandler) Refresh(c *gin.Context) {
 		businessID.String(),
 		roleID.String(),
 		claims.IsSuperAdmin,
+		claims.SessionID,
+		claims.ImpersonatorID,
 	)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al generar tokens", err))
 		return
 	}
 
-	_, err = h.repo.UpdateRefreshToken(c.Request.Context(), sqlc.UpdateRefreshTokenParams{
-		ID:           userID,
-		RefreshToken: pgtype.Text{String: tokenPair.RefreshToken, Valid: true},
+	// The session only accepts its current refresh token, so a revoked session
+	// or an already rotated token is rejected here.
+	tokenHash := token.Hash(refreshToken)
+	userAgent, ip := clientInfo(c)
+	rotated, err := h.repo.RotateSession(c.Request.Context(), sqlc.RotateUserSessionParams{
+		NewTokenHash: token.Hash(tokenPair.RefreshToken),
+		UserAgent:    userAgent,
+		IpAddress:    ip,
+		ExpiresAt:    h.refreshExpiresAt(),
+		ID:           sessionID,
+		UserID:       userID,
+		TokenHash:    tokenHash,
 	})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al guardar token", err))
 		return
 	}
+	if rotated == 0 {
+		h.rejectRefresh(c, businessID, userID, sessionID, tokenHash)
+		return
+	}
 
 	accessMaxAge := parseDurationToSeconds(h.cfg.JwtAccessExpiry)
 	refreshMaxAge := parseDurationToSeconds(h.cfg.JwtRefreshExpiry)
@@ -299,6 +373,7 @@ func (h *AuthHandler) GetMe(c *gin.Context) {
 	}
 
 	isSuperAdmin := ctxkeys.IsSuperAdmin(c)
+	impersonatorID, _ := ctxkeys.ImpersonatorID(c)
 
 	var result getMeResponse
 
@@ -323,18 +398,19 @@ func (h *AuthHandler) GetMe(c *gin.Context) {
 		}
 
 		result = getMeResponse{
-			ID:           user.ID,
-			Ic:           user.Ic,
-			UserName:     user.UserName,
-			FirstName:    user.FirstName,
-			LastName:     user.LastName,
-			Email:        user.Email,
-			PhoneNumber:  user.PhoneNumber,
-			RoleID:       user.RoleID,
-			BusinessID:   businessID, // active tenant from JWT, not user's home business
-			IsSuperAdmin: true,
-			CreatedAt:    user.CreatedAt,
-			UpdatedAt:    user.UpdatedAt,
+			ID:              user.ID,
+			Ic:              user.Ic,
+			UserName:        user.UserName,
+			FirstName:       user.FirstName,
+			LastName:        user.LastName,
+			Email:           user.Email,
+			EmailVerifiedAt: user.EmailVerifiedAt,
+			PhoneNumber:     user.PhoneNumber,
+			RoleID:          user.RoleID,
+			BusinessID:      businessID, // active tenant from JWT, not user's home business
+			IsSuperAdmin:    true,
+			CreatedAt:       user.CreatedAt,
+			UpdatedAt:       user.UpdatedAt,
 		}
 
 		if user.RoleID_2.Valid {
@@ -388,17 +464,19 @@ func (h *AuthHandler) GetMe(c *gin.Context) {
 	}
 
 	result = getMeResponse{
-		ID:          user.ID,
-		Ic:          user.Ic,
-		UserName:    user.UserName,
-		FirstName:   user.FirstName,
-		LastName:    user.LastName,
-		Email:       user.Email,
-		PhoneNumber: user.PhoneNumber,
-		RoleID:      user.RoleID,
-		BusinessID:  user.BusinessID,
-		CreatedAt:   user.CreatedAt,
-		UpdatedAt:   user.UpdatedAt,
+		ID:              user.ID,
+		Ic:              user.Ic,
+		UserName:        user.UserName,
+		FirstName:       user.FirstName,
+		LastName:        user.LastName,
+		Email:           user.Email,
+		EmailVerifiedAt: user.EmailVerifiedAt,
+		PhoneNumber:     user.PhoneNumber,
+		RoleID:          user.RoleID,
+		BusinessID:      user.BusinessID,
+		ImpersonatorID:  impersonatorID,
+		CreatedAt:       user.CreatedAt,
+		UpdatedAt:       user.UpdatedAt,
 	}
 
 	if user.RoleID_2.Valid {
@@ -428,6 +506,95 @@ func (h *AuthHandler) GetMe(c *gin.Context) {
 }
 
 // Helpers
+
+// maxUserAgentLength matches user_sessions.user_agent.
+const maxUserAgentLength = 255
+
+// clientInfo returns the user agent and IP stored with a session.
+func clientInfo(c *gin.Context) (string, string) {
+	userAgent := c.Request.UserAgent()
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = strings.ToValidUTF8(userAgent[:maxUserAgentLength], "")
+	}
+	return userAgent, c.ClientIP()
+}
+
+const eventRefreshTokenReuse = "refresh_token_reuse"
+
+// rejectRefresh answers a refresh token its session does not accept. When the
+// token is one the session already rotated away, it was copied: the session
+// is revoked, logging out both the thief and the owner, and a security event
+// is recorded.
+func (h *AuthHandler) rejectRefresh(c *gin.Context, businessID, userID, sessionID pgtype.UUID, tokenHash string) {
+	ctx := c.Request.Context()
+
+	reused, err := h.repo.IsRotatedSessionToken(ctx, sqlc.IsRotatedSessionTokenParams{SessionID: sessionID, TokenHash: tokenHash})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al verificar token", err))
+		return
+	}
+	if !reused {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Token de refresco inválido"))
+		return
+	}
+
+	if _, err := h.repo.DeleteSession(ctx, sqlc.DeleteUserSessionParams{ID: sessionID, UserID: userID}); err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al revocar la sesión", err))
+		return
+	}
+
+	userAgent, ip := clientInfo(c)
+	if err := h.repo.CreateSecurityEvent(ctx, sqlc.CreateSecurityEventParams{
+		BusinessID: businessID,
+		UserID:     userID,
+		SessionID:  sessionID,
+		EventType:  eventRefreshTokenReuse,
+		IpAddress:  ip,
+		UserAgent:  userAgent,
+	}); err != nil {
+		log.Printf("failed to record security event: %v", err)
+	}
+	log.Printf("refresh token reuse: revoked session %s of user %s", sessionID.String(), userID.String())
+
+	c.SetCookie("access_token", "", -1, "/", h.cfg.CookieDomain, h.cfg.CookieSecure, true)
+	c.SetCookie("refresh_token", "", -1, "/", h.cfg.CookieDomain, h.cfg.CookieSecure, true)
+	c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Sesión revocada por reutilización del token de refresco"))
+}
+
+// refreshExpiresAt is when a session expires if its refresh token is not used.
+func (h *AuthHandler) refreshExpiresAt() pgtype.Timestamptz {
+	d, _ := time.ParseDuration(h.cfg.JwtRefreshExpiry)
+	return pgtype.Timestamptz{Time: time.Now().Add(d), Valid: true}
+}
+
+// businessFromOrigin resolves the tenant from the subdomain of the Origin
+// header. It writes the error response and returns false when it cannot.
+func (h *AuthHandler) businessFromOrigin(c *gin.Context) (sqlc.Business, bool) {
+	origin := c.GetHeader("Origin")
+	if origin == "" {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Origen requerido"))
+		return sqlc.Business{}, false
+	}
+
+	slug, err := extractSubdomain(origin)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Subdominio inválido", err))
+		return sqlc.Business{}, false
+	}
+
+	business, err := h.repo.GetBusinessBySlug(c.Request.Context(), slug)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Negocio no encontrado"))
+			return sqlc.Business{}, false
+		}
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al buscar negocio", err))
+		return sqlc.Business{}, false
+	}
+
+	return business, true
+}
+
 func extractSubdomain(origin string) (string, error) {
 	host := origin
 	if _, after, ok := strings.Cut(origin, "://"); ok {
@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/alanloffler/go-calth-api/internal/common/response"
+	"github.com/alanloffler/go-calth-api/internal/common/throttle"
+	"github.com/alanloffler/go-calth-api/internal/common/utils"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Reasons stored in login_attempts.
+const (
+	loginAttemptInvalidCredentials = "invalid_credentials"
+	loginAttemptLocked             = "locked"
+)
+
+// throttleLogin rejects the login with 429 while the account is locked or
+// delayed, or the IP made too many failed attempts. It returns false when it
+// rejected. When Redis is down logins are let through.
+func (h *AuthHandler) throttleLogin(c *gin.Context, businessID pgtype.UUID, email string) bool {
+	wait, reason, err := h.guard.Check(c.Request.Context(), c.ClientIP(), businessID, email)
+	if err != nil {
+		log.Printf("login throttle unavailable: %v", err)
+		return true
+	}
+	if wait <= 0 {
+		return true
+	}
+
+	tooManyAttempts(c, wait, reason)
+	return false
+}
+
+// loginFailed records a wrong email or password and answers it. The answer is
+// the same whether the email exists or not.
+func (h *AuthHandler) loginFailed(c *gin.Context, businessID pgtype.UUID, email string) {
+	locked, err := h.guard.Fail(c.Request.Context(), c.ClientIP(), businessID, email)
+	if err != nil {
+		log.Printf("login throttle unavailable: %v", err)
+	}
+
+	reason := loginAttemptInvalidCredentials
+	if locked > 0 {
+		reason = loginAttemptLocked
+	}
+
+	userAgent, ip := clientInfo(c)
+	if err := h.repo.CreateLoginAttempt(c.Request.Context(), sqlc.CreateLoginAttemptParams{
+		BusinessID: businessID,
+		Email:      throttle.NormalizeEmail(email),
+		IpAddress:  ip,
+		UserAgent:  userAgent,
+		Reason:     reason,
+	}); err != nil {
+		log.Printf("failed to record login attempt: %v", err)
+	}
+
+	if locked > 0 {
+		tooManyAttempts(c, locked, throttle.ReasonLocked)
+		return
+	}
+
+	c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Credenciales inválidas"))
+}
+
+func tooManyAttempts(c *gin.Context, wait time.Duration, reason throttle.Reason) {
+	seconds := strconv.Itoa(int((wait + time.Second - 1) / time.Second))
+	minutes := utils.FormatDuration((wait + time.Minute - 1).Truncate(time.Minute))
+	c.Header("Retry-After", seconds)
+
+	var message string
+	switch reason {
+	case throttle.ReasonLocked:
+		message = "Cuenta bloqueada temporalmente por demasiados intentos fallidos. Intentá nuevamente en " + minutes
+	case throttle.ReasonIP:
+		message = "Demasiados intentos fallidos desde esta conexión. Intentá nuevamente en " + minutes
+	default:
+		message = "Demasiados intentos. Esperá " + seconds + " segundos antes de reintentar"
+	}
+
+	c.JSON(http.StatusTooManyRequests, response.Error(http.StatusTooManyRequests, message))
+}
@@ -0,0 +1,156 @@
+package auth
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/alanloffler/go-calth-api/internal/common/response"
+	"github.com/alanloffler/go-calth-api/internal/common/token"
+	"github.com/alanloffler/go-calth-api/internal/common/utils"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/alanloffler/go-calth-api/internal/queue"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=8,max=100"`
+}
+
+// ForgotPassword emails a single-use reset link to the user of the business
+// resolved from the Origin. The response is the same whether the email is
+// registered or not.
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+	var req ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Error de validación de datos", err))
+		return
+	}
+
+	business, ok := h.businessFromOrigin(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	accepted := response.Success[any]("Si el email está registrado, recibirás un enlace para restablecer la contraseña", nil)
+
+	user, err := h.repo.GetUserByEmail(ctx, sqlc.GetUserByEmailParams{BusinessID: business.ID, Email: req.Email})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusOK, accepted)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al buscar usuario", err))
+		return
+	}
+
+	resetToken, hash, err := token.Generate()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al generar el enlace", err))
+		return
+	}
+
+	if err := h.repo.UpsertPasswordResetToken(ctx, sqlc.UpsertPasswordResetTokenParams{
+		UserID:     user.ID,
+		BusinessID: business.ID,
+		TokenHash:  hash,
+		ExpiresAt:  pgtype.Timestamptz{Time: time.Now().Add(h.cfg.PasswordResetTTL), Valid: true},
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al generar el enlace", err))
+		return
+	}
+
+	if err := queue.EnqueuePasswordReset(h.queueClient, queue.PasswordResetPayload{
+		Email:       user.Email,
+		CompanyName: business.TradeName,
+		FullName:    user.FirstName + " " + user.LastName,
+		ResetLink:   "https://" + business.Slug + "." + h.cfg.AppDomain + "/reset-password?token=" + url.QueryEscape(resetToken),
+		ExpiresIn:   utils.FormatDuration(h.cfg.PasswordResetTTL),
+	}); err != nil {
+		log.Printf("failed to enqueue password_reset email: %v", err)
+	}
+
+	c.JSON(http.StatusOK, accepted)
+}
+
+// ResetPassword sets a new password with a token issued by ForgotPassword. The
+// token is consumed and every session of the user is closed.
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var req ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Error de validación de datos", err))
+		return
+	}
+
+	business, ok := h.businessFromOrigin(c)
+	if !ok {
+		return
+	}
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al procesar contraseña", err))
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	tx, err := h.pool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al iniciar transacción", err))
+		return
+	}
+	defer tx.Rollback(ctx)
+
+	rtx := h.repo.WithTx(tx)
+
+	userID, err := rtx.ConsumePasswordResetToken(ctx, sqlc.ConsumePasswordResetTokenParams{
+		BusinessID: business.ID,
+		TokenHash:  token.Hash(req.Token),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Enlace inválido o expirado"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al verificar el enlace", err))
+		return
+	}
+
+	rows, err := rtx.UpdateUser(ctx, sqlc.UpdateUserParams{
+		BusinessID: business.ID,
+		ID:         userID,
+		Password:   pgtype.Text{String: string(hashed), Valid: true},
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al actualizar la contraseña", err))
+		return
+	}
+	if rows == 0 {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Enlace inválido o expirado"))
+		return
+	}
+
+	if _, err := rtx.DeleteSessions(ctx, userID); err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al cerrar las sesiones", err))
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al confirmar transacción", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Success[any]("Contraseña restablecida", nil))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
@@ -15,6 +16,10 @@ func NewAuthRepository(q *sqlc.Queries) *AuthRepository {
 	return &AuthRepository{q: q}
 }
 
+func (r *AuthRepository) WithTx(tx pgx.Tx) *AuthRepository {
+	return &AuthRepository{q: r.q.WithTx(tx)}
+}
+
 func (r *AuthRepository) GetBusinessBySlug(ctx context.Context, slug string) (sqlc.Business, error) {
 	return r.q.GetBusinessBySlug(ctx, slug)
 }
@@ -31,23 +36,82 @@ func (r *AuthRepository) GetMe(ctx context.Context, arg sqlc.GetMeParams) (sqlc.
 	return r.q.GetMe(ctx, arg)
 }
 
-func (r *AuthRepository) UpdateRefreshToken(ctx context.Context, arg sqlc.UpdateRefreshTokenParams) (sqlc.User, error) {
-	return r.q.UpdateRefreshToken(ctx, arg)
+// Sessions
+func (r *AuthRepository) CreateSession(ctx context.Context, arg sqlc.CreateUserSessionParams) (sqlc.UserSession, error) {
+	return r.q.CreateUserSession(ctx, arg)
+}
+
+func (r *AuthRepository) RotateSession(ctx context.Context, arg sqlc.RotateUserSessionParams) (int64, error) {
+	return r.q.RotateUserSession(ctx, arg)
+}
+
+func (r *AuthRepository) IsRotatedSessionToken(ctx context.Context, arg sqlc.IsRotatedSessionTokenParams) (bool, error) {
+	return r.q.IsRotatedSessionToken(ctx, arg)
+}
+
+func (r *AuthRepository) DeleteSession(ctx context.Context, arg sqlc.DeleteUserSessionParams) (int64, error) {
+	return r.q.DeleteUserSession(ctx, arg)
+}
+
+func (r *AuthRepository) DeleteSessions(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	return r.q.DeleteUserSessions(ctx, userID)
+}
+
+func (r *AuthRepository) CreateSecurityEvent(ctx context.Context, arg sqlc.CreateSecurityEventParams) error {
+	return r.q.CreateSecurityEvent(ctx, arg)
+}
+
+// Two-factor challenges
+func (r *AuthRepository) CreateTwoFactorChallenge(ctx context.Context, arg sqlc.CreateTwoFactorChallengeParams) error {
+	return r.q.CreateTwoFactorChallenge(ctx, arg)
+}
+
+func (r *AuthRepository) GetTwoFactorChallenge(ctx context.Context, arg sqlc.GetTwoFactorChallengeParams) (sqlc.TwoFactorChallenge, error) {
+	return r.q.GetTwoFactorChallenge(ctx, arg)
 }
 
-func (r *AuthRepository) ClearRefreshToken(ctx context.Context, id pgtype.UUID) (sqlc.User, error) {
-	return r.q.ClearRefreshToken(ctx, id)
+func (r *AuthRepository) IncrementTwoFactorChallengeAttempts(ctx context.Context, id pgtype.UUID) (int32, error) {
+	return r.q.IncrementTwoFactorChallengeAttempts(ctx, id)
+}
+
+func (r *AuthRepository) DeleteTwoFactorChallenge(ctx context.Context, id pgtype.UUID) error {
+	return r.q.DeleteTwoFactorChallenge(ctx, id)
+}
+
+func (r *AuthRepository) CreateLoginAttempt(ctx context.Context, arg sqlc.CreateLoginAttemptParams) error {
+	return r.q.CreateLoginAttempt(ctx, arg)
 }
 
 func (r *AuthRepository) ListEffectivePermissions(ctx context.Context, arg sqlc.ListEffectivePermissionsParams) ([]sqlc.ListEffectivePermissionsRow, error) {
 	return r.q.ListEffectivePermissions(ctx, arg)
 }
 
+func (r *AuthRepository) UpdateUser(ctx context.Context, arg sqlc.UpdateUserParams) (int64, error) {
+	return r.q.UpdateUser(ctx, arg)
+}
+
+// Password reset
+func (r *AuthRepository) UpsertPasswordResetToken(ctx context.Context, arg sqlc.UpsertPasswordResetTokenParams) error {
+	return r.q.UpsertPasswordResetToken(ctx, arg)
+}
+
+func (r *AuthRepository) ConsumePasswordResetToken(ctx context.Context, arg sqlc.ConsumePasswordResetTokenParams) (pgtype.UUID, error) {
+	return r.q.ConsumePasswordResetToken(ctx, arg)
+}
+
 // Superadmin
 func (r *AuthRepository) GetSuperAdminByEmail(ctx context.Context, email string) (sqlc.User, error) {
 	return r.q.GetSuperAdminByEmail(ctx, email)
 }
 
+func (r *AuthRepository) GetBusinesses(ctx context.Context) ([]sqlc.Business, error) {
+	return r.q.GetBusinesses(ctx)
+}
+
+func (r *AuthRepository) GetBusiness(ctx context.Context, id pgtype.UUID) (sqlc.Business, error) {
+	return r.q.GetBusiness(ctx, id)
+}
+
 func (r *AuthRepository) GetMeGlobal(ctx context.Context, id pgtype.UUID) (sqlc.GetMeGlobalRow, error) {
 	return r.q.GetMeGlobal(ctx, id)
 }
@@ -55,3 +119,8 @@ func (r *AuthRepository) GetMeGlobal(ctx context.Context, id pgtype.UUID) (sqlc.
 func (r *AuthRepository) GetUserByIDGlobal(ctx context.Context, id pgtype.UUID) (sqlc.GetUserByIDGlobalRow, error) {
 	return r.q.GetUserByIDGlobal(ctx, id)
 }
+
+// Email verification
+func (r *AuthRepository) VerifyEmail(ctx context.Context, arg sqlc.VerifyEmailParams) (pgtype.UUID, error) {
+	return r.q.VerifyEmail(ctx, arg)
+}
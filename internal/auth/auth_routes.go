@@ -1,23 +1,42 @@
 package auth
 
 import (
+	"github.com/alanloffler/go-calth-api/internal/common/encryption"
+	"github.com/alanloffler/go-calth-api/internal/common/mfa"
+	"github.com/alanloffler/go-calth-api/internal/common/throttle"
 	"github.com/alanloffler/go-calth-api/internal/config"
 	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/alanloffler/go-calth-api/internal/email_verification"
 	"github.com/gin-gonic/gin"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-func RegisterRoutes(router *gin.Engine, protected *gin.RouterGroup, q *sqlc.Queries, cfg *config.Config) *AuthHandler {
+func RegisterRoutes(router *gin.Engine, protected *gin.RouterGroup, q *sqlc.Queries, pool *pgxpool.Pool, queueClient *asynq.Client, keys *encryption.Keyring, guard *throttle.Guard, cfg *config.Config) *AuthHandler {
 	var service *AuthService = NewAuthService(cfg)
 	var repo *AuthRepository = NewAuthRepository(q)
-	var handler *AuthHandler = NewAuthHandler(cfg, repo, service)
+	var verification *email_verification.Sender = email_verification.NewSender(q, queueClient, cfg)
+	var twoFactor *mfa.Service = mfa.NewService(pool, q, keys)
+	var handler *AuthHandler = NewAuthHandler(cfg, repo, service, pool, queueClient, verification, twoFactor, guard)
 
 	public := router.Group("/auth")
 
 	public.POST("/login", handler.Login)
 	public.POST("/logout", handler.Logout)
 	public.POST("/refresh", handler.Refresh)
+	public.POST("/password/forgot", handler.ForgotPassword)
+	public.POST("/password/reset", handler.ResetPassword)
+	public.POST("/email/verify", handler.VerifyEmail)
+	public.POST("/email/resend", handler.ResendVerification)
+	public.POST("/2fa/setup", handler.SetupTwoFactor)
+	public.POST("/2fa/verify", handler.VerifyTwoFactor)
 
 	protected.GET("/auth/me", handler.GetMe)
+	protected.GET("/auth/tenants", handler.GetTenants)
+
+	protected.POST("/auth/tenants/switch", handler.SwitchTenant)
+	protected.POST("/auth/impersonate/stop", handler.StopImpersonation)
+	protected.POST("/auth/impersonate/:userId", handler.Impersonate)
 
 	return handler
 }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/alanloffler/go-calth-api/internal/config"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 type AuthService struct {
@@ -22,6 +23,9 @@ type TokenClaims struct {
 	BusinessID   string `json:"businessId"`
 	RoleID       string `json:"roleId"`
 	IsSuperAdmin bool   `json:"isSuperAdmin"`
+	SessionID    string `json:"sid"`
+	// ImpersonatorID is the superadmin acting as the user, if any.
+	ImpersonatorID string `json:"imp,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -29,13 +33,16 @@ func NewAuthService(cfg *config.Config) *AuthService {
 	return &AuthService{cfg: cfg}
 }
 
-func (s *AuthService) GenerateTokenPair(userID, businessID, roleID string, isSuperAdmin bool) (*TokenPair, error) {
-	accessToken, err := s.generateToken(userID, businessID, roleID, isSuperAdmin, s.cfg.JwtSecret, s.cfg.JwtAccessExpiry)
+// GenerateTokenPair issues the tokens of a session. Both carry the session ID
+// so the refresh token can be matched against the stored session, and the
+// impersonator, empty unless a superadmin is acting as the user.
+func (s *AuthService) GenerateTokenPair(userID, businessID, roleID string, isSuperAdmin bool, sessionID, impersonatorID string) (*TokenPair, error) {
+	accessToken, err := s.generateToken(userID, businessID, roleID, isSuperAdmin, sessionID, impersonatorID, s.cfg.JwtSecret, s.cfg.JwtAccessExpiry)
 	if err != nil {
 		return nil, err
 	}
 
-	refreshToken, err := s.generateToken(userID, businessID, roleID, isSuperAdmin, s.cfg.JwtRefreshSecret, s.cfg.JwtRefreshExpiry)
+	refreshToken, err := s.generateToken(userID, businessID, roleID, isSuperAdmin, sessionID, impersonatorID, s.cfg.JwtRefreshSecret, s.cfg.JwtRefreshExpiry)
 	if err != nil {
 		return nil, err
 	}
@@ -51,18 +58,23 @@ func (s *AuthService) ValidateRefreshToken(tokenStr string) (*TokenClaims, error
 	return s.validateToken(tokenStr, s.cfg.JwtRefreshSecret)
 }
 
-func (s *AuthService) generateToken(userID, businessID, roleID string, isSuperAdmin bool, secret, expiry string) (string, error) {
+func (s *AuthService) generateToken(userID, businessID, roleID string, isSuperAdmin bool, sessionID, impersonatorID, secret, expiry string) (string, error) {
 	duration, err := time.ParseDuration(expiry)
 	if err != nil {
 		return "", err
 	}
 
 	claims := TokenClaims{
-		UserID:       userID,
-		BusinessID:   businessID,
-		RoleID:       roleID,
-		IsSuperAdmin: isSuperAdmin,
+		UserID:         userID,
+		BusinessID:     businessID,
+		RoleID:         roleID,
+		IsSuperAdmin:   isSuperAdmin,
+		SessionID:      sessionID,
+		ImpersonatorID: impersonatorID,
 		RegisteredClaims: jwt.RegisteredClaims{
+			// A unique ID keeps two tokens issued in the same second apart, so
+			// a rotated refresh token never matches the one replacing it.
+			ID:        uuid.NewString(),
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(duration)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/alanloffler/go-calth-api/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateTokenPair_RotatedRefreshTokenDiffers(t *testing.T) {
+	svc := NewAuthService(&config.Config{
+		JwtSecret:        "access-secret",
+		JwtRefreshSecret: "refresh-secret",
+		JwtAccessExpiry:  "15m",
+		JwtRefreshExpiry: "168h",
+	})
+
+	first, err := svc.GenerateTokenPair("user", "business", "role", false, "session", "")
+	require.NoError(t, err)
+	second, err := svc.GenerateTokenPair("user", "business", "role", false, "session", "")
+	require.NoError(t, err)
+
+	assert.NotEqual(t, first.RefreshToken, second.RefreshToken)
+
+	claims, err := svc.ValidateRefreshToken(second.RefreshToken)
+	require.NoError(t, err)
+	assert.Equal(t, "session", claims.SessionID)
+	assert.Empty(t, claims.ImpersonatorID)
+}
+
+func TestGenerateTokenPair_CarriesImpersonator(t *testing.T) {
+	svc := NewAuthService(&config.Config{
+		JwtSecret:        "access-secret",
+		JwtRefreshSecret: "refresh-secret",
+		JwtAccessExpiry:  "15m",
+		JwtRefreshExpiry: "168h",
+	})
+
+	pair, err := svc.GenerateTokenPair("user", "business", "role", false, "session", "superadmin")
+	require.NoError(t, err)
+
+	access, err := svc.ValidateAccessToken(pair.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, "superadmin", access.ImpersonatorID)
+	assert.False(t, access.IsSuperAdmin)
+
+	refresh, err := svc.ValidateRefreshToken(pair.RefreshToken)
+	require.NoError(t, err)
+	assert.Equal(t, "superadmin", refresh.ImpersonatorID)
+}
@@ -0,0 +1,271 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/alanloffler/go-calth-api/internal/common/ctxkeys"
+	"github.com/alanloffler/go-calth-api/internal/common/response"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	eventTenantSwitch       = "tenant_switch"
+	eventImpersonationStart = "impersonation_start"
+	eventImpersonationStop  = "impersonation_stop"
+)
+
+type TenantResponse struct {
+	ID          string `json:"id"`
+	Slug        string `json:"slug"`
+	TradeName   string `json:"tradeName"`
+	CompanyName string `json:"companyName"`
+	Current     bool   `json:"current"`
+}
+
+type SwitchTenantRequest struct {
+	BusinessID string `json:"businessId" binding:"required,uuid"`
+}
+
+// GetTenants lists the businesses a superadmin can switch to, marking the
+// active one.
+func (h *AuthHandler) GetTenants(c *gin.Context) {
+	if !ctxkeys.IsSuperAdmin(c) {
+		c.JSON(http.StatusForbidden, response.Error(http.StatusForbidden, "Acceso restringido a superadministradores"))
+		return
+	}
+
+	current, _ := ctxkeys.BusinessID(c)
+
+	businesses, err := h.repo.GetBusinesses(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener negocios", err))
+		return
+	}
+
+	result := make([]TenantResponse, 0, len(businesses))
+	for _, b := range businesses {
+		if b.DeletedAt.Valid {
+			continue
+		}
+		result = append(result, toTenantResponse(b, current))
+	}
+
+	c.JSON(http.StatusOK, response.Success("Negocios encontrados", &result))
+}
+
+// SwitchTenant moves a superadmin to another business. The current session is
+// replaced by one whose tokens carry the new business.
+func (h *AuthHandler) SwitchTenant(c *gin.Context) {
+	if !ctxkeys.IsSuperAdmin(c) {
+		c.JSON(http.StatusForbidden, response.Error(http.StatusForbidden, "Acceso restringido a superadministradores"))
+		return
+	}
+
+	userID, ok := ctxkeys.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	roleID, ok := ctxkeys.RoleID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	var req SwitchTenantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Error de validación de datos", err))
+		return
+	}
+
+	var businessID pgtype.UUID
+	if err := businessID.Scan(req.BusinessID); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID inválido", err))
+		return
+	}
+
+	business, err := h.repo.GetBusiness(c.Request.Context(), businessID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Negocio no encontrado"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al buscar negocio", err))
+		return
+	}
+	if business.DeletedAt.Valid {
+		c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Negocio no encontrado"))
+		return
+	}
+
+	if !h.replaceSession(c, userID, business.ID, roleID, true, pgtype.UUID{}) {
+		return
+	}
+
+	h.recordSecurityEvent(c, business.ID, userID, pgtype.UUID{}, eventTenantSwitch)
+
+	result := toTenantResponse(business, business.ID)
+	c.JSON(http.StatusOK, response.Success("Negocio activo cambiado", &result))
+}
+
+// Impersonate lets a superadmin act as the :userId user of the active
+// business. The tokens carry the superadmin in the imp claim, and every
+// request made with them is recorded in the access audit log.
+func (h *AuthHandler) Impersonate(c *gin.Context) {
+	if !ctxkeys.IsSuperAdmin(c) {
+		c.JSON(http.StatusForbidden, response.Error(http.StatusForbidden, "Acceso restringido a superadministradores"))
+		return
+	}
+
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	superAdminID, ok := ctxkeys.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(c.Param("userId")); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID inválido", err))
+		return
+	}
+
+	user, err := h.repo.GetUserByID(c.Request.Context(), sqlc.GetUserByIDParams{BusinessID: businessID, ID: id})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Usuario no encontrado"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al buscar usuario", err))
+		return
+	}
+	if user.RoleValue.String == roleSuperAdmin {
+		c.JSON(http.StatusForbidden, response.Error(http.StatusForbidden, "No se puede suplantar a un superadministrador"))
+		return
+	}
+
+	if !h.replaceSession(c, user.ID, businessID, user.RoleID, false, superAdminID) {
+		return
+	}
+
+	h.recordSecurityEvent(c, businessID, user.ID, superAdminID, eventImpersonationStart)
+	log.Printf("superadmin %s started impersonating user %s", superAdminID.String(), user.ID.String())
+
+	c.JSON(http.StatusOK, response.Success[any]("Suplantación iniciada", nil))
+}
+
+// StopImpersonation returns the superadmin to their own account, staying in
+// the same business.
+func (h *AuthHandler) StopImpersonation(c *gin.Context) {
+	superAdminID, ok := ctxkeys.ImpersonatorID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "No hay una suplantación activa"))
+		return
+	}
+
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	userID, ok := ctxkeys.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	superAdmin, err := h.repo.GetMeGlobal(c.Request.Context(), superAdminID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al buscar usuario", err))
+		return
+	}
+	if err != nil || superAdmin.RoleValue.String != roleSuperAdmin {
+		c.JSON(http.StatusForbidden, response.Error(http.StatusForbidden, "Suplantación no autorizada"))
+		return
+	}
+
+	if !h.replaceSession(c, superAdmin.ID, businessID, superAdmin.RoleID, true, pgtype.UUID{}) {
+		return
+	}
+
+	h.recordSecurityEvent(c, businessID, userID, superAdminID, eventImpersonationStop)
+	log.Printf("superadmin %s stopped impersonating user %s", superAdminID.String(), userID.String())
+
+	c.JSON(http.StatusOK, response.Success[any]("Suplantación finalizada", nil))
+}
+
+// Helpers
+
+const roleSuperAdmin = "superadmin"
+
+// replaceSession opens a session for the new identity and closes the one
+// making the request, so switching never leaves two sessions behind.
+func (h *AuthHandler) replaceSession(c *gin.Context, userID, businessID, roleID pgtype.UUID, isSuperAdmin bool, impersonatorID pgtype.UUID) bool {
+	currentUser, _ := ctxkeys.UserID(c)
+	currentSession, hasSession := ctxkeys.SessionID(c)
+
+	if !h.startSession(c, userID, businessID, roleID, isSuperAdmin, impersonatorID) {
+		return false
+	}
+
+	if hasSession {
+		if _, err := h.repo.DeleteSession(c.Request.Context(), sqlc.DeleteUserSessionParams{ID: currentSession, UserID: currentUser}); err != nil {
+			log.Printf("failed to close replaced session %s: %v", currentSession.String(), err)
+		}
+	}
+
+	return true
+}
+
+// isSuperAdmin reports whether the user still exists and holds the superadmin
+// role.
+func (h *AuthHandler) isSuperAdmin(ctx context.Context, id pgtype.UUID) (bool, error) {
+	user, err := h.repo.GetMeGlobal(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	return user.RoleValue.String == roleSuperAdmin, nil
+}
+
+// recordSecurityEvent logs the event without failing the request, as the
+// action it describes already happened.
+func (h *AuthHandler) recordSecurityEvent(c *gin.Context, businessID, userID, impersonatorID pgtype.UUID, eventType string) {
+	userAgent, ip := clientInfo(c)
+	if err := h.repo.CreateSecurityEvent(c.Request.Context(), sqlc.CreateSecurityEventParams{
+		BusinessID:     businessID,
+		UserID:         userID,
+		EventType:      eventType,
+		IpAddress:      ip,
+		UserAgent:      userAgent,
+		ImpersonatorID: impersonatorID,
+	}); err != nil {
+		log.Printf("failed to record security event: %v", err)
+	}
+}
+
+func toTenantResponse(b sqlc.Business, current pgtype.UUID) TenantResponse {
+	return TenantResponse{
+		ID:          uuid.UUID(b.ID.Bytes).String(),
+		Slug:        b.Slug,
+		TradeName:   b.TradeName,
+		CompanyName: b.CompanyName,
+		Current:     current.Valid && b.ID == current,
+	}
+}
@@ -0,0 +1,203 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/alanloffler/go-calth-api/internal/common/mfa"
+	"github.com/alanloffler/go-calth-api/internal/common/response"
+	"github.com/alanloffler/go-calth-api/internal/common/token"
+	"github.com/alanloffler/go-calth-api/internal/common/utils"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	twoFactorChallengeTTL = 5 * time.Minute
+	maxTwoFactorAttempts  = 5
+)
+
+type TwoFactorChallengeResponse struct {
+	TwoFactorRequired bool   `json:"twoFactorRequired"`
+	SetupRequired     bool   `json:"setupRequired"`
+	ChallengeToken    string `json:"challengeToken"`
+	ExpiresIn         string `json:"expiresIn"`
+}
+
+type TwoFactorSetupRequest struct {
+	ChallengeToken string `json:"challengeToken" binding:"required"`
+}
+
+type TwoFactorVerifyRequest struct {
+	ChallengeToken string `json:"challengeToken" binding:"required"`
+	Code           string `json:"code" binding:"required"`
+}
+
+type TwoFactorLoginResponse struct {
+	// RecoveryCodes is only set when the login completed the enrollment.
+	RecoveryCodes []string `json:"recoveryCodes,omitempty"`
+}
+
+// startTwoFactorChallenge answers a login whose password was correct with a
+// challenge token for the second step. setupRequired means the business
+// requires two-factor and the user has not enrolled yet.
+func (h *AuthHandler) startTwoFactorChallenge(c *gin.Context, userID, businessID pgtype.UUID, isSuperAdmin, setupRequired bool) {
+	challengeToken, hash, err := token.Generate()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al generar el desafío", err))
+		return
+	}
+
+	if err := h.repo.CreateTwoFactorChallenge(c.Request.Context(), sqlc.CreateTwoFactorChallengeParams{
+		UserID:       userID,
+		BusinessID:   businessID,
+		TokenHash:    hash,
+		IsSuperAdmin: isSuperAdmin,
+		ExpiresAt:    pgtype.Timestamptz{Time: time.Now().Add(twoFactorChallengeTTL), Valid: true},
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al generar el desafío", err))
+		return
+	}
+
+	result := TwoFactorChallengeResponse{
+		TwoFactorRequired: true,
+		SetupRequired:     setupRequired,
+		ChallengeToken:    challengeToken,
+		ExpiresIn:         utils.FormatDuration(twoFactorChallengeTTL),
+	}
+	c.JSON(http.StatusOK, response.Success("Ingresá el código de tu aplicación de autenticación", &result))
+}
+
+// SetupTwoFactor issues the authenticator secret for a user who must enroll
+// to finish logging in.
+func (h *AuthHandler) SetupTwoFactor(c *gin.Context) {
+	var req TwoFactorSetupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Error de validación de datos", err))
+		return
+	}
+
+	challenge, ok := h.twoFactorChallenge(c, req.ChallengeToken)
+	if !ok {
+		return
+	}
+
+	enrollment, err := h.twoFactor.Enroll(c.Request.Context(), challenge.UserID)
+	if err != nil {
+		if errors.Is(err, mfa.ErrAlreadyEnabled) {
+			c.JSON(http.StatusConflict, response.Error(http.StatusConflict, "La verificación en dos pasos ya está activada"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al configurar la verificación en dos pasos", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Success("Escaneá el código con tu aplicación de autenticación", &enrollment))
+}
+
+// VerifyTwoFactor completes the login with a TOTP or recovery code and issues
+// the tokens. For a user enrolling during the login, the code also enables
+// two-factor and the recovery codes are returned.
+func (h *AuthHandler) VerifyTwoFactor(c *gin.Context) {
+	var req TwoFactorVerifyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Error de validación de datos", err))
+		return
+	}
+
+	challenge, ok := h.twoFactorChallenge(c, req.ChallengeToken)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	status, err := h.twoFactor.Status(ctx, challenge.UserID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no encontrado"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener la verificación en dos pasos", err))
+		return
+	}
+
+	var result TwoFactorLoginResponse
+	if status.EnabledAt.Valid {
+		err = h.twoFactor.Verify(ctx, challenge.UserID, req.Code)
+	} else {
+		result.RecoveryCodes, err = h.twoFactor.Enable(ctx, challenge.UserID, req.Code)
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, mfa.ErrInvalidCode):
+			h.failTwoFactorChallenge(c, challenge.ID)
+		case errors.Is(err, mfa.ErrNotEnrolled):
+			c.JSON(http.StatusConflict, response.Error(http.StatusConflict, "Primero configurá la aplicación de autenticación"))
+		default:
+			c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al verificar el código", err))
+		}
+		return
+	}
+
+	if err := h.repo.DeleteTwoFactorChallenge(ctx, challenge.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al verificar el código", err))
+		return
+	}
+
+	if !h.startSession(c, challenge.UserID, challenge.BusinessID, status.RoleID, challenge.IsSuperAdmin, pgtype.UUID{}) {
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Success("Inicio de sesión exitoso", &result))
+}
+
+// twoFactorChallenge resolves a challenge token of the business resolved from
+// the Origin. It writes the error response and returns false when it cannot.
+func (h *AuthHandler) twoFactorChallenge(c *gin.Context, challengeToken string) (sqlc.TwoFactorChallenge, bool) {
+	business, ok := h.businessFromOrigin(c)
+	if !ok {
+		return sqlc.TwoFactorChallenge{}, false
+	}
+
+	challenge, err := h.repo.GetTwoFactorChallenge(c.Request.Context(), sqlc.GetTwoFactorChallengeParams{
+		BusinessID: business.ID,
+		TokenHash:  token.Hash(challengeToken),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Desafío inválido o expirado, iniciá sesión nuevamente"))
+			return sqlc.TwoFactorChallenge{}, false
+		}
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener el desafío", err))
+		return sqlc.TwoFactorChallenge{}, false
+	}
+
+	return challenge, true
+}
+
+// failTwoFactorChallenge counts a wrong code. After maxTwoFactorAttempts the
+// challenge is dropped and the password has to be entered again.
+func (h *AuthHandler) failTwoFactorChallenge(c *gin.Context, challengeID pgtype.UUID) {
+	ctx := c.Request.Context()
+
+	attempts, err := h.repo.IncrementTwoFactorChallengeAttempts(ctx, challengeID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al verificar el código", err))
+		return
+	}
+
+	if attempts >= maxTwoFactorAttempts {
+		if err := h.repo.DeleteTwoFactorChallenge(ctx, challengeID); err != nil {
+			c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al verificar el código", err))
+			return
+		}
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Demasiados intentos, iniciá sesión nuevamente"))
+		return
+	}
+
+	c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Código inválido"))
+}
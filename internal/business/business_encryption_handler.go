@@ -0,0 +1,45 @@
+package business
+
+import (
+	"net/http"
+
+	"github.com/alanloffler/go-calth-api/internal/common/response"
+	"github.com/alanloffler/go-calth-api/internal/queue"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type rotateKeyResponse struct {
+	KeyVersion int32 `json:"keyVersion"`
+}
+
+// RotateEncryptionKey activates a new data key for the business and queues the
+// re-encryption of its existing data. Old keys stay available for reading.
+func (h *BusinessHandler) RotateEncryptionKey(c *gin.Context) {
+	var id pgtype.UUID
+	if err := id.Scan(c.Param("id")); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de Id inválido", err))
+		return
+	}
+
+	if _, err := h.repo.GetOneByID(c.Request.Context(), id); err != nil {
+		c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Negocio no encontrado", err))
+		return
+	}
+
+	version, err := h.keys.Rotate(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al rotar la clave de cifrado", err))
+		return
+	}
+
+	if err := queue.EnqueueBusinessReencryption(h.queueClient, queue.BusinessReencryptionPayload{
+		BusinessID: uuid.UUID(id.Bytes).String(),
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al encolar el recifrado de datos", err))
+		return
+	}
+
+	c.JSON(http.StatusAccepted, response.Success("Clave de cifrado rotada", &rotateKeyResponse{KeyVersion: version}))
+}
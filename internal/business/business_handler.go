@@ -4,9 +4,11 @@ import (
 	"log"
 	"net/http"
 
+	"github.com/alanloffler/go-calth-api/internal/common/encryption"
 	"github.com/alanloffler/go-calth-api/internal/common/response"
 	"github.com/alanloffler/go-calth-api/internal/common/utils"
 	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/alanloffler/go-calth-api/internal/email_verification"
 	"github.com/alanloffler/go-calth-api/internal/queue"
 	"github.com/alanloffler/go-calth-api/internal/user"
 	"github.com/gin-gonic/gin"
@@ -17,15 +19,17 @@ import (
 )
 
 type BusinessHandler struct {
-	repo        *BusinessRepository
-	userRepo    *user.UserRepository
-	pool        *pgxpool.Pool
-	queueClient *asynq.Client
-	appDomain   string
+	repo         *BusinessRepository
+	userRepo     *user.UserRepository
+	pool         *pgxpool.Pool
+	queueClient  *asynq.Client
+	keys         *encryption.Keyring
+	verification *email_verification.Sender
+	appDomain    string
 }
 
-func NewBusinessHandler(repo *BusinessRepository, userRepo *user.UserRepository, pool *pgxpool.Pool, queueClient *asynq.Client, appDomain string) *BusinessHandler {
-	return &BusinessHandler{repo: repo, userRepo: userRepo, pool: pool, queueClient: queueClient, appDomain: appDomain}
+func NewBusinessHandler(repo *BusinessRepository, userRepo *user.UserRepository, pool *pgxpool.Pool, queueClient *asynq.Client, keys *encryption.Keyring, verification *email_verification.Sender, appDomain string) *BusinessHandler {
+	return &BusinessHandler{repo: repo, userRepo: userRepo, pool: pool, queueClient: queueClient, keys: keys, verification: verification, appDomain: appDomain}
 }
 
 type createBusinessData struct {
@@ -82,6 +86,11 @@ type UpdateBusinessRequest struct {
 	PhoneNumber    *string `json:"phoneNumber" binding:"omitempty,len=10,numeric"`
 	WhatsappNumber *string `json:"whatsappNumber" binding:"omitempty,len=10,numeric"`
 	Website        *string `json:"website" binding:"omitempty,min=6"`
+	// Users with an unverified email cannot log in or book appointments.
+	RequireVerifiedEmailLogin   *bool `json:"requireVerifiedEmailLogin"`
+	RequireVerifiedEmailBooking *bool `json:"requireVerifiedEmailBooking"`
+	// Every staff user must log in with two-factor authentication.
+	RequireTwoFactor *bool `json:"requireTwoFactor"`
 }
 
 type BusinessWithUsersResponse struct {
@@ -193,7 +202,7 @@ func (h *BusinessHandler) Create(c *gin.Context) {
 		return
 	}
 
-	_, err = qtx.CreateUser(ctx, sqlc.CreateUserParams{
+	admin, err := qtx.CreateUser(ctx, sqlc.CreateUserParams{
 		Ic:          req.Admin.Ic,
 		UserName:    req.Admin.UserName,
 		FirstName:   req.Admin.FirstName,
@@ -223,6 +232,10 @@ func (h *BusinessHandler) Create(c *gin.Context) {
 		log.Printf("failed to enqueue business_created email: %v", err)
 	}
 
+	if err := h.verification.Send(ctx, business.ID, admin.ID); err != nil {
+		log.Printf("failed to send email verification: %v", err)
+	}
+
 	c.JSON(http.StatusCreated, response.Created("Negocio creado", &business))
 }
 
@@ -316,6 +329,10 @@ func (h *BusinessHandler) Update(c *gin.Context) {
 		PhoneNumber:    utils.ToPgText(req.PhoneNumber),
 		WhatsappNumber: utils.ToPgText(req.WhatsappNumber),
 		Website:        utils.ToPgText(req.Website),
+
+		RequireVerifiedEmailLogin:   utils.ToPgBool(req.RequireVerifiedEmailLogin),
+		RequireVerifiedEmailBooking: utils.ToPgBool(req.RequireVerifiedEmailBooking),
+		RequireTwoFactor:            utils.ToPgBool(req.RequireTwoFactor),
 	})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al actualizar negocio", err))
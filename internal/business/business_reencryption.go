@@ -0,0 +1,241 @@
+package business
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alanloffler/go-calth-api/internal/common/encryption"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const reencryptionBatchSize = 200
+
+// ReencryptData rewrites every encrypted column of a business with its active
+// data key. Legacy plaintext values are encrypted on the way. It runs in the
+// worker after a key rotation and is safe to retry: values that are already
+// current are skipped. Each write only applies if the row still holds the value
+// that was read, so an edit saved meanwhile, already sealed with the active key,
+// is kept. Medical histories written before search existed get their blind
+// index tokens on the same pass.
+func ReencryptData(ctx context.Context, q *sqlc.Queries, keys *encryption.Keyring, businessID pgtype.UUID) error {
+	if err := reencryptMedicalHistories(ctx, q, keys, businessID); err != nil {
+		return fmt.Errorf("reencrypt medical histories: %w", err)
+	}
+	if err := indexMedicalHistories(ctx, q, keys, businessID); err != nil {
+		return fmt.Errorf("index medical histories: %w", err)
+	}
+	if err := reencryptAddenda(ctx, q, keys, businessID); err != nil {
+		return fmt.Errorf("reencrypt addenda: %w", err)
+	}
+	if err := reencryptRevisions(ctx, q, keys, businessID); err != nil {
+		return fmt.Errorf("reencrypt revisions: %w", err)
+	}
+	if err := reencryptPatientProfiles(ctx, q, keys, businessID); err != nil {
+		return fmt.Errorf("reencrypt patient profiles: %w", err)
+	}
+
+	return nil
+}
+
+// reencrypt returns the value sealed with the active key and whether it changed.
+func reencrypt(ctx context.Context, keys *encryption.Keyring, businessID pgtype.UUID, value string) (string, bool, error) {
+	current, err := keys.IsCurrent(ctx, businessID, value)
+	if err != nil || current {
+		return value, false, err
+	}
+
+	plaintext, err := keys.Decrypt(ctx, businessID, value)
+	if err != nil {
+		return "", false, err
+	}
+
+	encrypted, err := keys.Encrypt(ctx, businessID, plaintext)
+	return encrypted, true, err
+}
+
+func reencryptMedicalHistories(ctx context.Context, q *sqlc.Queries, keys *encryption.Keyring, businessID pgtype.UUID) error {
+	var afterID pgtype.UUID = pgtype.UUID{Valid: true}
+	for {
+		rows, err := q.ListMedicalHistoriesForReencryption(ctx, sqlc.ListMedicalHistoriesForReencryptionParams{
+			BusinessID: businessID,
+			AfterID:    afterID,
+			BatchSize:  reencryptionBatchSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			reason, reasonChanged, err := reencrypt(ctx, keys, businessID, row.Reason)
+			if err != nil {
+				return err
+			}
+			comments, commentsChanged, err := reencrypt(ctx, keys, businessID, row.Comments)
+			if err != nil {
+				return err
+			}
+			if !reasonChanged && !commentsChanged {
+				continue
+			}
+
+			if _, err := q.UpdateMedicalHistoryCiphertext(ctx, sqlc.UpdateMedicalHistoryCiphertextParams{
+				BusinessID:  businessID,
+				ID:          row.ID,
+				Reason:      reason,
+				Comments:    comments,
+				OldReason:   row.Reason,
+				OldComments: row.Comments,
+			}); err != nil {
+				return err
+			}
+		}
+
+		if len(rows) < reencryptionBatchSize {
+			return nil
+		}
+		afterID = rows[len(rows)-1].ID
+	}
+}
+
+func reencryptAddenda(ctx context.Context, q *sqlc.Queries, keys *encryption.Keyring, businessID pgtype.UUID) error {
+	var afterID pgtype.UUID = pgtype.UUID{Valid: true}
+	for {
+		rows, err := q.ListMedicalHistoryAddendaForReencryption(ctx, sqlc.ListMedicalHistoryAddendaForReencryptionParams{
+			BusinessID: businessID,
+			AfterID:    afterID,
+			BatchSize:  reencryptionBatchSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			content, changed, err := reencrypt(ctx, keys, businessID, row.Content)
+			if err != nil {
+				return err
+			}
+			if !changed {
+				continue
+			}
+
+			if _, err := q.UpdateMedicalHistoryAddendumCiphertext(ctx, sqlc.UpdateMedicalHistoryAddendumCiphertextParams{
+				BusinessID: businessID,
+				ID:         row.ID,
+				Content:    content,
+				OldContent: row.Content,
+			}); err != nil {
+				return err
+			}
+		}
+
+		if len(rows) < reencryptionBatchSize {
+			return nil
+		}
+		afterID = rows[len(rows)-1].ID
+	}
+}
+
+func reencryptRevisions(ctx context.Context, q *sqlc.Queries, keys *encryption.Keyring, businessID pgtype.UUID) error {
+	var afterID pgtype.UUID = pgtype.UUID{Valid: true}
+	for {
+		rows, err := q.ListMedicalHistoryRevisionsForReencryption(ctx, sqlc.ListMedicalHistoryRevisionsForReencryptionParams{
+			BusinessID: businessID,
+			AfterID:    afterID,
+			BatchSize:  reencryptionBatchSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			changesCurrent, err := keys.IsCurrentJSON(ctx, businessID, row.Changes)
+			if err != nil {
+				return err
+			}
+			snapshotCurrent, err := keys.IsCurrentJSON(ctx, businessID, row.Snapshot)
+			if err != nil {
+				return err
+			}
+			if changesCurrent && snapshotCurrent {
+				continue
+			}
+
+			changes, err := keys.DecryptJSON(ctx, businessID, row.Changes)
+			if err != nil {
+				return err
+			}
+			if changes, err = keys.EncryptJSON(ctx, businessID, changes); err != nil {
+				return err
+			}
+
+			snapshot, err := keys.DecryptJSON(ctx, businessID, row.Snapshot)
+			if err != nil {
+				return err
+			}
+			if snapshot, err = keys.EncryptJSON(ctx, businessID, snapshot); err != nil {
+				return err
+			}
+
+			if _, err := q.UpdateMedicalHistoryRevisionCiphertext(ctx, sqlc.UpdateMedicalHistoryRevisionCiphertextParams{
+				BusinessID:  businessID,
+				ID:          row.ID,
+				Changes:     changes,
+				Snapshot:    snapshot,
+				OldChanges:  row.Changes,
+				OldSnapshot: row.Snapshot,
+			}); err != nil {
+				return err
+			}
+		}
+
+		if len(rows) < reencryptionBatchSize {
+			return nil
+		}
+		afterID = rows[len(rows)-1].ID
+	}
+}
+
+func reencryptPatientProfiles(ctx context.Context, q *sqlc.Queries, keys *encryption.Keyring, businessID pgtype.UUID) error {
+	var afterID pgtype.UUID = pgtype.UUID{Valid: true}
+	for {
+		rows, err := q.ListPatientProfilesForReencryption(ctx, sqlc.ListPatientProfilesForReencryptionParams{
+			BusinessID: businessID,
+			AfterID:    afterID,
+			BatchSize:  reencryptionBatchSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			name, nameChanged, err := reencrypt(ctx, keys, businessID, row.EmergencyContactName)
+			if err != nil {
+				return err
+			}
+			phone, phoneChanged, err := reencrypt(ctx, keys, businessID, row.EmergencyContactPhone)
+			if err != nil {
+				return err
+			}
+			if !nameChanged && !phoneChanged {
+				continue
+			}
+
+			if _, err := q.UpdatePatientProfileCiphertext(ctx, sqlc.UpdatePatientProfileCiphertextParams{
+				BusinessID:               businessID,
+				ID:                       row.ID,
+				EmergencyContactName:     name,
+				EmergencyContactPhone:    phone,
+				OldEmergencyContactName:  row.EmergencyContactName,
+				OldEmergencyContactPhone: row.EmergencyContactPhone,
+			}); err != nil {
+				return err
+			}
+		}
+
+		if len(rows) < reencryptionBatchSize {
+			return nil
+		}
+		afterID = rows[len(rows)-1].ID
+	}
+}
@@ -1,7 +1,10 @@
 package business
 
 import (
+	"github.com/alanloffler/go-calth-api/internal/common/encryption"
+	"github.com/alanloffler/go-calth-api/internal/config"
 	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/alanloffler/go-calth-api/internal/email_verification"
 	"github.com/alanloffler/go-calth-api/internal/middleware"
 	"github.com/alanloffler/go-calth-api/internal/user"
 	"github.com/gin-gonic/gin"
@@ -9,10 +12,11 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-func RegisterRoutes(public *gin.Engine, protected *gin.RouterGroup, q *sqlc.Queries, pool *pgxpool.Pool, queueClient *asynq.Client, appDomain string) {
+func RegisterRoutes(public *gin.Engine, protected *gin.RouterGroup, q *sqlc.Queries, pool *pgxpool.Pool, queueClient *asynq.Client, keys *encryption.Keyring, cfg *config.Config) {
 	var repo *BusinessRepository = NewBusinessRepository(q)
 	var userRepo *user.UserRepository = user.NewUserRepository(q)
-	var handler *BusinessHandler = NewBusinessHandler(repo, userRepo, pool, queueClient, appDomain)
+	var verification *email_verification.Sender = email_verification.NewSender(q, queueClient, cfg)
+	var handler *BusinessHandler = NewBusinessHandler(repo, userRepo, pool, queueClient, keys, verification, cfg.AppDomain)
 
 	public.POST("/businesses", handler.Create)
 	public.GET("/businesses/availability/tax-id/:taxId", handler.CheckTaxIDAvailability)
@@ -23,6 +27,8 @@ func RegisterRoutes(public *gin.Engine, protected *gin.RouterGroup, q *sqlc.Quer
 	businesses.GET("", middleware.PermissionMiddleware(q, "business-view"), handler.GetAll)
 	businesses.GET("/:id", middleware.PermissionMiddleware(q, "business-view"), handler.GetOneByID)
 
+	businesses.POST("/:id/encryption/rotate", middleware.SuperAdminMiddleware(), handler.RotateEncryptionKey)
+
 	businesses.PATCH("/:id", middleware.PermissionMiddleware(q, "business-update"), handler.Update)
 
 	businesses.DELETE("/:id", middleware.PermissionMiddleware(q, "business-delete-hard"), handler.Delete)
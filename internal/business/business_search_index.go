@@ -0,0 +1,63 @@
+package business
+
+import (
+	"context"
+
+	"github.com/alanloffler/go-calth-api/internal/common/encryption"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// SearchIndexStore is the subset of queries the search index backfill needs.
+type SearchIndexStore interface {
+	ListMedicalHistoriesWithoutSearchTokens(ctx context.Context, arg sqlc.ListMedicalHistoriesWithoutSearchTokensParams) ([]sqlc.ListMedicalHistoriesWithoutSearchTokensRow, error)
+	UpsertMedicalHistorySearchTokens(ctx context.Context, arg sqlc.UpsertMedicalHistorySearchTokensParams) error
+}
+
+// BackfillSearchIndex builds the search tokens of the medical histories of a
+// business that have none yet, such as those written before search existed.
+// The worker runs it for every pending business on start, independently of key
+// rotation, and it is safe to retry: indexed histories are skipped.
+func BackfillSearchIndex(ctx context.Context, store SearchIndexStore, keys *encryption.Keyring, businessID pgtype.UUID) error {
+	return indexMedicalHistories(ctx, store, keys, businessID)
+}
+
+// indexMedicalHistories builds the search tokens of medical histories that have
+// none yet.
+func indexMedicalHistories(ctx context.Context, store SearchIndexStore, keys *encryption.Keyring, businessID pgtype.UUID) error {
+	var afterID pgtype.UUID = pgtype.UUID{Valid: true}
+	for {
+		rows, err := store.ListMedicalHistoriesWithoutSearchTokens(ctx, sqlc.ListMedicalHistoriesWithoutSearchTokensParams{
+			BusinessID: businessID,
+			AfterID:    afterID,
+			BatchSize:  reencryptionBatchSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			reason, err := keys.Decrypt(ctx, businessID, row.Reason)
+			if err != nil {
+				return err
+			}
+			comments, err := keys.Decrypt(ctx, businessID, row.Comments)
+			if err != nil {
+				return err
+			}
+
+			if err := store.UpsertMedicalHistorySearchTokens(ctx, sqlc.UpsertMedicalHistorySearchTokensParams{
+				MedicalHistoryID: row.ID,
+				BusinessID:       businessID,
+				Tokens:           keys.SearchTokens(businessID, reason, comments),
+			}); err != nil {
+				return err
+			}
+		}
+
+		if len(rows) < reencryptionBatchSize {
+			return nil
+		}
+		afterID = rows[len(rows)-1].ID
+	}
+}
@@ -0,0 +1,137 @@
+package business
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/alanloffler/go-calth-api/internal/common/encryption"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryStore keeps the data keys and medical histories of a single business.
+type memoryStore struct {
+	keys      []sqlc.BusinessDataKey
+	histories []sqlc.ListMedicalHistoriesWithoutSearchTokensRow
+	tokens    map[[16]byte][]string
+	upserts   int
+}
+
+func (s *memoryStore) CreateBusinessDataKey(_ context.Context, arg sqlc.CreateBusinessDataKeyParams) (sqlc.BusinessDataKey, error) {
+	key := sqlc.BusinessDataKey{BusinessID: arg.BusinessID, Version: int32(len(s.keys) + 1), WrappedKey: arg.WrappedKey}
+	s.keys = append(s.keys, key)
+	return key, nil
+}
+
+func (s *memoryStore) GetActiveBusinessDataKey(_ context.Context, _ pgtype.UUID) (sqlc.BusinessDataKey, error) {
+	if len(s.keys) == 0 {
+		return sqlc.BusinessDataKey{}, pgx.ErrNoRows
+	}
+	return s.keys[len(s.keys)-1], nil
+}
+
+func (s *memoryStore) GetBusinessDataKey(_ context.Context, arg sqlc.GetBusinessDataKeyParams) (sqlc.BusinessDataKey, error) {
+	if arg.Version < 1 || int(arg.Version) > len(s.keys) {
+		return sqlc.BusinessDataKey{}, pgx.ErrNoRows
+	}
+	return s.keys[arg.Version-1], nil
+}
+
+func (s *memoryStore) ListMedicalHistoriesWithoutSearchTokens(_ context.Context, arg sqlc.ListMedicalHistoriesWithoutSearchTokensParams) ([]sqlc.ListMedicalHistoriesWithoutSearchTokensRow, error) {
+	var rows []sqlc.ListMedicalHistoriesWithoutSearchTokensRow
+	for _, h := range s.histories {
+		if _, indexed := s.tokens[h.ID.Bytes]; indexed || bytes.Compare(h.ID.Bytes[:], arg.AfterID.Bytes[:]) <= 0 {
+			continue
+		}
+		if len(rows) == int(arg.BatchSize) {
+			break
+		}
+		rows = append(rows, h)
+	}
+	return rows, nil
+}
+
+func (s *memoryStore) UpsertMedicalHistorySearchTokens(_ context.Context, arg sqlc.UpsertMedicalHistorySearchTokensParams) error {
+	// pgx sends a nil slice as NULL, which the NOT NULL tokens column rejects.
+	if arg.Tokens == nil {
+		return errors.New("null value in column \"tokens\"")
+	}
+	s.tokens[arg.MedicalHistoryID.Bytes] = arg.Tokens
+	s.upserts++
+	return nil
+}
+
+func historyID(b byte) pgtype.UUID {
+	return pgtype.UUID{Bytes: [16]byte{15: b}, Valid: true}
+}
+
+func TestBackfillSearchIndex_IndexesLegacyHistories(t *testing.T) {
+	ctx := context.Background()
+	businessID := pgtype.UUID{Bytes: [16]byte{1}, Valid: true}
+	store := &memoryStore{tokens: make(map[[16]byte][]string)}
+
+	keys, err := encryption.NewKeyring(base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef")), store)
+	require.NoError(t, err)
+
+	encryptedReason, err := keys.Encrypt(ctx, businessID, "Control de presión arterial")
+	require.NoError(t, err)
+	encryptedComments, err := keys.Encrypt(ctx, businessID, "Sin cambios")
+	require.NoError(t, err)
+
+	// The first history predates encryption and stores plaintext.
+	store.histories = []sqlc.ListMedicalHistoriesWithoutSearchTokensRow{
+		{ID: historyID(1), Reason: "Dolor lumbar crónico", Comments: "Se indica reposo"},
+		{ID: historyID(2), Reason: encryptedReason, Comments: encryptedComments},
+	}
+
+	require.NoError(t, BackfillSearchIndex(ctx, store, keys, businessID))
+
+	assert.Subset(t, store.tokens[historyID(1).Bytes], keys.QueryTokens(businessID, "lumbar reposo"))
+	assert.Subset(t, store.tokens[historyID(2).Bytes], keys.QueryTokens(businessID, "presion"))
+	assert.NotSubset(t, store.tokens[historyID(1).Bytes], keys.QueryTokens(businessID, "presion"))
+}
+
+func TestBackfillSearchIndex_SkipsIndexedHistories(t *testing.T) {
+	ctx := context.Background()
+	businessID := pgtype.UUID{Bytes: [16]byte{1}, Valid: true}
+	store := &memoryStore{tokens: make(map[[16]byte][]string)}
+	store.histories = []sqlc.ListMedicalHistoriesWithoutSearchTokensRow{
+		{ID: historyID(1), Reason: "Dolor lumbar", Comments: ""},
+	}
+
+	keys, err := encryption.NewKeyring(base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef")), store)
+	require.NoError(t, err)
+
+	require.NoError(t, BackfillSearchIndex(ctx, store, keys, businessID))
+	require.NoError(t, BackfillSearchIndex(ctx, store, keys, businessID))
+
+	assert.Equal(t, 1, store.upserts)
+}
+
+func TestBackfillSearchIndex_IndexesHistoriesWithoutWords(t *testing.T) {
+	ctx := context.Background()
+	businessID := pgtype.UUID{Bytes: [16]byte{1}, Valid: true}
+	store := &memoryStore{tokens: make(map[[16]byte][]string)}
+	store.histories = []sqlc.ListMedicalHistoriesWithoutSearchTokensRow{
+		{ID: historyID(1), Reason: "a.b", Comments: "x y"},
+	}
+
+	keys, err := encryption.NewKeyring(base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef")), store)
+	require.NoError(t, err)
+
+	require.NoError(t, BackfillSearchIndex(ctx, store, keys, businessID))
+	require.NoError(t, BackfillSearchIndex(ctx, store, keys, businessID))
+
+	// An empty token list still marks the history as indexed.
+	tokens, indexed := store.tokens[historyID(1).Bytes]
+	assert.True(t, indexed)
+	assert.NotNil(t, tokens)
+	assert.Empty(t, tokens)
+	assert.Equal(t, 1, store.upserts)
+}
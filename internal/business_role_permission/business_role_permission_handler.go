@@ -6,6 +6,7 @@ import (
 	"github.com/alanloffler/go-calth-api/internal/common/ctxkeys"
 	"github.com/alanloffler/go-calth-api/internal/common/response"
 	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/alanloffler/go-calth-api/internal/middleware"
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgtype"
 )
@@ -97,6 +98,10 @@ func (h *BusinessRolePermissionHandler) Upsert(c *gin.Context) {
 		return
 	}
 
+	if req.Effect == "grant" && !ctxkeys.IsSuperAdmin(c) && !h.callerHolds(c, businessID, permissionID) {
+		return
+	}
+
 	row, err := h.repo.Upsert(c.Request.Context(), sqlc.UpsertBusinessRolePermissionParams{
 		BusinessID:   businessID,
 		RoleID:       roleID,
@@ -111,6 +116,56 @@ func (h *BusinessRolePermissionHandler) Upsert(c *gin.Context) {
 	c.JSON(http.StatusOK, response.Success("Override guardado", &row))
 }
 
+// callerHolds writes the error response and returns false unless the caller's
+// own role has every permission, so a grant cannot hand out more than the
+// caller already has.
+func (h *BusinessRolePermissionHandler) callerHolds(c *gin.Context, businessID pgtype.UUID, permissionIDs ...pgtype.UUID) bool {
+	callerRoleID, ok := ctxkeys.RoleID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return false
+	}
+
+	holds, err := middleware.CallerHolds(c.Request.Context(), h.repo.q, businessID, callerRoleID, permissionIDs...)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al verificar permisos", err))
+		return false
+	}
+	if !holds {
+		c.JSON(http.StatusForbidden, response.Error(http.StatusForbidden, "No puede otorgar un permiso que no posee"))
+		return false
+	}
+
+	return true
+}
+
+// canLiftDenies applies callerHolds to the deny overrides of a role that a reset
+// would remove, since dropping a deny grants the permission back. A valid
+// permissionID limits the check to that override.
+func (h *BusinessRolePermissionHandler) canLiftDenies(c *gin.Context, businessID, roleID, permissionID pgtype.UUID) bool {
+	if ctxkeys.IsSuperAdmin(c) {
+		return true
+	}
+
+	overrides, err := h.repo.ListOverrides(c.Request.Context(), sqlc.GetBusinessRoleOverridesParams{
+		BusinessID: businessID,
+		RoleID:     roleID,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener overrides", err))
+		return false
+	}
+
+	var denied []pgtype.UUID
+	for _, o := range overrides {
+		if o.Effect == "deny" && (!permissionID.Valid || o.PermissionID == permissionID) {
+			denied = append(denied, o.PermissionID)
+		}
+	}
+
+	return h.callerHolds(c, businessID, denied...)
+}
+
 func (h *BusinessRolePermissionHandler) ResetOne(c *gin.Context) {
 	businessID, ok := ctxkeys.BusinessID(c)
 	if !ok {
@@ -130,6 +185,10 @@ func (h *BusinessRolePermissionHandler) ResetOne(c *gin.Context) {
 		return
 	}
 
+	if !h.canLiftDenies(c, businessID, roleID, permissionID) {
+		return
+	}
+
 	affected, err := h.repo.DeleteOne(c.Request.Context(), sqlc.DeleteBusinessRolePermissionParams{
 		BusinessID:   businessID,
 		RoleID:       roleID,
@@ -160,6 +219,10 @@ func (h *BusinessRolePermissionHandler) ResetAll(c *gin.Context) {
 		return
 	}
 
+	if !h.canLiftDenies(c, businessID, roleID, pgtype.UUID{}) {
+		return
+	}
+
 	_, err := h.repo.DeleteAll(c.Request.Context(), sqlc.DeleteBusinessRoleOverridesParams{
 		BusinessID: businessID,
 		RoleID:     roleID,
@@ -0,0 +1,66 @@
+package clinical_record
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/alanloffler/go-calth-api/internal/common/encryption"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/alanloffler/go-calth-api/internal/storage"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ProcessExport renders a queued export and stores the resulting PDF. It runs
+// in the worker and is safe to retry: completed exports are left untouched.
+func ProcessExport(ctx context.Context, q *sqlc.Queries, keys *encryption.Keyring, store storage.Storage, businessID, exportID pgtype.UUID) error {
+	export, err := q.GetClinicalRecordExport(ctx, sqlc.GetClinicalRecordExportParams{BusinessID: businessID, ID: exportID})
+	if err != nil {
+		return fmt.Errorf("get export: %w", err)
+	}
+
+	if export.Status == "completed" {
+		return nil
+	}
+
+	if err := q.UpdateClinicalRecordExportStatus(ctx, sqlc.UpdateClinicalRecordExportStatusParams{ID: export.ID, Status: "processing"}); err != nil {
+		return fmt.Errorf("mark export processing: %w", err)
+	}
+
+	data, err := Render(ctx, q, keys, export.BusinessID, export.PatientID, export.OwnerID, export.IncludeDeleted)
+	if err != nil {
+		markFailed(ctx, q, export.ID, "Error al generar la historia clínica")
+		if errors.Is(err, ErrPatientNotFound) {
+			// Retrying cannot fix a missing patient.
+			return nil
+		}
+		return err
+	}
+
+	key := fmt.Sprintf("%s/clinical-records/%s.pdf", uuid.UUID(export.BusinessID.Bytes), uuid.UUID(export.ID.Bytes))
+
+	if err := store.Put(ctx, key, bytes.NewReader(data), int64(len(data)), "application/pdf"); err != nil {
+		markFailed(ctx, q, export.ID, "Error al guardar el archivo")
+		return err
+	}
+
+	if err := q.UpdateClinicalRecordExportStatus(ctx, sqlc.UpdateClinicalRecordExportStatusParams{
+		ID:         export.ID,
+		Status:     "completed",
+		StorageKey: pgtype.Text{String: key, Valid: true},
+	}); err != nil {
+		return fmt.Errorf("mark export completed: %w", err)
+	}
+
+	return nil
+}
+
+func markFailed(ctx context.Context, q *sqlc.Queries, id pgtype.UUID, msg string) {
+	_ = q.UpdateClinicalRecordExportStatus(ctx, sqlc.UpdateClinicalRecordExportStatusParams{
+		ID:     id,
+		Status: "failed",
+		Error:  pgtype.Text{String: msg, Valid: true},
+	})
+}
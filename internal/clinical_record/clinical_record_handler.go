@@ -0,0 +1,250 @@
+package clinical_record
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/alanloffler/go-calth-api/internal/common/ctxkeys"
+	"github.com/alanloffler/go-calth-api/internal/common/response"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/alanloffler/go-calth-api/internal/queue"
+	"github.com/alanloffler/go-calth-api/internal/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// syncHistoryLimit is the number of medical histories above which the record
+// has to be exported through the worker instead of rendered in the request.
+const syncHistoryLimit = 100
+
+type ClinicalRecordHandler struct {
+	repo        *ClinicalRecordRepository
+	storage     storage.Storage
+	queueClient *asynq.Client
+}
+
+type CreateExportRequest struct {
+	IncludeDeleted bool `json:"includeDeleted"`
+}
+
+type ExportResponse struct {
+	ID             string  `json:"id"`
+	PatientID      string  `json:"patientId"`
+	RequestedBy    string  `json:"requestedBy"`
+	IncludeDeleted bool    `json:"includeDeleted"`
+	Status         string  `json:"status"`
+	Error          *string `json:"error"`
+	CreatedAt      string  `json:"createdAt"`
+	CompletedAt    *string `json:"completedAt"`
+}
+
+func NewClinicalRecordHandler(repo *ClinicalRecordRepository, store storage.Storage, queueClient *asynq.Client) *ClinicalRecordHandler {
+	return &ClinicalRecordHandler{repo: repo, storage: store, queueClient: queueClient}
+}
+
+func (h *ClinicalRecordHandler) GetPDF(c *gin.Context) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	var patientID pgtype.UUID
+	if err := patientID.Scan(c.Param("id")); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID del paciente inválido", err))
+		return
+	}
+
+	ctx := c.Request.Context()
+	owner := ctxkeys.OwnerScope(c)
+
+	count, err := h.repo.CountHistories(ctx, sqlc.CountMedicalHistoriesByPatientIDParams{BusinessID: businessID, UserID: patientID, OwnerID: owner})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener las historias médicas", err))
+		return
+	}
+	if count > syncHistoryLimit {
+		c.JSON(http.StatusUnprocessableEntity, response.Error(http.StatusUnprocessableEntity, "La historia clínica es demasiado extensa, solicitá una exportación"))
+		return
+	}
+
+	data, err := h.repo.Render(ctx, businessID, patientID, owner, c.Query("includeDeleted") == "true")
+	if err != nil {
+		if errors.Is(err, ErrPatientNotFound) {
+			c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Paciente no encontrado"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al generar la historia clínica", err))
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="historia-clinica-%s.pdf"`, time.Now().Format("20060102")))
+	c.Header("Cache-Control", "private, no-store")
+	c.Data(http.StatusOK, "application/pdf", data)
+}
+
+func (h *ClinicalRecordHandler) CreateExport(c *gin.Context) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	userID, ok := ctxkeys.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	var patientID pgtype.UUID
+	if err := patientID.Scan(c.Param("id")); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID del paciente inválido", err))
+		return
+	}
+
+	var req CreateExportRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Error de validación de datos", err))
+			return
+		}
+	}
+
+	ctx := c.Request.Context()
+
+	if _, err := h.repo.GetPatient(ctx, sqlc.GetClinicalRecordPatientParams{BusinessID: businessID, ID: patientID}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Paciente no encontrado"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al buscar el paciente", err))
+		return
+	}
+
+	export, err := h.repo.CreateExport(ctx, sqlc.CreateClinicalRecordExportParams{
+		BusinessID:     businessID,
+		PatientID:      patientID,
+		RequestedBy:    userID,
+		IncludeDeleted: req.IncludeDeleted,
+		OwnerID:        ctxkeys.OwnerScope(c),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al crear la exportación", err))
+		return
+	}
+
+	if err := queue.EnqueueClinicalRecordExport(h.queueClient, queue.ClinicalRecordExportPayload{
+		ExportID:   uuid.UUID(export.ID.Bytes).String(),
+		BusinessID: uuid.UUID(businessID.Bytes).String(),
+	}); err != nil {
+		_ = h.repo.UpdateExportStatus(ctx, sqlc.UpdateClinicalRecordExportStatusParams{
+			ID:     export.ID,
+			Status: "failed",
+			Error:  pgtype.Text{String: "No se pudo encolar la exportación", Valid: true},
+		})
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al encolar la exportación", err))
+		return
+	}
+
+	result := toExportResponse(export)
+	c.JSON(http.StatusAccepted, response.Success("Exportación en proceso", &result))
+}
+
+func (h *ClinicalRecordHandler) GetExport(c *gin.Context) {
+	export, ok := h.findExport(c)
+	if !ok {
+		return
+	}
+
+	result := toExportResponse(export)
+	c.JSON(http.StatusOK, response.Success("Exportación encontrada", &result))
+}
+
+func (h *ClinicalRecordHandler) DownloadExport(c *gin.Context) {
+	export, ok := h.findExport(c)
+	if !ok {
+		return
+	}
+
+	if export.Status != "completed" || !export.StorageKey.Valid {
+		c.JSON(http.StatusConflict, response.Error(http.StatusConflict, "La exportación todavía no está disponible"))
+		return
+	}
+
+	body, err := h.storage.Get(c.Request.Context(), export.StorageKey.String)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Archivo no encontrado"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al leer el archivo", err))
+		return
+	}
+	defer body.Close()
+
+	c.DataFromReader(http.StatusOK, -1, "application/pdf", body, map[string]string{
+		"Content-Disposition": fmt.Sprintf(`attachment; filename="historia-clinica-%s.pdf"`, export.CreatedAt.Time.Format("20060102")),
+		"Cache-Control":       "private, no-store",
+	})
+}
+
+func (h *ClinicalRecordHandler) findExport(c *gin.Context) (sqlc.ClinicalRecordExport, bool) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return sqlc.ClinicalRecordExport{}, false
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(c.Param("exportId")); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID inválido", err))
+		return sqlc.ClinicalRecordExport{}, false
+	}
+
+	export, err := h.repo.GetExport(c.Request.Context(), sqlc.GetClinicalRecordExportParams{BusinessID: businessID, ID: id})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Exportación no encontrada"))
+			return sqlc.ClinicalRecordExport{}, false
+		}
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener la exportación", err))
+		return sqlc.ClinicalRecordExport{}, false
+	}
+
+	// A restricted professional may only read exports rendered with their own scope.
+	if !ctxkeys.InOwnerScope(c, export.OwnerID) {
+		c.JSON(http.StatusForbidden, response.Error(http.StatusForbidden, "Permisos insuficientes"))
+		return sqlc.ClinicalRecordExport{}, false
+	}
+
+	return export, true
+}
+
+func toExportResponse(e sqlc.ClinicalRecordExport) ExportResponse {
+	var exportErr *string
+	if e.Error.Valid {
+		exportErr = &e.Error.String
+	}
+
+	var completedAt *string
+	if e.CompletedAt.Valid {
+		s := e.CompletedAt.Time.Format(time.RFC3339)
+		completedAt = &s
+	}
+
+	return ExportResponse{
+		ID:             uuid.UUID(e.ID.Bytes).String(),
+		PatientID:      uuid.UUID(e.PatientID.Bytes).String(),
+		RequestedBy:    uuid.UUID(e.RequestedBy.Bytes).String(),
+		IncludeDeleted: e.IncludeDeleted,
+		Status:         e.Status,
+		Error:          exportErr,
+		CreatedAt:      e.CreatedAt.Time.Format(time.RFC3339),
+		CompletedAt:    completedAt,
+	}
+}
@@ -0,0 +1,249 @@
+package clinical_record
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alanloffler/go-calth-api/internal/common/encryption"
+	"github.com/alanloffler/go-calth-api/internal/common/pdf"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var ErrPatientNotFound = errors.New("clinical record: patient not found")
+
+var eventStatusLabels = map[sqlc.EventStatus]string{
+	sqlc.EventStatusAbsent:     "Ausente",
+	sqlc.EventStatusCancelled:  "Cancelado",
+	sqlc.EventStatusInProgress: "En curso",
+	sqlc.EventStatusPending:    "Pendiente",
+	sqlc.EventStatusPresent:    "Presente",
+}
+
+var genderLabels = map[string]string{
+	"male":   "Masculino",
+	"female": "Femenino",
+}
+
+// Render builds the PDF of a patient's clinical record. It is shared by the API,
+// which renders small records inline, and the worker, which handles large exports.
+// A valid ownerID limits the record to what that professional may see.
+func Render(ctx context.Context, q *sqlc.Queries, keys *encryption.Keyring, businessID, patientID, ownerID pgtype.UUID, includeDeleted bool) ([]byte, error) {
+	business, err := q.GetBusiness(ctx, businessID)
+	if err != nil {
+		return nil, fmt.Errorf("get business: %w", err)
+	}
+
+	patient, err := q.GetClinicalRecordPatient(ctx, sqlc.GetClinicalRecordPatientParams{BusinessID: businessID, ID: patientID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrPatientNotFound
+		}
+		return nil, fmt.Errorf("get patient: %w", err)
+	}
+	if patient.EmergencyContactName.String, err = keys.Decrypt(ctx, businessID, patient.EmergencyContactName.String); err != nil {
+		return nil, fmt.Errorf("decrypt patient: %w", err)
+	}
+	if patient.EmergencyContactPhone.String, err = keys.Decrypt(ctx, businessID, patient.EmergencyContactPhone.String); err != nil {
+		return nil, fmt.Errorf("decrypt patient: %w", err)
+	}
+
+	histories, err := q.GetMedicalHistoriesByPatientIDWithSoftDeleted(ctx, sqlc.GetMedicalHistoriesByPatientIDWithSoftDeletedParams{BusinessID: businessID, UserID: patientID, OwnerID: ownerID})
+	if err != nil {
+		return nil, fmt.Errorf("get medical histories: %w", err)
+	}
+	for i := range histories {
+		if histories[i].Reason, err = keys.Decrypt(ctx, businessID, histories[i].Reason); err != nil {
+			return nil, fmt.Errorf("decrypt medical history: %w", err)
+		}
+		if histories[i].Comments, err = keys.Decrypt(ctx, businessID, histories[i].Comments); err != nil {
+			return nil, fmt.Errorf("decrypt medical history: %w", err)
+		}
+	}
+
+	events, err := q.GetClinicalRecordEvents(ctx, sqlc.GetClinicalRecordEventsParams{BusinessID: businessID, UserID: patientID, OwnerID: ownerID})
+	if err != nil {
+		return nil, fmt.Errorf("get events: %w", err)
+	}
+
+	attachments, err := q.GetClinicalRecordAttachments(ctx, sqlc.GetClinicalRecordAttachmentsParams{BusinessID: businessID, UserID: patientID, OwnerID: ownerID})
+	if err != nil {
+		return nil, fmt.Errorf("get attachments: %w", err)
+	}
+
+	prescriptions, err := q.GetPrescriptionsByPatientID(ctx, sqlc.GetPrescriptionsByPatientIDParams{BusinessID: businessID, UserID: patientID, OwnerID: ownerID})
+	if err != nil {
+		return nil, fmt.Errorf("get prescriptions: %w", err)
+	}
+
+	prescriptionIDs := make([]pgtype.UUID, len(prescriptions))
+	for i, pr := range prescriptions {
+		prescriptionIDs[i] = pr.ID
+	}
+
+	items, err := q.GetPrescriptionItemsByPrescriptionIDs(ctx, prescriptionIDs)
+	if err != nil {
+		return nil, fmt.Errorf("get prescription items: %w", err)
+	}
+
+	attachmentsByHistory := make(map[pgtype.UUID][]string)
+	for _, a := range attachments {
+		attachmentsByHistory[a.MedicalHistoryID] = append(attachmentsByHistory[a.MedicalHistoryID], a.FileName)
+	}
+
+	itemsByPrescription := make(map[pgtype.UUID][]sqlc.PrescriptionItem)
+	for _, item := range items {
+		itemsByPrescription[item.PrescriptionID] = append(itemsByPrescription[item.PrescriptionID], item)
+	}
+
+	medicationsByHistory := make(map[pgtype.UUID][]string)
+	for _, pr := range prescriptions {
+		for _, item := range itemsByPrescription[pr.ID] {
+			medicationsByHistory[pr.MedicalHistoryID] = append(medicationsByHistory[pr.MedicalHistoryID],
+				fmt.Sprintf("%s %s - %s, %s", item.Medication, item.Presentation, item.Dose, item.Frequency))
+		}
+	}
+
+	loc, err := time.LoadLocation(business.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	fullName := patient.FirstName + " " + patient.LastName
+	doc := pdf.New("Historia clínica - " + fullName)
+	margin := doc.Margin()
+	right := pdf.PageWidth - margin
+	generatedAt := time.Now().In(loc).Format("02/01/2006 15:04")
+
+	doc.SetHeader(func(d *pdf.Document) {
+		d.Text(margin, margin+14, pdf.Bold, 14, business.TradeName)
+		d.Text(margin, margin+28, pdf.Regular, 8, fmt.Sprintf("%s, %s, %s - Tel: %s - %s", business.Street, business.City, business.Province, business.PhoneNumber, business.Email))
+		d.TextRight(right, margin+14, pdf.Bold, 11, "Historia clínica")
+		d.TextRight(right, margin+28, pdf.Regular, 8, fullName+" - DNI "+patient.Ic)
+		d.Line(margin, margin+36, right, margin+36, 1)
+		d.SetY(margin + 48)
+	})
+	doc.SetFooter(20, func(d *pdf.Document, page, total int) {
+		y := pdf.PageHeight - margin + 10
+		d.Text(margin, y, pdf.Regular, 7, "Documento confidencial - Generado el "+generatedAt)
+		d.TextRight(right, y, pdf.Regular, 8, fmt.Sprintf("Página %d de %d", page, total))
+	})
+	doc.AddPage()
+
+	// Patient
+	section(doc, "Datos del paciente")
+	field(doc, "Nombre", fullName)
+	field(doc, "DNI", patient.Ic)
+	field(doc, "Email", patient.Email)
+	field(doc, "Teléfono", patient.PhoneNumber)
+	if patient.Gender.Valid {
+		field(doc, "Género", label(genderLabels, patient.Gender.String))
+	}
+	if patient.BirthDay.Valid {
+		field(doc, "Fecha de nacimiento", fmt.Sprintf("%s (%d años)", patient.BirthDay.Time.Format("02/01/2006"), age(patient.BirthDay.Time, time.Now())))
+	}
+	if patient.BloodType.Valid {
+		field(doc, "Grupo sanguíneo", patient.BloodType.String)
+	}
+	if v, ok := numeric(patient.Weight); ok {
+		field(doc, "Peso", fmt.Sprintf("%.1f kg", v))
+	}
+	if v, ok := numeric(patient.Height); ok {
+		field(doc, "Altura", fmt.Sprintf("%.0f cm", v))
+	}
+	if patient.EmergencyContactName.Valid {
+		field(doc, "Contacto de emergencia", fmt.Sprintf("%s (%s)", patient.EmergencyContactName.String, patient.EmergencyContactPhone.String))
+	}
+
+	// Medical histories
+	section(doc, "Historias médicas")
+	written := 0
+	for _, mh := range histories {
+		if mh.DeletedAt.Valid && !includeDeleted {
+			continue
+		}
+		written++
+
+		doc.EnsureSpace(60)
+		title := fmt.Sprintf("%s - %s", mh.Date.Time.In(loc).Format("02/01/2006 15:04"), professionalName(mh.ProfessionalPrefix, mh.FirstName_2, mh.LastName_2))
+		if mh.DeletedAt.Valid {
+			title += " (eliminada)"
+		}
+		doc.Paragraph(pdf.Bold, 10, title)
+		field(doc, "Motivo / diagnóstico", mh.Reason)
+		doc.Paragraph(pdf.Regular, 9, mh.Comments)
+
+		if meds := medicationsByHistory[mh.ID]; len(meds) > 0 {
+			field(doc, "Medicación indicada", strings.Join(meds, "; "))
+		}
+		if files := attachmentsByHistory[mh.ID]; len(files) > 0 {
+			field(doc, "Adjuntos", strings.Join(files, ", "))
+		}
+		doc.Rule()
+	}
+	if written == 0 {
+		doc.Paragraph(pdf.Regular, 9, "Sin historias médicas registradas.")
+	}
+
+	// Appointments
+	section(doc, "Turnos")
+	if len(events) == 0 {
+		doc.Paragraph(pdf.Regular, 9, "Sin turnos registrados.")
+	}
+	for _, e := range events {
+		doc.Paragraph(pdf.Regular, 9, fmt.Sprintf("%s  %s - %s  |  %s  |  %s",
+			e.StartDate.Time.In(loc).Format("02/01/2006"),
+			e.StartDate.Time.In(loc).Format("15:04"),
+			e.EndDate.Time.In(loc).Format("15:04"),
+			professionalName(e.ProfessionalPrefix, e.FirstName, e.LastName),
+			label(eventStatusLabels, e.Status),
+		))
+	}
+
+	return doc.Bytes()
+}
+
+func section(doc *pdf.Document, title string) {
+	doc.EnsureSpace(40)
+	doc.MoveDown(10)
+	doc.Paragraph(pdf.Bold, 12, title)
+	doc.Rule()
+}
+
+func field(doc *pdf.Document, name, value string) {
+	doc.Paragraph(pdf.Regular, 9, name+": "+value)
+}
+
+func label[K comparable](labels map[K]string, key K) string {
+	if l, ok := labels[key]; ok {
+		return l
+	}
+	return fmt.Sprint(key)
+}
+
+func professionalName(prefix, firstName, lastName pgtype.Text) string {
+	return strings.TrimSpace(strings.Join([]string{prefix.String, firstName.String, lastName.String}, " "))
+}
+
+func numeric(n pgtype.Numeric) (float64, bool) {
+	if !n.Valid {
+		return 0, false
+	}
+	f, err := n.Float64Value()
+	if err != nil || !f.Valid {
+		return 0, false
+	}
+	return f.Float64, true
+}
+
+func age(birth, now time.Time) int {
+	years := now.Year() - birth.Year()
+	if now.Month() < birth.Month() || (now.Month() == birth.Month() && now.Day() < birth.Day()) {
+		years--
+	}
+	return years
+}
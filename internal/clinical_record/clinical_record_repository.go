@@ -0,0 +1,42 @@
+package clinical_record
+
+import (
+	"context"
+
+	"github.com/alanloffler/go-calth-api/internal/common/encryption"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type ClinicalRecordRepository struct {
+	q    *sqlc.Queries
+	keys *encryption.Keyring
+}
+
+func NewClinicalRecordRepository(q *sqlc.Queries, keys *encryption.Keyring) *ClinicalRecordRepository {
+	return &ClinicalRecordRepository{q: q, keys: keys}
+}
+
+func (r *ClinicalRecordRepository) Render(ctx context.Context, businessID, patientID, ownerID pgtype.UUID, includeDeleted bool) ([]byte, error) {
+	return Render(ctx, r.q, r.keys, businessID, patientID, ownerID, includeDeleted)
+}
+
+func (r *ClinicalRecordRepository) GetPatient(ctx context.Context, arg sqlc.GetClinicalRecordPatientParams) (sqlc.GetClinicalRecordPatientRow, error) {
+	return r.q.GetClinicalRecordPatient(ctx, arg)
+}
+
+func (r *ClinicalRecordRepository) CountHistories(ctx context.Context, arg sqlc.CountMedicalHistoriesByPatientIDParams) (int64, error) {
+	return r.q.CountMedicalHistoriesByPatientID(ctx, arg)
+}
+
+func (r *ClinicalRecordRepository) CreateExport(ctx context.Context, arg sqlc.CreateClinicalRecordExportParams) (sqlc.ClinicalRecordExport, error) {
+	return r.q.CreateClinicalRecordExport(ctx, arg)
+}
+
+func (r *ClinicalRecordRepository) GetExport(ctx context.Context, arg sqlc.GetClinicalRecordExportParams) (sqlc.ClinicalRecordExport, error) {
+	return r.q.GetClinicalRecordExport(ctx, arg)
+}
+
+func (r *ClinicalRecordRepository) UpdateExportStatus(ctx context.Context, arg sqlc.UpdateClinicalRecordExportStatusParams) error {
+	return r.q.UpdateClinicalRecordExportStatus(ctx, arg)
+}
@@ -0,0 +1,31 @@
+package clinical_record
+
+import (
+	"context"
+
+	"github.com/alanloffler/go-calth-api/internal/common/encryption"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/alanloffler/go-calth-api/internal/middleware"
+	"github.com/alanloffler/go-calth-api/internal/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func RegisterRoutes(router *gin.RouterGroup, q *sqlc.Queries, store storage.Storage, keys *encryption.Keyring, queueClient *asynq.Client) {
+	var repo *ClinicalRecordRepository = NewClinicalRecordRepository(q, keys)
+	var handler *ClinicalRecordHandler = NewClinicalRecordHandler(repo, store, queueClient)
+	var records *gin.RouterGroup = router.Group("/clinical-records")
+	var exportPatient middleware.PatientLookup = func(ctx context.Context, businessID, id pgtype.UUID) (pgtype.UUID, error) {
+		export, err := q.GetClinicalRecordExport(ctx, sqlc.GetClinicalRecordExportParams{BusinessID: businessID, ID: id})
+		return export.PatientID, err
+	}
+
+	records.Use(middleware.OwnershipMiddleware(q, "medical_history-all"))
+
+	records.POST("/:id/exports", middleware.PermissionMiddleware(q, "medical_history-view"), handler.CreateExport)
+
+	records.GET("/exports/:exportId", middleware.PermissionMiddleware(q, "medical_history-view"), handler.GetExport)
+	records.GET("/exports/:exportId/download", middleware.PermissionMiddleware(q, "medical_history-view"), middleware.AuditMiddleware(q, "clinical_record", "exportId", exportPatient), handler.DownloadExport)
+	records.GET("/:id/pdf", middleware.PermissionMiddleware(q, "medical_history-view"), middleware.AuditMiddleware(q, "clinical_record", "id", nil), handler.GetPDF)
+}
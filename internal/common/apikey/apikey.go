@@ -0,0 +1,39 @@
+// Package apikey issues the keys integrations send as a bearer token instead
+// of logging in. Only their hash is stored.
+package apikey
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/alanloffler/go-calth-api/internal/common/token"
+)
+
+// Prefix starts every key, so it can be told apart from an access token.
+const Prefix = "calth_"
+
+// displayLength is how much of the key is kept in clear to identify it in
+// listings.
+const displayLength = len(Prefix) + 6
+
+// Generate returns a new key to hand to the user once, its display prefix and
+// the hash to store in its place.
+func Generate() (key, prefix, hash string, err error) {
+	secret, _, err := token.Generate()
+	if err != nil {
+		return "", "", "", err
+	}
+
+	key = Prefix + secret
+	return key, key[:displayLength], token.Hash(key), nil
+}
+
+// IsKey reports whether a bearer token is an API key.
+func IsKey(bearer string) bool {
+	return strings.HasPrefix(bearer, Prefix)
+}
+
+// Allows reports whether scopes grant the permission action key.
+func Allows(scopes []string, actionKey string) bool {
+	return slices.Contains(scopes, actionKey)
+}
@@ -0,0 +1,37 @@
+package apikey
+
+import (
+	"testing"
+
+	"github.com/alanloffler/go-calth-api/internal/common/token"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	key, prefix, hash, err := Generate()
+	require.NoError(t, err)
+
+	assert.True(t, IsKey(key))
+	assert.Len(t, prefix, 12)
+	assert.Equal(t, key[:12], prefix)
+	assert.Equal(t, token.Hash(key), hash)
+
+	other, _, _, err := Generate()
+	require.NoError(t, err)
+	assert.NotEqual(t, key, other)
+}
+
+func TestIsKey(t *testing.T) {
+	assert.True(t, IsKey("calth_abc"))
+	assert.False(t, IsKey("eyJhbGciOiJIUzI1NiJ9.e30.x"))
+	assert.False(t, IsKey(""))
+}
+
+func TestAllows(t *testing.T) {
+	scopes := []string{"events-view", "patients-view"}
+
+	assert.True(t, Allows(scopes, "events-view"))
+	assert.False(t, Allows(scopes, "events-create"))
+	assert.False(t, Allows(nil, "events-view"))
+}
@@ -17,6 +17,59 @@ func UserID(c *gin.Context) (pgtype.UUID, bool) {
 	return scanUUID(c, "userID")
 }
 
+// SessionID returns the session of the access token. Tokens issued before
+// sessions existed have none.
+func SessionID(c *gin.Context) (pgtype.UUID, bool) {
+	return scanUUID(c, "sessionID")
+}
+
+// ImpersonatorID returns the superadmin acting as the user of the request.
+// ok is false outside an impersonation.
+func ImpersonatorID(c *gin.Context) (pgtype.UUID, bool) {
+	return scanUUID(c, "impersonatorID")
+}
+
+// APIKeyID returns the API key the request was authenticated with. Requests
+// made with an access token have none.
+func APIKeyID(c *gin.Context) (pgtype.UUID, bool) {
+	return scanUUID(c, "apiKeyID")
+}
+
+// APIKeyScopes returns the permission action keys granted to the API key of
+// the request. ok is false for requests made with an access token.
+func APIKeyScopes(c *gin.Context) (scopes []string, ok bool) {
+	val, exists := c.Get("apiKeyScopes")
+	if !exists {
+		return nil, false
+	}
+	scopes, ok = val.([]string)
+	return scopes, ok
+}
+
+// OwnerScope returns the user the request is restricted to by the ownership
+// policy. The UUID is not valid when the caller may access every resource.
+func OwnerScope(c *gin.Context) pgtype.UUID {
+	id, _ := scanUUID(c, "ownerScope")
+	return id
+}
+
+// InOwnerScope reports whether the request may act on resources owned by userID.
+func InOwnerScope(c *gin.Context, userID pgtype.UUID) bool {
+	owner := OwnerScope(c)
+	return !owner.Valid || owner == userID
+}
+
+// HasClinicalAccess reports whether the caller may see the clinical data of the
+// patient in the request, as decided by middleware.ClinicalAccessMiddleware.
+func HasClinicalAccess(c *gin.Context) bool {
+	val, exists := c.Get("clinicalAccess")
+	if !exists {
+		return false
+	}
+	b, ok := val.(bool)
+	return ok && b
+}
+
 func IsSuperAdmin(c *gin.Context) bool {
 	val, exists := c.Get("isSuperAdmin")
 	if !exists {
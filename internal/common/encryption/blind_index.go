@@ -0,0 +1,105 @@
+package encryption
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Words shorter than minTokenLength are not indexed, and longer words are
+// indexed by their prefixes up to maxTokenLength runes so a search for the
+// beginning of a word still matches.
+const (
+	minTokenLength = 3
+	maxTokenLength = 12
+)
+
+// deriveIndexKey derives the blind index key from the master key. Unlike data
+// keys it never rotates, otherwise every stored token would need rebuilding.
+func deriveIndexKey(masterKey []byte) []byte {
+	mac := hmac.New(sha256.New, masterKey)
+	mac.Write([]byte("calth blind index v1"))
+	return mac.Sum(nil)
+}
+
+// SearchTokens returns the blind index tokens for the words in texts. Tokens
+// are keyed per business, so equal words never produce equal tokens across
+// tenants.
+func (k *Keyring) SearchTokens(businessID pgtype.UUID, texts ...string) []string {
+	seen := make(map[string]struct{})
+	for _, text := range texts {
+		for _, word := range words(text) {
+			runes := []rune(word)
+			for n := minTokenLength; n <= len(runes) && n <= maxTokenLength; n++ {
+				seen[string(runes[:n])] = struct{}{}
+			}
+		}
+	}
+
+	return k.tokens(businessID, seen)
+}
+
+// QueryTokens returns the tokens an indexed text must contain to match query.
+// Every word of the query has to match, either whole or as a word prefix. An
+// empty result means the query has nothing searchable.
+func (k *Keyring) QueryTokens(businessID pgtype.UUID, query string) []string {
+	seen := make(map[string]struct{})
+	for _, word := range words(query) {
+		runes := []rune(word)
+		if len(runes) < minTokenLength {
+			continue
+		}
+		if len(runes) > maxTokenLength {
+			runes = runes[:maxTokenLength]
+		}
+		seen[string(runes)] = struct{}{}
+	}
+
+	return k.tokens(businessID, seen)
+}
+
+func (k *Keyring) tokens(businessID pgtype.UUID, terms map[string]struct{}) []string {
+	// Never nil: pgx stores a nil slice as NULL and the tokens column is NOT NULL.
+	if len(terms) == 0 {
+		return []string{}
+	}
+
+	key := hmac.New(sha256.New, k.index)
+	key.Write(businessID.Bytes[:])
+	businessKey := key.Sum(nil)
+
+	tokens := make([]string, 0, len(terms))
+	for term := range terms {
+		mac := hmac.New(sha256.New, businessKey)
+		mac.Write([]byte(term))
+		// 12 bytes keep collisions negligible while holding the index small.
+		tokens = append(tokens, base64.RawStdEncoding.EncodeToString(mac.Sum(nil)[:12]))
+	}
+	sort.Strings(tokens)
+
+	return tokens
+}
+
+// words lowercases text, strips accents and splits it on anything that is not
+// a letter or a digit, so "Diabetes Mellitus tipo 2" and "diabétes" compare
+// alike.
+func words(text string) []string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(strings.ToLower(text)) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		default:
+			b.WriteRune(' ')
+		}
+	}
+
+	return strings.Fields(b.String())
+}
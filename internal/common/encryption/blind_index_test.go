@@ -0,0 +1,56 @@
+package encryption
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWords(t *testing.T) {
+	assert.Equal(t, []string{"diabetes", "mellitus", "tipo", "2"}, words("Diabétes  Mellitus, tipo-2"))
+	assert.Empty(t, words(" ..; "))
+}
+
+func TestKeyring_QueryMatchesIndexedText(t *testing.T) {
+	k := newTestKeyring(t, newMemoryKeyStore())
+	businessID := testBusinessID(1)
+
+	indexed := k.SearchTokens(businessID, "Dolor lumbar crónico", "Se indica reposo")
+
+	assert.Subset(t, indexed, k.QueryTokens(businessID, "lumbar"))
+	assert.Subset(t, indexed, k.QueryTokens(businessID, "CRONICO"))
+	assert.Subset(t, indexed, k.QueryTokens(businessID, "lumb repo"))
+	assert.NotSubset(t, indexed, k.QueryTokens(businessID, "lumbar fiebre"))
+}
+
+func TestKeyring_QueryTokensSkipsShortWords(t *testing.T) {
+	k := newTestKeyring(t, newMemoryKeyStore())
+
+	assert.Empty(t, k.QueryTokens(testBusinessID(1), "de la"))
+	assert.Len(t, k.QueryTokens(testBusinessID(1), "de electrocardiograma"), 1)
+}
+
+func TestKeyring_LongWordsMatchByPrefix(t *testing.T) {
+	k := newTestKeyring(t, newMemoryKeyStore())
+	businessID := testBusinessID(1)
+
+	indexed := k.SearchTokens(businessID, "electrocardiograma")
+
+	assert.Subset(t, indexed, k.QueryTokens(businessID, "electrocardiogramas"))
+	assert.Len(t, indexed, maxTokenLength-minTokenLength+1)
+}
+
+func TestKeyring_SearchTokensBoundToBusiness(t *testing.T) {
+	k := newTestKeyring(t, newMemoryKeyStore())
+
+	assert.NotEqual(t, k.QueryTokens(testBusinessID(1), "lumbar"), k.QueryTokens(testBusinessID(2), "lumbar"))
+}
+
+func TestKeyring_SearchTokensNeverNil(t *testing.T) {
+	k := newTestKeyring(t, newMemoryKeyStore())
+
+	indexed := k.SearchTokens(testBusinessID(1), "a.b", "x y")
+
+	assert.NotNil(t, indexed)
+	assert.Empty(t, indexed)
+}
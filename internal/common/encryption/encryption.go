@@ -0,0 +1,355 @@
+package encryption
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// prefix marks a value produced by Encrypt. Anything without it is treated as
+// legacy plaintext and returned unchanged by Decrypt.
+const prefix = "enc:v1:"
+
+// activeKeyTTL bounds how long a process keeps using a data key after another
+// process rotated it.
+const activeKeyTTL = time.Minute
+
+var (
+	ErrInvalidMasterKey = errors.New("encryption master key must be 32 bytes encoded in base64")
+	ErrMalformed        = errors.New("malformed encrypted value")
+)
+
+// KeyStore persists the wrapped per-business data keys.
+type KeyStore interface {
+	CreateBusinessDataKey(ctx context.Context, arg sqlc.CreateBusinessDataKeyParams) (sqlc.BusinessDataKey, error)
+	GetActiveBusinessDataKey(ctx context.Context, businessID pgtype.UUID) (sqlc.BusinessDataKey, error)
+	GetBusinessDataKey(ctx context.Context, arg sqlc.GetBusinessDataKeyParams) (sqlc.BusinessDataKey, error)
+}
+
+type keyRef struct {
+	businessID [16]byte
+	version    int32
+}
+
+type activeKey struct {
+	version int32
+	expires time.Time
+}
+
+// Keyring implements envelope encryption: values are sealed with AES-GCM using a
+// per-business data key, and data keys are stored wrapped by the master key.
+type Keyring struct {
+	store  KeyStore
+	master cipher.AEAD
+	index  []byte
+
+	mu     sync.RWMutex
+	keys   map[keyRef]cipher.AEAD
+	active map[[16]byte]activeKey
+}
+
+func NewKeyring(masterKey string, store KeyStore) (*Keyring, error) {
+	raw, err := base64.StdEncoding.DecodeString(masterKey)
+	if err != nil || len(raw) != 32 {
+		return nil, ErrInvalidMasterKey
+	}
+
+	master, err := newAEAD(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Keyring{
+		store:  store,
+		master: master,
+		index:  deriveIndexKey(raw),
+		keys:   make(map[keyRef]cipher.AEAD),
+		active: make(map[[16]byte]activeKey),
+	}, nil
+}
+
+// Encrypt seals plaintext with the active data key of the business. Empty
+// strings are stored as-is so optional fields stay empty.
+func (k *Keyring) Encrypt(ctx context.Context, businessID pgtype.UUID, plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+
+	version, aead, err := k.activeKey(ctx, businessID)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), businessID.Bytes[:])
+	return prefix + strconv.Itoa(int(version)) + ":" + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt opens a value produced by Encrypt. Values without the encryption
+// prefix are returned unchanged.
+func (k *Keyring) Decrypt(ctx context.Context, businessID pgtype.UUID, value string) (string, error) {
+	version, payload, ok, err := parse(value)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return value, nil
+	}
+
+	aead, err := k.key(ctx, businessID, version)
+	if err != nil {
+		return "", err
+	}
+
+	sealed, err := base64.RawStdEncoding.DecodeString(payload)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", ErrMalformed
+	}
+
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], businessID.Bytes[:])
+	if err != nil {
+		return "", fmt.Errorf("decrypt value: %w", err)
+	}
+
+	return string(plaintext), nil
+}
+
+// EncryptText encrypts a nullable text, leaving NULL untouched.
+func (k *Keyring) EncryptText(ctx context.Context, businessID pgtype.UUID, value pgtype.Text) (pgtype.Text, error) {
+	if !value.Valid {
+		return value, nil
+	}
+
+	encrypted, err := k.Encrypt(ctx, businessID, value.String)
+	if err != nil {
+		return pgtype.Text{}, err
+	}
+
+	return pgtype.Text{String: encrypted, Valid: true}, nil
+}
+
+// EncryptJSON encrypts a JSON document into a JSON string so it still fits a
+// JSONB column.
+func (k *Keyring) EncryptJSON(ctx context.Context, businessID pgtype.UUID, document []byte) ([]byte, error) {
+	if len(document) == 0 {
+		return document, nil
+	}
+
+	encrypted, err := k.Encrypt(ctx, businessID, string(document))
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(encrypted)
+}
+
+// DecryptJSON reverses EncryptJSON. Documents that were never encrypted are
+// returned unchanged.
+func (k *Keyring) DecryptJSON(ctx context.Context, businessID pgtype.UUID, document []byte) ([]byte, error) {
+	var value string
+	if len(document) == 0 || document[0] != '"' || json.Unmarshal(document, &value) != nil || !strings.HasPrefix(value, prefix) {
+		return document, nil
+	}
+
+	plaintext, err := k.Decrypt(ctx, businessID, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(plaintext), nil
+}
+
+// IsCurrent reports whether value is already encrypted with the active data
+// key of the business, so the re-encryption job can skip it.
+func (k *Keyring) IsCurrent(ctx context.Context, businessID pgtype.UUID, value string) (bool, error) {
+	if value == "" {
+		return true, nil
+	}
+
+	version, _, ok, err := parse(value)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	active, _, err := k.activeKey(ctx, businessID)
+	if err != nil {
+		return false, err
+	}
+
+	return version == active, nil
+}
+
+// IsCurrentJSON is the JSONB counterpart of IsCurrent.
+func (k *Keyring) IsCurrentJSON(ctx context.Context, businessID pgtype.UUID, document []byte) (bool, error) {
+	var value string
+	if len(document) == 0 {
+		return true, nil
+	}
+	if document[0] != '"' || json.Unmarshal(document, &value) != nil {
+		return false, nil
+	}
+
+	return k.IsCurrent(ctx, businessID, value)
+}
+
+// Rotate creates a new data key for the business and makes it the active one.
+// Existing ciphertexts keep working until they are re-encrypted.
+func (k *Keyring) Rotate(ctx context.Context, businessID pgtype.UUID) (int32, error) {
+	return k.createKey(ctx, businessID)
+}
+
+func (k *Keyring) activeKey(ctx context.Context, businessID pgtype.UUID) (int32, cipher.AEAD, error) {
+	k.mu.RLock()
+	cached, ok := k.active[businessID.Bytes]
+	k.mu.RUnlock()
+
+	if ok && time.Now().Before(cached.expires) {
+		aead, err := k.key(ctx, businessID, cached.version)
+		return cached.version, aead, err
+	}
+
+	row, err := k.store.GetActiveBusinessDataKey(ctx, businessID)
+	var version int32
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		if version, err = k.createKey(ctx, businessID); err != nil {
+			// Another process may have created the first key concurrently; use theirs.
+			existing, getErr := k.store.GetActiveBusinessDataKey(ctx, businessID)
+			if getErr != nil {
+				return 0, nil, err
+			}
+			version = existing.Version
+		}
+	case err != nil:
+		return 0, nil, fmt.Errorf("load active data key: %w", err)
+	default:
+		if _, err := k.unwrap(businessID, row); err != nil {
+			return 0, nil, err
+		}
+		version = row.Version
+	}
+
+	k.mu.Lock()
+	k.active[businessID.Bytes] = activeKey{version: version, expires: time.Now().Add(activeKeyTTL)}
+	k.mu.Unlock()
+
+	aead, err := k.key(ctx, businessID, version)
+	return version, aead, err
+}
+
+func (k *Keyring) key(ctx context.Context, businessID pgtype.UUID, version int32) (cipher.AEAD, error) {
+	ref := keyRef{businessID: businessID.Bytes, version: version}
+
+	k.mu.RLock()
+	aead, ok := k.keys[ref]
+	k.mu.RUnlock()
+	if ok {
+		return aead, nil
+	}
+
+	row, err := k.store.GetBusinessDataKey(ctx, sqlc.GetBusinessDataKeyParams{BusinessID: businessID, Version: version})
+	if err != nil {
+		return nil, fmt.Errorf("load data key version %d: %w", version, err)
+	}
+
+	return k.unwrap(businessID, row)
+}
+
+func (k *Keyring) createKey(ctx context.Context, businessID pgtype.UUID) (int32, error) {
+	dek := make([]byte, 32)
+	if _, err := rand.Read(dek); err != nil {
+		return 0, err
+	}
+
+	nonce := make([]byte, k.master.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return 0, err
+	}
+
+	row, err := k.store.CreateBusinessDataKey(ctx, sqlc.CreateBusinessDataKeyParams{
+		BusinessID: businessID,
+		WrappedKey: k.master.Seal(nonce, nonce, dek, businessID.Bytes[:]),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("create data key: %w", err)
+	}
+
+	if _, err := k.unwrap(businessID, row); err != nil {
+		return 0, err
+	}
+
+	k.mu.Lock()
+	k.active[businessID.Bytes] = activeKey{version: row.Version, expires: time.Now().Add(activeKeyTTL)}
+	k.mu.Unlock()
+
+	return row.Version, nil
+}
+
+// unwrap decrypts a stored data key with the master key and caches it.
+func (k *Keyring) unwrap(businessID pgtype.UUID, row sqlc.BusinessDataKey) (cipher.AEAD, error) {
+	nonceSize := k.master.NonceSize()
+	if len(row.WrappedKey) < nonceSize {
+		return nil, ErrMalformed
+	}
+
+	dek, err := k.master.Open(nil, row.WrappedKey[:nonceSize], row.WrappedKey[nonceSize:], businessID.Bytes[:])
+	if err != nil {
+		return nil, fmt.Errorf("unwrap data key version %d: %w", row.Version, err)
+	}
+
+	aead, err := newAEAD(dek)
+	if err != nil {
+		return nil, err
+	}
+
+	k.mu.Lock()
+	k.keys[keyRef{businessID: businessID.Bytes, version: row.Version}] = aead
+	k.mu.Unlock()
+
+	return aead, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// parse splits "enc:v1:<version>:<payload>". ok is false for plaintext values.
+func parse(value string) (version int32, payload string, ok bool, err error) {
+	if !strings.HasPrefix(value, prefix) {
+		return 0, "", false, nil
+	}
+
+	versionStr, payload, found := strings.Cut(strings.TrimPrefix(value, prefix), ":")
+	if !found {
+		return 0, "", false, ErrMalformed
+	}
+
+	v, err := strconv.ParseInt(versionStr, 10, 32)
+	if err != nil || v < 1 {
+		return 0, "", false, ErrMalformed
+	}
+
+	return int32(v), payload, true, nil
+}
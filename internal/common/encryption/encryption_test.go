@@ -0,0 +1,177 @@
+package encryption
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryKeyStore struct {
+	keys map[[16]byte][]sqlc.BusinessDataKey
+}
+
+func newMemoryKeyStore() *memoryKeyStore {
+	return &memoryKeyStore{keys: make(map[[16]byte][]sqlc.BusinessDataKey)}
+}
+
+func (s *memoryKeyStore) CreateBusinessDataKey(_ context.Context, arg sqlc.CreateBusinessDataKeyParams) (sqlc.BusinessDataKey, error) {
+	key := sqlc.BusinessDataKey{
+		BusinessID: arg.BusinessID,
+		Version:    int32(len(s.keys[arg.BusinessID.Bytes]) + 1),
+		WrappedKey: arg.WrappedKey,
+	}
+	s.keys[arg.BusinessID.Bytes] = append(s.keys[arg.BusinessID.Bytes], key)
+	return key, nil
+}
+
+func (s *memoryKeyStore) GetActiveBusinessDataKey(_ context.Context, businessID pgtype.UUID) (sqlc.BusinessDataKey, error) {
+	keys := s.keys[businessID.Bytes]
+	if len(keys) == 0 {
+		return sqlc.BusinessDataKey{}, pgx.ErrNoRows
+	}
+	return keys[len(keys)-1], nil
+}
+
+func (s *memoryKeyStore) GetBusinessDataKey(_ context.Context, arg sqlc.GetBusinessDataKeyParams) (sqlc.BusinessDataKey, error) {
+	keys := s.keys[arg.BusinessID.Bytes]
+	if arg.Version < 1 || int(arg.Version) > len(keys) {
+		return sqlc.BusinessDataKey{}, pgx.ErrNoRows
+	}
+	return keys[arg.Version-1], nil
+}
+
+func testMasterKey() string {
+	return base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+}
+
+func testBusinessID(b byte) pgtype.UUID {
+	return pgtype.UUID{Bytes: [16]byte{b}, Valid: true}
+}
+
+func newTestKeyring(t *testing.T, store KeyStore) *Keyring {
+	t.Helper()
+	k, err := NewKeyring(testMasterKey(), store)
+	require.NoError(t, err)
+	return k
+}
+
+func TestNewKeyring_InvalidMasterKey(t *testing.T) {
+	_, err := NewKeyring("", newMemoryKeyStore())
+	assert.ErrorIs(t, err, ErrInvalidMasterKey)
+
+	_, err = NewKeyring(base64.StdEncoding.EncodeToString([]byte("short")), newMemoryKeyStore())
+	assert.ErrorIs(t, err, ErrInvalidMasterKey)
+}
+
+func TestKeyring_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	k := newTestKeyring(t, newMemoryKeyStore())
+	businessID := testBusinessID(1)
+
+	encrypted, err := k.Encrypt(ctx, businessID, "Dolor lumbar crónico")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(encrypted, "enc:v1:1:"))
+	assert.NotContains(t, encrypted, "Dolor")
+
+	decrypted, err := k.Decrypt(ctx, businessID, encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "Dolor lumbar crónico", decrypted)
+}
+
+func TestKeyring_EmptyAndPlaintextPassThrough(t *testing.T) {
+	ctx := context.Background()
+	k := newTestKeyring(t, newMemoryKeyStore())
+	businessID := testBusinessID(1)
+
+	encrypted, err := k.Encrypt(ctx, businessID, "")
+	require.NoError(t, err)
+	assert.Equal(t, "", encrypted)
+
+	decrypted, err := k.Decrypt(ctx, businessID, "texto heredado")
+	require.NoError(t, err)
+	assert.Equal(t, "texto heredado", decrypted)
+}
+
+func TestKeyring_BoundToBusiness(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryKeyStore()
+	k := newTestKeyring(t, store)
+
+	encrypted, err := k.Encrypt(ctx, testBusinessID(1), "secreto")
+	require.NoError(t, err)
+
+	_, err = k.Encrypt(ctx, testBusinessID(2), "otro")
+	require.NoError(t, err)
+
+	_, err = k.Decrypt(ctx, testBusinessID(2), encrypted)
+	assert.Error(t, err)
+}
+
+func TestKeyring_Rotate(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryKeyStore()
+	k := newTestKeyring(t, store)
+	businessID := testBusinessID(1)
+
+	old, err := k.Encrypt(ctx, businessID, "antes")
+	require.NoError(t, err)
+
+	version, err := k.Rotate(ctx, businessID)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), version)
+
+	current, err := k.IsCurrent(ctx, businessID, old)
+	require.NoError(t, err)
+	assert.False(t, current)
+
+	fresh, err := k.Encrypt(ctx, businessID, "después")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(fresh, "enc:v1:2:"))
+
+	// A separate process with a cold cache still reads both versions.
+	other := newTestKeyring(t, store)
+	decrypted, err := other.Decrypt(ctx, businessID, old)
+	require.NoError(t, err)
+	assert.Equal(t, "antes", decrypted)
+}
+
+func TestKeyring_JSON(t *testing.T) {
+	ctx := context.Background()
+	k := newTestKeyring(t, newMemoryKeyStore())
+	businessID := testBusinessID(1)
+
+	document := []byte(`{"reason":"control"}`)
+	encrypted, err := k.EncryptJSON(ctx, businessID, document)
+	require.NoError(t, err)
+	assert.Equal(t, byte('"'), encrypted[0])
+
+	decrypted, err := k.DecryptJSON(ctx, businessID, encrypted)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(document), string(decrypted))
+
+	plain, err := k.DecryptJSON(ctx, businessID, document)
+	require.NoError(t, err)
+	assert.Equal(t, document, plain)
+}
+
+func TestKeyring_WrongMasterKey(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryKeyStore()
+	businessID := testBusinessID(1)
+
+	encrypted, err := newTestKeyring(t, store).Encrypt(ctx, businessID, "secreto")
+	require.NoError(t, err)
+
+	other, err := NewKeyring(base64.StdEncoding.EncodeToString([]byte("fedcba9876543210fedcba9876543210")), store)
+	require.NoError(t, err)
+
+	_, err = other.Decrypt(ctx, businessID, encrypted)
+	assert.Error(t, err)
+}
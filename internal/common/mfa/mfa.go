@@ -0,0 +1,306 @@
+// Package mfa keeps the two-factor state of users: TOTP enrollment, code
+// checks and recovery codes.
+package mfa
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alanloffler/go-calth-api/internal/common/encryption"
+	"github.com/alanloffler/go-calth-api/internal/common/token"
+	"github.com/alanloffler/go-calth-api/internal/common/totp"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrNotStaff       = errors.New("mfa: only staff users can enroll")
+	ErrAlreadyEnabled = errors.New("mfa: already enabled")
+	ErrNotEnrolled    = errors.New("mfa: not enrolled")
+	ErrNotEnabled     = errors.New("mfa: not enabled")
+	ErrRequired       = errors.New("mfa: required for the user")
+	ErrInvalidCode    = errors.New("mfa: invalid code")
+)
+
+const (
+	rolePatient       = "patient"
+	recoveryCodeCount = 10
+	// recoveryCodeLength is the number of base32 characters of a recovery
+	// code, 50 random bits.
+	recoveryCodeLength = 10
+)
+
+var recoveryEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// Enrollment is what an authenticator app needs to add the account. The URI
+// is usually shown as a QR code.
+type Enrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+// Service reads and changes the two-factor state of users. Secrets are
+// encrypted with the key of the user's business.
+type Service struct {
+	pool *pgxpool.Pool
+	q    *sqlc.Queries
+	keys *encryption.Keyring
+}
+
+func NewService(pool *pgxpool.Pool, q *sqlc.Queries, keys *encryption.Keyring) *Service {
+	return &Service{pool: pool, q: q, keys: keys}
+}
+
+// Status returns the two-factor state of the user and whether the policy of
+// their business requires it.
+func (s *Service) Status(ctx context.Context, userID pgtype.UUID) (sqlc.GetTwoFactorStatusRow, error) {
+	return s.q.GetTwoFactorStatus(ctx, userID)
+}
+
+// Enroll starts an enrollment with a new secret, replacing a pending one. It
+// takes effect once Enable receives a code of the secret.
+func (s *Service) Enroll(ctx context.Context, userID pgtype.UUID) (Enrollment, error) {
+	status, err := s.q.GetTwoFactorStatus(ctx, userID)
+	if err != nil {
+		return Enrollment{}, fmt.Errorf("get status: %w", err)
+	}
+	if status.RoleValue == rolePatient {
+		return Enrollment{}, ErrNotStaff
+	}
+	if status.EnabledAt.Valid {
+		return Enrollment{}, ErrAlreadyEnabled
+	}
+
+	business, err := s.q.GetBusiness(ctx, status.BusinessID)
+	if err != nil {
+		return Enrollment{}, fmt.Errorf("get business: %w", err)
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return Enrollment{}, fmt.Errorf("generate secret: %w", err)
+	}
+
+	encrypted, err := s.keys.Encrypt(ctx, status.BusinessID, secret)
+	if err != nil {
+		return Enrollment{}, fmt.Errorf("encrypt secret: %w", err)
+	}
+
+	saved, err := s.q.UpsertTwoFactorSecret(ctx, sqlc.UpsertTwoFactorSecretParams{
+		UserID:     userID,
+		BusinessID: status.BusinessID,
+		Secret:     encrypted,
+	})
+	if err != nil {
+		return Enrollment{}, fmt.Errorf("save secret: %w", err)
+	}
+	if saved == 0 {
+		return Enrollment{}, ErrAlreadyEnabled
+	}
+
+	return Enrollment{Secret: secret, URI: totp.URI(business.TradeName, status.Email, secret)}, nil
+}
+
+// Enable completes the enrollment with a code of the pending secret and
+// returns the recovery codes, which are only shown this once.
+func (s *Service) Enable(ctx context.Context, userID pgtype.UUID, code string) ([]string, error) {
+	status, err := s.q.GetTwoFactorStatus(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get status: %w", err)
+	}
+	if status.EnabledAt.Valid {
+		return nil, ErrAlreadyEnabled
+	}
+	if !status.Secret.Valid {
+		return nil, ErrNotEnrolled
+	}
+
+	if err := s.checkTOTP(ctx, status, code); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+
+	enabled, err := qtx.EnableTwoFactor(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("enable: %w", err)
+	}
+	if enabled == 0 {
+		return nil, ErrAlreadyEnabled
+	}
+
+	codes, err := replaceRecoveryCodes(ctx, qtx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+
+	return codes, nil
+}
+
+// Verify checks the code of a user with two-factor enabled. The code is a
+// TOTP code or an unused recovery code, which is consumed.
+func (s *Service) Verify(ctx context.Context, userID pgtype.UUID, code string) error {
+	status, err := s.q.GetTwoFactorStatus(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("get status: %w", err)
+	}
+	if !status.EnabledAt.Valid {
+		return ErrNotEnabled
+	}
+
+	return s.verify(ctx, status, code)
+}
+
+// Disable turns two-factor off for a user the policy does not require it from.
+func (s *Service) Disable(ctx context.Context, userID pgtype.UUID, code string) error {
+	status, err := s.q.GetTwoFactorStatus(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("get status: %w", err)
+	}
+	if !status.EnabledAt.Valid {
+		return ErrNotEnabled
+	}
+	if status.Required {
+		return ErrRequired
+	}
+
+	if err := s.verify(ctx, status, code); err != nil {
+		return err
+	}
+
+	if _, err := s.q.DeleteTwoFactor(ctx, userID); err != nil {
+		return fmt.Errorf("delete: %w", err)
+	}
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces every recovery code of the user, used or
+// not, after checking a TOTP code.
+func (s *Service) RegenerateRecoveryCodes(ctx context.Context, userID pgtype.UUID, code string) ([]string, error) {
+	status, err := s.q.GetTwoFactorStatus(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get status: %w", err)
+	}
+	if !status.EnabledAt.Valid {
+		return nil, ErrNotEnabled
+	}
+
+	if err := s.checkTOTP(ctx, status, code); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	codes, err := replaceRecoveryCodes(ctx, s.q.WithTx(tx), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+
+	return codes, nil
+}
+
+// Reset removes the secret and recovery codes of the user, who enrolls again
+// on the next login when the policy requires it.
+func (s *Service) Reset(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	return s.q.DeleteTwoFactor(ctx, userID)
+}
+
+func (s *Service) verify(ctx context.Context, status sqlc.GetTwoFactorStatusRow, code string) error {
+	if recovery := normalizeRecoveryCode(code); len(recovery) == recoveryCodeLength {
+		used, err := s.q.UseRecoveryCode(ctx, sqlc.UseRecoveryCodeParams{UserID: status.ID, CodeHash: token.Hash(recovery)})
+		if err != nil {
+			return fmt.Errorf("use recovery code: %w", err)
+		}
+		if used == 0 {
+			return ErrInvalidCode
+		}
+		return nil
+	}
+
+	return s.checkTOTP(ctx, status, code)
+}
+
+// checkTOTP validates a TOTP code and records its step, so the same code is
+// not accepted twice.
+func (s *Service) checkTOTP(ctx context.Context, status sqlc.GetTwoFactorStatusRow, code string) error {
+	secret, err := s.keys.Decrypt(ctx, status.BusinessID, status.Secret.String)
+	if err != nil {
+		return fmt.Errorf("decrypt secret: %w", err)
+	}
+
+	step, ok := totp.Validate(secret, code, time.Now(), status.LastUsedStep)
+	if !ok {
+		return ErrInvalidCode
+	}
+
+	used, err := s.q.UseTwoFactorStep(ctx, sqlc.UseTwoFactorStepParams{Step: step, UserID: status.ID})
+	if err != nil {
+		return fmt.Errorf("use step: %w", err)
+	}
+	if used == 0 {
+		return ErrInvalidCode
+	}
+	return nil
+}
+
+func replaceRecoveryCodes(ctx context.Context, q *sqlc.Queries, userID pgtype.UUID) ([]string, error) {
+	codes, hashes, err := generateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, fmt.Errorf("generate recovery codes: %w", err)
+	}
+
+	if err := q.DeleteRecoveryCodes(ctx, userID); err != nil {
+		return nil, fmt.Errorf("delete recovery codes: %w", err)
+	}
+	if err := q.CreateRecoveryCodes(ctx, sqlc.CreateRecoveryCodesParams{UserID: userID, CodeHashes: hashes}); err != nil {
+		return nil, fmt.Errorf("save recovery codes: %w", err)
+	}
+
+	return codes, nil
+}
+
+// generateRecoveryCodes returns n codes formatted as xxxxx-xxxxx and the
+// hashes to store.
+func generateRecoveryCodes(n int) (codes, hashes []string, err error) {
+	for range n {
+		b := make([]byte, 8)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+
+		raw := strings.ToLower(recoveryEncoding.EncodeToString(b))[:recoveryCodeLength]
+		codes = append(codes, raw[:5]+"-"+raw[5:])
+		hashes = append(hashes, token.Hash(raw))
+	}
+	return codes, hashes, nil
+}
+
+// normalizeRecoveryCode accepts a recovery code typed in any case, with or
+// without the dash.
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
@@ -0,0 +1,30 @@
+package mfa
+
+import (
+	"testing"
+
+	"github.com/alanloffler/go-calth-api/internal/common/token"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, hashes, err := generateRecoveryCodes(recoveryCodeCount)
+	require.NoError(t, err)
+	require.Len(t, codes, recoveryCodeCount)
+	require.Len(t, hashes, recoveryCodeCount)
+
+	seen := map[string]bool{}
+	for i, code := range codes {
+		assert.Regexp(t, `^[a-z2-7]{5}-[a-z2-7]{5}$`, code)
+		assert.Equal(t, token.Hash(normalizeRecoveryCode(code)), hashes[i])
+		assert.False(t, seen[code], "duplicate code %s", code)
+		seen[code] = true
+	}
+}
+
+func TestNormalizeRecoveryCode(t *testing.T) {
+	assert.Equal(t, "abcde23456", normalizeRecoveryCode("ABCDE-23456"))
+	assert.Equal(t, "abcde23456", normalizeRecoveryCode(" abcde 23456 "))
+	assert.Len(t, normalizeRecoveryCode("123456"), 6, "a TOTP code is not taken for a recovery code")
+}
@@ -0,0 +1,92 @@
+package pdf
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// helveticaWidths holds the advance widths of the printable ASCII range
+// (32-126) in thousandths of an em, from the Helvetica AFM.
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+// TextWidth estimates the rendered width of s in points. Bold glyphs are
+// approximated from the regular metrics, which is accurate enough for wrapping.
+func TextWidth(font Font, size float64, s string) float64 {
+	total := 0
+	for _, r := range s {
+		if r >= 32 && r <= 126 {
+			total += helveticaWidths[r-32]
+		} else {
+			total += 556
+		}
+	}
+
+	w := float64(total) * size / 1000
+	if font == Bold {
+		w *= 1.07
+	}
+	return w
+}
+
+// Wrap splits s into lines no wider than width. Explicit newlines are kept
+// and words longer than a line are broken.
+func Wrap(font Font, size, width float64, s string) []string {
+	var lines []string
+
+	for _, paragraph := range strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n") {
+		words := strings.Fields(paragraph)
+		if len(words) == 0 {
+			lines = append(lines, "")
+			continue
+		}
+
+		line := ""
+		for _, word := range words {
+			candidate := word
+			if line != "" {
+				candidate = line + " " + word
+			}
+
+			if TextWidth(font, size, candidate) <= width {
+				line = candidate
+				continue
+			}
+
+			if line != "" {
+				lines = append(lines, line)
+			}
+
+			for TextWidth(font, size, word) > width {
+				cut := breakPoint(font, size, width, word)
+				lines = append(lines, word[:cut])
+				word = word[cut:]
+			}
+			line = word
+		}
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+func breakPoint(font Font, size, width float64, word string) int {
+	cut := 0
+	for i := range word {
+		if i > 0 && TextWidth(font, size, word[:i]) > width {
+			break
+		}
+		cut = i
+	}
+	if cut == 0 {
+		_, n := utf8.DecodeRuneInString(word)
+		return n
+	}
+	return cut
+}
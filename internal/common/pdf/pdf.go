@@ -0,0 +1,254 @@
+// Package pdf is a minimal PDF 1.4 writer for server-side documents such as
+// prescriptions and clinical records. It only supports the standard Helvetica
+// fonts, text and lines, which is enough for printable reports and keeps the
+// API free of external dependencies.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type Font int
+
+const (
+	Regular Font = iota
+	Bold
+)
+
+// A4 in points.
+const (
+	PageWidth  = 595.28
+	PageHeight = 841.89
+)
+
+const lineSpacing = 1.35
+
+type Document struct {
+	title        string
+	margin       float64
+	footerHeight float64
+	pages        []*bytes.Buffer
+	current      *bytes.Buffer
+	y            float64
+	header       func(d *Document)
+	footer       func(d *Document, page, total int)
+}
+
+func New(title string) *Document {
+	return &Document{title: title, margin: 50}
+}
+
+func (d *Document) Margin() float64 {
+	return d.margin
+}
+
+func (d *Document) ContentWidth() float64 {
+	return PageWidth - 2*d.margin
+}
+
+// SetHeader registers a function drawn at the top of every new page. It must
+// be called before the first page is added.
+func (d *Document) SetHeader(fn func(d *Document)) {
+	d.header = fn
+}
+
+// SetFooter registers a function drawn on every page once the total number of
+// pages is known. height is the space reserved at the bottom of each page.
+func (d *Document) SetFooter(height float64, fn func(d *Document, page, total int)) {
+	d.footerHeight = height
+	d.footer = fn
+}
+
+func (d *Document) AddPage() {
+	d.current = &bytes.Buffer{}
+	d.pages = append(d.pages, d.current)
+	d.y = d.margin
+
+	if d.header != nil {
+		d.header(d)
+	}
+}
+
+func (d *Document) PageCount() int {
+	return len(d.pages)
+}
+
+func (d *Document) Y() float64 {
+	return d.y
+}
+
+func (d *Document) SetY(y float64) {
+	d.y = y
+}
+
+func (d *Document) MoveDown(h float64) {
+	d.y += h
+}
+
+// EnsureSpace starts a new page when less than h points are left on the current one.
+func (d *Document) EnsureSpace(h float64) {
+	if d.current == nil || d.y+h > PageHeight-d.margin-d.footerHeight {
+		d.AddPage()
+	}
+}
+
+// Text draws s with its baseline at (x, y), measured from the top-left corner.
+func (d *Document) Text(x, y float64, font Font, size float64, s string) {
+	if d.current == nil {
+		d.AddPage()
+	}
+
+	fmt.Fprintf(d.current, "BT /F%d %s Tf %s %s Td (%s) Tj ET\n",
+		int(font)+1, num(size), num(x), num(PageHeight-y), escape(encode(s)))
+}
+
+// TextRight draws s so that it ends at x.
+func (d *Document) TextRight(x, y float64, font Font, size float64, s string) {
+	d.Text(x-TextWidth(font, size, s), y, font, size, s)
+}
+
+func (d *Document) Line(x1, y1, x2, y2, width float64) {
+	if d.current == nil {
+		d.AddPage()
+	}
+
+	fmt.Fprintf(d.current, "%s w %s %s m %s %s l S\n",
+		num(width), num(x1), num(PageHeight-y1), num(x2), num(PageHeight-y2))
+}
+
+// Paragraph writes s at the cursor, wrapping it to the content width and
+// breaking pages as needed.
+func (d *Document) Paragraph(font Font, size float64, s string) {
+	d.ParagraphAt(d.margin, d.ContentWidth(), font, size, s)
+}
+
+func (d *Document) ParagraphAt(x, width float64, font Font, size float64, s string) {
+	lineHeight := size * lineSpacing
+
+	for _, line := range Wrap(font, size, width, s) {
+		d.EnsureSpace(lineHeight)
+		d.y += size
+		d.Text(x, d.y, font, size, line)
+		d.y += lineHeight - size
+	}
+}
+
+// Rule draws a horizontal line across the content width at the cursor.
+func (d *Document) Rule() {
+	d.EnsureSpace(8)
+	d.y += 4
+	d.Line(d.margin, d.y, PageWidth-d.margin, d.y, 0.5)
+	d.y += 4
+}
+
+func (d *Document) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := d.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+
+	if d.footer != nil {
+		for i, page := range d.pages {
+			d.current = page
+			d.footer(d, i+1, len(d.pages))
+		}
+	}
+
+	var out bytes.Buffer
+	offsets := []int{}
+
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Fixed objects: 1 catalog, 2 page tree, 3-4 fonts, 5 info. Pages follow
+	// as (page, content) pairs starting at object 6.
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 6+i*2)
+	}
+
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	object(fmt.Sprintf("<< /Title (%s) /Producer (Calth) >>", escape(encode(d.title))))
+
+	for i, page := range d.pages {
+		object(fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			num(PageWidth), num(PageHeight), 7+i*2,
+		))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	n, err := w.Write(out.Bytes())
+	return int64(n), err
+}
+
+func num(f float64) string {
+	s := fmt.Sprintf("%.2f", f)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// encode converts s to WinAnsiEncoding, replacing unsupported characters.
+func encode(s string) []byte {
+	out := make([]byte, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r == '\n' || r == '\r' || r == '\t':
+			out = append(out, ' ')
+		case r < 0x80 || (r >= 0xa0 && r <= 0xff):
+			out = append(out, byte(r))
+		default:
+			if b, ok := winAnsiExtras[r]; ok {
+				out = append(out, b)
+			} else {
+				out = append(out, '?')
+			}
+		}
+	}
+	return out
+}
+
+var winAnsiExtras = map[rune]byte{
+	'€': 0x80, '…': 0x85, '‘': 0x91, '’': 0x92, '“': 0x93,
+	'”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97,
+}
+
+func escape(b []byte) string {
+	var sb strings.Builder
+	for _, c := range b {
+		switch {
+		case c == '(' || c == ')' || c == '\\':
+			sb.WriteByte('\\')
+			sb.WriteByte(c)
+		case c < 0x20 || c >= 0x80:
+			fmt.Fprintf(&sb, "\\%03o", c)
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,65 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument_WritesValidXref(t *testing.T) {
+	doc := New("Receta (copia)")
+	doc.SetFooter(20, func(d *Document, page, total int) {
+		d.Text(d.Margin(), PageHeight-30, Regular, 8, fmt.Sprintf("Página %d de %d", page, total))
+	})
+	doc.Paragraph(Bold, 14, "Clínica San José")
+	doc.AddPage()
+	doc.Paragraph(Regular, 10, "Segunda página")
+
+	out, err := doc.Bytes()
+	require.NoError(t, err)
+
+	assert.True(t, bytes.HasPrefix(out, []byte("%PDF-1.4")))
+	assert.True(t, bytes.HasSuffix(out, []byte("%%EOF\n")))
+	assert.Contains(t, string(out), "/Count 2")
+	assert.Contains(t, string(out), `(Receta \(copia\))`)
+	assert.Contains(t, string(out), `P\341gina 2 de 2`)
+
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(out)
+	require.NotNil(t, startxref)
+	xrefOffset, _ := strconv.Atoi(string(startxref[1]))
+	require.True(t, bytes.HasPrefix(out[xrefOffset:], []byte("xref\n")))
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(out[xrefOffset:], -1)
+	require.Len(t, entries, 9)
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		assert.True(t, bytes.HasPrefix(out[offset:], []byte(fmt.Sprintf("%d 0 obj", i+1))), "object %d", i+1)
+	}
+}
+
+func TestDocument_ParagraphBreaksPages(t *testing.T) {
+	doc := New("Largo")
+	doc.Paragraph(Regular, 12, strings.Repeat("palabra ", 3000))
+
+	assert.Greater(t, doc.PageCount(), 1)
+}
+
+func TestWrap(t *testing.T) {
+	lines := Wrap(Regular, 10, 100, "uno dos tres cuatro cinco seis siete ocho nueve diez")
+	require.Greater(t, len(lines), 1)
+	for _, line := range lines {
+		assert.LessOrEqual(t, TextWidth(Regular, 10, line), 100.0)
+	}
+
+	long := Wrap(Regular, 10, 50, strings.Repeat("x", 60))
+	assert.Greater(t, len(long), 1)
+	assert.Equal(t, strings.Repeat("x", 60), strings.Join(long, ""))
+
+	assert.Equal(t, []string{"a", "", "b"}, Wrap(Regular, 10, 100, "a\n\nb"))
+}
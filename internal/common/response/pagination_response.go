@@ -4,3 +4,10 @@ type PaginatedData[T any] struct {
 	Result []T   `json:"result"`
 	Total  int32 `json:"total"`
 }
+
+// CursorData is a page of a keyset paginated listing. NextCursor is nil on the
+// last page.
+type CursorData[T any] struct {
+	Result     []T     `json:"result"`
+	NextCursor *string `json:"nextCursor"`
+}
@@ -0,0 +1,198 @@
+// Package throttle slows down password guessing on login. Attempts are
+// counted in Redis per (business, email) and failures per IP: each attempt
+// adds a growing delay before the next one, and too many failures lock the
+// account for a while.
+package throttle
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alanloffler/go-calth-api/internal/config"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// firstDelayedFailure is the failure after which attempts start to wait.
+	firstDelayedFailure = 2
+	maxDelay            = 30 * time.Second
+)
+
+// Reason tells why an attempt has to wait.
+type Reason string
+
+const (
+	ReasonNone    Reason = ""
+	ReasonDelayed Reason = "delayed"
+	ReasonLocked  Reason = "locked"
+	ReasonIP      Reason = "rate_limited"
+)
+
+// checkScript admits a login attempt or tells why it has to wait. An admitted
+// attempt is counted and the delay before the next one is set in the same
+// step, so parallel attempts cannot all slip in before the first one fails.
+// The delay mirrors Delay.
+//
+// KEYS: lock, delay, attempts, ip. ARGV: IP max failures, window in ms, first
+// delayed attempt, max delay in ms.
+var checkScript = redis.NewScript(`
+local lock = redis.call('PTTL', KEYS[1])
+if lock > 0 then
+	return {'locked', lock}
+end
+
+local ipFailures = tonumber(redis.call('GET', KEYS[4]) or '0')
+local ipTTL = redis.call('PTTL', KEYS[4])
+if ipFailures >= tonumber(ARGV[1]) and ipTTL > 0 then
+	return {'rate_limited', ipTTL}
+end
+
+local delay = redis.call('PTTL', KEYS[2])
+if delay > 0 then
+	return {'delayed', delay}
+end
+
+local attempts = redis.call('INCR', KEYS[3])
+if attempts == 1 then
+	redis.call('PEXPIRE', KEYS[3], ARGV[2])
+end
+
+local first = tonumber(ARGV[3])
+if attempts >= first then
+	local maxDelay = tonumber(ARGV[4])
+	local wait = 1000
+	for _ = first + 1, attempts do
+		wait = wait * 2
+		if wait >= maxDelay then
+			break
+		end
+	end
+	redis.call('SET', KEYS[2], attempts, 'PX', math.min(wait, maxDelay))
+end
+
+return {'', 0}
+`)
+
+type Guard struct {
+	rdb redis.UniversalClient
+	cfg *config.Config
+}
+
+func NewGuard(rdb redis.UniversalClient, cfg *config.Config) *Guard {
+	return &Guard{rdb: rdb, cfg: cfg}
+}
+
+// Check returns how long a login for email from ip has to wait and why. A
+// zero wait means the attempt may go ahead; it is then already counted, and
+// the next attempt waits until Succeed clears the count.
+func (g *Guard) Check(ctx context.Context, ip string, businessID pgtype.UUID, email string) (time.Duration, Reason, error) {
+	account := accountKey(businessID, email)
+
+	keys := []string{"login:lock:" + account, "login:delay:" + account, "login:failures:" + account, ipKey(ip)}
+	res, err := checkScript.Run(ctx, g.rdb, keys,
+		g.cfg.LoginIPMaxAttempts,
+		g.cfg.LoginAttemptWindow.Milliseconds(),
+		firstDelayedFailure,
+		maxDelay.Milliseconds(),
+	).Slice()
+	if err != nil {
+		return 0, ReasonNone, fmt.Errorf("check login throttle: %w", err)
+	}
+
+	reason, _ := res[0].(string)
+	wait, _ := res[1].(int64)
+	return time.Duration(wait) * time.Millisecond, Reason(reason), nil
+}
+
+// Fail records that the attempt admitted by Check had a wrong password and
+// returns how long the account is now locked, zero if it is not.
+func (g *Guard) Fail(ctx context.Context, ip string, businessID pgtype.UUID, email string) (time.Duration, error) {
+	account := accountKey(businessID, email)
+
+	if _, err := g.count(ctx, ipKey(ip)); err != nil {
+		return 0, err
+	}
+
+	failures, err := g.rdb.Get(ctx, "login:failures:"+account).Int64()
+	if err != nil && err != redis.Nil {
+		return 0, fmt.Errorf("count login failure: %w", err)
+	}
+
+	if failures >= g.cfg.LoginMaxAttempts {
+		pipe := g.rdb.TxPipeline()
+		pipe.Set(ctx, "login:lock:"+account, failures, g.cfg.LoginLockoutDuration)
+		pipe.Del(ctx, "login:failures:"+account, "login:delay:"+account)
+		if _, err := pipe.Exec(ctx); err != nil {
+			return 0, fmt.Errorf("lock account: %w", err)
+		}
+		return g.cfg.LoginLockoutDuration, nil
+	}
+	return 0, nil
+}
+
+// Succeed forgets the failures of the account after a correct password. The
+// IP count is kept, so one valid account cannot reset it.
+func (g *Guard) Succeed(ctx context.Context, businessID pgtype.UUID, email string) error {
+	account := accountKey(businessID, email)
+	return g.rdb.Del(ctx, "login:failures:"+account, "login:delay:"+account).Err()
+}
+
+// Unlock lifts the lock and the failures of the account. It reports whether
+// the account was locked.
+func (g *Guard) Unlock(ctx context.Context, businessID pgtype.UUID, email string) (bool, error) {
+	account := accountKey(businessID, email)
+
+	locked, err := g.rdb.Del(ctx, "login:lock:"+account).Result()
+	if err != nil {
+		return false, fmt.Errorf("unlock account: %w", err)
+	}
+	if err := g.rdb.Del(ctx, "login:failures:"+account, "login:delay:"+account).Err(); err != nil {
+		return false, fmt.Errorf("unlock account: %w", err)
+	}
+	return locked > 0, nil
+}
+
+// count increments key, starting its window on the first failure.
+func (g *Guard) count(ctx context.Context, key string) (int64, error) {
+	n, err := g.rdb.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, fmt.Errorf("count login failure: %w", err)
+	}
+	if n == 1 {
+		if err := g.rdb.Expire(ctx, key, g.cfg.LoginAttemptWindow).Err(); err != nil {
+			return 0, fmt.Errorf("count login failure: %w", err)
+		}
+	}
+	return n, nil
+}
+
+// Delay is the wait after the given number of consecutive failures: none for
+// the first, then 1s, 2s, 4s... up to maxDelay. checkScript applies the same
+// schedule inside Redis.
+func Delay(failures int64) time.Duration {
+	if failures < firstDelayedFailure {
+		return 0
+	}
+
+	shift := failures - firstDelayedFailure
+	if shift >= 5 {
+		return maxDelay
+	}
+	return min(time.Second<<shift, maxDelay)
+}
+
+// NormalizeEmail is the form of email used in keys and records.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func accountKey(businessID pgtype.UUID, email string) string {
+	return businessID.String() + ":" + NormalizeEmail(email)
+}
+
+func ipKey(ip string) string {
+	return "login:ip:" + ip
+}
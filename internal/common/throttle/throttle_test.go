@@ -0,0 +1,175 @@
+package throttle
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alanloffler/go-calth-api/internal/config"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDelay(t *testing.T) {
+	cases := map[int64]time.Duration{
+		0:  0,
+		1:  0,
+		2:  time.Second,
+		3:  2 * time.Second,
+		4:  4 * time.Second,
+		6:  16 * time.Second,
+		7:  maxDelay,
+		50: maxDelay,
+	}
+
+	for failures, want := range cases {
+		assert.Equal(t, want, Delay(failures), "failures=%d", failures)
+	}
+}
+
+func TestNormalizeEmail(t *testing.T) {
+	assert.Equal(t, "ana@example.com", NormalizeEmail("  Ana@Example.COM "))
+}
+
+func newTestGuard(t *testing.T) (*Guard, *miniredis.Miniredis) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+
+	cfg := &config.Config{
+		LoginMaxAttempts:     5,
+		LoginIPMaxAttempts:   50,
+		LoginAttemptWindow:   15 * time.Minute,
+		LoginLockoutDuration: 15 * time.Minute,
+	}
+	return NewGuard(rdb, cfg), mr
+}
+
+func TestGuard_CheckCountsParallelAttempts(t *testing.T) {
+	guard, _ := newTestGuard(t)
+	ctx := context.Background()
+	businessID := pgtype.UUID{Bytes: [16]byte{1}, Valid: true}
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		allowed int
+		delayed int
+	)
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			wait, reason, err := guard.Check(ctx, "10.0.0.1", businessID, "ana@example.com")
+			require.NoError(t, err)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if wait == 0 {
+				allowed++
+				return
+			}
+			assert.Equal(t, ReasonDelayed, reason)
+			delayed++
+		}()
+	}
+	wg.Wait()
+
+	// Only the attempts before the first delay get through, however many race.
+	assert.Equal(t, firstDelayedFailure, allowed)
+	assert.Equal(t, 20-allowed, delayed)
+}
+
+func TestGuard_CheckDelaysFollowSchedule(t *testing.T) {
+	guard, mr := newTestGuard(t)
+	ctx := context.Background()
+	businessID := pgtype.UUID{Bytes: [16]byte{1}, Valid: true}
+
+	for attempt := int64(1); attempt <= 8; attempt++ {
+		wait, reason, err := guard.Check(ctx, "10.0.0.1", businessID, "ana@example.com")
+		require.NoError(t, err)
+		require.Zero(t, wait, "attempt=%d", attempt)
+		require.Equal(t, ReasonNone, reason)
+
+		if Delay(attempt) == 0 {
+			continue
+		}
+
+		// The attempt itself already holds back the next one.
+		wait, reason, err = guard.Check(ctx, "10.0.0.1", businessID, "ana@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, ReasonDelayed, reason)
+		assert.Equal(t, Delay(attempt), wait, "attempt=%d", attempt)
+		mr.FastForward(wait)
+	}
+}
+
+func TestGuard_FailLocksAfterMaxAttempts(t *testing.T) {
+	guard, mr := newTestGuard(t)
+	ctx := context.Background()
+	businessID := pgtype.UUID{Bytes: [16]byte{1}, Valid: true}
+
+	for attempt := 1; attempt <= 5; attempt++ {
+		wait, _, err := guard.Check(ctx, "10.0.0.1", businessID, "Ana@example.com")
+		require.NoError(t, err)
+		require.Zero(t, wait, "attempt=%d", attempt)
+
+		locked, err := guard.Fail(ctx, "10.0.0.1", businessID, "ana@example.com")
+		require.NoError(t, err)
+		if attempt < 5 {
+			assert.Zero(t, locked, "attempt=%d", attempt)
+		} else {
+			assert.Equal(t, 15*time.Minute, locked)
+		}
+		mr.FastForward(maxDelay)
+	}
+
+	wait, reason, err := guard.Check(ctx, "10.0.0.1", businessID, "ana@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, ReasonLocked, reason)
+	assert.Positive(t, wait)
+}
+
+func TestGuard_SucceedClearsAttempts(t *testing.T) {
+	guard, _ := newTestGuard(t)
+	ctx := context.Background()
+	businessID := pgtype.UUID{Bytes: [16]byte{1}, Valid: true}
+
+	for range 2 {
+		wait, _, err := guard.Check(ctx, "10.0.0.1", businessID, "ana@example.com")
+		require.NoError(t, err)
+		require.Zero(t, wait)
+	}
+	require.NoError(t, guard.Succeed(ctx, businessID, "ana@example.com"))
+
+	wait, reason, err := guard.Check(ctx, "10.0.0.1", businessID, "ana@example.com")
+	require.NoError(t, err)
+	assert.Zero(t, wait)
+	assert.Equal(t, ReasonNone, reason)
+}
+
+func TestGuard_CheckLimitsIP(t *testing.T) {
+	guard, _ := newTestGuard(t)
+	ctx := context.Background()
+	businessID := pgtype.UUID{Bytes: [16]byte{1}, Valid: true}
+
+	// Failures spread over many accounts still add up for the IP.
+	for i := range 50 {
+		_, err := guard.Fail(ctx, "10.0.0.1", businessID, fmt.Sprintf("user%d@example.com", i))
+		require.NoError(t, err)
+	}
+
+	wait, reason, err := guard.Check(ctx, "10.0.0.1", businessID, "ana@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, ReasonIP, reason)
+	assert.Positive(t, wait)
+
+	wait, _, err = guard.Check(ctx, "10.0.0.2", businessID, "ana@example.com")
+	require.NoError(t, err)
+	assert.Zero(t, wait)
+}
@@ -0,0 +1,22 @@
+package token
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	token, hash, err := Generate()
+	require.NoError(t, err)
+
+	assert.Len(t, token, 43)
+	assert.Len(t, hash, 64)
+	assert.Equal(t, Hash(token), hash)
+	assert.NotEqual(t, token, hash)
+
+	other, _, err := Generate()
+	require.NoError(t, err)
+	assert.NotEqual(t, token, other)
+}
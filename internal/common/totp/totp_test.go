@@ -0,0 +1,71 @@
+package totp
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfcSecret is the SHA-1 seed of the RFC 6238 test vectors.
+var rfcSecret = base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestCode_RFCVectors(t *testing.T) {
+	cases := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+
+	for unix, want := range cases {
+		got, err := Code(rfcSecret, Step(time.Unix(unix, 0)))
+		require.NoError(t, err)
+		assert.Equal(t, want, got, "t=%d", unix)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+	step := Step(now)
+
+	code, err := Code(rfcSecret, step)
+	require.NoError(t, err)
+
+	got, ok := Validate(rfcSecret, code, now, 0)
+	assert.True(t, ok)
+	assert.Equal(t, step, got)
+
+	_, ok = Validate(rfcSecret, code, now.Add(period), 0)
+	assert.True(t, ok, "previous step is accepted")
+
+	_, ok = Validate(rfcSecret, code, now.Add(2*period), 0)
+	assert.False(t, ok, "older steps are rejected")
+
+	_, ok = Validate(rfcSecret, code, now, step)
+	assert.False(t, ok, "a used step is rejected")
+
+	_, ok = Validate(rfcSecret, "12345", now, 0)
+	assert.False(t, ok)
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	require.NoError(t, err)
+	assert.Len(t, secret, 32)
+
+	_, err = Code(secret, 1)
+	assert.NoError(t, err)
+}
+
+func TestURI(t *testing.T) {
+	uri := URI("Clínica Sur", "ana@example.com", "JBSWY3DPEHPK3PXP")
+
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/Cl%C3%ADnica%20Sur:ana@example.com?"))
+	assert.Contains(t, uri, "secret=JBSWY3DPEHPK3PXP")
+	assert.Contains(t, uri, "issuer=Cl%C3%ADnica+Sur")
+}
@@ -0,0 +1,28 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+)
+
+// FormatDuration renders d in Spanish using its largest whole unit, e.g.
+// "7 días", "1 hora" or "90 minutos", for messages sent to users.
+func FormatDuration(d time.Duration) string {
+	const day = 24 * time.Hour
+
+	switch {
+	case d >= day && d%day == 0:
+		return plural(int(d/day), "día", "días")
+	case d >= time.Hour && d%time.Hour == 0:
+		return plural(int(d/time.Hour), "hora", "horas")
+	default:
+		return plural(int(d/time.Minute), "minuto", "minutos")
+	}
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, one)
+	}
+	return fmt.Sprintf("%d %s", n, many)
+}
@@ -0,0 +1,27 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		in   time.Duration
+		want string
+	}{
+		{time.Minute, "1 minuto"},
+		{30 * time.Minute, "30 minutos"},
+		{90 * time.Minute, "90 minutos"},
+		{time.Hour, "1 hora"},
+		{36 * time.Hour, "36 horas"},
+		{24 * time.Hour, "1 día"},
+		{7 * 24 * time.Hour, "7 días"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, FormatDuration(tt.in))
+	}
+}
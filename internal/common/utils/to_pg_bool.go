@@ -0,0 +1,11 @@
+package utils
+
+import "github.com/jackc/pgx/v5/pgtype"
+
+func ToPgBool(b *bool) pgtype.Bool {
+	if b != nil {
+		return pgtype.Bool{Bool: *b, Valid: true}
+	}
+
+	return pgtype.Bool{}
+}
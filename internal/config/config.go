@@ -3,22 +3,43 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	AppDomain        string
-	DatabaseURL      string
-	Port             string
-	JwtSecret        string
-	JwtRefreshSecret string
-	JwtAccessExpiry  string
-	JwtRefreshExpiry string
-	CookieDomain     string
-	CookieSecure     bool
-	CorsOrigin       string
-	RedisAddr        string
+	AppDomain                string
+	DatabaseURL              string
+	Port                     string
+	JwtSecret                string
+	JwtRefreshSecret         string
+	JwtAccessExpiry          string
+	JwtRefreshExpiry         string
+	CookieDomain             string
+	CookieSecure             bool
+	CorsOrigin               string
+	RedisAddr                string
+	StorageDriver            string
+	StorageLocalPath         string
+	S3Endpoint               string
+	S3Region                 string
+	S3Bucket                 string
+	S3AccessKey              string
+	S3SecretKey              string
+	S3UsePathStyle           bool
+	UploadMaxSize            int64
+	MedicalHistoryLockPeriod time.Duration
+	PortalChangeNotice       time.Duration
+	PasswordResetTTL         time.Duration
+	InvitationTTL            time.Duration
+	EmailVerificationTTL     time.Duration
+	LoginMaxAttempts         int64
+	LoginIPMaxAttempts       int64
+	LoginAttemptWindow       time.Duration
+	LoginLockoutDuration     time.Duration
+	EncryptionMasterKey      string
 }
 
 func Load() (*Config, error) {
@@ -29,18 +50,65 @@ func Load() (*Config, error) {
 	}
 
 	var config *Config = &Config{
-		AppDomain:        os.Getenv("APP_DOMAIN"),
-		DatabaseURL:      os.Getenv("DATABASE_URL"),
-		Port:             os.Getenv("PORT"),
-		JwtSecret:        os.Getenv("JWT_SECRET"),
-		JwtRefreshSecret: os.Getenv("JWT_REFRESH_SECRET"),
-		JwtAccessExpiry:  os.Getenv("JWT_ACCESS_EXPIRY"),
-		JwtRefreshExpiry: os.Getenv("JWT_REFRESH_EXPIRY"),
-		CookieDomain:     os.Getenv("COOKIE_DOMAIN"),
-		CookieSecure:     os.Getenv("COOKIE_SECURE") == "true",
-		CorsOrigin:       os.Getenv("CORS_ORIGIN"),
-		RedisAddr:        os.Getenv("REDIS_ADDR"),
+		AppDomain:                os.Getenv("APP_DOMAIN"),
+		DatabaseURL:              os.Getenv("DATABASE_URL"),
+		Port:                     os.Getenv("PORT"),
+		JwtSecret:                os.Getenv("JWT_SECRET"),
+		JwtRefreshSecret:         os.Getenv("JWT_REFRESH_SECRET"),
+		JwtAccessExpiry:          os.Getenv("JWT_ACCESS_EXPIRY"),
+		JwtRefreshExpiry:         os.Getenv("JWT_REFRESH_EXPIRY"),
+		CookieDomain:             os.Getenv("COOKIE_DOMAIN"),
+		CookieSecure:             os.Getenv("COOKIE_SECURE") == "true",
+		CorsOrigin:               os.Getenv("CORS_ORIGIN"),
+		RedisAddr:                os.Getenv("REDIS_ADDR"),
+		StorageDriver:            os.Getenv("STORAGE_DRIVER"),
+		StorageLocalPath:         os.Getenv("STORAGE_LOCAL_PATH"),
+		S3Endpoint:               os.Getenv("S3_ENDPOINT"),
+		S3Region:                 os.Getenv("S3_REGION"),
+		S3Bucket:                 os.Getenv("S3_BUCKET"),
+		S3AccessKey:              os.Getenv("S3_ACCESS_KEY"),
+		S3SecretKey:              os.Getenv("S3_SECRET_KEY"),
+		S3UsePathStyle:           os.Getenv("S3_USE_PATH_STYLE") == "true",
+		UploadMaxSize:            parseInt64(os.Getenv("UPLOAD_MAX_SIZE"), 10<<20),
+		MedicalHistoryLockPeriod: parseDuration(os.Getenv("MEDICAL_HISTORY_LOCK_PERIOD"), 24*time.Hour),
+		PortalChangeNotice:       parseDuration(os.Getenv("PORTAL_CHANGE_NOTICE"), 24*time.Hour),
+		PasswordResetTTL:         parseDuration(os.Getenv("PASSWORD_RESET_TTL"), time.Hour),
+		InvitationTTL:            parseDuration(os.Getenv("INVITATION_TTL"), 7*24*time.Hour),
+		EmailVerificationTTL:     parseDuration(os.Getenv("EMAIL_VERIFICATION_TTL"), 48*time.Hour),
+		LoginMaxAttempts:         parseInt64(os.Getenv("LOGIN_MAX_ATTEMPTS"), 5),
+		LoginIPMaxAttempts:       parseInt64(os.Getenv("LOGIN_IP_MAX_ATTEMPTS"), 50),
+		LoginAttemptWindow:       parseDuration(os.Getenv("LOGIN_ATTEMPT_WINDOW"), 15*time.Minute),
+		LoginLockoutDuration:     parseDuration(os.Getenv("LOGIN_LOCKOUT_DURATION"), 15*time.Minute),
+		EncryptionMasterKey:      os.Getenv("ENCRYPTION_MASTER_KEY"),
 	}
 
 	return config, nil
 }
+
+func parseInt64(value string, fallback int64) int64 {
+	if value == "" {
+		return fallback
+	}
+
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		log.Printf("Invalid integer value %q, using default %d", value, fallback)
+		return fallback
+	}
+
+	return n
+}
+
+func parseDuration(value string, fallback time.Duration) time.Duration {
+	if value == "" {
+		return fallback
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("Invalid duration value %q, using default %s", value, fallback)
+		return fallback
+	}
+
+	return d
+}
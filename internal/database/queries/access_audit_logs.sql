@@ -0,0 +1,112 @@
+-- name: CreateAccessAuditLog :exec
+INSERT INTO
+  access_audit_logs (
+    business_id,
+    user_id,
+    role_id,
+    resource,
+    resource_id,
+    patient_id,
+    method,
+    path,
+    status_code,
+    ip_address,
+    user_agent,
+    impersonator_id
+  )
+VALUES
+  ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12);
+
+-- name: GetAccessAuditLogs :many
+SELECT
+  l.id, l.business_id, l.user_id, l.role_id, l.resource, l.resource_id, l.patient_id, l.method, l.path, l.status_code, l.ip_address, l.user_agent, l.created_at, l.impersonator_id,
+  u.first_name,
+  u.last_name,
+  r.name AS role_name,
+  p.first_name,
+  p.last_name
+FROM
+  access_audit_logs l
+  LEFT JOIN users u ON u.id = l.user_id
+  LEFT JOIN roles r ON r.id = l.role_id
+  LEFT JOIN users p ON p.id = l.patient_id
+WHERE
+  l.business_id = sqlc.arg (business_id)
+  AND (
+    sqlc.narg (user_id)::uuid IS NULL
+    OR l.user_id = sqlc.narg (user_id)
+  )
+  AND (
+    sqlc.narg (patient_id)::uuid IS NULL
+    OR l.patient_id = sqlc.narg (patient_id)
+  )
+  AND (
+    sqlc.narg (resource)::text IS NULL
+    OR l.resource = sqlc.narg (resource)
+  )
+  AND (
+    sqlc.narg (date_from)::timestamptz IS NULL
+    OR l.created_at >= sqlc.narg (date_from)
+  )
+  AND (
+    sqlc.narg (date_to)::timestamptz IS NULL
+    OR l.created_at < sqlc.narg (date_to)
+  )
+ORDER BY
+  l.created_at DESC
+LIMIT
+  sqlc.arg (query_limit)
+OFFSET
+  sqlc.arg (query_offset);
+
+-- name: CountAccessAuditLogs :one
+SELECT
+  COUNT(l.id)::int AS total
+FROM
+  access_audit_logs l
+WHERE
+  l.business_id = sqlc.arg (business_id)
+  AND (
+    sqlc.narg (user_id)::uuid IS NULL
+    OR l.user_id = sqlc.narg (user_id)
+  )
+  AND (
+    sqlc.narg (patient_id)::uuid IS NULL
+    OR l.patient_id = sqlc.narg (patient_id)
+  )
+  AND (
+    sqlc.narg (resource)::text IS NULL
+    OR l.resource = sqlc.narg (resource)
+  )
+  AND (
+    sqlc.narg (date_from)::timestamptz IS NULL
+    OR l.created_at >= sqlc.narg (date_from)
+  )
+  AND (
+    sqlc.narg (date_to)::timestamptz IS NULL
+    OR l.created_at < sqlc.narg (date_to)
+  );
+
+-- name: GetPatientAccessSummary :many
+SELECT
+  l.user_id,
+  u.first_name,
+  u.last_name,
+  r.name AS role_name,
+  COUNT(l.id)::int AS access_count,
+  MIN(l.created_at)::timestamptz AS first_access,
+  MAX(l.created_at)::timestamptz AS last_access
+FROM
+  access_audit_logs l
+  LEFT JOIN users u ON u.id = l.user_id
+  LEFT JOIN roles r ON r.id = l.role_id
+WHERE
+  l.business_id = $1
+  AND l.patient_id = $2
+GROUP BY
+  l.user_id,
+  u.first_name,
+  u.last_name,
+  r.name
+ORDER BY
+  last_access DESC;
@@ -0,0 +1,64 @@
+-- name: CreateAPIKey :one
+INSERT INTO
+  api_keys (
+    business_id,
+    user_id,
+    name,
+    prefix,
+    key_hash,
+    scopes,
+    expires_at
+  )
+VALUES
+  (
+    sqlc.arg (business_id),
+    sqlc.arg (user_id),
+    sqlc.arg (name),
+    sqlc.arg (prefix),
+    sqlc.arg (key_hash),
+    sqlc.arg (scopes),
+    sqlc.narg (expires_at)
+  )
+RETURNING
+  *;
+
+-- name: ListAPIKeys :many
+SELECT
+  *
+FROM
+  api_keys
+WHERE
+  business_id = sqlc.arg (business_id)
+ORDER BY
+  created_at DESC;
+
+-- name: RevokeAPIKey :execrows
+UPDATE api_keys
+SET
+  revoked_at = now()
+WHERE
+  id = sqlc.arg (id)
+  AND business_id = sqlc.arg (business_id)
+  AND revoked_at IS NULL;
+
+-- name: AuthenticateAPIKey :one
+UPDATE api_keys k
+SET
+  last_used_at = now()
+FROM
+  users u
+WHERE
+  k.key_hash = sqlc.arg (key_hash)
+  AND k.revoked_at IS NULL
+  AND (
+    k.expires_at IS NULL
+    OR k.expires_at > now()
+  )
+  AND u.id = k.user_id
+  AND u.deleted_at IS NULL
+RETURNING
+  k.id,
+  k.business_id,
+  k.user_id,
+  u.role_id,
+  k.scopes;
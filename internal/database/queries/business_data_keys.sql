@@ -0,0 +1,34 @@
+-- name: CreateBusinessDataKey :one
+INSERT INTO
+  business_data_keys (business_id, version, wrapped_key)
+SELECT
+  $1::uuid,
+  COALESCE(MAX(version), 0) + 1,
+  $2::bytea
+FROM
+  business_data_keys
+WHERE
+  business_id = $1::uuid
+RETURNING
+  business_id, version, wrapped_key, created_at;
+
+-- name: GetActiveBusinessDataKey :one
+SELECT
+  business_id, version, wrapped_key, created_at
+FROM
+  business_data_keys
+WHERE
+  business_id = $1
+ORDER BY
+  version DESC
+LIMIT
+  1;
+
+-- name: GetBusinessDataKey :one
+SELECT
+  business_id, version, wrapped_key, created_at
+FROM
+  business_data_keys
+WHERE
+  business_id = $1
+  AND version = $2;
@@ -77,7 +77,10 @@ SET
   email = COALESCE(sqlc.narg ('email'), email),
   phone_number = COALESCE(sqlc.narg ('phone_number'), phone_number),
   whatsapp_number = COALESCE(sqlc.narg ('whatsapp_number'), whatsapp_number),
-  website = COALESCE(sqlc.narg ('website'), website)
+  website = COALESCE(sqlc.narg ('website'), website),
+  require_verified_email_login = COALESCE(sqlc.narg ('require_verified_email_login'), require_verified_email_login),
+  require_verified_email_booking = COALESCE(sqlc.narg ('require_verified_email_booking'), require_verified_email_booking),
+  require_two_factor = COALESCE(sqlc.narg ('require_two_factor'), require_two_factor)
 WHERE
   id = $1;
 
@@ -0,0 +1,149 @@
+-- name: GetClinicalRecordPatient :one
+SELECT
+  u.id,
+  u.ic,
+  u.first_name,
+  u.last_name,
+  u.email,
+  u.phone_number,
+  pp.gender,
+  pp.birth_day,
+  pp.blood_type,
+  pp.weight,
+  pp.height,
+  pp.emergency_contact_name,
+  pp.emergency_contact_phone
+FROM
+  users u
+  LEFT JOIN patient_profile pp ON pp.user_id = u.id
+  AND pp.business_id = u.business_id
+WHERE
+  u.business_id = $1
+  AND u.id = $2;
+
+-- name: GetClinicalRecordEvents :many
+SELECT
+  e.id,
+  e.title,
+  e.start_date,
+  e.end_date,
+  e.status,
+  p.first_name,
+  p.last_name,
+  pp.professional_prefix
+FROM
+  events e
+  LEFT JOIN users p ON p.id = e.professional_id
+  LEFT JOIN professional_profile pp ON pp.user_id = p.id
+WHERE
+  e.business_id = sqlc.arg (business_id)
+  AND e.user_id = sqlc.arg (user_id)
+  AND e.deleted_at IS NULL
+  AND (
+    sqlc.narg (owner_id)::uuid IS NULL
+    OR e.professional_id = sqlc.narg (owner_id)
+    OR EXISTS (
+      SELECT
+        1
+      FROM
+        events oe
+      WHERE
+        oe.business_id = e.business_id
+        AND oe.professional_id = sqlc.narg (owner_id)
+        AND oe.user_id = e.user_id
+        AND oe.deleted_at IS NULL
+    )
+  )
+ORDER BY
+  e.start_date DESC;
+
+-- name: GetClinicalRecordAttachments :many
+SELECT
+  a.medical_history_id,
+  a.file_name,
+  a.mime_type
+FROM
+  medical_history_attachments a
+  JOIN medical_histories mh ON mh.id = a.medical_history_id
+WHERE
+  a.business_id = sqlc.arg (business_id)
+  AND mh.user_id = sqlc.arg (user_id)
+  AND (
+    sqlc.narg (owner_id)::uuid IS NULL
+    OR mh.professional_id = sqlc.narg (owner_id)
+    OR EXISTS (
+      SELECT
+        1
+      FROM
+        events e
+      WHERE
+        e.business_id = mh.business_id
+        AND e.professional_id = sqlc.narg (owner_id)
+        AND e.user_id = mh.user_id
+        AND e.deleted_at IS NULL
+    )
+  )
+ORDER BY
+  a.created_at;
+
+-- name: CountMedicalHistoriesByPatientID :one
+SELECT
+  COUNT(*)
+FROM
+  medical_histories mh
+WHERE
+  mh.business_id = sqlc.arg (business_id)
+  AND mh.user_id = sqlc.arg (user_id)
+  AND mh.deleted_at IS NULL
+  AND (
+    sqlc.narg (owner_id)::uuid IS NULL
+    OR mh.professional_id = sqlc.narg (owner_id)
+    OR EXISTS (
+      SELECT
+        1
+      FROM
+        events e
+      WHERE
+        e.business_id = mh.business_id
+        AND e.professional_id = sqlc.narg (owner_id)
+        AND e.user_id = mh.user_id
+        AND e.deleted_at IS NULL
+    )
+  );
+
+-- name: CreateClinicalRecordExport :one
+INSERT INTO
+  clinical_record_exports (
+    business_id,
+    patient_id,
+    requested_by,
+    include_deleted,
+    owner_id
+  )
+VALUES
+  ($1, $2, $3, $4, $5)
+RETURNING
+  *;
+
+-- name: GetClinicalRecordExport :one
+SELECT
+  *
+FROM
+  clinical_record_exports
+WHERE
+  business_id = $1
+  AND id = $2;
+
+-- name: UpdateClinicalRecordExportStatus :exec
+UPDATE clinical_record_exports
+SET
+  status = sqlc.arg ('status'),
+  storage_key = COALESCE(sqlc.narg ('storage_key'), storage_key),
+  error = sqlc.narg ('error'),
+  completed_at = CASE
+    WHEN sqlc.arg ('status') IN ('completed', 'failed') THEN now()
+    ELSE completed_at
+  END,
+  updated_at = now()
+WHERE
+  id = sqlc.arg ('id');
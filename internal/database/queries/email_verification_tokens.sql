@@ -0,0 +1,92 @@
+-- name: GetEmailVerificationToken :one
+SELECT
+  *
+FROM
+  email_verification_tokens
+WHERE
+  user_id = sqlc.arg (user_id);
+
+-- name: UpsertEmailVerificationToken :exec
+INSERT INTO
+  email_verification_tokens (user_id, business_id, token_hash, expires_at)
+VALUES
+  (
+    sqlc.arg (user_id),
+    sqlc.arg (business_id),
+    sqlc.arg (token_hash),
+    sqlc.arg (expires_at)
+  )
+ON CONFLICT (user_id) DO UPDATE
+SET
+  business_id = EXCLUDED.business_id,
+  token_hash = EXCLUDED.token_hash,
+  expires_at = EXCLUDED.expires_at,
+  send_count = CASE
+    WHEN email_verification_tokens.window_started_at > sqlc.arg (window_start) THEN email_verification_tokens.send_count + 1
+    ELSE 1
+  END,
+  window_started_at = CASE
+    WHEN email_verification_tokens.window_started_at > sqlc.arg (window_start) THEN email_verification_tokens.window_started_at
+    ELSE now()
+  END,
+  last_sent_at = now();
+
+-- name: VerifyEmail :one
+WITH
+  consumed AS (
+    DELETE FROM email_verification_tokens
+    WHERE
+      business_id = sqlc.arg (business_id)
+      AND token_hash = sqlc.arg (token_hash)
+      AND expires_at > now()
+    RETURNING
+      user_id
+  )
+UPDATE users u
+SET
+  email_verified_at = COALESCE(u.email_verified_at, now())
+FROM
+  consumed c
+WHERE
+  u.business_id = sqlc.arg (business_id)
+  AND u.id = c.user_id
+  AND u.deleted_at IS NULL
+RETURNING
+  u.id;
+
+-- name: MarkEmailVerified :execrows
+UPDATE users
+SET
+  email_verified_at = now()
+WHERE
+  business_id = sqlc.arg (business_id)
+  AND id = sqlc.arg (id)
+  AND email_verified_at IS NULL;
+
+-- name: GetEmailVerificationUser :one
+SELECT
+  id,
+  business_id,
+  email,
+  first_name,
+  last_name,
+  email_verified_at
+FROM
+  users
+WHERE
+  business_id = sqlc.arg (business_id)
+  AND id = sqlc.arg (id)
+  AND deleted_at IS NULL;
+
+-- name: CheckBookingEmailVerified :one
+SELECT
+  (
+    NOT b.require_verified_email_booking
+    OR u.email_verified_at IS NOT NULL
+  )::BOOLEAN AS allowed
+FROM
+  users u
+  JOIN businesses b ON b.id = u.business_id
+WHERE
+  u.business_id = sqlc.arg (business_id)
+  AND u.id = sqlc.arg (id);
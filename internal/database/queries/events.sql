@@ -180,13 +180,17 @@ FROM
   LEFT JOIN users p ON p.id = e.professional_id
   LEFT JOIN professional_profile pp ON pp.user_id = p.id
 WHERE
-  e.business_id = $1
+  e.business_id = sqlc.arg (business_id)
   AND e.deleted_at IS NULL
+  AND (
+    sqlc.narg (professional_id)::uuid IS NULL
+    OR e.professional_id = sqlc.narg (professional_id)
+  )
 ORDER BY
   e.start_date::date DESC,
   e.end_date::time DESC
 LIMIT
-  $2;
+  sqlc.arg (query_limit);
 
 -- name: GetByProfessionalDay :many
 SELECT
@@ -612,8 +616,6 @@ SELECT
       p.role_id,
       'businessId',
       p.business_id,
-      'refreshToken',
-      p.refresh_token,
       'createdAt',
       p.created_at,
       'updatedAt',
@@ -654,6 +656,89 @@ SELECT
         pp.deleted_at
       )
     ),
+    'clinicalAlerts',
+    jsonb_build_object(
+      'allergies',
+      COALESCE(
+        (
+          SELECT
+            jsonb_agg(
+              jsonb_build_object(
+                'id',
+                a.id,
+                'substance',
+                a.substance,
+                'reaction',
+                a.reaction,
+                'severity',
+                a.severity
+              )
+              ORDER BY
+                CASE a.severity
+                  WHEN 'life_threatening' THEN 0
+                  WHEN 'severe' THEN 1
+                  WHEN 'moderate' THEN 2
+                  ELSE 3
+                END,
+                a.substance
+            )
+          FROM
+            patient_allergies a
+          WHERE
+            a.business_id = e.business_id
+            AND a.patient_id = e.user_id
+            AND a.deleted_at IS NULL
+        ),
+        '[]'::jsonb
+      ),
+      'conditions',
+      COALESCE(
+        (
+          SELECT
+            jsonb_agg(
+              jsonb_build_object('id', pc.id, 'name', pc.name, 'code', pc.code)
+              ORDER BY
+                pc.name
+            )
+          FROM
+            patient_conditions pc
+          WHERE
+            pc.business_id = e.business_id
+            AND pc.patient_id = e.user_id
+            AND pc.status = 'active'
+            AND pc.deleted_at IS NULL
+        ),
+        '[]'::jsonb
+      ),
+      'medications',
+      COALESCE(
+        (
+          SELECT
+            jsonb_agg(
+              jsonb_build_object(
+                'id',
+                pm.id,
+                'name',
+                pm.name,
+                'dose',
+                pm.dose,
+                'frequency',
+                pm.frequency
+              )
+              ORDER BY
+                pm.name
+            )
+          FROM
+            patient_medications pm
+          WHERE
+            pm.business_id = e.business_id
+            AND pm.patient_id = e.user_id
+            AND pm.status = 'active'
+            AND pm.deleted_at IS NULL
+        ),
+        '[]'::jsonb
+      )
+    ),
     'user',
     jsonb_build_object(
       'id',
@@ -676,8 +761,6 @@ SELECT
       u.role_id,
       'businessId',
       u.business_id,
-      'refreshToken',
-      u.refresh_token,
       'createdAt',
       u.created_at,
       'updatedAt',
@@ -714,6 +797,16 @@ WHERE
   AND e.id = $2
   AND e.deleted_at IS NULL;
 
+-- name: GetEventProfessionalID :one
+SELECT
+  professional_id
+FROM
+  events
+WHERE
+  business_id = $1
+  AND id = $2
+  AND deleted_at IS NULL;
+
 -- name: CheckRecurringEvents :many
 SELECT
   id,
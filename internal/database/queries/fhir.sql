@@ -0,0 +1,248 @@
+-- name: SearchFhirPatients :many
+SELECT
+  u.id,
+  u.ic,
+  u.first_name,
+  u.last_name,
+  u.email,
+  u.phone_number,
+  u.updated_at,
+  pp.gender,
+  pp.birth_day,
+  pp.emergency_contact_name,
+  pp.emergency_contact_phone
+FROM
+  users u
+  JOIN roles r ON r.id = u.role_id
+  LEFT JOIN patient_profile pp ON pp.business_id = u.business_id
+  AND pp.user_id = u.id
+  AND pp.deleted_at IS NULL
+WHERE
+  u.business_id = sqlc.arg (business_id)
+  AND r.value = 'patient'
+  AND u.deleted_at IS NULL
+  AND (
+    sqlc.narg (id)::UUID IS NULL
+    OR u.id = sqlc.narg (id)
+  )
+  AND (
+    sqlc.narg (identifier)::TEXT IS NULL
+    OR u.ic = sqlc.narg (identifier)
+  )
+  AND (
+    sqlc.narg (name)::TEXT IS NULL
+    OR u.first_name ILIKE '%' || sqlc.narg (name) || '%'
+    OR u.last_name ILIKE '%' || sqlc.narg (name) || '%'
+  )
+  AND (
+    sqlc.narg (birthdate)::DATE IS NULL
+    OR pp.birth_day = sqlc.narg (birthdate)
+  )
+ORDER BY
+  u.last_name,
+  u.first_name,
+  u.id
+LIMIT
+  sqlc.arg (query_limit);
+
+-- name: SearchFhirPractitioners :many
+SELECT
+  u.id,
+  u.ic,
+  u.first_name,
+  u.last_name,
+  u.email,
+  u.phone_number,
+  u.updated_at,
+  prp.license_id,
+  prp.professional_prefix,
+  prp.specialty
+FROM
+  users u
+  JOIN professional_profile prp ON prp.business_id = u.business_id
+  AND prp.user_id = u.id
+  AND prp.deleted_at IS NULL
+WHERE
+  u.business_id = sqlc.arg (business_id)
+  AND u.deleted_at IS NULL
+  AND (
+    sqlc.narg (id)::UUID IS NULL
+    OR u.id = sqlc.narg (id)
+  )
+  AND (
+    sqlc.narg (identifier)::TEXT IS NULL
+    OR u.ic = sqlc.narg (identifier)
+    OR prp.license_id = sqlc.narg (identifier)
+  )
+  AND (
+    sqlc.narg (name)::TEXT IS NULL
+    OR u.first_name ILIKE '%' || sqlc.narg (name) || '%'
+    OR u.last_name ILIKE '%' || sqlc.narg (name) || '%'
+  )
+ORDER BY
+  u.last_name,
+  u.first_name,
+  u.id
+LIMIT
+  sqlc.arg (query_limit);
+
+-- name: SearchFhirAppointments :many
+SELECT
+  e.id,
+  e.title,
+  e.start_date,
+  e.end_date,
+  e.status,
+  e.user_id,
+  e.professional_id,
+  e.created_at,
+  e.updated_at,
+  p.first_name AS patient_first_name,
+  p.last_name AS patient_last_name,
+  pr.first_name AS professional_first_name,
+  pr.last_name AS professional_last_name
+FROM
+  events e
+  JOIN users p ON p.id = e.user_id
+  JOIN users pr ON pr.id = e.professional_id
+WHERE
+  e.business_id = sqlc.arg (business_id)
+  AND e.deleted_at IS NULL
+  AND (
+    sqlc.narg (id)::UUID IS NULL
+    OR e.id = sqlc.narg (id)
+  )
+  AND (
+    sqlc.narg (patient_id)::UUID IS NULL
+    OR e.user_id = sqlc.narg (patient_id)
+  )
+  AND (
+    sqlc.narg (professional_id)::UUID IS NULL
+    OR e.professional_id = sqlc.narg (professional_id)
+  )
+  AND (
+    sqlc.narg (date_from)::TIMESTAMPTZ IS NULL
+    OR e.start_date >= sqlc.narg (date_from)
+  )
+  AND (
+    sqlc.narg (date_to)::TIMESTAMPTZ IS NULL
+    OR e.start_date < sqlc.narg (date_to)
+  )
+ORDER BY
+  e.start_date DESC,
+  e.id
+LIMIT
+  sqlc.arg (query_limit);
+
+-- name: SearchFhirEncounters :many
+SELECT
+  mh.id,
+  mh.business_id,
+  mh.user_id,
+  mh.professional_id,
+  mh.event_id,
+  mh.date,
+  mh.reason,
+  mh.created_at,
+  mh.updated_at,
+  p.first_name AS patient_first_name,
+  p.last_name AS patient_last_name,
+  pr.first_name AS professional_first_name,
+  pr.last_name AS professional_last_name
+FROM
+  medical_histories mh
+  JOIN users p ON p.id = mh.user_id
+  LEFT JOIN users pr ON pr.id = mh.professional_id
+WHERE
+  mh.business_id = sqlc.arg (business_id)
+  AND mh.deleted_at IS NULL
+  AND (
+    sqlc.narg (id)::UUID IS NULL
+    OR mh.id = sqlc.narg (id)
+  )
+  AND (
+    sqlc.narg (patient_id)::UUID IS NULL
+    OR mh.user_id = sqlc.narg (patient_id)
+  )
+  AND (
+    sqlc.narg (professional_id)::UUID IS NULL
+    OR mh.professional_id = sqlc.narg (professional_id)
+  )
+  AND (
+    sqlc.narg (owner_id)::uuid IS NULL
+    OR mh.professional_id = sqlc.narg (owner_id)
+    OR EXISTS (
+      SELECT
+        1
+      FROM
+        events e
+      WHERE
+        e.business_id = mh.business_id
+        AND e.professional_id = sqlc.narg (owner_id)
+        AND e.user_id = mh.user_id
+        AND e.deleted_at IS NULL
+    )
+  )
+  AND (
+    sqlc.narg (date_from)::TIMESTAMPTZ IS NULL
+    OR mh.date >= sqlc.narg (date_from)
+  )
+  AND (
+    sqlc.narg (date_to)::TIMESTAMPTZ IS NULL
+    OR mh.date < sqlc.narg (date_to)
+  )
+ORDER BY
+  mh.date DESC,
+  mh.id
+LIMIT
+  sqlc.arg (query_limit);
+
+-- name: SearchFhirConditions :many
+SELECT
+  *
+FROM
+  patient_conditions
+WHERE
+  business_id = sqlc.arg (business_id)
+  AND deleted_at IS NULL
+  AND (
+    sqlc.narg (id)::UUID IS NULL
+    OR id = sqlc.narg (id)
+  )
+  AND (
+    sqlc.narg (patient_id)::UUID IS NULL
+    OR patient_id = sqlc.narg (patient_id)
+  )
+  AND (
+    sqlc.narg (owner_id)::uuid IS NULL
+    OR EXISTS (
+      SELECT
+        1
+      FROM
+        medical_histories mh
+      WHERE
+        mh.business_id = patient_conditions.business_id
+        AND mh.user_id = patient_conditions.patient_id
+        AND mh.professional_id = sqlc.narg (owner_id)
+    )
+    OR EXISTS (
+      SELECT
+        1
+      FROM
+        events e
+      WHERE
+        e.business_id = patient_conditions.business_id
+        AND e.professional_id = sqlc.narg (owner_id)
+        AND e.user_id = patient_conditions.patient_id
+        AND e.deleted_at IS NULL
+    )
+  )
+  AND (
+    sqlc.narg (status)::TEXT IS NULL
+    OR status = sqlc.narg (status)
+  )
+ORDER BY
+  created_at DESC,
+  id
+LIMIT
+  sqlc.arg (query_limit);
@@ -0,0 +1,164 @@
+-- name: CreateInvitation :one
+INSERT INTO
+  invitations (
+    business_id,
+    email,
+    role_id,
+    invited_by,
+    token_hash,
+    expires_at
+  )
+VALUES
+  (
+    sqlc.arg (business_id),
+    sqlc.arg (email),
+    sqlc.arg (role_id),
+    sqlc.arg (invited_by),
+    sqlc.arg (token_hash),
+    sqlc.arg (expires_at)
+  )
+RETURNING
+  *;
+
+-- name: ExpireInvitations :exec
+UPDATE invitations
+SET
+  status = 'expired',
+  updated_at = now()
+WHERE
+  business_id = sqlc.arg (business_id)
+  AND email = sqlc.arg (email)
+  AND status = 'pending'
+  AND expires_at <= now();
+
+-- name: GetInvitation :one
+SELECT
+  i.id,
+  i.email,
+  i.role_id,
+  r.value AS role_value,
+  r.name AS role_name,
+  i.invited_by,
+  CASE
+    WHEN i.status = 'pending'
+    AND i.expires_at <= now() THEN 'expired'
+    ELSE i.status
+  END::VARCHAR AS status,
+  i.expires_at,
+  i.accepted_user_id,
+  i.accepted_at,
+  i.revoked_at,
+  i.created_at
+FROM
+  invitations i
+  JOIN roles r ON r.id = i.role_id
+WHERE
+  i.business_id = sqlc.arg (business_id)
+  AND i.id = sqlc.arg (id);
+
+-- name: ListInvitations :many
+SELECT
+  id,
+  email,
+  role_id,
+  role_value,
+  role_name,
+  invited_by,
+  status,
+  expires_at,
+  accepted_user_id,
+  accepted_at,
+  revoked_at,
+  created_at
+FROM
+  (
+    SELECT
+      i.id,
+      i.email,
+      i.role_id,
+      r.value AS role_value,
+      r.name AS role_name,
+      i.invited_by,
+      CASE
+        WHEN i.status = 'pending'
+        AND i.expires_at <= now() THEN 'expired'
+        ELSE i.status
+      END::VARCHAR AS status,
+      i.expires_at,
+      i.accepted_user_id,
+      i.accepted_at,
+      i.revoked_at,
+      i.created_at
+    FROM
+      invitations i
+      JOIN roles r ON r.id = i.role_id
+    WHERE
+      i.business_id = sqlc.arg (business_id)
+  ) AS inv
+WHERE
+  (
+    sqlc.narg (status)::VARCHAR IS NULL
+    OR inv.status = sqlc.narg (status)::VARCHAR
+  )
+ORDER BY
+  inv.created_at DESC
+LIMIT
+  sqlc.arg (query_limit);
+
+-- name: RenewInvitation :one
+UPDATE invitations
+SET
+  token_hash = sqlc.arg (token_hash),
+  expires_at = sqlc.arg (expires_at),
+  status = 'pending',
+  updated_at = now()
+WHERE
+  business_id = sqlc.arg (business_id)
+  AND id = sqlc.arg (id)
+  AND status IN ('pending', 'expired')
+RETURNING
+  *;
+
+-- name: RevokeInvitation :execrows
+UPDATE invitations
+SET
+  status = 'revoked',
+  revoked_at = now(),
+  updated_at = now()
+WHERE
+  business_id = sqlc.arg (business_id)
+  AND id = sqlc.arg (id)
+  AND status = 'pending'
+  AND expires_at > now();
+
+-- name: GetInvitationByTokenHash :one
+SELECT
+  i.id,
+  i.business_id,
+  i.email,
+  i.role_id,
+  r.value AS role_value,
+  r.name AS role_name,
+  b.trade_name AS business_name,
+  i.expires_at
+FROM
+  invitations i
+  JOIN roles r ON r.id = i.role_id
+  JOIN businesses b ON b.id = i.business_id
+WHERE
+  i.token_hash = sqlc.arg (token_hash)
+  AND i.status = 'pending'
+  AND i.expires_at > now()
+FOR UPDATE OF
+  i;
+
+-- name: AcceptInvitation :execrows
+UPDATE invitations
+SET
+  status = 'accepted',
+  accepted_user_id = sqlc.arg (accepted_user_id),
+  accepted_at = now(),
+  updated_at = now()
+WHERE
+  id = sqlc.arg (id)
+  AND status = 'pending';
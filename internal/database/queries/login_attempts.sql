@@ -0,0 +1,52 @@
+-- name: CreateLoginAttempt :exec
+INSERT INTO
+  login_attempts (
+    business_id,
+    email,
+    ip_address,
+    user_agent,
+    reason
+  )
+VALUES
+  (
+    sqlc.arg (business_id),
+    sqlc.arg (email),
+    sqlc.arg (ip_address),
+    sqlc.arg (user_agent),
+    sqlc.arg (reason)
+  );
+
+-- name: GetLoginAttempts :many
+SELECT
+  *
+FROM
+  login_attempts
+WHERE
+  business_id = sqlc.arg (business_id)
+  AND (
+    sqlc.narg (email)::text IS NULL
+    OR email = sqlc.narg (email)
+  )
+ORDER BY
+  created_at DESC
+LIMIT
+  sqlc.arg (query_limit)
+OFFSET
+  sqlc.arg (query_offset);
+
+-- name: CountLoginAttempts :one
+SELECT
+  COUNT(*)::INT AS total
+FROM
+  login_attempts
+WHERE
+  business_id = sqlc.arg (business_id)
+  AND (
+    sqlc.narg (email)::text IS NULL
+    OR email = sqlc.narg (email)
+  );
+
+-- name: DeleteLoginAttemptsBefore :execrows
+DELETE FROM login_attempts
+WHERE
+  created_at < sqlc.arg (before);
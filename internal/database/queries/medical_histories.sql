@@ -7,11 +7,10 @@ INSERT INTO
     event_id,
     date,
     reason,
-    recipe,
     comments
   )
 VALUES
-  ($1, $2, $3, $4, $5, $6, $7, $8)
+  ($1, $2, $3, $4, $5, $6, $7)
 RETURNING
   *;
 
@@ -23,18 +22,62 @@ SELECT
   u.last_name,
   p.first_name,
   p.last_name,
-  pp.professional_prefix
+  pp.professional_prefix,
+  (
+    EXISTS (
+      SELECT
+        1
+      FROM
+        prescriptions pr
+      WHERE
+        pr.medical_history_id = mh.id
+        AND pr.deleted_at IS NULL
+    )
+    OR EXISTS (
+      SELECT
+        1
+      FROM
+        medical_history_legacy_recipes lr
+      WHERE
+        lr.medical_history_id = mh.id
+    )
+  )::BOOLEAN AS recipe
 FROM
   medical_histories mh
   LEFT JOIN users u ON u.id = mh.user_id
   LEFT JOIN users p ON p.id = mh.professional_id
   LEFT JOIN professional_profile pp ON pp.user_id = p.id
 WHERE
-  mh.business_id = $1
-  AND mh.user_id = $2
+  mh.business_id = sqlc.arg (business_id)
+  AND mh.user_id = sqlc.arg (user_id)
+  AND (
+    sqlc.narg (owner_id)::uuid IS NULL
+    OR mh.professional_id = sqlc.narg (owner_id)
+    OR EXISTS (
+      SELECT
+        1
+      FROM
+        events e
+      WHERE
+        e.business_id = mh.business_id
+        AND e.professional_id = sqlc.narg (owner_id)
+        AND e.user_id = mh.user_id
+        AND e.deleted_at IS NULL
+    )
+  )
 ORDER BY
   mh.date DESC;
 
+-- name: GetMedicalHistoryByID :one
+SELECT
+  *
+FROM
+  medical_histories
+WHERE
+  business_id = $1
+  AND id = $2
+  AND deleted_at IS NULL;
+
 -- name: UpdateMedicalHistory :execrows
 UPDATE medical_histories
 SET
@@ -44,7 +87,6 @@ SET
   event_id = COALESCE(sqlc.narg ('event_id'), event_id),
   date = COALESCE(sqlc.narg ('date'), date),
   reason = COALESCE(sqlc.narg ('reason'), reason),
-  recipe = COALESCE(sqlc.narg ('recipe'), recipe),
   comments = COALESCE(sqlc.narg ('comments'), comments),
   updated_at = now()
 WHERE
@@ -80,3 +122,81 @@ WHERE
   business_id = $1
   AND id = $2
   AND deleted_at IS NULL;
+
+-- name: MedicalHistoryExists :one
+SELECT
+  EXISTS (
+    SELECT
+      1
+    FROM
+      medical_histories
+    WHERE
+      business_id = $1
+      AND id = $2
+      AND deleted_at IS NULL
+  ) AS medical_history_exists;
+
+-- name: GetMedicalHistoryForUpdate :one
+SELECT
+  *
+FROM
+  medical_histories
+WHERE
+  business_id = $1
+  AND id = $2
+FOR UPDATE;
+
+-- name: GetMedicalHistoryPatientID :one
+SELECT
+  user_id
+FROM
+  medical_histories
+WHERE
+  business_id = $1
+  AND id = $2;
+
+-- name: GetMedicalHistoryAccess :one
+SELECT
+  mh.professional_id = sqlc.arg (owner_id)::uuid AS authored,
+  EXISTS (
+    SELECT
+      1
+    FROM
+      events e
+    WHERE
+      e.business_id = mh.business_id
+      AND e.professional_id = sqlc.arg (owner_id)
+      AND e.user_id = mh.user_id
+      AND e.deleted_at IS NULL
+  ) AS treats_patient
+FROM
+  medical_histories mh
+WHERE
+  mh.business_id = sqlc.arg (business_id)
+  AND mh.id = sqlc.arg (id);
+
+-- name: IsPatientInOwnerScope :one
+SELECT
+  (
+    EXISTS (
+      SELECT
+        1
+      FROM
+        medical_histories mh
+      WHERE
+        mh.business_id = sqlc.arg (business_id)
+        AND mh.user_id = sqlc.arg (patient_id)
+        AND mh.professional_id = sqlc.arg (owner_id)
+    )
+    OR EXISTS (
+      SELECT
+        1
+      FROM
+        events e
+      WHERE
+        e.business_id = sqlc.arg (business_id)
+        AND e.professional_id = sqlc.arg (owner_id)
+        AND e.user_id = sqlc.arg (patient_id)
+        AND e.deleted_at IS NULL
+    )
+  )::BOOLEAN AS in_scope;
@@ -0,0 +1,43 @@
+-- name: CreateMedicalHistoryAttachment :one
+INSERT INTO
+  medical_history_attachments (
+    business_id,
+    medical_history_id,
+    uploaded_by,
+    file_name,
+    mime_type,
+    size_bytes,
+    storage_key
+  )
+VALUES
+  ($1, $2, $3, $4, $5, $6, $7)
+RETURNING
+  *;
+
+-- name: GetMedicalHistoryAttachments :many
+SELECT
+  *
+FROM
+  medical_history_attachments
+WHERE
+  business_id = $1
+  AND medical_history_id = $2
+ORDER BY
+  created_at ASC;
+
+-- name: GetMedicalHistoryAttachment :one
+SELECT
+  *
+FROM
+  medical_history_attachments
+WHERE
+  business_id = $1
+  AND medical_history_id = $2
+  AND id = $3;
+
+-- name: DeleteMedicalHistoryAttachment :execrows
+DELETE FROM medical_history_attachments
+WHERE
+  business_id = $1
+  AND medical_history_id = $2
+  AND id = $3;
@@ -0,0 +1,68 @@
+-- name: CreateMedicalHistoryRevision :one
+INSERT INTO
+  medical_history_revisions (
+    business_id,
+    medical_history_id,
+    revision,
+    action,
+    changed_by,
+    changes,
+    snapshot,
+    reason
+  )
+SELECT
+  sqlc.arg ('business_id')::uuid,
+  sqlc.arg ('medical_history_id')::uuid,
+  COALESCE(MAX(revision), 0) + 1,
+  sqlc.arg ('action')::varchar,
+  sqlc.arg ('changed_by')::uuid,
+  sqlc.arg ('changes')::jsonb,
+  sqlc.narg ('snapshot')::jsonb,
+  sqlc.narg ('reason')::varchar
+FROM
+  medical_history_revisions
+WHERE
+  medical_history_id = sqlc.arg ('medical_history_id')::uuid
+RETURNING
+  *;
+
+-- name: GetMedicalHistoryRevisions :many
+SELECT
+  r.*,
+  u.first_name,
+  u.last_name
+FROM
+  medical_history_revisions r
+  LEFT JOIN users u ON u.id = r.changed_by
+WHERE
+  r.business_id = $1
+  AND r.medical_history_id = $2
+ORDER BY
+  r.revision DESC;
+
+-- name: CreateMedicalHistoryAddendum :one
+INSERT INTO
+  medical_history_addenda (
+    business_id,
+    medical_history_id,
+    author_id,
+    content
+  )
+VALUES
+  ($1, $2, $3, $4)
+RETURNING
+  *;
+
+-- name: GetMedicalHistoryAddenda :many
+SELECT
+  a.*,
+  u.first_name,
+  u.last_name
+FROM
+  medical_history_addenda a
+  LEFT JOIN users u ON u.id = a.author_id
+WHERE
+  a.business_id = $1
+  AND a.medical_history_id = $2
+ORDER BY
+  a.created_at ASC;
@@ -0,0 +1,154 @@
+-- name: ListPatientMedicalHistories :many
+SELECT
+  mh.*,
+  u.ic,
+  u.first_name,
+  u.last_name,
+  p.first_name,
+  p.last_name,
+  pp.professional_prefix,
+  (
+    EXISTS (
+      SELECT
+        1
+      FROM
+        prescriptions pr
+      WHERE
+        pr.medical_history_id = mh.id
+        AND pr.deleted_at IS NULL
+    )
+    OR EXISTS (
+      SELECT
+        1
+      FROM
+        medical_history_legacy_recipes lr
+      WHERE
+        lr.medical_history_id = mh.id
+    )
+  )::BOOLEAN AS recipe
+FROM
+  medical_histories mh
+  LEFT JOIN users u ON u.id = mh.user_id
+  LEFT JOIN users p ON p.id = mh.professional_id
+  LEFT JOIN professional_profile pp ON pp.user_id = p.id
+WHERE
+  mh.business_id = sqlc.arg (business_id)
+  AND mh.user_id = sqlc.arg (user_id)
+  AND (
+    sqlc.narg (owner_id)::uuid IS NULL
+    OR mh.professional_id = sqlc.narg (owner_id)
+    OR EXISTS (
+      SELECT
+        1
+      FROM
+        events e
+      WHERE
+        e.business_id = mh.business_id
+        AND e.professional_id = sqlc.narg (owner_id)
+        AND e.user_id = mh.user_id
+        AND e.deleted_at IS NULL
+    )
+  )
+  AND CASE sqlc.arg (deleted)::text
+    WHEN 'only' THEN mh.deleted_at IS NOT NULL
+    WHEN 'include' THEN TRUE
+    ELSE mh.deleted_at IS NULL
+  END
+  AND (
+    sqlc.narg (professional_id)::uuid IS NULL
+    OR mh.professional_id = sqlc.narg (professional_id)
+  )
+  AND (
+    sqlc.narg (date_from)::timestamptz IS NULL
+    OR mh.date >= sqlc.narg (date_from)
+  )
+  AND (
+    sqlc.narg (date_to)::timestamptz IS NULL
+    OR mh.date < sqlc.narg (date_to)
+  )
+  AND (
+    sqlc.narg (tokens)::text[] IS NULL
+    OR EXISTS (
+      SELECT
+        1
+      FROM
+        medical_history_search_tokens st
+      WHERE
+        st.medical_history_id = mh.id
+        AND st.tokens @> sqlc.narg (tokens)::text[]
+    )
+  )
+  AND (
+    sqlc.narg (cursor_date)::timestamptz IS NULL
+    OR (
+      sqlc.arg (sort_asc)::boolean
+      AND (mh.date, mh.id) > (sqlc.narg (cursor_date), sqlc.narg (cursor_id)::uuid)
+    )
+    OR (
+      NOT sqlc.arg (sort_asc)::boolean
+      AND (mh.date, mh.id) < (sqlc.narg (cursor_date), sqlc.narg (cursor_id)::uuid)
+    )
+  )
+ORDER BY
+  CASE
+    WHEN sqlc.arg (sort_asc)::boolean THEN mh.date
+  END ASC,
+  CASE
+    WHEN sqlc.arg (sort_asc)::boolean THEN mh.id
+  END ASC,
+  mh.date DESC,
+  mh.id DESC
+LIMIT
+  sqlc.arg (query_limit);
+
+-- name: UpsertMedicalHistorySearchTokens :exec
+INSERT INTO
+  medical_history_search_tokens (medical_history_id, business_id, tokens)
+VALUES
+  (
+    sqlc.arg (medical_history_id),
+    sqlc.arg (business_id),
+    sqlc.arg (tokens)
+  )
+ON CONFLICT (medical_history_id) DO UPDATE
+SET
+  tokens = EXCLUDED.tokens,
+  updated_at = now();
+
+-- name: ListMedicalHistoriesWithoutSearchTokens :many
+SELECT
+  mh.id,
+  mh.reason,
+  mh.comments
+FROM
+  medical_histories mh
+WHERE
+  mh.business_id = sqlc.arg (business_id)
+  AND mh.id > sqlc.arg (after_id)
+  AND NOT EXISTS (
+    SELECT
+      1
+    FROM
+      medical_history_search_tokens st
+    WHERE
+      st.medical_history_id = mh.id
+  )
+ORDER BY
+  mh.id ASC
+LIMIT
+  sqlc.arg (batch_size);
+
+-- name: ListBusinessesWithoutSearchTokens :many
+SELECT DISTINCT
+  mh.business_id
+FROM
+  medical_histories mh
+WHERE
+  NOT EXISTS (
+    SELECT
+      1
+    FROM
+      medical_history_search_tokens st
+    WHERE
+      st.medical_history_id = mh.id
+  );
@@ -0,0 +1,20 @@
+-- name: ShareMedicalHistory :execrows
+INSERT INTO
+  medical_history_shares (medical_history_id, business_id, shared_by)
+SELECT
+  id,
+  business_id,
+  sqlc.arg (shared_by)
+FROM
+  medical_histories
+WHERE
+  business_id = sqlc.arg (business_id)
+  AND id = sqlc.arg (medical_history_id)
+  AND deleted_at IS NULL
+ON CONFLICT (medical_history_id) DO NOTHING;
+
+-- name: UnshareMedicalHistory :execrows
+DELETE FROM medical_history_shares
+WHERE
+  business_id = sqlc.arg (business_id)
+  AND medical_history_id = sqlc.arg (medical_history_id);
@@ -0,0 +1,79 @@
+-- name: CreateMedicalHistoryTemplate :one
+INSERT INTO
+  medical_history_templates (
+    business_id,
+    title,
+    specialty,
+    reason,
+    comments,
+    fields,
+    created_by
+  )
+VALUES
+  ($1, $2, $3, $4, $5, $6, $7)
+RETURNING
+  *;
+
+-- name: GetMedicalHistoryTemplates :many
+SELECT
+  *
+FROM
+  medical_history_templates
+WHERE
+  business_id = sqlc.arg (business_id)
+  AND deleted_at IS NULL
+  AND (
+    sqlc.narg (specialty)::TEXT IS NULL
+    OR specialty = sqlc.narg (specialty)
+    OR specialty = ''
+  )
+ORDER BY
+  specialty,
+  title;
+
+-- name: GetMedicalHistoryTemplateByID :one
+SELECT
+  *
+FROM
+  medical_history_templates
+WHERE
+  business_id = $1
+  AND id = $2
+  AND deleted_at IS NULL;
+
+-- name: UpdateMedicalHistoryTemplate :one
+UPDATE medical_history_templates
+SET
+  title = COALESCE(sqlc.narg (title), title),
+  specialty = COALESCE(sqlc.narg (specialty), specialty),
+  reason = COALESCE(sqlc.narg (reason), reason),
+  comments = COALESCE(sqlc.narg (comments), comments),
+  fields = COALESCE(sqlc.narg (fields), fields),
+  updated_at = now()
+WHERE
+  business_id = sqlc.arg (business_id)
+  AND id = sqlc.arg (id)
+  AND deleted_at IS NULL
+RETURNING
+  *;
+
+-- name: SoftDeleteMedicalHistoryTemplate :execrows
+UPDATE medical_history_templates
+SET
+  deleted_at = now()
+WHERE
+  business_id = $1
+  AND id = $2
+  AND deleted_at IS NULL;
+
+-- name: GetBusinessSpecialties :many
+SELECT DISTINCT
+  specialty
+FROM
+  professional_profile
+WHERE
+  business_id = $1
+  AND deleted_at IS NULL
+  AND specialty <> ''
+ORDER BY
+  specialty;
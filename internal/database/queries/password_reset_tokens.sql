@@ -0,0 +1,25 @@
+-- name: UpsertPasswordResetToken :exec
+INSERT INTO
+  password_reset_tokens (user_id, business_id, token_hash, expires_at)
+VALUES
+  (
+    sqlc.arg (user_id),
+    sqlc.arg (business_id),
+    sqlc.arg (token_hash),
+    sqlc.arg (expires_at)
+  )
+ON CONFLICT (user_id) DO UPDATE
+SET
+  business_id = EXCLUDED.business_id,
+  token_hash = EXCLUDED.token_hash,
+  expires_at = EXCLUDED.expires_at,
+  created_at = now();
+
+-- name: ConsumePasswordResetToken :one
+DELETE FROM password_reset_tokens
+WHERE
+  business_id = sqlc.arg (business_id)
+  AND token_hash = sqlc.arg (token_hash)
+  AND expires_at > now()
+RETURNING
+  user_id;
@@ -0,0 +1,57 @@
+-- name: CreatePatientAllergy :one
+INSERT INTO
+  patient_allergies (
+    business_id,
+    patient_id,
+    substance,
+    reaction,
+    severity,
+    created_by
+  )
+VALUES
+  ($1, $2, $3, $4, $5, $6)
+RETURNING
+  *;
+
+-- name: GetPatientAllergiesByPatientID :many
+SELECT
+  *
+FROM
+  patient_allergies
+WHERE
+  business_id = $1
+  AND patient_id = $2
+  AND deleted_at IS NULL
+ORDER BY
+  CASE severity
+    WHEN 'life_threatening' THEN 0
+    WHEN 'severe' THEN 1
+    WHEN 'moderate' THEN 2
+    ELSE 3
+  END,
+  substance;
+
+-- name: UpdatePatientAllergy :one
+UPDATE patient_allergies
+SET
+  substance = COALESCE(sqlc.narg (substance), substance),
+  reaction = COALESCE(sqlc.narg (reaction), reaction),
+  severity = COALESCE(sqlc.narg (severity), severity),
+  updated_at = now()
+WHERE
+  business_id = sqlc.arg (business_id)
+  AND patient_id = sqlc.arg (patient_id)
+  AND id = sqlc.arg (id)
+  AND deleted_at IS NULL
+RETURNING
+  *;
+
+-- name: SoftDeletePatientAllergy :execrows
+UPDATE patient_allergies
+SET
+  deleted_at = now()
+WHERE
+  business_id = $1
+  AND patient_id = $2
+  AND id = $3
+  AND deleted_at IS NULL;
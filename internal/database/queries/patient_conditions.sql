@@ -0,0 +1,57 @@
+-- name: CreatePatientCondition :one
+INSERT INTO
+  patient_conditions (
+    business_id,
+    patient_id,
+    name,
+    code,
+    status,
+    onset_date,
+    resolved_date,
+    created_by
+  )
+VALUES
+  ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING
+  *;
+
+-- name: GetPatientConditionsByPatientID :many
+SELECT
+  *
+FROM
+  patient_conditions
+WHERE
+  business_id = $1
+  AND patient_id = $2
+  AND deleted_at IS NULL
+ORDER BY
+  status,
+  onset_date DESC NULLS LAST,
+  name;
+
+-- name: UpdatePatientCondition :one
+UPDATE patient_conditions
+SET
+  name = COALESCE(sqlc.narg (name), name),
+  code = COALESCE(sqlc.narg (code), code),
+  status = COALESCE(sqlc.narg (status), status),
+  onset_date = COALESCE(sqlc.narg (onset_date), onset_date),
+  resolved_date = COALESCE(sqlc.narg (resolved_date), resolved_date),
+  updated_at = now()
+WHERE
+  business_id = sqlc.arg (business_id)
+  AND patient_id = sqlc.arg (patient_id)
+  AND id = sqlc.arg (id)
+  AND deleted_at IS NULL
+RETURNING
+  *;
+
+-- name: SoftDeletePatientCondition :execrows
+UPDATE patient_conditions
+SET
+  deleted_at = now()
+WHERE
+  business_id = $1
+  AND patient_id = $2
+  AND id = $3
+  AND deleted_at IS NULL;
@@ -0,0 +1,79 @@
+-- name: CreatePatientImport :one
+INSERT INTO
+  patient_imports (
+    business_id,
+    requested_by,
+    file_name,
+    storage_key,
+    total_rows
+  )
+VALUES
+  (
+    sqlc.arg (business_id),
+    sqlc.arg (requested_by),
+    sqlc.arg (file_name),
+    sqlc.arg (storage_key),
+    sqlc.arg (total_rows)
+  )
+RETURNING
+  *;
+
+-- name: GetPatientImport :one
+SELECT
+  *
+FROM
+  patient_imports
+WHERE
+  business_id = sqlc.arg (business_id)
+  AND id = sqlc.arg (id);
+
+-- name: GetPatientImports :many
+SELECT
+  *
+FROM
+  patient_imports
+WHERE
+  business_id = sqlc.arg (business_id)
+ORDER BY
+  created_at DESC
+LIMIT
+  sqlc.arg (query_limit);
+
+-- name: UpdatePatientImportStatus :exec
+UPDATE patient_imports
+SET
+  status = sqlc.arg (status),
+  error = sqlc.narg (error),
+  completed_at = CASE
+    WHEN sqlc.arg (status) IN ('completed', 'failed') THEN now()
+    ELSE completed_at
+  END,
+  updated_at = now()
+WHERE
+  id = sqlc.arg (id);
+
+-- name: UpdatePatientImportProgress :exec
+UPDATE patient_imports
+SET
+  processed_rows = sqlc.arg (processed_rows),
+  imported_rows = sqlc.arg (imported_rows),
+  failed_rows = sqlc.arg (failed_rows),
+  errors = sqlc.arg (errors),
+  updated_at = now()
+WHERE
+  id = sqlc.arg (id);
+
+-- name: GetExistingUserIdentifiers :many
+SELECT
+  ic,
+  email,
+  user_name
+FROM
+  users
+WHERE
+  business_id = sqlc.arg (business_id)
+  AND (
+    ic = ANY (sqlc.arg (ics)::text[])
+    OR email = ANY (sqlc.arg (emails)::text[])
+    OR user_name = ANY (sqlc.arg (user_names)::text[])
+  );
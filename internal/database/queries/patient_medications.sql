@@ -0,0 +1,58 @@
+-- name: CreatePatientMedication :one
+INSERT INTO
+  patient_medications (
+    business_id,
+    patient_id,
+    name,
+    dose,
+    frequency,
+    status,
+    start_date,
+    end_date,
+    created_by
+  )
+VALUES
+  ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING
+  *;
+
+-- name: GetPatientMedicationsByPatientID :many
+SELECT
+  *
+FROM
+  patient_medications
+WHERE
+  business_id = $1
+  AND patient_id = $2
+  AND deleted_at IS NULL
+ORDER BY
+  status,
+  name;
+
+-- name: UpdatePatientMedication :one
+UPDATE patient_medications
+SET
+  name = COALESCE(sqlc.narg (name), name),
+  dose = COALESCE(sqlc.narg (dose), dose),
+  frequency = COALESCE(sqlc.narg (frequency), frequency),
+  status = COALESCE(sqlc.narg (status), status),
+  start_date = COALESCE(sqlc.narg (start_date), start_date),
+  end_date = COALESCE(sqlc.narg (end_date), end_date),
+  updated_at = now()
+WHERE
+  business_id = sqlc.arg (business_id)
+  AND patient_id = sqlc.arg (patient_id)
+  AND id = sqlc.arg (id)
+  AND deleted_at IS NULL
+RETURNING
+  *;
+
+-- name: SoftDeletePatientMedication :execrows
+UPDATE patient_medications
+SET
+  deleted_at = now()
+WHERE
+  business_id = $1
+  AND patient_id = $2
+  AND id = $3
+  AND deleted_at IS NULL;
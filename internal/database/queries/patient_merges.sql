@@ -0,0 +1,203 @@
+-- name: FindPatientDuplicates :many
+SELECT
+  a.id AS patient_id,
+  a.ic AS patient_ic,
+  a.first_name AS patient_first_name,
+  a.last_name AS patient_last_name,
+  a.email AS patient_email,
+  a.phone_number AS patient_phone_number,
+  b.id AS candidate_id,
+  b.ic AS candidate_ic,
+  b.first_name AS candidate_first_name,
+  b.last_name AS candidate_last_name,
+  b.email AS candidate_email,
+  b.phone_number AS candidate_phone_number,
+  a.ic = b.ic AS same_ic,
+  a.phone_number = b.phone_number AS same_phone,
+  similarity(
+    lower(a.first_name || ' ' || a.last_name),
+    lower(b.first_name || ' ' || b.last_name)
+  )::float8 AS name_similarity
+FROM
+  users a
+  JOIN roles ra ON ra.id = a.role_id
+  JOIN users b ON b.business_id = a.business_id
+  AND b.id <> a.id
+  AND (
+    b.ic = a.ic
+    OR b.phone_number = a.phone_number
+    OR lower(b.first_name || ' ' || b.last_name) % lower(a.first_name || ' ' || a.last_name)
+  )
+  JOIN roles rb ON rb.id = b.role_id
+WHERE
+  a.business_id = sqlc.arg (business_id)
+  AND a.deleted_at IS NULL
+  AND b.deleted_at IS NULL
+  AND ra.value = 'patient'
+  AND rb.value = 'patient'
+  AND (
+    (
+      sqlc.narg (patient_id)::uuid IS NULL
+      AND a.id < b.id
+    )
+    OR a.id = sqlc.narg (patient_id)
+  )
+ORDER BY
+  same_ic DESC,
+  same_phone DESC,
+  name_similarity DESC,
+  a.id,
+  b.id
+LIMIT
+  sqlc.arg (query_limit);
+
+-- name: GetPatientForMerge :one
+SELECT
+  u.id,
+  r.value AS role_value,
+  u.deleted_at
+FROM
+  users u
+  JOIN roles r ON r.id = u.role_id
+WHERE
+  u.business_id = sqlc.arg (business_id)
+  AND u.id = sqlc.arg (id)
+FOR UPDATE OF
+  u;
+
+-- name: MergePatientEvents :execrows
+UPDATE events
+SET
+  user_id = sqlc.arg (survivor_id),
+  updated_at = now()
+WHERE
+  business_id = sqlc.arg (business_id)
+  AND user_id = sqlc.arg (duplicate_id);
+
+-- name: MergePatientMedicalHistories :execrows
+UPDATE medical_histories
+SET
+  user_id = sqlc.arg (survivor_id),
+  updated_at = now()
+WHERE
+  business_id = sqlc.arg (business_id)
+  AND user_id = sqlc.arg (duplicate_id);
+
+-- name: MergePatientPrescriptions :execrows
+UPDATE prescriptions
+SET
+  user_id = sqlc.arg (survivor_id),
+  updated_at = now()
+WHERE
+  business_id = sqlc.arg (business_id)
+  AND user_id = sqlc.arg (duplicate_id);
+
+-- name: MergePatientVitalSigns :execrows
+UPDATE vital_signs
+SET
+  patient_id = sqlc.arg (survivor_id),
+  updated_at = now()
+WHERE
+  business_id = sqlc.arg (business_id)
+  AND patient_id = sqlc.arg (duplicate_id);
+
+-- name: MergePatientAllergies :execrows
+UPDATE patient_allergies
+SET
+  patient_id = sqlc.arg (survivor_id),
+  updated_at = now()
+WHERE
+  business_id = sqlc.arg (business_id)
+  AND patient_id = sqlc.arg (duplicate_id);
+
+-- name: MergePatientConditions :execrows
+UPDATE patient_conditions
+SET
+  patient_id = sqlc.arg (survivor_id),
+  updated_at = now()
+WHERE
+  business_id = sqlc.arg (business_id)
+  AND patient_id = sqlc.arg (duplicate_id);
+
+-- name: MergePatientMedications :execrows
+UPDATE patient_medications
+SET
+  patient_id = sqlc.arg (survivor_id),
+  updated_at = now()
+WHERE
+  business_id = sqlc.arg (business_id)
+  AND patient_id = sqlc.arg (duplicate_id);
+
+-- name: MovePatientProfile :execrows
+UPDATE patient_profile
+SET
+  user_id = sqlc.arg (survivor_id),
+  updated_at = now()
+WHERE
+  business_id = sqlc.arg (business_id)
+  AND user_id = sqlc.arg (duplicate_id)
+  AND NOT EXISTS (
+    SELECT
+      1
+    FROM
+      patient_profile
+    WHERE
+      business_id = sqlc.arg (business_id)
+      AND user_id = sqlc.arg (survivor_id)
+  );
+
+-- name: CopyPatientProfile :execrows
+UPDATE patient_profile s
+SET
+  gender = d.gender,
+  birth_day = d.birth_day,
+  blood_type = d.blood_type,
+  weight = d.weight,
+  height = d.height,
+  emergency_contact_name = d.emergency_contact_name,
+  emergency_contact_phone = d.emergency_contact_phone,
+  updated_at = now()
+FROM
+  patient_profile d
+WHERE
+  s.business_id = sqlc.arg (business_id)
+  AND s.user_id = sqlc.arg (survivor_id)
+  AND d.business_id = sqlc.arg (business_id)
+  AND d.user_id = sqlc.arg (duplicate_id);
+
+-- name: CreatePatientMerge :one
+INSERT INTO
+  patient_merges (
+    business_id,
+    survivor_id,
+    duplicate_id,
+    merged_by,
+    summary
+  )
+VALUES
+  (
+    sqlc.arg (business_id),
+    sqlc.arg (survivor_id),
+    sqlc.arg (duplicate_id),
+    sqlc.arg (merged_by),
+    sqlc.arg (summary)
+  )
+RETURNING
+  *;
+
+-- name: GetPatientMerges :many
+SELECT
+  *
+FROM
+  patient_merges
+WHERE
+  business_id = sqlc.arg (business_id)
+  AND (
+    sqlc.narg (patient_id)::uuid IS NULL
+    OR survivor_id = sqlc.narg (patient_id)
+    OR duplicate_id = sqlc.narg (patient_id)
+  )
+ORDER BY
+  created_at DESC
+LIMIT
+  sqlc.arg (query_limit);
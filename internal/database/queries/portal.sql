@@ -0,0 +1,142 @@
+-- name: GetPortalPatient :one
+SELECT
+  u.id,
+  u.ic,
+  u.user_name,
+  u.first_name,
+  u.last_name,
+  u.email,
+  u.phone_number,
+  r.value AS role_value
+FROM
+  users u
+  JOIN roles r ON r.id = u.role_id
+WHERE
+  u.business_id = sqlc.arg (business_id)
+  AND u.id = sqlc.arg (id)
+  AND u.deleted_at IS NULL;
+
+-- name: GetPortalAppointments :many
+SELECT
+  e.id,
+  e.title,
+  e.start_date,
+  e.end_date,
+  e.status,
+  e.professional_id,
+  p.first_name AS professional_first_name,
+  p.last_name AS professional_last_name,
+  pp.professional_prefix
+FROM
+  events e
+  LEFT JOIN users p ON p.id = e.professional_id
+  LEFT JOIN professional_profile pp ON pp.user_id = e.professional_id
+WHERE
+  e.business_id = sqlc.arg (business_id)
+  AND e.user_id = sqlc.arg (user_id)
+  AND e.deleted_at IS NULL
+  AND (e.start_date >= now()) = sqlc.arg (upcoming)::boolean
+ORDER BY
+  CASE
+    WHEN sqlc.arg (upcoming)::boolean THEN e.start_date
+  END ASC,
+  e.start_date DESC
+LIMIT
+  sqlc.arg (query_limit);
+
+-- name: GetPortalAppointment :one
+SELECT
+  id,
+  start_date,
+  end_date,
+  status,
+  professional_id
+FROM
+  events
+WHERE
+  business_id = sqlc.arg (business_id)
+  AND user_id = sqlc.arg (user_id)
+  AND id = sqlc.arg (id)
+  AND deleted_at IS NULL;
+
+-- name: CancelPortalAppointment :execrows
+UPDATE events
+SET
+  status = 'cancelled',
+  updated_at = now()
+WHERE
+  business_id = sqlc.arg (business_id)
+  AND user_id = sqlc.arg (user_id)
+  AND id = sqlc.arg (id)
+  AND status = 'pending'
+  AND deleted_at IS NULL;
+
+-- name: ReschedulePortalAppointment :execrows
+UPDATE events
+SET
+  start_date = sqlc.arg (start_date),
+  end_date = sqlc.arg (end_date),
+  updated_at = now()
+WHERE
+  business_id = sqlc.arg (business_id)
+  AND user_id = sqlc.arg (user_id)
+  AND id = sqlc.arg (id)
+  AND status = 'pending'
+  AND deleted_at IS NULL;
+
+-- name: IsPortalSlotTaken :one
+SELECT
+  EXISTS (
+    SELECT
+      1
+    FROM
+      events
+    WHERE
+      business_id = sqlc.arg (business_id)
+      AND professional_id = sqlc.arg (professional_id)
+      AND id <> sqlc.arg (event_id)
+      AND status <> 'cancelled'
+      AND deleted_at IS NULL
+      AND start_date < sqlc.arg (end_date)
+      AND end_date > sqlc.arg (start_date)
+  )
+  OR EXISTS (
+    SELECT
+      1
+    FROM
+      blocked_days
+    WHERE
+      business_id = sqlc.arg (business_id)
+      AND professional_id = sqlc.arg (professional_id)
+      AND (
+        (date AT TIME ZONE 'America/Argentina/Buenos_Aires')::date = (sqlc.arg (start_date) AT TIME ZONE 'America/Argentina/Buenos_Aires')::date
+        OR (
+          recurrent
+          AND to_char(date AT TIME ZONE 'America/Argentina/Buenos_Aires', 'MM-DD') = to_char(sqlc.arg (start_date) AT TIME ZONE 'America/Argentina/Buenos_Aires', 'MM-DD')
+        )
+      )
+  ) AS taken;
+
+-- name: GetPortalMedicalHistories :many
+SELECT
+  mh.id,
+  mh.business_id,
+  mh.date,
+  mh.reason,
+  s.shared_at,
+  p.first_name AS professional_first_name,
+  p.last_name AS professional_last_name,
+  pp.professional_prefix
+FROM
+  medical_histories mh
+  JOIN medical_history_shares s ON s.medical_history_id = mh.id
+  LEFT JOIN users p ON p.id = mh.professional_id
+  LEFT JOIN professional_profile pp ON pp.user_id = mh.professional_id
+WHERE
+  mh.business_id = sqlc.arg (business_id)
+  AND mh.user_id = sqlc.arg (user_id)
+  AND mh.deleted_at IS NULL
+ORDER BY
+  mh.date DESC
+LIMIT
+  sqlc.arg (query_limit);
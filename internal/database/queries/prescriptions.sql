@@ -0,0 +1,180 @@
+-- name: NextPrescriptionNumber :one
+INSERT INTO
+  prescription_sequences (business_id, last_number)
+VALUES
+  ($1, 1)
+ON CONFLICT (business_id) DO UPDATE
+SET
+  last_number = prescription_sequences.last_number + 1
+RETURNING
+  last_number;
+
+-- name: CreatePrescription :one
+INSERT INTO
+  prescriptions (
+    business_id,
+    number,
+    medical_history_id,
+    user_id,
+    professional_id,
+    date,
+    notes
+  )
+VALUES
+  ($1, $2, $3, $4, $5, $6, $7)
+RETURNING
+  *;
+
+-- name: CreatePrescriptionItem :one
+INSERT INTO
+  prescription_items (
+    prescription_id,
+    position,
+    medication,
+    presentation,
+    dose,
+    frequency,
+    duration_days
+  )
+VALUES
+  ($1, $2, $3, $4, $5, $6, $7)
+RETURNING
+  *;
+
+-- name: GetPrescriptionByID :one
+SELECT
+  pr.*,
+  u.ic,
+  u.first_name,
+  u.last_name,
+  p.first_name,
+  p.last_name,
+  pp.professional_prefix,
+  pp.license_id,
+  pp.specialty
+FROM
+  prescriptions pr
+  LEFT JOIN users u ON u.id = pr.user_id
+  LEFT JOIN users p ON p.id = pr.professional_id
+  LEFT JOIN professional_profile pp ON pp.user_id = p.id
+WHERE
+  pr.business_id = sqlc.arg ('business_id')
+  AND pr.id = sqlc.arg ('id')
+  AND (
+    sqlc.narg ('owner_id')::uuid IS NULL
+    OR pr.professional_id = sqlc.narg ('owner_id')
+    OR EXISTS (
+      SELECT
+        1
+      FROM
+        events e
+      WHERE
+        e.business_id = pr.business_id
+        AND e.professional_id = sqlc.narg ('owner_id')
+        AND e.user_id = pr.user_id
+        AND e.deleted_at IS NULL
+    )
+  );
+
+-- name: GetPrescriptionsByPatientID :many
+SELECT
+  pr.*,
+  p.first_name,
+  p.last_name,
+  pp.professional_prefix
+FROM
+  prescriptions pr
+  LEFT JOIN users p ON p.id = pr.professional_id
+  LEFT JOIN professional_profile pp ON pp.user_id = p.id
+WHERE
+  pr.business_id = sqlc.arg ('business_id')
+  AND pr.user_id = sqlc.arg ('user_id')
+  AND (
+    sqlc.narg ('medical_history_id')::uuid IS NULL
+    OR pr.medical_history_id = sqlc.narg ('medical_history_id')::uuid
+  )
+  AND (
+    sqlc.narg ('owner_id')::uuid IS NULL
+    OR pr.professional_id = sqlc.narg ('owner_id')
+    OR EXISTS (
+      SELECT
+        1
+      FROM
+        events e
+      WHERE
+        e.business_id = pr.business_id
+        AND e.professional_id = sqlc.narg ('owner_id')
+        AND e.user_id = pr.user_id
+        AND e.deleted_at IS NULL
+    )
+  )
+  AND pr.deleted_at IS NULL
+ORDER BY
+  pr.date DESC;
+
+-- name: GetPrescriptionItemsByPrescriptionIDs :many
+SELECT
+  *
+FROM
+  prescription_items
+WHERE
+  prescription_id = ANY (sqlc.arg ('prescription_ids')::uuid[])
+ORDER BY
+  prescription_id,
+  position;
+
+-- name: GetPatientMedications :many
+SELECT
+  pi.*,
+  pr.number,
+  pr.date,
+  pr.medical_history_id,
+  p.first_name,
+  p.last_name,
+  pp.professional_prefix
+FROM
+  prescription_items pi
+  JOIN prescriptions pr ON pr.id = pi.prescription_id
+  LEFT JOIN users p ON p.id = pr.professional_id
+  LEFT JOIN professional_profile pp ON pp.user_id = p.id
+WHERE
+  pr.business_id = sqlc.arg ('business_id')
+  AND pr.user_id = sqlc.arg ('user_id')
+  AND pr.deleted_at IS NULL
+  AND (
+    NOT sqlc.arg ('active_only')::boolean
+    OR pi.duration_days IS NULL
+    OR pr.date + make_interval(days => pi.duration_days) >= now()
+  )
+  AND (
+    sqlc.narg ('owner_id')::uuid IS NULL
+    OR pr.professional_id = sqlc.narg ('owner_id')
+    OR EXISTS (
+      SELECT
+        1
+      FROM
+        events e
+      WHERE
+        e.business_id = pr.business_id
+        AND e.professional_id = sqlc.narg ('owner_id')
+        AND e.user_id = pr.user_id
+        AND e.deleted_at IS NULL
+    )
+  )
+ORDER BY
+  pr.date DESC,
+  pi.position;
+
+-- name: SoftDeletePrescription :execrows
+UPDATE prescriptions
+SET
+  deleted_at = now(),
+  updated_at = now()
+WHERE
+  business_id = sqlc.arg ('business_id')
+  AND id = sqlc.arg ('id')
+  AND deleted_at IS NULL
+  AND (
+    sqlc.narg ('owner_id')::uuid IS NULL
+    OR professional_id = sqlc.narg ('owner_id')
+  );
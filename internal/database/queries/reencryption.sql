@@ -0,0 +1,100 @@
+-- name: ListMedicalHistoriesForReencryption :many
+SELECT
+  id,
+  reason,
+  comments
+FROM
+  medical_histories
+WHERE
+  business_id = sqlc.arg (business_id)
+  AND id > sqlc.arg (after_id)
+ORDER BY
+  id ASC
+LIMIT
+  sqlc.arg (batch_size);
+
+-- name: UpdateMedicalHistoryCiphertext :execrows
+UPDATE medical_histories
+SET
+  reason = sqlc.arg (reason),
+  comments = sqlc.arg (comments)
+WHERE
+  business_id = sqlc.arg (business_id)
+  AND id = sqlc.arg (id)
+  AND reason = sqlc.arg (old_reason)
+  AND comments = sqlc.arg (old_comments);
+
+-- name: ListPatientProfilesForReencryption :many
+SELECT
+  id,
+  emergency_contact_name,
+  emergency_contact_phone
+FROM
+  patient_profile
+WHERE
+  business_id = sqlc.arg (business_id)
+  AND id > sqlc.arg (after_id)
+ORDER BY
+  id ASC
+LIMIT
+  sqlc.arg (batch_size);
+
+-- name: UpdatePatientProfileCiphertext :execrows
+UPDATE patient_profile
+SET
+  emergency_contact_name = sqlc.arg (emergency_contact_name),
+  emergency_contact_phone = sqlc.arg (emergency_contact_phone)
+WHERE
+  business_id = sqlc.arg (business_id)
+  AND id = sqlc.arg (id)
+  AND emergency_contact_name = sqlc.arg (old_emergency_contact_name)
+  AND emergency_contact_phone = sqlc.arg (old_emergency_contact_phone);
+
+-- name: ListMedicalHistoryAddendaForReencryption :many
+SELECT
+  id,
+  content
+FROM
+  medical_history_addenda
+WHERE
+  business_id = sqlc.arg (business_id)
+  AND id > sqlc.arg (after_id)
+ORDER BY
+  id ASC
+LIMIT
+  sqlc.arg (batch_size);
+
+-- name: UpdateMedicalHistoryAddendumCiphertext :execrows
+UPDATE medical_history_addenda
+SET
+  content = sqlc.arg (content)
+WHERE
+  business_id = sqlc.arg (business_id)
+  AND id = sqlc.arg (id)
+  AND content = sqlc.arg (old_content);
+
+-- name: ListMedicalHistoryRevisionsForReencryption :many
+SELECT
+  id,
+  changes,
+  snapshot
+FROM
+  medical_history_revisions
+WHERE
+  business_id = sqlc.arg (business_id)
+  AND id > sqlc.arg (after_id)
+ORDER BY
+  id ASC
+LIMIT
+  sqlc.arg (batch_size);
+
+-- name: UpdateMedicalHistoryRevisionCiphertext :execrows
+UPDATE medical_history_revisions
+SET
+  changes = sqlc.arg (changes),
+  snapshot = sqlc.arg (snapshot)
+WHERE
+  business_id = sqlc.arg (business_id)
+  AND id = sqlc.arg (id)
+  AND changes = sqlc.arg (old_changes)
+  AND snapshot IS NOT DISTINCT FROM sqlc.arg (old_snapshot);
@@ -0,0 +1,51 @@
+-- name: CreateSecurityEvent :exec
+INSERT INTO
+  security_events (
+    business_id,
+    user_id,
+    session_id,
+    event_type,
+    ip_address,
+    user_agent,
+    impersonator_id
+  )
+VALUES
+  (
+    sqlc.arg (business_id),
+    sqlc.arg (user_id),
+    sqlc.arg (session_id),
+    sqlc.arg (event_type),
+    sqlc.arg (ip_address),
+    sqlc.arg (user_agent),
+    sqlc.narg (impersonator_id)
+  );
+
+-- name: GetSecurityEvents :many
+SELECT
+  *
+FROM
+  security_events
+WHERE
+  business_id = sqlc.arg (business_id)
+  AND (
+    sqlc.narg (user_id)::uuid IS NULL
+    OR user_id = sqlc.narg (user_id)
+  )
+ORDER BY
+  created_at DESC
+LIMIT
+  sqlc.arg (query_limit)
+OFFSET
+  sqlc.arg (query_offset);
+
+-- name: CountSecurityEvents :one
+SELECT
+  COUNT(*)::INT AS total
+FROM
+  security_events
+WHERE
+  business_id = sqlc.arg (business_id)
+  AND (
+    sqlc.narg (user_id)::uuid IS NULL
+    OR user_id = sqlc.narg (user_id)
+  );
@@ -0,0 +1,175 @@
+-- name: GetTwoFactorStatus :one
+SELECT
+  u.id,
+  u.business_id,
+  u.role_id,
+  u.email,
+  r.value AS role_value,
+  tf.secret,
+  tf.enabled_at,
+  COALESCE(tf.last_used_step, 0)::BIGINT AS last_used_step,
+  (
+    r.value <> 'patient'
+    AND (
+      b.require_two_factor
+      OR EXISTS (
+        SELECT
+          1
+        FROM
+          business_two_factor_roles btr
+        WHERE
+          btr.business_id = u.business_id
+          AND btr.role_id = u.role_id
+      )
+    )
+  )::BOOLEAN AS required,
+  (
+    SELECT
+      COUNT(*)
+    FROM
+      user_recovery_codes rc
+    WHERE
+      rc.user_id = u.id
+      AND rc.used_at IS NULL
+  )::INT AS recovery_codes_left
+FROM
+  users u
+  JOIN roles r ON r.id = u.role_id
+  JOIN businesses b ON b.id = u.business_id
+  LEFT JOIN user_two_factor tf ON tf.user_id = u.id
+WHERE
+  u.id = sqlc.arg (id)
+  AND u.deleted_at IS NULL;
+
+-- name: UpsertTwoFactorSecret :execrows
+INSERT INTO
+  user_two_factor (user_id, business_id, secret)
+VALUES
+  (
+    sqlc.arg (user_id),
+    sqlc.arg (business_id),
+    sqlc.arg (secret)
+  )
+ON CONFLICT (user_id) DO UPDATE
+SET
+  secret = EXCLUDED.secret,
+  last_used_step = 0,
+  updated_at = now()
+WHERE
+  user_two_factor.enabled_at IS NULL;
+
+-- name: EnableTwoFactor :execrows
+UPDATE user_two_factor
+SET
+  enabled_at = now(),
+  updated_at = now()
+WHERE
+  user_id = sqlc.arg (user_id)
+  AND enabled_at IS NULL;
+
+-- name: UseTwoFactorStep :execrows
+UPDATE user_two_factor
+SET
+  last_used_step = sqlc.arg (step),
+  updated_at = now()
+WHERE
+  user_id = sqlc.arg (user_id)
+  AND last_used_step < sqlc.arg (step);
+
+-- name: DeleteTwoFactor :execrows
+DELETE FROM user_two_factor
+WHERE
+  user_id = sqlc.arg (user_id);
+
+-- name: CreateRecoveryCodes :exec
+INSERT INTO
+  user_recovery_codes (user_id, code_hash)
+SELECT
+  sqlc.arg (user_id),
+  unnest(sqlc.arg (code_hashes)::TEXT[]);
+
+-- name: DeleteRecoveryCodes :exec
+DELETE FROM user_recovery_codes
+WHERE
+  user_id = sqlc.arg (user_id);
+
+-- name: UseRecoveryCode :execrows
+UPDATE user_recovery_codes
+SET
+  used_at = now()
+WHERE
+  user_id = sqlc.arg (user_id)
+  AND code_hash = sqlc.arg (code_hash)
+  AND used_at IS NULL;
+
+-- name: CreateTwoFactorChallenge :exec
+INSERT INTO
+  two_factor_challenges (
+    user_id,
+    business_id,
+    token_hash,
+    is_super_admin,
+    expires_at
+  )
+VALUES
+  (
+    sqlc.arg (user_id),
+    sqlc.arg (business_id),
+    sqlc.arg (token_hash),
+    sqlc.arg (is_super_admin),
+    sqlc.arg (expires_at)
+  );
+
+-- name: GetTwoFactorChallenge :one
+SELECT
+  *
+FROM
+  two_factor_challenges
+WHERE
+  business_id = sqlc.arg (business_id)
+  AND token_hash = sqlc.arg (token_hash)
+  AND expires_at > now();
+
+-- name: IncrementTwoFactorChallengeAttempts :one
+UPDATE two_factor_challenges
+SET
+  attempts = attempts + 1
+WHERE
+  id = sqlc.arg (id)
+RETURNING
+  attempts;
+
+-- name: DeleteTwoFactorChallenge :exec
+DELETE FROM two_factor_challenges
+WHERE
+  id = sqlc.arg (id);
+
+-- name: DeleteExpiredTwoFactorChallenges :execrows
+DELETE FROM two_factor_challenges
+WHERE
+  expires_at <= now();
+
+-- name: ListTwoFactorRoles :many
+SELECT
+  r.id,
+  r.name,
+  r.value
+FROM
+  business_two_factor_roles btr
+  JOIN roles r ON r.id = btr.role_id
+WHERE
+  btr.business_id = sqlc.arg (business_id)
+ORDER BY
+  r.name;
+
+-- name: DeleteTwoFactorRoles :exec
+DELETE FROM business_two_factor_roles
+WHERE
+  business_id = sqlc.arg (business_id);
+
+-- name: AddTwoFactorRoles :exec
+INSERT INTO
+  business_two_factor_roles (business_id, role_id)
+SELECT
+  sqlc.arg (business_id),
+  unnest(sqlc.arg (role_ids)::UUID[]);
@@ -0,0 +1,94 @@
+-- name: CreateUserSession :one
+INSERT INTO
+  user_sessions (
+    id,
+    user_id,
+    business_id,
+    token_hash,
+    user_agent,
+    ip_address,
+    expires_at
+  )
+VALUES
+  (
+    sqlc.arg (id),
+    sqlc.arg (user_id),
+    sqlc.arg (business_id),
+    sqlc.arg (token_hash),
+    sqlc.arg (user_agent),
+    sqlc.arg (ip_address),
+    sqlc.arg (expires_at)
+  )
+RETURNING
+  *;
+
+-- name: RotateUserSession :execrows
+WITH
+  rotated AS (
+    UPDATE user_sessions
+    SET
+      token_hash = sqlc.arg (new_token_hash),
+      user_agent = sqlc.arg (user_agent),
+      ip_address = sqlc.arg (ip_address),
+      last_used_at = now(),
+      expires_at = sqlc.arg (expires_at)
+    WHERE
+      id = sqlc.arg (id)
+      AND user_id = sqlc.arg (user_id)
+      AND token_hash = sqlc.arg (token_hash)
+      AND expires_at > now()
+    RETURNING
+      id
+  )
+INSERT INTO
+  user_session_rotated_tokens (token_hash, session_id)
+SELECT
+  sqlc.arg (token_hash),
+  id
+FROM
+  rotated;
+
+-- name: IsRotatedSessionToken :one
+SELECT
+  EXISTS (
+    SELECT
+      1
+    FROM
+      user_session_rotated_tokens
+    WHERE
+      session_id = sqlc.arg (session_id)
+      AND token_hash = sqlc.arg (token_hash)
+  )::BOOLEAN AS reused;
+
+-- name: ListUserSessions :many
+SELECT
+  *
+FROM
+  user_sessions
+WHERE
+  user_id = sqlc.arg (user_id)
+  AND expires_at > now()
+ORDER BY
+  last_used_at DESC;
+
+-- name: DeleteUserSession :execrows
+DELETE FROM user_sessions
+WHERE
+  id = sqlc.arg (id)
+  AND user_id = sqlc.arg (user_id);
+
+-- name: DeleteUserSessions :execrows
+DELETE FROM user_sessions
+WHERE
+  user_id = sqlc.arg (user_id);
+
+-- name: DeleteOtherUserSessions :execrows
+DELETE FROM user_sessions
+WHERE
+  user_id = sqlc.arg (user_id)
+  AND id <> sqlc.arg (keep_id);
+
+-- name: DeleteExpiredUserSessions :execrows
+DELETE FROM user_sessions
+WHERE
+  expires_at <= now();
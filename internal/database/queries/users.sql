@@ -27,7 +27,7 @@ SELECT
   "user"."phone_number",
   "user"."role_id",
   "user"."business_id",
-  "user"."refresh_token",
+  "user"."email_verified_at",
   "user"."created_at",
   "user"."updated_at",
   "user"."deleted_at",
@@ -52,7 +52,6 @@ SELECT
   "user"."password",
   "user"."phone_number",
   "user"."business_id",
-  "user"."refresh_token",
   "user"."created_at",
   "user"."updated_at",
   "user"."deleted_at",
@@ -114,7 +113,6 @@ SELECT
   "user"."password",
   "user"."phone_number",
   "user"."business_id",
-  "user"."refresh_token",
   "user"."created_at",
   "user"."updated_at",
   "user"."deleted_at",
@@ -247,7 +245,7 @@ SELECT
   "user"."phone_number",
   "user"."role_id",
   "user"."business_id",
-  "user"."refresh_token",
+  "user"."email_verified_at",
   "user"."created_at",
   "user"."updated_at",
   "user"."deleted_at",
@@ -271,6 +269,10 @@ SET
   email = COALESCE(sqlc.narg ('email'), email),
   password = COALESCE(sqlc.narg ('password'), password),
   phone_number = COALESCE(sqlc.narg ('phone_number'), phone_number),
+  -- A new email has to be verified again.
+  email_verified_at = CASE
+    WHEN COALESCE(sqlc.narg ('email'), email) = email THEN email_verified_at
+  END,
   updated_at = now()
 WHERE
   business_id = $1
@@ -289,7 +291,8 @@ UPDATE users
 SET
   deleted_at = now()
 WHERE
-  id = $1
+  business_id = $1
+  AND id = $2
   AND deleted_at IS NULL
 RETURNING
   *;
@@ -299,7 +302,8 @@ UPDATE users
 SET
   deleted_at = NULL
 WHERE
-  id = $1
+  business_id = $1
+  AND id = $2
   AND deleted_at IS NOT NULL
 RETURNING
   *;
@@ -315,26 +319,6 @@ WHERE
   AND email = $2
   AND deleted_at IS NULL;
 
--- name: UpdateRefreshToken :one
-UPDATE users
-SET
-  refresh_token = $2,
-  updated_at = now()
-WHERE
-  id = $1
-RETURNING
-  *;
-
--- name: ClearRefreshToken :one
-UPDATE users
-SET
-  refresh_token = NULL,
-  updated_at = now()
-WHERE
-  id = $1
-RETURNING
-  *;
-
 -- name: CheckIcAvailability :one
 SELECT
   EXISTS (
@@ -0,0 +1,137 @@
+-- name: CreateVitalSign :one
+INSERT INTO
+  vital_signs (
+    business_id,
+    patient_id,
+    medical_history_id,
+    event_id,
+    measured_at,
+    weight_kg,
+    height_cm,
+    systolic_bp,
+    diastolic_bp,
+    heart_rate,
+    temperature_c,
+    spo2,
+    notes,
+    recorded_by
+  )
+SELECT
+  sqlc.arg (business_id)::UUID,
+  sqlc.arg (patient_id)::UUID,
+  sqlc.arg (medical_history_id)::UUID,
+  sqlc.arg (event_id)::UUID,
+  sqlc.arg (measured_at)::TIMESTAMPTZ,
+  sqlc.arg (weight_kg)::NUMERIC,
+  sqlc.arg (height_cm)::NUMERIC,
+  sqlc.arg (systolic_bp)::SMALLINT,
+  sqlc.arg (diastolic_bp)::SMALLINT,
+  sqlc.arg (heart_rate)::SMALLINT,
+  sqlc.arg (temperature_c)::NUMERIC,
+  sqlc.arg (spo2)::SMALLINT,
+  sqlc.arg (notes)::TEXT,
+  sqlc.arg (recorded_by)::UUID
+WHERE
+  (
+    sqlc.arg (medical_history_id)::UUID IS NULL
+    OR EXISTS (
+      SELECT
+        1
+      FROM
+        medical_histories mh
+      WHERE
+        mh.id = sqlc.arg (medical_history_id)
+        AND mh.business_id = sqlc.arg (business_id)
+        AND mh.user_id = sqlc.arg (patient_id)
+        AND mh.deleted_at IS NULL
+    )
+  )
+  AND (
+    sqlc.arg (event_id)::UUID IS NULL
+    OR EXISTS (
+      SELECT
+        1
+      FROM
+        events e
+      WHERE
+        e.id = sqlc.arg (event_id)
+        AND e.business_id = sqlc.arg (business_id)
+        AND e.user_id = sqlc.arg (patient_id)
+        AND e.deleted_at IS NULL
+    )
+  )
+RETURNING
+  *;
+
+-- name: GetVitalSignsByPatientID :many
+SELECT
+  *
+FROM
+  vital_signs
+WHERE
+  business_id = sqlc.arg (business_id)
+  AND patient_id = sqlc.arg (patient_id)
+  AND deleted_at IS NULL
+  AND (
+    sqlc.narg (measured_from)::TIMESTAMPTZ IS NULL
+    OR measured_at >= sqlc.narg (measured_from)
+  )
+  AND (
+    sqlc.narg (measured_to)::TIMESTAMPTZ IS NULL
+    OR measured_at < sqlc.narg (measured_to)
+  )
+ORDER BY
+  measured_at DESC
+LIMIT
+  sqlc.arg (query_limit);
+
+-- name: SoftDeleteVitalSign :execrows
+UPDATE vital_signs
+SET
+  deleted_at = now()
+WHERE
+  business_id = $1
+  AND patient_id = $2
+  AND id = $3
+  AND deleted_at IS NULL;
+
+-- name: SyncPatientProfileMeasurements :exec
+UPDATE patient_profile pp
+SET
+  weight = CASE
+    WHEN sqlc.narg (weight)::NUMERIC IS NOT NULL
+    AND NOT EXISTS (
+      SELECT
+        1
+      FROM
+        vital_signs v
+      WHERE
+        v.business_id = pp.business_id
+        AND v.patient_id = pp.user_id
+        AND v.weight_kg IS NOT NULL
+        AND v.deleted_at IS NULL
+        AND v.measured_at > sqlc.arg (measured_at)
+    ) THEN sqlc.narg (weight)
+    ELSE pp.weight
+  END,
+  height = CASE
+    WHEN sqlc.narg (height)::NUMERIC IS NOT NULL
+    AND NOT EXISTS (
+      SELECT
+        1
+      FROM
+        vital_signs v
+      WHERE
+        v.business_id = pp.business_id
+        AND v.patient_id = pp.user_id
+        AND v.height_cm IS NOT NULL
+        AND v.deleted_at IS NULL
+        AND v.measured_at > sqlc.arg (measured_at)
+    ) THEN sqlc.narg (height)
+    ELSE pp.height
+  END,
+  updated_at = now()
+WHERE
+  pp.business_id = sqlc.arg (business_id)
+  AND pp.user_id = sqlc.arg (user_id)
+  AND pp.deleted_at IS NULL;
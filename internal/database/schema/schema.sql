@@ -1,5 +1,7 @@
 CREATE EXTENSION IF NOT EXISTS "pgcrypto";
 
+CREATE EXTENSION IF NOT EXISTS "pg_trgm";
+
 CREATE TABLE roles (
   id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
   name VARCHAR(100) NOT NULL,
@@ -29,6 +31,9 @@ CREATE TABLE businesses (
   phone_number VARCHAR(10) NOT NULL,
   whatsapp_number VARCHAR(10),
   website VARCHAR(100),
+  require_verified_email_login BOOLEAN NOT NULL DEFAULT false,
+  require_verified_email_booking BOOLEAN NOT NULL DEFAULT false,
+  require_two_factor BOOLEAN NOT NULL DEFAULT false,
   created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
   updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
   deleted_at TIMESTAMPTZ
@@ -45,7 +50,7 @@ CREATE TABLE users (
   phone_number VARCHAR(10) NOT NULL,
   role_id UUID NOT NULL REFERENCES roles (id),
   business_id UUID NOT NULL REFERENCES businesses (id),
-  refresh_token TEXT,
+  email_verified_at TIMESTAMPTZ,
   created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
   updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
   deleted_at TIMESTAMPTZ,
@@ -63,6 +68,10 @@ CREATE INDEX IF NOT EXISTS idx_users_business_email ON users (business_id, email
 
 CREATE INDEX IF NOT EXISTS idx_users_business_role ON users (business_id, role_id);
 
+CREATE INDEX idx_users_business_phone ON users (business_id, phone_number);
+
+CREATE INDEX idx_users_full_name_trgm ON users USING GIN (lower(first_name || ' ' || last_name) gin_trgm_ops);
+
 CREATE TABLE patient_profile (
   id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
   business_id UUID NOT NULL,
@@ -72,8 +81,8 @@ CREATE TABLE patient_profile (
   blood_type VARCHAR(20) NOT NULL,
   weight NUMERIC NOT NULL,
   height NUMERIC NOT NULL,
-  emergency_contact_name VARCHAR(50) NOT NULL,
-  emergency_contact_phone VARCHAR(10) NOT NULL,
+  emergency_contact_name VARCHAR NOT NULL,
+  emergency_contact_phone VARCHAR NOT NULL,
   created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
   updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
   deleted_at TIMESTAMPTZ,
@@ -147,6 +156,127 @@ CREATE INDEX idx_brp_business_role ON business_role_permissions (business_id, ro
 
 CREATE INDEX idx_brp_permission ON business_role_permissions (permission_id);
 
+-- // Auth //
+-- One pending reset per user: requesting a new link replaces the previous one.
+-- Only the SHA-256 of the token is stored.
+CREATE TABLE password_reset_tokens (
+  user_id UUID PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+  business_id UUID NOT NULL REFERENCES businesses (id) ON DELETE CASCADE,
+  token_hash VARCHAR(64) NOT NULL UNIQUE,
+  expires_at TIMESTAMPTZ NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- One row per logged in device. Only the SHA-256 of the current refresh token
+-- is stored; every refresh rotates it.
+CREATE TABLE user_sessions (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+  business_id UUID NOT NULL REFERENCES businesses (id) ON DELETE CASCADE,
+  token_hash VARCHAR(64) NOT NULL UNIQUE,
+  user_agent VARCHAR(255) NOT NULL DEFAULT '',
+  ip_address VARCHAR(45) NOT NULL DEFAULT '',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  last_used_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_user_sessions_user ON user_sessions (user_id, last_used_at DESC);
+
+CREATE INDEX idx_user_sessions_expires ON user_sessions (expires_at);
+
+-- Hashes of the refresh tokens a session already rotated away. Presenting one
+-- again means the token was copied, so the whole session is revoked.
+CREATE TABLE user_session_rotated_tokens (
+  token_hash VARCHAR(64) PRIMARY KEY,
+  session_id UUID NOT NULL REFERENCES user_sessions (id) ON DELETE CASCADE,
+  rotated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_user_session_rotated_tokens_session ON user_session_rotated_tokens (session_id);
+
+-- Roles whose users must log in with two-factor authentication, on top of
+-- businesses.require_two_factor which covers every staff role.
+CREATE TABLE business_two_factor_roles (
+  business_id UUID NOT NULL REFERENCES businesses (id) ON DELETE CASCADE,
+  role_id UUID NOT NULL REFERENCES roles (id) ON DELETE CASCADE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (business_id, role_id)
+);
+
+-- The TOTP secret is encrypted with the key of the user's business. A row
+-- without enabled_at is an enrollment waiting for its first code.
+-- last_used_step keeps a code from being used twice.
+CREATE TABLE user_two_factor (
+  user_id UUID PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+  business_id UUID NOT NULL REFERENCES businesses (id) ON DELETE CASCADE,
+  secret TEXT NOT NULL,
+  enabled_at TIMESTAMPTZ,
+  last_used_step BIGINT NOT NULL DEFAULT 0,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Single-use codes to log in without the phone. Only their SHA-256 is stored.
+CREATE TABLE user_recovery_codes (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID NOT NULL REFERENCES user_two_factor (user_id) ON DELETE CASCADE,
+  code_hash VARCHAR(64) NOT NULL,
+  used_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  UNIQUE (user_id, code_hash)
+);
+
+-- Second login step: the password was checked and the code is pending.
+CREATE TABLE two_factor_challenges (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+  business_id UUID NOT NULL REFERENCES businesses (id) ON DELETE CASCADE,
+  token_hash VARCHAR(64) NOT NULL UNIQUE,
+  is_super_admin BOOLEAN NOT NULL DEFAULT false,
+  attempts INT NOT NULL DEFAULT 0,
+  expires_at TIMESTAMPTZ NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_two_factor_challenges_expires ON two_factor_challenges (expires_at);
+
+-- One pending verification per user: resending replaces the token. send_count
+-- counts the emails sent since window_started_at to limit resends.
+CREATE TABLE email_verification_tokens (
+  user_id UUID PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+  business_id UUID NOT NULL REFERENCES businesses (id) ON DELETE CASCADE,
+  token_hash VARCHAR(64) NOT NULL UNIQUE,
+  expires_at TIMESTAMPTZ NOT NULL,
+  send_count INT NOT NULL DEFAULT 1,
+  window_started_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  last_sent_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Staff invitations. A pending invitation past expires_at is reported as
+-- expired; it is marked so when the email is invited again.
+CREATE TABLE invitations (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  business_id UUID NOT NULL REFERENCES businesses (id) ON DELETE CASCADE,
+  email VARCHAR(100) NOT NULL,
+  role_id UUID NOT NULL REFERENCES roles (id),
+  invited_by UUID REFERENCES users (id) ON DELETE SET NULL,
+  token_hash VARCHAR(64) NOT NULL UNIQUE,
+  status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'revoked', 'expired')),
+  expires_at TIMESTAMPTZ NOT NULL,
+  accepted_user_id UUID REFERENCES users (id) ON DELETE SET NULL,
+  accepted_at TIMESTAMPTZ,
+  revoked_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX uq_invitations_pending_email ON invitations (business_id, email)
+WHERE
+  status = 'pending';
+
+CREATE INDEX idx_invitations_business_created ON invitations (business_id, created_at DESC);
+
 -- // Events //
 CREATE TYPE event_status AS ENUM(
   'absent',
@@ -203,7 +333,6 @@ CREATE TABLE medical_histories (
   event_id UUID NULL,
   date TIMESTAMPTZ NOT NULL,
   reason VARCHAR NOT NULL,
-  recipe BOOLEAN NOT NULL DEFAULT FALSE,
   comments VARCHAR NOT NULL,
   created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
   updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
@@ -219,6 +348,423 @@ CREATE INDEX idx_mh_business_event ON medical_histories (business_id, event_id);
 
 CREATE INDEX idx_mh_business_user_created ON medical_histories (business_id, user_id, created_at);
 
+CREATE INDEX idx_mh_business_user_date ON medical_histories (business_id, user_id, date DESC, id DESC);
+
+CREATE TABLE medical_history_attachments (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  business_id UUID NOT NULL REFERENCES businesses (id) ON DELETE CASCADE,
+  medical_history_id UUID NOT NULL REFERENCES medical_histories (id) ON DELETE CASCADE,
+  uploaded_by UUID NOT NULL REFERENCES users (id) ON DELETE RESTRICT,
+  file_name VARCHAR(255) NOT NULL,
+  mime_type VARCHAR(100) NOT NULL,
+  size_bytes BIGINT NOT NULL,
+  storage_key VARCHAR(500) NOT NULL UNIQUE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_mh_attachments_business_mh ON medical_history_attachments (business_id, medical_history_id);
+
+-- Reason and comments are encrypted, so text search runs against keyed HMAC
+-- tokens of their words instead of the plaintext.
+CREATE TABLE medical_history_search_tokens (
+  medical_history_id UUID PRIMARY KEY REFERENCES medical_histories (id) ON DELETE CASCADE,
+  business_id UUID NOT NULL REFERENCES businesses (id) ON DELETE CASCADE,
+  tokens TEXT[] NOT NULL DEFAULT '{}',
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_mh_search_tokens_tokens ON medical_history_search_tokens USING GIN (tokens);
+
+-- Medical histories a professional made visible to the patient in the portal.
+CREATE TABLE medical_history_shares (
+  medical_history_id UUID PRIMARY KEY REFERENCES medical_histories (id) ON DELETE CASCADE,
+  business_id UUID NOT NULL REFERENCES businesses (id) ON DELETE CASCADE,
+  shared_by UUID REFERENCES users (id) ON DELETE SET NULL,
+  shared_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_mh_shares_business ON medical_history_shares (business_id);
+
+-- // Medical history revisions //
+-- Revisions intentionally have no foreign key to medical_histories so the
+-- trail survives a hard delete.
+CREATE TABLE medical_history_revisions (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  business_id UUID NOT NULL REFERENCES businesses (id) ON DELETE CASCADE,
+  medical_history_id UUID NOT NULL,
+  revision INT NOT NULL,
+  action VARCHAR(20) NOT NULL CHECK (
+    action IN (
+      'create',
+      'update',
+      'addendum',
+      'soft_delete',
+      'restore',
+      'delete'
+    )
+  ),
+  changed_by UUID NOT NULL,
+  changes JSONB NOT NULL DEFAULT '{}',
+  snapshot JSONB,
+  reason VARCHAR(500),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CONSTRAINT uq_mh_revisions_revision UNIQUE (medical_history_id, revision)
+);
+
+CREATE INDEX idx_mh_revisions_business_mh ON medical_history_revisions (business_id, medical_history_id);
+
+CREATE TABLE medical_history_addenda (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  business_id UUID NOT NULL REFERENCES businesses (id) ON DELETE CASCADE,
+  medical_history_id UUID NOT NULL REFERENCES medical_histories (id) ON DELETE CASCADE,
+  author_id UUID NOT NULL REFERENCES users (id) ON DELETE RESTRICT,
+  content VARCHAR NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_mh_addenda_business_mh ON medical_history_addenda (business_id, medical_history_id, created_at);
+
+-- // Medical history templates //
+CREATE TABLE medical_history_templates (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  business_id UUID NOT NULL REFERENCES businesses (id) ON DELETE CASCADE,
+  title VARCHAR(100) NOT NULL,
+  -- An empty specialty makes the template available to every professional.
+  specialty VARCHAR NOT NULL DEFAULT '',
+  reason VARCHAR(100) NOT NULL DEFAULT '',
+  comments TEXT NOT NULL DEFAULT '',
+  fields JSONB NOT NULL DEFAULT '[]',
+  created_by UUID REFERENCES users (id) ON DELETE SET NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  deleted_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX uq_mh_templates_business_title ON medical_history_templates (business_id, lower(title))
+WHERE
+  deleted_at IS NULL;
+
+CREATE INDEX idx_mh_templates_business_specialty ON medical_history_templates (business_id, specialty)
+WHERE
+  deleted_at IS NULL;
+
+-- // Prescriptions //
+CREATE TABLE prescription_sequences (
+  business_id UUID PRIMARY KEY REFERENCES businesses (id) ON DELETE CASCADE,
+  last_number INT NOT NULL DEFAULT 0
+);
+
+CREATE TABLE prescriptions (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  business_id UUID NOT NULL REFERENCES businesses (id) ON DELETE CASCADE,
+  number INT NOT NULL,
+  medical_history_id UUID NOT NULL REFERENCES medical_histories (id) ON DELETE CASCADE,
+  user_id UUID NOT NULL REFERENCES users (id) ON DELETE RESTRICT,
+  professional_id UUID NOT NULL REFERENCES users (id) ON DELETE RESTRICT,
+  date TIMESTAMPTZ NOT NULL,
+  notes VARCHAR,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  deleted_at TIMESTAMPTZ,
+  CONSTRAINT uq_prescriptions_business_number UNIQUE (business_id, number)
+);
+
+CREATE INDEX idx_prescriptions_business_user ON prescriptions (business_id, user_id, date);
+
+CREATE INDEX idx_prescriptions_medical_history ON prescriptions (medical_history_id);
+
+CREATE TABLE prescription_items (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  prescription_id UUID NOT NULL REFERENCES prescriptions (id) ON DELETE CASCADE,
+  position INT NOT NULL,
+  medication VARCHAR(150) NOT NULL,
+  presentation VARCHAR(100) NOT NULL,
+  dose VARCHAR(100) NOT NULL,
+  frequency VARCHAR(100) NOT NULL,
+  duration_days INT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_prescription_items_prescription ON prescription_items (prescription_id, position);
+
+-- Entries flagged before prescriptions existed keep reporting a prescription.
+CREATE TABLE medical_history_legacy_recipes (
+  medical_history_id UUID PRIMARY KEY REFERENCES medical_histories (id) ON DELETE CASCADE
+);
+
+-- // Clinical record exports //
+CREATE TABLE clinical_record_exports (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  business_id UUID NOT NULL REFERENCES businesses (id) ON DELETE CASCADE,
+  patient_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+  requested_by UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+  include_deleted BOOLEAN NOT NULL DEFAULT FALSE,
+  status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (
+    status IN ('pending', 'processing', 'completed', 'failed')
+  ),
+  storage_key VARCHAR(500),
+  error VARCHAR(500),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  completed_at TIMESTAMPTZ,
+  owner_id UUID
+);
+
+CREATE INDEX idx_clinical_record_exports_business_patient ON clinical_record_exports (business_id, patient_id, created_at);
+
+-- // Patient imports //
+CREATE TABLE patient_imports (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  business_id UUID NOT NULL REFERENCES businesses (id) ON DELETE CASCADE,
+  requested_by UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+  file_name VARCHAR(255) NOT NULL,
+  storage_key VARCHAR(500) NOT NULL,
+  status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (
+    status IN ('pending', 'processing', 'completed', 'failed')
+  ),
+  total_rows INT NOT NULL,
+  processed_rows INT NOT NULL DEFAULT 0,
+  imported_rows INT NOT NULL DEFAULT 0,
+  failed_rows INT NOT NULL DEFAULT 0,
+  errors JSONB NOT NULL DEFAULT '[]',
+  error VARCHAR(500),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  completed_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_patient_imports_business_created ON patient_imports (business_id, created_at DESC);
+
+-- // Patient merges //
+-- Merge rows outlive the users they mention, so the table has no foreign keys
+-- to them.
+CREATE TABLE patient_merges (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  business_id UUID NOT NULL REFERENCES businesses (id) ON DELETE CASCADE,
+  survivor_id UUID NOT NULL,
+  duplicate_id UUID NOT NULL,
+  merged_by UUID NOT NULL,
+  summary JSONB NOT NULL DEFAULT '{}',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_patient_merges_business_created ON patient_merges (business_id, created_at DESC);
+
+CREATE INDEX idx_patient_merges_business_survivor ON patient_merges (business_id, survivor_id);
+
+CREATE INDEX idx_patient_merges_business_duplicate ON patient_merges (business_id, duplicate_id);
+
+-- // Patient clinical summary //
+-- Problem list, allergies and current medications shown as alerts before a consultation.
+CREATE TABLE patient_allergies (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  business_id UUID NOT NULL REFERENCES businesses (id) ON DELETE CASCADE,
+  patient_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+  substance VARCHAR(150) NOT NULL,
+  reaction VARCHAR(255) NOT NULL DEFAULT '',
+  severity VARCHAR(20) NOT NULL CHECK (
+    severity IN ('mild', 'moderate', 'severe', 'life_threatening')
+  ),
+  created_by UUID REFERENCES users (id) ON DELETE SET NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  deleted_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_patient_allergies_patient ON patient_allergies (business_id, patient_id)
+WHERE
+  deleted_at IS NULL;
+
+CREATE TABLE patient_conditions (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  business_id UUID NOT NULL REFERENCES businesses (id) ON DELETE CASCADE,
+  patient_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+  name VARCHAR(150) NOT NULL,
+  code VARCHAR(20) NOT NULL DEFAULT '',
+  status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'resolved')),
+  onset_date DATE,
+  resolved_date DATE,
+  created_by UUID REFERENCES users (id) ON DELETE SET NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  deleted_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_patient_conditions_patient ON patient_conditions (business_id, patient_id)
+WHERE
+  deleted_at IS NULL;
+
+CREATE TABLE patient_medications (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  business_id UUID NOT NULL REFERENCES businesses (id) ON DELETE CASCADE,
+  patient_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+  name VARCHAR(150) NOT NULL,
+  dose VARCHAR(100) NOT NULL DEFAULT '',
+  frequency VARCHAR(100) NOT NULL DEFAULT '',
+  status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'discontinued')),
+  start_date DATE,
+  end_date DATE,
+  created_by UUID REFERENCES users (id) ON DELETE SET NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  deleted_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_patient_medications_patient ON patient_medications (business_id, patient_id)
+WHERE
+  deleted_at IS NULL;
+
+-- // Vital signs //
+CREATE TABLE vital_signs (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  business_id UUID NOT NULL REFERENCES businesses (id) ON DELETE CASCADE,
+  patient_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+  medical_history_id UUID REFERENCES medical_histories (id) ON DELETE SET NULL,
+  event_id UUID REFERENCES events (id) ON DELETE SET NULL,
+  measured_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  weight_kg NUMERIC(5, 2) CHECK (weight_kg > 0),
+  height_cm NUMERIC(5, 1) CHECK (height_cm > 0),
+  bmi NUMERIC(5, 2) GENERATED ALWAYS AS (
+    CASE
+      WHEN weight_kg IS NOT NULL
+      AND height_cm IS NOT NULL THEN round(weight_kg / ((height_cm / 100) ^ 2), 2)
+    END
+  ) STORED,
+  systolic_bp SMALLINT CHECK (systolic_bp BETWEEN 30 AND 300),
+  diastolic_bp SMALLINT CHECK (diastolic_bp BETWEEN 20 AND 200),
+  heart_rate SMALLINT CHECK (heart_rate BETWEEN 20 AND 300),
+  temperature_c NUMERIC(4, 1) CHECK (temperature_c BETWEEN 25 AND 45),
+  spo2 SMALLINT CHECK (spo2 BETWEEN 50 AND 100),
+  notes TEXT NOT NULL DEFAULT '',
+  recorded_by UUID REFERENCES users (id) ON DELETE SET NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  deleted_at TIMESTAMPTZ,
+  CONSTRAINT chk_vital_signs_measurement CHECK (
+    num_nonnulls(
+      weight_kg,
+      height_cm,
+      systolic_bp,
+      diastolic_bp,
+      heart_rate,
+      temperature_c,
+      spo2
+    ) > 0
+  ),
+  CONSTRAINT chk_vital_signs_blood_pressure CHECK ((systolic_bp IS NULL) = (diastolic_bp IS NULL))
+);
+
+CREATE INDEX idx_vital_signs_patient_measured ON vital_signs (business_id, patient_id, measured_at DESC)
+WHERE
+  deleted_at IS NULL;
+
+-- // Business data keys //
+-- Per-business data encryption keys, wrapped with the application master key.
+-- The highest version is the active one; older versions are kept so existing
+-- ciphertexts stay readable until the re-encryption job rewrites them.
+CREATE TABLE business_data_keys (
+  business_id UUID NOT NULL REFERENCES businesses (id) ON DELETE CASCADE,
+  version INT NOT NULL,
+  wrapped_key BYTEA NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (business_id, version)
+);
+
+-- // Access audit logs //
+-- Audit rows outlive the users, patients and businesses they mention, so the
+-- table has no foreign keys.
+CREATE TABLE access_audit_logs (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  business_id UUID NOT NULL,
+  user_id UUID NOT NULL,
+  role_id UUID NOT NULL,
+  resource VARCHAR(50) NOT NULL,
+  resource_id UUID,
+  patient_id UUID,
+  method VARCHAR(10) NOT NULL,
+  path VARCHAR(255) NOT NULL,
+  status_code INT NOT NULL,
+  ip_address VARCHAR(45) NOT NULL,
+  user_agent VARCHAR(500) NOT NULL DEFAULT '',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  impersonator_id UUID
+);
+
+CREATE INDEX idx_access_audit_business_created ON access_audit_logs (business_id, created_at DESC);
+
+CREATE INDEX idx_access_audit_business_patient ON access_audit_logs (business_id, patient_id, created_at DESC);
+
+CREATE INDEX idx_access_audit_business_user ON access_audit_logs (business_id, user_id, created_at DESC);
+
+CREATE FUNCTION access_audit_logs_append_only () RETURNS trigger AS $$
+BEGIN
+  RAISE EXCEPTION 'access_audit_logs is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_access_audit_logs_no_update BEFORE
+UPDATE
+OR DELETE ON access_audit_logs FOR EACH ROW
+EXECUTE FUNCTION access_audit_logs_append_only ();
+
+CREATE TRIGGER trg_access_audit_logs_no_truncate BEFORE TRUNCATE ON access_audit_logs FOR EACH STATEMENT
+EXECUTE FUNCTION access_audit_logs_append_only ();
+
+-- // Security events //
+-- Like the access audit logs, security events outlive the users and sessions
+-- they mention, so the table has no foreign keys.
+CREATE TABLE security_events (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  business_id UUID NOT NULL,
+  user_id UUID NOT NULL,
+  session_id UUID,
+  event_type VARCHAR(50) NOT NULL,
+  ip_address VARCHAR(45) NOT NULL,
+  user_agent VARCHAR(255) NOT NULL DEFAULT '',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  impersonator_id UUID
+);
+
+CREATE INDEX idx_security_events_business_created ON security_events (business_id, created_at DESC);
+
+-- // Login attempts //
+-- Failed and throttled logins. Like the access audit logs, rows outlive the
+-- users and businesses they mention, so the table has no foreign keys.
+CREATE TABLE login_attempts (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  business_id UUID NOT NULL,
+  email VARCHAR(100) NOT NULL,
+  ip_address VARCHAR(45) NOT NULL,
+  user_agent VARCHAR(255) NOT NULL DEFAULT '',
+  reason VARCHAR(30) NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_login_attempts_business_created ON login_attempts (business_id, created_at DESC);
+
+CREATE INDEX idx_login_attempts_business_email ON login_attempts (business_id, email, created_at DESC);
+
+CREATE INDEX idx_login_attempts_created ON login_attempts (created_at);
+
+-- Keys for server-to-server integrations. A key acts as the user who created
+-- it, limited to its scopes (permission action keys). Only the SHA-256 of the
+-- key is stored; prefix is the start of the key, kept to tell keys apart.
+CREATE TABLE api_keys (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  business_id UUID NOT NULL REFERENCES businesses (id) ON DELETE CASCADE,
+  user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+  name VARCHAR(100) NOT NULL,
+  prefix VARCHAR(16) NOT NULL,
+  key_hash VARCHAR(64) NOT NULL UNIQUE,
+  scopes TEXT[] NOT NULL,
+  expires_at TIMESTAMPTZ,
+  last_used_at TIMESTAMPTZ,
+  revoked_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_api_keys_business ON api_keys (business_id, created_at DESC);
+
 -- // Settings //
 CREATE TABLE settings (
   id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
@@ -87,7 +87,7 @@ VALUES
     $15
   )
 RETURNING
-  id, slug, tax_id, company_name, trade_name, description, street, city, province, country, zip_code, timezone, email, phone_number, whatsapp_number, website, created_at, updated_at, deleted_at
+  id, slug, tax_id, company_name, trade_name, description, street, city, province, country, zip_code, timezone, email, phone_number, whatsapp_number, website, require_verified_email_login, require_verified_email_booking, require_two_factor, created_at, updated_at, deleted_at
 `
 
 type CreateBusinessParams struct {
@@ -144,6 +144,9 @@ func (q *Queries) CreateBusiness(ctx context.Context, arg CreateBusinessParams)
 		&i.PhoneNumber,
 		&i.WhatsappNumber,
 		&i.Website,
+		&i.RequireVerifiedEmailLogin,
+		&i.RequireVerifiedEmailBooking,
+		&i.RequireTwoFactor,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
@@ -167,7 +170,7 @@ func (q *Queries) DeleteBusiness(ctx context.Context, id pgtype.UUID) (int64, er
 
 const getBusiness = `-- name: GetBusiness :one
 SELECT
-  id, slug, tax_id, company_name, trade_name, description, street, city, province, country, zip_code, timezone, email, phone_number, whatsapp_number, website, created_at, updated_at, deleted_at
+  id, slug, tax_id, company_name, trade_name, description, street, city, province, country, zip_code, timezone, email, phone_number, whatsapp_number, website, require_verified_email_login, require_verified_email_booking, require_two_factor, created_at, updated_at, deleted_at
 FROM
   businesses
 WHERE
@@ -194,6 +197,9 @@ func (q *Queries) GetBusiness(ctx context.Context, id pgtype.UUID) (Business, er
 		&i.PhoneNumber,
 		&i.WhatsappNumber,
 		&i.Website,
+		&i.RequireVerifiedEmailLogin,
+		&i.RequireVerifiedEmailBooking,
+		&i.RequireTwoFactor,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
@@ -203,7 +209,7 @@ func (q *Queries) GetBusiness(ctx context.Context, id pgtype.UUID) (Business, er
 
 const getBusinessBySlug = `-- name: GetBusinessBySlug :one
 SELECT
-  id, slug, tax_id, company_name, trade_name, description, street, city, province, country, zip_code, timezone, email, phone_number, whatsapp_number, website, created_at, updated_at, deleted_at
+  id, slug, tax_id, company_name, trade_name, description, street, city, province, country, zip_code, timezone, email, phone_number, whatsapp_number, website, require_verified_email_login, require_verified_email_booking, require_two_factor, created_at, updated_at, deleted_at
 FROM
   businesses
 WHERE
@@ -230,6 +236,9 @@ func (q *Queries) GetBusinessBySlug(ctx context.Context, slug string) (Business,
 		&i.PhoneNumber,
 		&i.WhatsappNumber,
 		&i.Website,
+		&i.RequireVerifiedEmailLogin,
+		&i.RequireVerifiedEmailBooking,
+		&i.RequireTwoFactor,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
@@ -239,7 +248,7 @@ func (q *Queries) GetBusinessBySlug(ctx context.Context, slug string) (Business,
 
 const getBusinesses = `-- name: GetBusinesses :many
 SELECT
-  id, slug, tax_id, company_name, trade_name, description, street, city, province, country, zip_code, timezone, email, phone_number, whatsapp_number, website, created_at, updated_at, deleted_at
+  id, slug, tax_id, company_name, trade_name, description, street, city, province, country, zip_code, timezone, email, phone_number, whatsapp_number, website, require_verified_email_login, require_verified_email_booking, require_two_factor, created_at, updated_at, deleted_at
 FROM
   businesses
 `
@@ -270,6 +279,9 @@ func (q *Queries) GetBusinesses(ctx context.Context) ([]Business, error) {
 			&i.PhoneNumber,
 			&i.WhatsappNumber,
 			&i.Website,
+			&i.RequireVerifiedEmailLogin,
+			&i.RequireVerifiedEmailBooking,
+			&i.RequireTwoFactor,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
@@ -301,28 +313,34 @@ SET
   email = COALESCE($13, email),
   phone_number = COALESCE($14, phone_number),
   whatsapp_number = COALESCE($15, whatsapp_number),
-  website = COALESCE($16, website)
+  website = COALESCE($16, website),
+  require_verified_email_login = COALESCE($17, require_verified_email_login),
+  require_verified_email_booking = COALESCE($18, require_verified_email_booking),
+  require_two_factor = COALESCE($19, require_two_factor)
 WHERE
   id = $1
 `
 
 type UpdateBusinessParams struct {
-	ID             pgtype.UUID `json:"id"`
-	Slug           pgtype.Text `json:"slug"`
-	TaxID          pgtype.Text `json:"taxId"`
-	CompanyName    pgtype.Text `json:"companyName"`
-	TradeName      pgtype.Text `json:"tradeName"`
-	Description    pgtype.Text `json:"description"`
-	Street         pgtype.Text `json:"street"`
-	City           pgtype.Text `json:"city"`
-	Province       pgtype.Text `json:"province"`
-	Country        pgtype.Text `json:"country"`
-	ZipCode        pgtype.Text `json:"zipCode"`
-	Timezone       pgtype.Text `json:"timezone"`
-	Email          pgtype.Text `json:"email"`
-	PhoneNumber    pgtype.Text `json:"phoneNumber"`
-	WhatsappNumber pgtype.Text `json:"whatsappNumber"`
-	Website        pgtype.Text `json:"website"`
+	ID                          pgtype.UUID `json:"id"`
+	Slug                        pgtype.Text `json:"slug"`
+	TaxID                       pgtype.Text `json:"taxId"`
+	CompanyName                 pgtype.Text `json:"companyName"`
+	TradeName                   pgtype.Text `json:"tradeName"`
+	Description                 pgtype.Text `json:"description"`
+	Street                      pgtype.Text `json:"street"`
+	City                        pgtype.Text `json:"city"`
+	Province                    pgtype.Text `json:"province"`
+	Country                     pgtype.Text `json:"country"`
+	ZipCode                     pgtype.Text `json:"zipCode"`
+	Timezone                    pgtype.Text `json:"timezone"`
+	Email                       pgtype.Text `json:"email"`
+	PhoneNumber                 pgtype.Text `json:"phoneNumber"`
+	WhatsappNumber              pgtype.Text `json:"whatsappNumber"`
+	Website                     pgtype.Text `json:"website"`
+	RequireVerifiedEmailLogin   pgtype.Bool `json:"requireVerifiedEmailLogin"`
+	RequireVerifiedEmailBooking pgtype.Bool `json:"requireVerifiedEmailBooking"`
+	RequireTwoFactor            pgtype.Bool `json:"requireTwoFactor"`
 }
 
 func (q *Queries) UpdateBusiness(ctx context.Context, arg UpdateBusinessParams) (int64, error) {
@@ -343,6 +361,9 @@ func (q *Queries) UpdateBusiness(ctx context.Context, arg UpdateBusinessParams)
 		arg.PhoneNumber,
 		arg.WhatsappNumber,
 		arg.Website,
+		arg.RequireVerifiedEmailLogin,
+		arg.RequireVerifiedEmailBooking,
+		arg.RequireTwoFactor,
 	)
 	if err != nil {
 		return 0, err
@@ -264,20 +264,25 @@ FROM
 WHERE
   e.business_id = $1
   AND e.deleted_at IS NULL
+  AND (
+    $2::uuid IS NULL
+    OR e.professional_id = $2
+  )
 ORDER BY
   e.start_date::date DESC,
   e.end_date::time DESC
 LIMIT
-  $2
+  $3
 `
 
 type GetByBusinessIDParams struct {
-	BusinessID pgtype.UUID `json:"businessId"`
-	Limit      int32       `json:"limit"`
+	BusinessID     pgtype.UUID `json:"businessId"`
+	ProfessionalID pgtype.UUID `json:"professionalId"`
+	QueryLimit     int32       `json:"queryLimit"`
 }
 
 func (q *Queries) GetByBusinessID(ctx context.Context, arg GetByBusinessIDParams) ([][]byte, error) {
-	rows, err := q.db.Query(ctx, getByBusinessID, arg.BusinessID, arg.Limit)
+	rows, err := q.db.Query(ctx, getByBusinessID, arg.BusinessID, arg.ProfessionalID, arg.QueryLimit)
 	if err != nil {
 		return nil, err
 	}
@@ -437,8 +442,6 @@ SELECT
       p.role_id,
       'businessId',
       p.business_id,
-      'refreshToken',
-      p.refresh_token,
       'createdAt',
       p.created_at,
       'updatedAt',
@@ -479,6 +482,89 @@ SELECT
         pp.deleted_at
       )
     ),
+    'clinicalAlerts',
+    jsonb_build_object(
+      'allergies',
+      COALESCE(
+        (
+          SELECT
+            jsonb_agg(
+              jsonb_build_object(
+                'id',
+                a.id,
+                'substance',
+                a.substance,
+                'reaction',
+                a.reaction,
+                'severity',
+                a.severity
+              )
+              ORDER BY
+                CASE a.severity
+                  WHEN 'life_threatening' THEN 0
+                  WHEN 'severe' THEN 1
+                  WHEN 'moderate' THEN 2
+                  ELSE 3
+                END,
+                a.substance
+            )
+          FROM
+            patient_allergies a
+          WHERE
+            a.business_id = e.business_id
+            AND a.patient_id = e.user_id
+            AND a.deleted_at IS NULL
+        ),
+        '[]'::jsonb
+      ),
+      'conditions',
+      COALESCE(
+        (
+          SELECT
+            jsonb_agg(
+              jsonb_build_object('id', pc.id, 'name', pc.name, 'code', pc.code)
+              ORDER BY
+                pc.name
+            )
+          FROM
+            patient_conditions pc
+          WHERE
+            pc.business_id = e.business_id
+            AND pc.patient_id = e.user_id
+            AND pc.status = 'active'
+            AND pc.deleted_at IS NULL
+        ),
+        '[]'::jsonb
+      ),
+      'medications',
+      COALESCE(
+        (
+          SELECT
+            jsonb_agg(
+              jsonb_build_object(
+                'id',
+                pm.id,
+                'name',
+                pm.name,
+                'dose',
+                pm.dose,
+                'frequency',
+                pm.frequency
+              )
+              ORDER BY
+                pm.name
+            )
+          FROM
+            patient_medications pm
+          WHERE
+            pm.business_id = e.business_id
+            AND pm.patient_id = e.user_id
+            AND pm.status = 'active'
+            AND pm.deleted_at IS NULL
+        ),
+        '[]'::jsonb
+      )
+    ),
     'user',
     jsonb_build_object(
       'id',
@@ -501,8 +587,6 @@ SELECT
       u.role_id,
       'businessId',
       u.business_id,
-      'refreshToken',
-      u.refresh_token,
       'createdAt',
       u.created_at,
       'updatedAt',
@@ -750,6 +834,29 @@ func (q *Queries) GetDaysWithEvents(ctx context.Context, arg GetDaysWithEventsPa
 	return items, nil
 }
 
+const getEventProfessionalID = `-- name: GetEventProfessionalID :one
+SELECT
+  professional_id
+FROM
+  events
+WHERE
+  business_id = $1
+  AND id = $2
+  AND deleted_at IS NULL
+`
+
+type GetEventProfessionalIDParams struct {
+	BusinessID pgtype.UUID `json:"businessId"`
+	ID         pgtype.UUID `json:"id"`
+}
+
+func (q *Queries) GetEventProfessionalID(ctx context.Context, arg GetEventProfessionalIDParams) (pgtype.UUID, error) {
+	row := q.db.QueryRow(ctx, getEventProfessionalID, arg.BusinessID, arg.ID)
+	var professional_id pgtype.UUID
+	err := row.Scan(&professional_id)
+	return professional_id, err
+}
+
 const getEventRecurrentID = `-- name: GetEventRecurrentID :one
 SELECT
   recurrent_id
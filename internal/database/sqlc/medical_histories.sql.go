@@ -20,13 +20,12 @@ INSERT INTO
     event_id,
     date,
     reason,
-    recipe,
     comments
   )
 VALUES
-  ($1, $2, $3, $4, $5, $6, $7, $8)
+  ($1, $2, $3, $4, $5, $6, $7)
 RETURNING
-  id, business_id, user_id, professional_id, event_id, date, reason, recipe, comments, created_at, updated_at, deleted_at
+  id, business_id, user_id, professional_id, event_id, date, reason, comments, created_at, updated_at, deleted_at
 `
 
 type CreateMedicalHistoryParams struct {
@@ -36,7 +35,6 @@ type CreateMedicalHistoryParams struct {
 	EventID        pgtype.UUID        `json:"eventId"`
 	Date           pgtype.Timestamptz `json:"date"`
 	Reason         string             `json:"reason"`
-	Recipe         bool               `json:"recipe"`
 	Comments       string             `json:"comments"`
 }
 
@@ -48,7 +46,6 @@ func (q *Queries) CreateMedicalHistory(ctx context.Context, arg CreateMedicalHis
 		arg.EventID,
 		arg.Date,
 		arg.Reason,
-		arg.Recipe,
 		arg.Comments,
 	)
 	var i MedicalHistory
@@ -60,7 +57,6 @@ func (q *Queries) CreateMedicalHistory(ctx context.Context, arg CreateMedicalHis
 		&i.EventID,
 		&i.Date,
 		&i.Reason,
-		&i.Recipe,
 		&i.Comments,
 		&i.CreatedAt,
 		&i.UpdatedAt,
@@ -92,13 +88,32 @@ func (q *Queries) DeleteMedicalHistory(ctx context.Context, arg DeleteMedicalHis
 
 const getMedicalHistoriesByPatientIDWithSoftDeleted = `-- name: GetMedicalHistoriesByPatientIDWithSoftDeleted :many
 SELECT
-  mh.id, mh.business_id, mh.user_id, mh.professional_id, mh.event_id, mh.date, mh.reason, mh.recipe, mh.comments, mh.created_at, mh.updated_at, mh.deleted_at,
+  mh.id, mh.business_id, mh.user_id, mh.professional_id, mh.event_id, mh.date, mh.reason, mh.comments, mh.created_at, mh.updated_at, mh.deleted_at,
   u.ic,
   u.first_name,
   u.last_name,
   p.first_name,
   p.last_name,
-  pp.professional_prefix
+  pp.professional_prefix,
+  (
+    EXISTS (
+      SELECT
+        1
+      FROM
+        prescriptions pr
+      WHERE
+        pr.medical_history_id = mh.id
+        AND pr.deleted_at IS NULL
+    )
+    OR EXISTS (
+      SELECT
+        1
+      FROM
+        medical_history_legacy_recipes lr
+      WHERE
+        lr.medical_history_id = mh.id
+    )
+  )::BOOLEAN AS recipe
 FROM
   medical_histories mh
   LEFT JOIN users u ON u.id = mh.user_id
@@ -107,6 +122,21 @@ FROM
 WHERE
   mh.business_id = $1
   AND mh.user_id = $2
+  AND (
+    $3::uuid IS NULL
+    OR mh.professional_id = $3
+    OR EXISTS (
+      SELECT
+        1
+      FROM
+        events e
+      WHERE
+        e.business_id = mh.business_id
+        AND e.professional_id = $3
+        AND e.user_id = mh.user_id
+        AND e.deleted_at IS NULL
+    )
+  )
 ORDER BY
   mh.date DESC
 `
@@ -114,6 +144,7 @@ ORDER BY
 type GetMedicalHistoriesByPatientIDWithSoftDeletedParams struct {
 	BusinessID pgtype.UUID `json:"businessId"`
 	UserID     pgtype.UUID `json:"userId"`
+	OwnerID    pgtype.UUID `json:"ownerId"`
 }
 
 type GetMedicalHistoriesByPatientIDWithSoftDeletedRow struct {
@@ -124,7 +155,6 @@ type GetMedicalHistoriesByPatientIDWithSoftDeletedRow struct {
 	EventID            pgtype.UUID        `json:"eventId"`
 	Date               pgtype.Timestamptz `json:"date"`
 	Reason             string             `json:"reason"`
-	Recipe             bool               `json:"recipe"`
 	Comments           string             `json:"comments"`
 	CreatedAt          pgtype.Timestamptz `json:"createdAt"`
 	UpdatedAt          pgtype.Timestamptz `json:"updatedAt"`
@@ -135,10 +165,11 @@ type GetMedicalHistoriesByPatientIDWithSoftDeletedRow struct {
 	FirstName_2        pgtype.Text        `json:"firstName2"`
 	LastName_2         pgtype.Text        `json:"lastName2"`
 	ProfessionalPrefix pgtype.Text        `json:"professionalPrefix"`
+	Recipe             bool               `json:"recipe"`
 }
 
 func (q *Queries) GetMedicalHistoriesByPatientIDWithSoftDeleted(ctx context.Context, arg GetMedicalHistoriesByPatientIDWithSoftDeletedParams) ([]GetMedicalHistoriesByPatientIDWithSoftDeletedRow, error) {
-	rows, err := q.db.Query(ctx, getMedicalHistoriesByPatientIDWithSoftDeleted, arg.BusinessID, arg.UserID)
+	rows, err := q.db.Query(ctx, getMedicalHistoriesByPatientIDWithSoftDeleted, arg.BusinessID, arg.UserID, arg.OwnerID)
 	if err != nil {
 		return nil, err
 	}
@@ -154,7 +185,6 @@ func (q *Queries) GetMedicalHistoriesByPatientIDWithSoftDeleted(ctx context.Cont
 			&i.EventID,
 			&i.Date,
 			&i.Reason,
-			&i.Recipe,
 			&i.Comments,
 			&i.CreatedAt,
 			&i.UpdatedAt,
@@ -165,6 +195,7 @@ func (q *Queries) GetMedicalHistoriesByPatientIDWithSoftDeleted(ctx context.Cont
 			&i.FirstName_2,
 			&i.LastName_2,
 			&i.ProfessionalPrefix,
+			&i.Recipe,
 		); err != nil {
 			return nil, err
 		}
@@ -176,6 +207,206 @@ func (q *Queries) GetMedicalHistoriesByPatientIDWithSoftDeleted(ctx context.Cont
 	return items, nil
 }
 
+const getMedicalHistoryAccess = `-- name: GetMedicalHistoryAccess :one
+SELECT
+  mh.professional_id = $1::uuid AS authored,
+  EXISTS (
+    SELECT
+      1
+    FROM
+      events e
+    WHERE
+      e.business_id = mh.business_id
+      AND e.professional_id = $1
+      AND e.user_id = mh.user_id
+      AND e.deleted_at IS NULL
+  ) AS treats_patient
+FROM
+  medical_histories mh
+WHERE
+  mh.business_id = $2
+  AND mh.id = $3
+`
+
+type GetMedicalHistoryAccessParams struct {
+	OwnerID    pgtype.UUID `json:"ownerId"`
+	BusinessID pgtype.UUID `json:"businessId"`
+	ID         pgtype.UUID `json:"id"`
+}
+
+type GetMedicalHistoryAccessRow struct {
+	Authored      bool `json:"authored"`
+	TreatsPatient bool `json:"treatsPatient"`
+}
+
+func (q *Queries) GetMedicalHistoryAccess(ctx context.Context, arg GetMedicalHistoryAccessParams) (GetMedicalHistoryAccessRow, error) {
+	row := q.db.QueryRow(ctx, getMedicalHistoryAccess, arg.OwnerID, arg.BusinessID, arg.ID)
+	var i GetMedicalHistoryAccessRow
+	err := row.Scan(
+		&i.Authored,
+		&i.TreatsPatient,
+	)
+	return i, err
+}
+
+const getMedicalHistoryByID = `-- name: GetMedicalHistoryByID :one
+SELECT
+  id, business_id, user_id, professional_id, event_id, date, reason, comments, created_at, updated_at, deleted_at
+FROM
+  medical_histories
+WHERE
+  business_id = $1
+  AND id = $2
+  AND deleted_at IS NULL
+`
+
+type GetMedicalHistoryByIDParams struct {
+	BusinessID pgtype.UUID `json:"businessId"`
+	ID         pgtype.UUID `json:"id"`
+}
+
+func (q *Queries) GetMedicalHistoryByID(ctx context.Context, arg GetMedicalHistoryByIDParams) (MedicalHistory, error) {
+	row := q.db.QueryRow(ctx, getMedicalHistoryByID, arg.BusinessID, arg.ID)
+	var i MedicalHistory
+	err := row.Scan(
+		&i.ID,
+		&i.BusinessID,
+		&i.UserID,
+		&i.ProfessionalID,
+		&i.EventID,
+		&i.Date,
+		&i.Reason,
+		&i.Comments,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const getMedicalHistoryForUpdate = `-- name: GetMedicalHistoryForUpdate :one
+SELECT
+  id, business_id, user_id, professional_id, event_id, date, reason, comments, created_at, updated_at, deleted_at
+FROM
+  medical_histories
+WHERE
+  business_id = $1
+  AND id = $2
+FOR UPDATE
+`
+
+type GetMedicalHistoryForUpdateParams struct {
+	BusinessID pgtype.UUID `json:"businessId"`
+	ID         pgtype.UUID `json:"id"`
+}
+
+func (q *Queries) GetMedicalHistoryForUpdate(ctx context.Context, arg GetMedicalHistoryForUpdateParams) (MedicalHistory, error) {
+	row := q.db.QueryRow(ctx, getMedicalHistoryForUpdate, arg.BusinessID, arg.ID)
+	var i MedicalHistory
+	err := row.Scan(
+		&i.ID,
+		&i.BusinessID,
+		&i.UserID,
+		&i.ProfessionalID,
+		&i.EventID,
+		&i.Date,
+		&i.Reason,
+		&i.Comments,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const getMedicalHistoryPatientID = `-- name: GetMedicalHistoryPatientID :one
+SELECT
+  user_id
+FROM
+  medical_histories
+WHERE
+  business_id = $1
+  AND id = $2
+`
+
+type GetMedicalHistoryPatientIDParams struct {
+	BusinessID pgtype.UUID `json:"businessId"`
+	ID         pgtype.UUID `json:"id"`
+}
+
+func (q *Queries) GetMedicalHistoryPatientID(ctx context.Context, arg GetMedicalHistoryPatientIDParams) (pgtype.UUID, error) {
+	row := q.db.QueryRow(ctx, getMedicalHistoryPatientID, arg.BusinessID, arg.ID)
+	var user_id pgtype.UUID
+	err := row.Scan(&user_id)
+	return user_id, err
+}
+
+const isPatientInOwnerScope = `-- name: IsPatientInOwnerScope :one
+SELECT
+  (
+    EXISTS (
+      SELECT
+        1
+      FROM
+        medical_histories mh
+      WHERE
+        mh.business_id = $1
+        AND mh.user_id = $2
+        AND mh.professional_id = $3
+    )
+    OR EXISTS (
+      SELECT
+        1
+      FROM
+        events e
+      WHERE
+        e.business_id = $1
+        AND e.professional_id = $3
+        AND e.user_id = $2
+        AND e.deleted_at IS NULL
+    )
+  )::BOOLEAN AS in_scope
+`
+
+type IsPatientInOwnerScopeParams struct {
+	BusinessID pgtype.UUID `json:"businessId"`
+	PatientID  pgtype.UUID `json:"patientId"`
+	OwnerID    pgtype.UUID `json:"ownerId"`
+}
+
+func (q *Queries) IsPatientInOwnerScope(ctx context.Context, arg IsPatientInOwnerScopeParams) (bool, error) {
+	row := q.db.QueryRow(ctx, isPatientInOwnerScope, arg.BusinessID, arg.PatientID, arg.OwnerID)
+	var in_scope bool
+	err := row.Scan(&in_scope)
+	return in_scope, err
+}
+
+const medicalHistoryExists = `-- name: MedicalHistoryExists :one
+SELECT
+  EXISTS (
+    SELECT
+      1
+    FROM
+      medical_histories
+    WHERE
+      business_id = $1
+      AND id = $2
+      AND deleted_at IS NULL
+  ) AS medical_history_exists
+`
+
+type MedicalHistoryExistsParams struct {
+	BusinessID pgtype.UUID `json:"businessId"`
+	ID         pgtype.UUID `json:"id"`
+}
+
+func (q *Queries) MedicalHistoryExists(ctx context.Context, arg MedicalHistoryExistsParams) (bool, error) {
+	row := q.db.QueryRow(ctx, medicalHistoryExists, arg.BusinessID, arg.ID)
+	var medical_history_exists bool
+	err := row.Scan(&medical_history_exists)
+	return medical_history_exists, err
+}
+
 const restoreMedicalHistory = `-- name: RestoreMedicalHistory :execrows
 UPDATE medical_histories
 SET
@@ -185,7 +416,7 @@ WHERE
   AND id = $2
   AND deleted_at IS NOT NULL
 RETURNING
-  id, business_id, user_id, professional_id, event_id, date, reason, recipe, comments, created_at, updated_at, deleted_at
+  id, business_id, user_id, professional_id, event_id, date, reason, comments, created_at, updated_at, deleted_at
 `
 
 type RestoreMedicalHistoryParams struct {
@@ -210,7 +441,7 @@ WHERE
   AND id = $2
   AND deleted_at IS NULL
 RETURNING
-  id, business_id, user_id, professional_id, event_id, date, reason, recipe, comments, created_at, updated_at, deleted_at
+  id, business_id, user_id, professional_id, event_id, date, reason, comments, created_at, updated_at, deleted_at
 `
 
 type SoftDeleteMedicalHistoryParams struct {
@@ -235,12 +466,11 @@ SET
   event_id = COALESCE($4, event_id),
   date = COALESCE($5, date),
   reason = COALESCE($6, reason),
-  recipe = COALESCE($7, recipe),
-  comments = COALESCE($8, comments),
+  comments = COALESCE($7, comments),
   updated_at = now()
 WHERE
-  business_id = $9
-  AND id = $10
+  business_id = $8
+  AND id = $9
   AND deleted_at IS NULL
 `
 
@@ -251,7 +481,6 @@ type UpdateMedicalHistoryParams struct {
 	EventID          pgtype.UUID        `json:"eventId"`
 	Date             pgtype.Timestamptz `json:"date"`
 	Reason           pgtype.Text        `json:"reason"`
-	Recipe           pgtype.Bool        `json:"recipe"`
 	Comments         pgtype.Text        `json:"comments"`
 	BusinessIDFilter pgtype.UUID        `json:"businessIdFilter"`
 	ID               pgtype.UUID        `json:"id"`
@@ -265,7 +494,6 @@ func (q *Queries) UpdateMedicalHistory(ctx context.Context, arg UpdateMedicalHis
 		arg.EventID,
 		arg.Date,
 		arg.Reason,
-		arg.Recipe,
 		arg.Comments,
 		arg.BusinessIDFilter,
 		arg.ID,
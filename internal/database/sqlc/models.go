@@ -56,6 +56,37 @@ func (ns NullEventStatus) Value() (driver.Value, error) {
 	return string(ns.EventStatus), nil
 }
 
+type AccessAuditLog struct {
+	ID             pgtype.UUID        `json:"id"`
+	BusinessID     pgtype.UUID        `json:"businessId"`
+	UserID         pgtype.UUID        `json:"userId"`
+	RoleID         pgtype.UUID        `json:"roleId"`
+	Resource       string             `json:"resource"`
+	ResourceID     pgtype.UUID        `json:"resourceId"`
+	PatientID      pgtype.UUID        `json:"patientId"`
+	Method         string             `json:"method"`
+	Path           string             `json:"path"`
+	StatusCode     int32              `json:"statusCode"`
+	IpAddress      string             `json:"ipAddress"`
+	UserAgent      string             `json:"userAgent"`
+	CreatedAt      pgtype.Timestamptz `json:"createdAt"`
+	ImpersonatorID pgtype.UUID        `json:"impersonatorId"`
+}
+
+type ApiKey struct {
+	ID         pgtype.UUID        `json:"id"`
+	BusinessID pgtype.UUID        `json:"businessId"`
+	UserID     pgtype.UUID        `json:"userId"`
+	Name       string             `json:"name"`
+	Prefix     string             `json:"prefix"`
+	KeyHash    string             `json:"keyHash"`
+	Scopes     []string           `json:"scopes"`
+	ExpiresAt  pgtype.Timestamptz `json:"expiresAt"`
+	LastUsedAt pgtype.Timestamptz `json:"lastUsedAt"`
+	RevokedAt  pgtype.Timestamptz `json:"revokedAt"`
+	CreatedAt  pgtype.Timestamptz `json:"createdAt"`
+}
+
 type BlockedDay struct {
 	ID             pgtype.UUID        `json:"id"`
 	Date           pgtype.Timestamptz `json:"date"`
@@ -68,25 +99,35 @@ type BlockedDay struct {
 }
 
 type Business struct {
-	ID             pgtype.UUID        `json:"id"`
-	Slug           string             `json:"slug"`
-	TaxID          string             `json:"taxId"`
-	CompanyName    string             `json:"companyName"`
-	TradeName      string             `json:"tradeName"`
-	Description    string             `json:"description"`
-	Street         string             `json:"street"`
-	City           string             `json:"city"`
-	Province       string             `json:"province"`
-	Country        string             `json:"country"`
-	ZipCode        string             `json:"zipCode"`
-	Timezone       string             `json:"timezone"`
-	Email          string             `json:"email"`
-	PhoneNumber    string             `json:"phoneNumber"`
-	WhatsappNumber pgtype.Text        `json:"whatsappNumber"`
-	Website        pgtype.Text        `json:"website"`
-	CreatedAt      pgtype.Timestamptz `json:"createdAt"`
-	UpdatedAt      pgtype.Timestamptz `json:"updatedAt"`
-	DeletedAt      pgtype.Timestamptz `json:"deletedAt"`
+	ID                          pgtype.UUID        `json:"id"`
+	Slug                        string             `json:"slug"`
+	TaxID                       string             `json:"taxId"`
+	CompanyName                 string             `json:"companyName"`
+	TradeName                   string             `json:"tradeName"`
+	Description                 string             `json:"description"`
+	Street                      string             `json:"street"`
+	City                        string             `json:"city"`
+	Province                    string             `json:"province"`
+	Country                     string             `json:"country"`
+	ZipCode                     string             `json:"zipCode"`
+	Timezone                    string             `json:"timezone"`
+	Email                       string             `json:"email"`
+	PhoneNumber                 string             `json:"phoneNumber"`
+	WhatsappNumber              pgtype.Text        `json:"whatsappNumber"`
+	Website                     pgtype.Text        `json:"website"`
+	RequireVerifiedEmailLogin   bool               `json:"requireVerifiedEmailLogin"`
+	RequireVerifiedEmailBooking bool               `json:"requireVerifiedEmailBooking"`
+	RequireTwoFactor            bool               `json:"requireTwoFactor"`
+	CreatedAt                   pgtype.Timestamptz `json:"createdAt"`
+	UpdatedAt                   pgtype.Timestamptz `json:"updatedAt"`
+	DeletedAt                   pgtype.Timestamptz `json:"deletedAt"`
+}
+
+type BusinessDataKey struct {
+	BusinessID pgtype.UUID        `json:"businessId"`
+	Version    int32              `json:"version"`
+	WrappedKey []byte             `json:"wrappedKey"`
+	CreatedAt  pgtype.Timestamptz `json:"createdAt"`
 }
 
 type BusinessRolePermission struct {
@@ -98,6 +139,37 @@ type BusinessRolePermission struct {
 	UpdatedAt    pgtype.Timestamptz `json:"updatedAt"`
 }
 
+type BusinessTwoFactorRole struct {
+	BusinessID pgtype.UUID        `json:"businessId"`
+	RoleID     pgtype.UUID        `json:"roleId"`
+	CreatedAt  pgtype.Timestamptz `json:"createdAt"`
+}
+
+type ClinicalRecordExport struct {
+	ID             pgtype.UUID        `json:"id"`
+	BusinessID     pgtype.UUID        `json:"businessId"`
+	PatientID      pgtype.UUID        `json:"patientId"`
+	RequestedBy    pgtype.UUID        `json:"requestedBy"`
+	IncludeDeleted bool               `json:"includeDeleted"`
+	Status         string             `json:"status"`
+	StorageKey     pgtype.Text        `json:"storageKey"`
+	Error          pgtype.Text        `json:"error"`
+	CreatedAt      pgtype.Timestamptz `json:"createdAt"`
+	UpdatedAt      pgtype.Timestamptz `json:"updatedAt"`
+	CompletedAt    pgtype.Timestamptz `json:"completedAt"`
+	OwnerID        pgtype.UUID        `json:"ownerId"`
+}
+
+type EmailVerificationToken struct {
+	UserID          pgtype.UUID        `json:"userId"`
+	BusinessID      pgtype.UUID        `json:"businessId"`
+	TokenHash       string             `json:"tokenHash"`
+	ExpiresAt       pgtype.Timestamptz `json:"expiresAt"`
+	SendCount       int32              `json:"sendCount"`
+	WindowStartedAt pgtype.Timestamptz `json:"windowStartedAt"`
+	LastSentAt      pgtype.Timestamptz `json:"lastSentAt"`
+}
+
 type Event struct {
 	ID             pgtype.UUID        `json:"id"`
 	Title          string             `json:"title"`
@@ -113,6 +185,32 @@ type Event struct {
 	DeletedAt      pgtype.Timestamptz `json:"deletedAt"`
 }
 
+type Invitation struct {
+	ID             pgtype.UUID        `json:"id"`
+	BusinessID     pgtype.UUID        `json:"businessId"`
+	Email          string             `json:"email"`
+	RoleID         pgtype.UUID        `json:"roleId"`
+	InvitedBy      pgtype.UUID        `json:"invitedBy"`
+	TokenHash      string             `json:"tokenHash"`
+	Status         string             `json:"status"`
+	ExpiresAt      pgtype.Timestamptz `json:"expiresAt"`
+	AcceptedUserID pgtype.UUID        `json:"acceptedUserId"`
+	AcceptedAt     pgtype.Timestamptz `json:"acceptedAt"`
+	RevokedAt      pgtype.Timestamptz `json:"revokedAt"`
+	CreatedAt      pgtype.Timestamptz `json:"createdAt"`
+	UpdatedAt      pgtype.Timestamptz `json:"updatedAt"`
+}
+
+type LoginAttempt struct {
+	ID         pgtype.UUID        `json:"id"`
+	BusinessID pgtype.UUID        `json:"businessId"`
+	Email      string             `json:"email"`
+	IpAddress  string             `json:"ipAddress"`
+	UserAgent  string             `json:"userAgent"`
+	Reason     string             `json:"reason"`
+	CreatedAt  pgtype.Timestamptz `json:"createdAt"`
+}
+
 type MedicalHistory struct {
 	ID             pgtype.UUID        `json:"id"`
 	BusinessID     pgtype.UUID        `json:"businessId"`
@@ -121,13 +219,144 @@ type MedicalHistory struct {
 	EventID        pgtype.UUID        `json:"eventId"`
 	Date           pgtype.Timestamptz `json:"date"`
 	Reason         string             `json:"reason"`
-	Recipe         bool               `json:"recipe"`
 	Comments       string             `json:"comments"`
 	CreatedAt      pgtype.Timestamptz `json:"createdAt"`
 	UpdatedAt      pgtype.Timestamptz `json:"updatedAt"`
 	DeletedAt      pgtype.Timestamptz `json:"deletedAt"`
 }
 
+type MedicalHistoryAddenda struct {
+	ID               pgtype.UUID        `json:"id"`
+	BusinessID       pgtype.UUID        `json:"businessId"`
+	MedicalHistoryID pgtype.UUID        `json:"medicalHistoryId"`
+	AuthorID         pgtype.UUID        `json:"authorId"`
+	Content          string             `json:"content"`
+	CreatedAt        pgtype.Timestamptz `json:"createdAt"`
+}
+
+type MedicalHistoryAttachment struct {
+	ID               pgtype.UUID        `json:"id"`
+	BusinessID       pgtype.UUID        `json:"businessId"`
+	MedicalHistoryID pgtype.UUID        `json:"medicalHistoryId"`
+	UploadedBy       pgtype.UUID        `json:"uploadedBy"`
+	FileName         string             `json:"fileName"`
+	MimeType         string             `json:"mimeType"`
+	SizeBytes        int64              `json:"sizeBytes"`
+	StorageKey       string             `json:"storageKey"`
+	CreatedAt        pgtype.Timestamptz `json:"createdAt"`
+}
+
+type MedicalHistoryLegacyRecipe struct {
+	MedicalHistoryID pgtype.UUID `json:"medicalHistoryId"`
+}
+
+type MedicalHistoryRevision struct {
+	ID               pgtype.UUID        `json:"id"`
+	BusinessID       pgtype.UUID        `json:"businessId"`
+	MedicalHistoryID pgtype.UUID        `json:"medicalHistoryId"`
+	Revision         int32              `json:"revision"`
+	Action           string             `json:"action"`
+	ChangedBy        pgtype.UUID        `json:"changedBy"`
+	Changes          []byte             `json:"changes"`
+	Snapshot         []byte             `json:"snapshot"`
+	Reason           pgtype.Text        `json:"reason"`
+	CreatedAt        pgtype.Timestamptz `json:"createdAt"`
+}
+
+type MedicalHistoryTemplate struct {
+	ID         pgtype.UUID        `json:"id"`
+	BusinessID pgtype.UUID        `json:"businessId"`
+	Title      string             `json:"title"`
+	Specialty  string             `json:"specialty"`
+	Reason     string             `json:"reason"`
+	Comments   string             `json:"comments"`
+	Fields     []byte             `json:"fields"`
+	CreatedBy  pgtype.UUID        `json:"createdBy"`
+	CreatedAt  pgtype.Timestamptz `json:"createdAt"`
+	UpdatedAt  pgtype.Timestamptz `json:"updatedAt"`
+	DeletedAt  pgtype.Timestamptz `json:"deletedAt"`
+}
+
+type PasswordResetToken struct {
+	UserID     pgtype.UUID        `json:"userId"`
+	BusinessID pgtype.UUID        `json:"businessId"`
+	TokenHash  string             `json:"tokenHash"`
+	ExpiresAt  pgtype.Timestamptz `json:"expiresAt"`
+	CreatedAt  pgtype.Timestamptz `json:"createdAt"`
+}
+
+type PatientAllergy struct {
+	ID         pgtype.UUID        `json:"id"`
+	BusinessID pgtype.UUID        `json:"businessId"`
+	PatientID  pgtype.UUID        `json:"patientId"`
+	Substance  string             `json:"substance"`
+	Reaction   string             `json:"reaction"`
+	Severity   string             `json:"severity"`
+	CreatedBy  pgtype.UUID        `json:"createdBy"`
+	CreatedAt  pgtype.Timestamptz `json:"createdAt"`
+	UpdatedAt  pgtype.Timestamptz `json:"updatedAt"`
+	DeletedAt  pgtype.Timestamptz `json:"deletedAt"`
+}
+
+type PatientCondition struct {
+	ID           pgtype.UUID        `json:"id"`
+	BusinessID   pgtype.UUID        `json:"businessId"`
+	PatientID    pgtype.UUID        `json:"patientId"`
+	Name         string             `json:"name"`
+	Code         string             `json:"code"`
+	Status       string             `json:"status"`
+	OnsetDate    pgtype.Date        `json:"onsetDate"`
+	ResolvedDate pgtype.Date        `json:"resolvedDate"`
+	CreatedBy    pgtype.UUID        `json:"createdBy"`
+	CreatedAt    pgtype.Timestamptz `json:"createdAt"`
+	UpdatedAt    pgtype.Timestamptz `json:"updatedAt"`
+	DeletedAt    pgtype.Timestamptz `json:"deletedAt"`
+}
+
+type PatientImport struct {
+	ID            pgtype.UUID        `json:"id"`
+	BusinessID    pgtype.UUID        `json:"businessId"`
+	RequestedBy   pgtype.UUID        `json:"requestedBy"`
+	FileName      string             `json:"fileName"`
+	StorageKey    string             `json:"storageKey"`
+	Status        string             `json:"status"`
+	TotalRows     int32              `json:"totalRows"`
+	ProcessedRows int32              `json:"processedRows"`
+	ImportedRows  int32              `json:"importedRows"`
+	FailedRows    int32              `json:"failedRows"`
+	Errors        []byte             `json:"errors"`
+	Error         pgtype.Text        `json:"error"`
+	CreatedAt     pgtype.Timestamptz `json:"createdAt"`
+	UpdatedAt     pgtype.Timestamptz `json:"updatedAt"`
+	CompletedAt   pgtype.Timestamptz `json:"completedAt"`
+}
+
+type PatientMedication struct {
+	ID         pgtype.UUID        `json:"id"`
+	BusinessID pgtype.UUID        `json:"businessId"`
+	PatientID  pgtype.UUID        `json:"patientId"`
+	Name       string             `json:"name"`
+	Dose       string             `json:"dose"`
+	Frequency  string             `json:"frequency"`
+	Status     string             `json:"status"`
+	StartDate  pgtype.Date        `json:"startDate"`
+	EndDate    pgtype.Date        `json:"endDate"`
+	CreatedBy  pgtype.UUID        `json:"createdBy"`
+	CreatedAt  pgtype.Timestamptz `json:"createdAt"`
+	UpdatedAt  pgtype.Timestamptz `json:"updatedAt"`
+	DeletedAt  pgtype.Timestamptz `json:"deletedAt"`
+}
+
+type PatientMerge struct {
+	ID          pgtype.UUID        `json:"id"`
+	BusinessID  pgtype.UUID        `json:"businessId"`
+	SurvivorID  pgtype.UUID        `json:"survivorId"`
+	DuplicateID pgtype.UUID        `json:"duplicateId"`
+	MergedBy    pgtype.UUID        `json:"mergedBy"`
+	Summary     []byte             `json:"summary"`
+	CreatedAt   pgtype.Timestamptz `json:"createdAt"`
+}
+
 type PatientProfile struct {
 	ID                    pgtype.UUID        `json:"id"`
 	BusinessID            pgtype.UUID        `json:"businessId"`
@@ -155,6 +384,37 @@ type Permission struct {
 	DeletedAt   pgtype.Timestamptz `json:"deletedAt"`
 }
 
+type Prescription struct {
+	ID               pgtype.UUID        `json:"id"`
+	BusinessID       pgtype.UUID        `json:"businessId"`
+	Number           int32              `json:"number"`
+	MedicalHistoryID pgtype.UUID        `json:"medicalHistoryId"`
+	UserID           pgtype.UUID        `json:"userId"`
+	ProfessionalID   pgtype.UUID        `json:"professionalId"`
+	Date             pgtype.Timestamptz `json:"date"`
+	Notes            pgtype.Text        `json:"notes"`
+	CreatedAt        pgtype.Timestamptz `json:"createdAt"`
+	UpdatedAt        pgtype.Timestamptz `json:"updatedAt"`
+	DeletedAt        pgtype.Timestamptz `json:"deletedAt"`
+}
+
+type PrescriptionItem struct {
+	ID             pgtype.UUID        `json:"id"`
+	PrescriptionID pgtype.UUID        `json:"prescriptionId"`
+	Position       int32              `json:"position"`
+	Medication     string             `json:"medication"`
+	Presentation   string             `json:"presentation"`
+	Dose           string             `json:"dose"`
+	Frequency      string             `json:"frequency"`
+	DurationDays   pgtype.Int4        `json:"durationDays"`
+	CreatedAt      pgtype.Timestamptz `json:"createdAt"`
+}
+
+type PrescriptionSequence struct {
+	BusinessID pgtype.UUID `json:"businessId"`
+	LastNumber int32       `json:"lastNumber"`
+}
+
 type ProfessionalProfile struct {
 	ID                  pgtype.UUID        `json:"id"`
 	BusinessID          pgtype.UUID        `json:"businessId"`
@@ -190,6 +450,18 @@ type RolePermission struct {
 	UpdatedAt    pgtype.Timestamptz `json:"updatedAt"`
 }
 
+type SecurityEvent struct {
+	ID             pgtype.UUID        `json:"id"`
+	BusinessID     pgtype.UUID        `json:"businessId"`
+	UserID         pgtype.UUID        `json:"userId"`
+	SessionID      pgtype.UUID        `json:"sessionId"`
+	EventType      string             `json:"eventType"`
+	IpAddress      string             `json:"ipAddress"`
+	UserAgent      string             `json:"userAgent"`
+	CreatedAt      pgtype.Timestamptz `json:"createdAt"`
+	ImpersonatorID pgtype.UUID        `json:"impersonatorId"`
+}
+
 type Setting struct {
 	ID        pgtype.UUID        `json:"id"`
 	Module    string             `json:"module"`
@@ -201,19 +473,88 @@ type Setting struct {
 	UpdatedAt pgtype.Timestamptz `json:"updatedAt"`
 }
 
-type User struct {
+type TwoFactorChallenge struct {
 	ID           pgtype.UUID        `json:"id"`
-	Ic           string             `json:"ic"`
-	UserName     string             `json:"userName"`
-	FirstName    string             `json:"firstName"`
-	LastName     string             `json:"lastName"`
-	Email        string             `json:"email"`
-	Password     string             `json:"password"`
-	PhoneNumber  string             `json:"phoneNumber"`
-	RoleID       pgtype.UUID        `json:"roleId"`
+	UserID       pgtype.UUID        `json:"userId"`
+	BusinessID   pgtype.UUID        `json:"businessId"`
+	TokenHash    string             `json:"tokenHash"`
+	IsSuperAdmin bool               `json:"isSuperAdmin"`
+	Attempts     int32              `json:"attempts"`
+	ExpiresAt    pgtype.Timestamptz `json:"expiresAt"`
+	CreatedAt    pgtype.Timestamptz `json:"createdAt"`
+}
+
+type User struct {
+	ID              pgtype.UUID        `json:"id"`
+	Ic              string             `json:"ic"`
+	UserName        string             `json:"userName"`
+	FirstName       string             `json:"firstName"`
+	LastName        string             `json:"lastName"`
+	Email           string             `json:"email"`
+	Password        string             `json:"password"`
+	PhoneNumber     string             `json:"phoneNumber"`
+	RoleID          pgtype.UUID        `json:"roleId"`
+	BusinessID      pgtype.UUID        `json:"businessId"`
+	EmailVerifiedAt pgtype.Timestamptz `json:"emailVerifiedAt"`
+	CreatedAt       pgtype.Timestamptz `json:"createdAt"`
+	UpdatedAt       pgtype.Timestamptz `json:"updatedAt"`
+	DeletedAt       pgtype.Timestamptz `json:"deletedAt"`
+}
+
+type UserRecoveryCode struct {
+	ID        pgtype.UUID        `json:"id"`
+	UserID    pgtype.UUID        `json:"userId"`
+	CodeHash  string             `json:"codeHash"`
+	UsedAt    pgtype.Timestamptz `json:"usedAt"`
+	CreatedAt pgtype.Timestamptz `json:"createdAt"`
+}
+
+type UserSession struct {
+	ID         pgtype.UUID        `json:"id"`
+	UserID     pgtype.UUID        `json:"userId"`
+	BusinessID pgtype.UUID        `json:"businessId"`
+	TokenHash  string             `json:"tokenHash"`
+	UserAgent  string             `json:"userAgent"`
+	IpAddress  string             `json:"ipAddress"`
+	CreatedAt  pgtype.Timestamptz `json:"createdAt"`
+	LastUsedAt pgtype.Timestamptz `json:"lastUsedAt"`
+	ExpiresAt  pgtype.Timestamptz `json:"expiresAt"`
+}
+
+type UserSessionRotatedToken struct {
+	TokenHash string             `json:"tokenHash"`
+	SessionID pgtype.UUID        `json:"sessionId"`
+	RotatedAt pgtype.Timestamptz `json:"rotatedAt"`
+}
+
+type UserTwoFactor struct {
+	UserID       pgtype.UUID        `json:"userId"`
 	BusinessID   pgtype.UUID        `json:"businessId"`
-	RefreshToken pgtype.Text        `json:"refreshToken"`
+	Secret       string             `json:"secret"`
+	EnabledAt    pgtype.Timestamptz `json:"enabledAt"`
+	LastUsedStep int64              `json:"lastUsedStep"`
 	CreatedAt    pgtype.Timestamptz `json:"createdAt"`
 	UpdatedAt    pgtype.Timestamptz `json:"updatedAt"`
-	DeletedAt    pgtype.Timestamptz `json:"deletedAt"`
+}
+
+type VitalSign struct {
+	ID               pgtype.UUID        `json:"id"`
+	BusinessID       pgtype.UUID        `json:"businessId"`
+	PatientID        pgtype.UUID        `json:"patientId"`
+	MedicalHistoryID pgtype.UUID        `json:"medicalHistoryId"`
+	EventID          pgtype.UUID        `json:"eventId"`
+	MeasuredAt       pgtype.Timestamptz `json:"measuredAt"`
+	WeightKg         pgtype.Numeric     `json:"weightKg"`
+	HeightCm         pgtype.Numeric     `json:"heightCm"`
+	Bmi              pgtype.Numeric     `json:"bmi"`
+	SystolicBp       pgtype.Int2        `json:"systolicBp"`
+	DiastolicBp      pgtype.Int2        `json:"diastolicBp"`
+	HeartRate        pgtype.Int2        `json:"heartRate"`
+	TemperatureC     pgtype.Numeric     `json:"temperatureC"`
+	Spo2             pgtype.Int2        `json:"spo2"`
+	Notes            string             `json:"notes"`
+	RecordedBy       pgtype.UUID        `json:"recordedBy"`
+	CreatedAt        pgtype.Timestamptz `json:"createdAt"`
+	UpdatedAt        pgtype.Timestamptz `json:"updatedAt"`
+	DeletedAt        pgtype.Timestamptz `json:"deletedAt"`
 }
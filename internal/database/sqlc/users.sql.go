@@ -86,39 +86,6 @@ func (q *Queries) CheckUsernameAvailability(ctx context.Context, arg CheckUserna
 	return username_available, err
 }
 
-const clearRefreshToken = `-- name: ClearRefreshToken :one
-UPDATE users
-SET
-  refresh_token = NULL,
-  updated_at = now()
-WHERE
-  id = $1
-RETURNING
-  id, ic, user_name, first_name, last_name, email, password, phone_number, role_id, business_id, refresh_token, created_at, updated_at, deleted_at
-`
-
-func (q *Queries) ClearRefreshToken(ctx context.Context, id pgtype.UUID) (User, error) {
-	row := q.db.QueryRow(ctx, clearRefreshToken, id)
-	var i User
-	err := row.Scan(
-		&i.ID,
-		&i.Ic,
-		&i.UserName,
-		&i.FirstName,
-		&i.LastName,
-		&i.Email,
-		&i.Password,
-		&i.PhoneNumber,
-		&i.RoleID,
-		&i.BusinessID,
-		&i.RefreshToken,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-		&i.DeletedAt,
-	)
-	return i, err
-}
-
 const createUser = `-- name: CreateUser :one
 INSERT INTO
   users (
@@ -135,7 +102,7 @@ INSERT INTO
 VALUES
   ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 RETURNING
-  id, ic, user_name, first_name, last_name, email, password, phone_number, role_id, business_id, refresh_token, created_at, updated_at, deleted_at
+  id, ic, user_name, first_name, last_name, email, password, phone_number, role_id, business_id, email_verified_at, created_at, updated_at, deleted_at
 `
 
 type CreateUserParams struct {
@@ -174,7 +141,7 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.PhoneNumber,
 		&i.RoleID,
 		&i.BusinessID,
-		&i.RefreshToken,
+		&i.EmailVerifiedAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
@@ -214,7 +181,7 @@ SELECT
   "user"."phone_number",
   "user"."role_id",
   "user"."business_id",
-  "user"."refresh_token",
+  "user"."email_verified_at",
   "user"."created_at",
   "user"."updated_at",
   "user"."deleted_at",
@@ -235,22 +202,22 @@ type GetMeParams struct {
 }
 
 type GetMeRow struct {
-	ID           pgtype.UUID        `json:"id"`
-	Ic           string             `json:"ic"`
-	UserName     string             `json:"userName"`
-	FirstName    string             `json:"firstName"`
-	LastName     string             `json:"lastName"`
-	Email        string             `json:"email"`
-	PhoneNumber  string             `json:"phoneNumber"`
-	RoleID       pgtype.UUID        `json:"roleId"`
-	BusinessID   pgtype.UUID        `json:"businessId"`
-	RefreshToken pgtype.Text        `json:"refreshToken"`
-	CreatedAt    pgtype.Timestamptz `json:"createdAt"`
-	UpdatedAt    pgtype.Timestamptz `json:"updatedAt"`
-	DeletedAt    pgtype.Timestamptz `json:"deletedAt"`
-	RoleID_2     pgtype.UUID        `json:"roleId2"`
-	RoleName     pgtype.Text        `json:"roleName"`
-	RoleValue    pgtype.Text        `json:"roleValue"`
+	ID              pgtype.UUID        `json:"id"`
+	Ic              string             `json:"ic"`
+	UserName        string             `json:"userName"`
+	FirstName       string             `json:"firstName"`
+	LastName        string             `json:"lastName"`
+	Email           string             `json:"email"`
+	PhoneNumber     string             `json:"phoneNumber"`
+	RoleID          pgtype.UUID        `json:"roleId"`
+	BusinessID      pgtype.UUID        `json:"businessId"`
+	EmailVerifiedAt pgtype.Timestamptz `json:"emailVerifiedAt"`
+	CreatedAt       pgtype.Timestamptz `json:"createdAt"`
+	UpdatedAt       pgtype.Timestamptz `json:"updatedAt"`
+	DeletedAt       pgtype.Timestamptz `json:"deletedAt"`
+	RoleID_2        pgtype.UUID        `json:"roleId2"`
+	RoleName        pgtype.Text        `json:"roleName"`
+	RoleValue       pgtype.Text        `json:"roleValue"`
 }
 
 func (q *Queries) GetMe(ctx context.Context, arg GetMeParams) (GetMeRow, error) {
@@ -266,7 +233,7 @@ func (q *Queries) GetMe(ctx context.Context, arg GetMeParams) (GetMeRow, error)
 		&i.PhoneNumber,
 		&i.RoleID,
 		&i.BusinessID,
-		&i.RefreshToken,
+		&i.EmailVerifiedAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
@@ -288,7 +255,7 @@ SELECT
   "user"."phone_number",
   "user"."role_id",
   "user"."business_id",
-  "user"."refresh_token",
+  "user"."email_verified_at",
   "user"."created_at",
   "user"."updated_at",
   "user"."deleted_at",
@@ -304,22 +271,22 @@ WHERE
 `
 
 type GetMeGlobalRow struct {
-	ID           pgtype.UUID        `json:"id"`
-	Ic           string             `json:"ic"`
-	UserName     string             `json:"userName"`
-	FirstName    string             `json:"firstName"`
-	LastName     string             `json:"lastName"`
-	Email        string             `json:"email"`
-	PhoneNumber  string             `json:"phoneNumber"`
-	RoleID       pgtype.UUID        `json:"roleId"`
-	BusinessID   pgtype.UUID        `json:"businessId"`
-	RefreshToken pgtype.Text        `json:"refreshToken"`
-	CreatedAt    pgtype.Timestamptz `json:"createdAt"`
-	UpdatedAt    pgtype.Timestamptz `json:"updatedAt"`
-	DeletedAt    pgtype.Timestamptz `json:"deletedAt"`
-	RoleID_2     pgtype.UUID        `json:"roleId2"`
-	RoleName     pgtype.Text        `json:"roleName"`
-	RoleValue    pgtype.Text        `json:"roleValue"`
+	ID              pgtype.UUID        `json:"id"`
+	Ic              string             `json:"ic"`
+	UserName        string             `json:"userName"`
+	FirstName       string             `json:"firstName"`
+	LastName        string             `json:"lastName"`
+	Email           string             `json:"email"`
+	PhoneNumber     string             `json:"phoneNumber"`
+	RoleID          pgtype.UUID        `json:"roleId"`
+	BusinessID      pgtype.UUID        `json:"businessId"`
+	EmailVerifiedAt pgtype.Timestamptz `json:"emailVerifiedAt"`
+	CreatedAt       pgtype.Timestamptz `json:"createdAt"`
+	UpdatedAt       pgtype.Timestamptz `json:"updatedAt"`
+	DeletedAt       pgtype.Timestamptz `json:"deletedAt"`
+	RoleID_2        pgtype.UUID        `json:"roleId2"`
+	RoleName        pgtype.Text        `json:"roleName"`
+	RoleValue       pgtype.Text        `json:"roleValue"`
 }
 
 func (q *Queries) GetMeGlobal(ctx context.Context, id pgtype.UUID) (GetMeGlobalRow, error) {
@@ -335,7 +302,7 @@ func (q *Queries) GetMeGlobal(ctx context.Context, id pgtype.UUID) (GetMeGlobalR
 		&i.PhoneNumber,
 		&i.RoleID,
 		&i.BusinessID,
-		&i.RefreshToken,
+		&i.EmailVerifiedAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
@@ -348,7 +315,7 @@ func (q *Queries) GetMeGlobal(ctx context.Context, id pgtype.UUID) (GetMeGlobalR
 
 const getSuperAdminByEmail = `-- name: GetSuperAdminByEmail :one
 SELECT
-  u.id, u.ic, u.user_name, u.first_name, u.last_name, u.email, u.password, u.phone_number, u.role_id, u.business_id, u.refresh_token, u.created_at, u.updated_at, u.deleted_at
+  u.id, u.ic, u.user_name, u.first_name, u.last_name, u.email, u.password, u.phone_number, u.role_id, u.business_id, u.email_verified_at, u.created_at, u.updated_at, u.deleted_at
 FROM
   users u
 WHERE
@@ -379,7 +346,7 @@ func (q *Queries) GetSuperAdminByEmail(ctx context.Context, email string) (User,
 		&i.PhoneNumber,
 		&i.RoleID,
 		&i.BusinessID,
-		&i.RefreshToken,
+		&i.EmailVerifiedAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
@@ -389,7 +356,7 @@ func (q *Queries) GetSuperAdminByEmail(ctx context.Context, email string) (User,
 
 const getUserByEmail = `-- name: GetUserByEmail :one
 SELECT
-  id, ic, user_name, first_name, last_name, email, password, phone_number, role_id, business_id, refresh_token, created_at, updated_at, deleted_at
+  id, ic, user_name, first_name, last_name, email, password, phone_number, role_id, business_id, email_verified_at, created_at, updated_at, deleted_at
 FROM
   users
 WHERE
@@ -418,7 +385,7 @@ func (q *Queries) GetUserByEmail(ctx context.Context, arg GetUserByEmailParams)
 		&i.PhoneNumber,
 		&i.RoleID,
 		&i.BusinessID,
-		&i.RefreshToken,
+		&i.EmailVerifiedAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
@@ -437,7 +404,6 @@ SELECT
   "user"."password",
   "user"."phone_number",
   "user"."business_id",
-  "user"."refresh_token",
   "user"."created_at",
   "user"."updated_at",
   "user"."deleted_at",
@@ -469,7 +435,6 @@ type GetUserByIDRow struct {
 	Password        string             `json:"password"`
 	PhoneNumber     string             `json:"phoneNumber"`
 	BusinessID      pgtype.UUID        `json:"businessId"`
-	RefreshToken    pgtype.Text        `json:"refreshToken"`
 	CreatedAt       pgtype.Timestamptz `json:"createdAt"`
 	UpdatedAt       pgtype.Timestamptz `json:"updatedAt"`
 	DeletedAt       pgtype.Timestamptz `json:"deletedAt"`
@@ -492,7 +457,6 @@ func (q *Queries) GetUserByID(ctx context.Context, arg GetUserByIDParams) (GetUs
 		&i.Password,
 		&i.PhoneNumber,
 		&i.BusinessID,
-		&i.RefreshToken,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
@@ -515,7 +479,6 @@ SELECT
   "user"."password",
   "user"."phone_number",
   "user"."business_id",
-  "user"."refresh_token",
   "user"."created_at",
   "user"."updated_at",
   "user"."deleted_at",
@@ -541,7 +504,6 @@ type GetUserByIDGlobalRow struct {
 	Password        string             `json:"password"`
 	PhoneNumber     string             `json:"phoneNumber"`
 	BusinessID      pgtype.UUID        `json:"businessId"`
-	RefreshToken    pgtype.Text        `json:"refreshToken"`
 	CreatedAt       pgtype.Timestamptz `json:"createdAt"`
 	UpdatedAt       pgtype.Timestamptz `json:"updatedAt"`
 	DeletedAt       pgtype.Timestamptz `json:"deletedAt"`
@@ -564,7 +526,6 @@ func (q *Queries) GetUserByIDGlobal(ctx context.Context, id pgtype.UUID) (GetUse
 		&i.Password,
 		&i.PhoneNumber,
 		&i.BusinessID,
-		&i.RefreshToken,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
@@ -649,7 +610,7 @@ func (q *Queries) GetUserByIDWithSoftDeleted(ctx context.Context, arg GetUserByI
 
 const getUsers = `-- name: GetUsers :many
 SELECT
-  id, ic, user_name, first_name, last_name, email, password, phone_number, role_id, business_id, refresh_token, created_at, updated_at, deleted_at
+  id, ic, user_name, first_name, last_name, email, password, phone_number, role_id, business_id, email_verified_at, created_at, updated_at, deleted_at
 FROM
   users
 WHERE
@@ -678,7 +639,7 @@ func (q *Queries) GetUsers(ctx context.Context) ([]User, error) {
 			&i.PhoneNumber,
 			&i.RoleID,
 			&i.BusinessID,
-			&i.RefreshToken,
+			&i.EmailVerifiedAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
@@ -954,7 +915,7 @@ func (q *Queries) GetUsersByRoleWithSoftDeleted(ctx context.Context, arg GetUser
 
 const getUsersWithSoftDeleted = `-- name: GetUsersWithSoftDeleted :many
 SELECT
-  id, ic, user_name, first_name, last_name, email, password, phone_number, role_id, business_id, refresh_token, created_at, updated_at, deleted_at
+  id, ic, user_name, first_name, last_name, email, password, phone_number, role_id, business_id, email_verified_at, created_at, updated_at, deleted_at
 FROM
   users
 ORDER BY
@@ -981,7 +942,7 @@ func (q *Queries) GetUsersWithSoftDeleted(ctx context.Context) ([]User, error) {
 			&i.PhoneNumber,
 			&i.RoleID,
 			&i.BusinessID,
-			&i.RefreshToken,
+			&i.EmailVerifiedAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
@@ -1001,14 +962,20 @@ UPDATE users
 SET
   deleted_at = NULL
 WHERE
-  id = $1
+  business_id = $1
+  AND id = $2
   AND deleted_at IS NOT NULL
 RETURNING
-  id, ic, user_name, first_name, last_name, email, password, phone_number, role_id, business_id, refresh_token, created_at, updated_at, deleted_at
+  id, ic, user_name, first_name, last_name, email, password, phone_number, role_id, business_id, email_verified_at, created_at, updated_at, deleted_at
 `
 
-func (q *Queries) RestoreUser(ctx context.Context, id pgtype.UUID) (int64, error) {
-	result, err := q.db.Exec(ctx, restoreUser, id)
+type RestoreUserParams struct {
+	BusinessID pgtype.UUID `json:"businessId"`
+	ID         pgtype.UUID `json:"id"`
+}
+
+func (q *Queries) RestoreUser(ctx context.Context, arg RestoreUserParams) (int64, error) {
+	result, err := q.db.Exec(ctx, restoreUser, arg.BusinessID, arg.ID)
 	if err != nil {
 		return 0, err
 	}
@@ -1020,58 +987,26 @@ UPDATE users
 SET
   deleted_at = now()
 WHERE
-  id = $1
+  business_id = $1
+  AND id = $2
   AND deleted_at IS NULL
 RETURNING
-  id, ic, user_name, first_name, last_name, email, password, phone_number, role_id, business_id, refresh_token, created_at, updated_at, deleted_at
+  id, ic, user_name, first_name, last_name, email, password, phone_number, role_id, business_id, email_verified_at, created_at, updated_at, deleted_at
 `
 
-func (q *Queries) SoftDeleteUser(ctx context.Context, id pgtype.UUID) (int64, error) {
-	result, err := q.db.Exec(ctx, softDeleteUser, id)
+type SoftDeleteUserParams struct {
+	BusinessID pgtype.UUID `json:"businessId"`
+	ID         pgtype.UUID `json:"id"`
+}
+
+func (q *Queries) SoftDeleteUser(ctx context.Context, arg SoftDeleteUserParams) (int64, error) {
+	result, err := q.db.Exec(ctx, softDeleteUser, arg.BusinessID, arg.ID)
 	if err != nil {
 		return 0, err
 	}
 	return result.RowsAffected(), nil
 }
 
-const updateRefreshToken = `-- name: UpdateRefreshToken :one
-UPDATE users
-SET
-  refresh_token = $2,
-  updated_at = now()
-WHERE
-  id = $1
-RETURNING
-  id, ic, user_name, first_name, last_name, email, password, phone_number, role_id, business_id, refresh_token, created_at, updated_at, deleted_at
-`
-
-type UpdateRefreshTokenParams struct {
-	ID           pgtype.UUID `json:"id"`
-	RefreshToken pgtype.Text `json:"refreshToken"`
-}
-
-func (q *Queries) UpdateRefreshToken(ctx context.Context, arg UpdateRefreshTokenParams) (User, error) {
-	row := q.db.QueryRow(ctx, updateRefreshToken, arg.ID, arg.RefreshToken)
-	var i User
-	err := row.Scan(
-		&i.ID,
-		&i.Ic,
-		&i.UserName,
-		&i.FirstName,
-		&i.LastName,
-		&i.Email,
-		&i.Password,
-		&i.PhoneNumber,
-		&i.RoleID,
-		&i.BusinessID,
-		&i.RefreshToken,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-		&i.DeletedAt,
-	)
-	return i, err
-}
-
 const updateUser = `-- name: UpdateUser :execrows
 UPDATE users
 SET
@@ -1082,6 +1017,9 @@ SET
   email = COALESCE($7, email),
   password = COALESCE($8, password),
   phone_number = COALESCE($9, phone_number),
+  email_verified_at = CASE
+    WHEN COALESCE($7, email) = email THEN email_verified_at
+  END,
   updated_at = now()
 WHERE
   business_id = $1
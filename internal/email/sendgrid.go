@@ -81,3 +81,94 @@ func (s *SendGridService) SendEventCreated(to, companyName, fullName, title, sta
 
 	return nil
 }
+
+func (s *SendGridService) SendPasswordReset(to, companyName, fullName, resetLink, expiresIn string) error {
+	html, err := renderTemplate("password-reset", map[string]string{
+		"companyName": companyName,
+		"fullName":    fullName,
+		"resetLink":   resetLink,
+		"expiresIn":   expiresIn,
+	})
+	if err != nil {
+		return fmt.Errorf("render template: %w", err)
+	}
+
+	from := mail.NewEmail(s.fromName, s.fromEmail)
+	toEmail := mail.NewEmail("", to)
+	subject := "Calth - Restablecer contraseña"
+	content := mail.NewContent("text/html", html)
+	message := mail.NewV3MailInit(from, subject, toEmail, content)
+
+	client := sendgrid.NewSendClient(s.apiKey)
+	resp, err := client.Send(message)
+	if err != nil {
+		return fmt.Errorf("sendgrid send: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("sendgrid rejected email: status=%d body=%s", resp.StatusCode, resp.Body)
+	}
+
+	return nil
+}
+
+func (s *SendGridService) SendEmailVerification(to, companyName, fullName, verifyLink, expiresIn string) error {
+	html, err := renderTemplate("email-verification", map[string]string{
+		"companyName": companyName,
+		"fullName":    fullName,
+		"verifyLink":  verifyLink,
+		"expiresIn":   expiresIn,
+	})
+	if err != nil {
+		return fmt.Errorf("render template: %w", err)
+	}
+
+	from := mail.NewEmail(s.fromName, s.fromEmail)
+	toEmail := mail.NewEmail("", to)
+	subject := "Calth - Verificá tu email"
+	content := mail.NewContent("text/html", html)
+	message := mail.NewV3MailInit(from, subject, toEmail, content)
+
+	client := sendgrid.NewSendClient(s.apiKey)
+	resp, err := client.Send(message)
+	if err != nil {
+		return fmt.Errorf("sendgrid send: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("sendgrid rejected email: status=%d body=%s", resp.StatusCode, resp.Body)
+	}
+
+	return nil
+}
+
+func (s *SendGridService) SendInvitation(to, companyName, inviterName, roleName, inviteLink, expiresIn string) error {
+	html, err := renderTemplate("invitation", map[string]string{
+		"companyName": companyName,
+		"inviterName": inviterName,
+		"roleName":    roleName,
+		"inviteLink":  inviteLink,
+		"expiresIn":   expiresIn,
+	})
+	if err != nil {
+		return fmt.Errorf("render template: %w", err)
+	}
+
+	from := mail.NewEmail(s.fromName, s.fromEmail)
+	toEmail := mail.NewEmail("", to)
+	subject := "Calth - Invitación a " + companyName
+	content := mail.NewContent("text/html", html)
+	message := mail.NewV3MailInit(from, subject, toEmail, content)
+
+	client := sendgrid.NewSendClient(s.apiKey)
+	resp, err := client.Send(message)
+	if err != nil {
+		return fmt.Errorf("sendgrid send: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("sendgrid rejected email: status=%d body=%s", resp.StatusCode, resp.Body)
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package email_verification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"time"
+
+	"github.com/alanloffler/go-calth-api/internal/common/token"
+	"github.com/alanloffler/go-calth-api/internal/common/utils"
+	"github.com/alanloffler/go-calth-api/internal/config"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/alanloffler/go-calth-api/internal/queue"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var ErrAlreadyVerified = errors.New("email verification: email already verified")
+
+// ResendLimitError is returned by Send when the user asked for too many
+// verification emails. Wait is the time left until the next one is allowed,
+// rounded up to the minute.
+type ResendLimitError struct {
+	Wait time.Duration
+}
+
+func (e *ResendLimitError) Error() string {
+	return fmt.Sprintf("email verification: resend allowed in %s", e.Wait)
+}
+
+// Sender issues verification tokens and queues the email with the link.
+type Sender struct {
+	q           *sqlc.Queries
+	queueClient *asynq.Client
+	cfg         *config.Config
+}
+
+func NewSender(q *sqlc.Queries, queueClient *asynq.Client, cfg *config.Config) *Sender {
+	return &Sender{q: q, queueClient: queueClient, cfg: cfg}
+}
+
+// Send replaces any pending token of the user and emails a new verification
+// link. Call it after the user is committed.
+func (s *Sender) Send(ctx context.Context, businessID, userID pgtype.UUID) error {
+	user, err := s.q.GetEmailVerificationUser(ctx, sqlc.GetEmailVerificationUserParams{BusinessID: businessID, ID: userID})
+	if err != nil {
+		return fmt.Errorf("get user: %w", err)
+	}
+	if user.EmailVerifiedAt.Valid {
+		return ErrAlreadyVerified
+	}
+
+	now := time.Now()
+
+	sent, err := s.q.GetEmailVerificationToken(ctx, userID)
+	switch {
+	case err == nil:
+		if wait := resendWait(sent, now); wait > 0 {
+			return &ResendLimitError{Wait: (wait + time.Minute - 1).Truncate(time.Minute)}
+		}
+	case !errors.Is(err, pgx.ErrNoRows):
+		return fmt.Errorf("get token: %w", err)
+	}
+
+	business, err := s.q.GetBusiness(ctx, businessID)
+	if err != nil {
+		return fmt.Errorf("get business: %w", err)
+	}
+
+	verifyToken, hash, err := token.Generate()
+	if err != nil {
+		return fmt.Errorf("generate token: %w", err)
+	}
+
+	if err := s.q.UpsertEmailVerificationToken(ctx, sqlc.UpsertEmailVerificationTokenParams{
+		UserID:      userID,
+		BusinessID:  businessID,
+		TokenHash:   hash,
+		ExpiresAt:   pgtype.Timestamptz{Time: now.Add(s.cfg.EmailVerificationTTL), Valid: true},
+		WindowStart: pgtype.Timestamptz{Time: now.Add(-sendWindow), Valid: true},
+	}); err != nil {
+		return fmt.Errorf("save token: %w", err)
+	}
+
+	if err := queue.EnqueueEmailVerification(s.queueClient, queue.EmailVerificationPayload{
+		Email:       user.Email,
+		CompanyName: business.TradeName,
+		FullName:    user.FirstName + " " + user.LastName,
+		VerifyLink:  "https://" + business.Slug + "." + s.cfg.AppDomain + "/verify-email?token=" + url.QueryEscape(verifyToken),
+		ExpiresIn:   utils.FormatDuration(s.cfg.EmailVerificationTTL),
+	}); err != nil {
+		log.Printf("failed to enqueue email_verification email: %v", err)
+	}
+
+	return nil
+}
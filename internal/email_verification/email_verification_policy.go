@@ -0,0 +1,32 @@
+package email_verification
+
+import (
+	"time"
+
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+)
+
+// Resend limits: one email per resendCooldown and at most maxSendsPerWindow
+// emails per sendWindow.
+const (
+	resendCooldown    = time.Minute
+	maxSendsPerWindow = 5
+	sendWindow        = 24 * time.Hour
+)
+
+// resendWait returns how long the user has to wait before another verification
+// email can be sent, or zero when it can be sent now.
+func resendWait(t sqlc.EmailVerificationToken, now time.Time) time.Duration {
+	var wait time.Duration
+
+	if next := t.LastSentAt.Time.Add(resendCooldown); next.After(now) {
+		wait = next.Sub(now)
+	}
+
+	windowEnd := t.WindowStartedAt.Time.Add(sendWindow)
+	if t.SendCount >= maxSendsPerWindow && windowEnd.After(now) {
+		wait = max(wait, windowEnd.Sub(now))
+	}
+
+	return wait
+}
@@ -0,0 +1,39 @@
+package email_verification
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func sentToken(count int32, windowStarted, lastSent time.Time) sqlc.EmailVerificationToken {
+	return sqlc.EmailVerificationToken{
+		SendCount:       count,
+		WindowStartedAt: pgtype.Timestamptz{Time: windowStarted, Valid: true},
+		LastSentAt:      pgtype.Timestamptz{Time: lastSent, Valid: true},
+	}
+}
+
+func TestResendWait(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		token sqlc.EmailVerificationToken
+		want  time.Duration
+	}{
+		{"allowed", sentToken(1, now.Add(-time.Hour), now.Add(-time.Hour)), 0},
+		{"cooldown", sentToken(1, now.Add(-20*time.Second), now.Add(-20*time.Second)), 40 * time.Second},
+		{"window limit", sentToken(maxSendsPerWindow, now.Add(-20*time.Hour), now.Add(-time.Hour)), 4 * time.Hour},
+		{"window over", sentToken(maxSendsPerWindow, now.Add(-25*time.Hour), now.Add(-time.Hour)), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, resendWait(tt.token, now))
+		})
+	}
+}
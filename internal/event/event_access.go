@@ -0,0 +1,43 @@
+package event
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/alanloffler/go-calth-api/internal/common/ctxkeys"
+	"github.com/alanloffler/go-calth-api/internal/common/response"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// authorize checks that the event belongs to the caller when the request is
+// restricted to its own events. It writes the error response and returns false
+// otherwise.
+func (h *EventHandler) authorize(c *gin.Context, businessID, id pgtype.UUID) bool {
+	owner := ctxkeys.OwnerScope(c)
+	if !owner.Valid {
+		return true
+	}
+
+	professionalID, err := h.repo.GetProfessionalID(c.Request.Context(), sqlc.GetEventProfessionalIDParams{
+		BusinessID: businessID,
+		ID:         id,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Evento no encontrado"))
+			return false
+		}
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al verificar permisos", err))
+		return false
+	}
+
+	if professionalID != owner {
+		c.JSON(http.StatusForbidden, response.Error(http.StatusForbidden, "Permisos insuficientes"))
+		return false
+	}
+
+	return true
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -87,12 +88,29 @@ func (h *EventHandler) Create(c *gin.Context) {
 		return
 	}
 
+	if !ctxkeys.InOwnerScope(c, professionalID) {
+		c.JSON(http.StatusForbidden, response.Error(http.StatusForbidden, "Permisos insuficientes"))
+		return
+	}
+
 	var userID pgtype.UUID
 	if err := userID.Scan(req.UserID); err != nil {
 		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID del usuario inválido", err))
 		return
 	}
 
+	// Businesses may only book patients with a verified email. An unknown user
+	// is left to the insert to reject.
+	allowed, err := h.repo.CheckBookingEmailVerified(c.Request.Context(), sqlc.CheckBookingEmailVerifiedParams{BusinessID: businessID, ID: userID})
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al verificar el email del paciente", err))
+		return
+	}
+	if err == nil && !allowed {
+		c.JSON(http.StatusForbidden, response.Error(http.StatusForbidden, "El paciente debe verificar su email antes de reservar un turno"))
+		return
+	}
+
 	if len(req.RecurringDates) > 0 {
 		h.createRecurring(c, req, startTime, endTime, businessID, professionalID, userID)
 		return
@@ -212,7 +230,11 @@ func (h *EventHandler) GetByBusinessID(c *gin.Context) {
 		return
 	}
 
-	rawEvents, err := h.repo.GetByBusinessID(c.Request.Context(), sqlc.GetByBusinessIDParams{BusinessID: businessID, Limit: int32(limit)})
+	rawEvents, err := h.repo.GetByBusinessID(c.Request.Context(), sqlc.GetByBusinessIDParams{
+		BusinessID:     businessID,
+		ProfessionalID: ctxkeys.OwnerScope(c),
+		QueryLimit:     int32(limit),
+	})
 	if err != nil {
 		c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Eventos no encontrados", err))
 		return
@@ -239,6 +261,11 @@ func (h *EventHandler) GetByProfessionalID(c *gin.Context) {
 		return
 	}
 
+	if !ctxkeys.InOwnerScope(c, id) {
+		c.JSON(http.StatusForbidden, response.Error(http.StatusForbidden, "Permisos insuficientes"))
+		return
+	}
+
 	var startDate, endDate pgtype.Timestamptz
 
 	if startDateStr := c.Query("startDate"); startDateStr != "" {
@@ -301,6 +328,11 @@ func (h *EventHandler) GetByBusinessProfessionalPatient(c *gin.Context) {
 		return
 	}
 
+	if !ctxkeys.InOwnerScope(c, professionalID) {
+		c.JSON(http.StatusForbidden, response.Error(http.StatusForbidden, "Permisos insuficientes"))
+		return
+	}
+
 	var userID pgtype.UUID
 	if err := userID.Scan(c.Param("patient_id")); err != nil {
 		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID de paciente inválido", err))
@@ -342,6 +374,11 @@ func (h *EventHandler) GetByProfessionalDay(c *gin.Context) {
 		return
 	}
 
+	if !ctxkeys.InOwnerScope(c, id) {
+		c.JSON(http.StatusForbidden, response.Error(http.StatusForbidden, "Permisos insuficientes"))
+		return
+	}
+
 	loc, err := time.LoadLocation("America/Argentina/Buenos_Aires")
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error de zona horaria", err))
@@ -390,6 +427,11 @@ func (h *EventHandler) GetByProfessionalDayArray(c *gin.Context) {
 		return
 	}
 
+	if !ctxkeys.InOwnerScope(c, id) {
+		c.JSON(http.StatusForbidden, response.Error(http.StatusForbidden, "Permisos insuficientes"))
+		return
+	}
+
 	loc, err := time.LoadLocation("America/Argentina/Buenos_Aires")
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error de zona horaria", err))
@@ -493,6 +535,15 @@ func (h *EventHandler) GetFiltered(c *gin.Context) {
 		params.ProfessionalID = professionalID
 	}
 
+	// Without the elevated permission the listing is always narrowed to the caller.
+	if owner := ctxkeys.OwnerScope(c); owner.Valid {
+		if params.ProfessionalID.Valid && params.ProfessionalID != owner {
+			c.JSON(http.StatusForbidden, response.Error(http.StatusForbidden, "Permisos insuficientes"))
+			return
+		}
+		params.ProfessionalID = owner
+	}
+
 	if patientIDStr := c.Query("patientId"); patientIDStr != "" {
 		var patientID pgtype.UUID
 		if err := patientID.Scan(patientIDStr); err != nil {
@@ -572,6 +623,11 @@ func (h *EventHandler) GetDaysWithEvents(c *gin.Context) {
 		return
 	}
 
+	if !ctxkeys.InOwnerScope(c, professionalID) {
+		c.JSON(http.StatusForbidden, response.Error(http.StatusForbidden, "Permisos insuficientes"))
+		return
+	}
+
 	fromDateStr := c.Query("fromDate")
 	toDateStr := c.Query("toDate")
 
@@ -630,6 +686,10 @@ func (h *EventHandler) GetByID(c *gin.Context) {
 		return
 	}
 
+	if !h.authorize(c, businessID, id) {
+		return
+	}
+
 	rawEvent, err := h.repo.GetByID(c.Request.Context(), sqlc.GetByIDParams{
 		BusinessID: businessID,
 		ID:         id,
@@ -787,6 +847,10 @@ func (h *EventHandler) Update(c *gin.Context) {
 			c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID del profesional inválido", err))
 			return
 		}
+		if !ctxkeys.InOwnerScope(c, professionalID) {
+			c.JSON(http.StatusForbidden, response.Error(http.StatusForbidden, "Permisos insuficientes"))
+			return
+		}
 		params.ProfessionalID = professionalID
 	}
 
@@ -819,6 +883,10 @@ func (h *EventHandler) Update(c *gin.Context) {
 		params.RecurrentID = recurrentID
 	}
 
+	if !h.authorize(c, businessID, id) {
+		return
+	}
+
 	event, err := h.repo.Update(c.Request.Context(), params)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al actualizar evento", err))
@@ -855,6 +923,10 @@ func (h *EventHandler) UpdateStatus(c *gin.Context) {
 		return
 	}
 
+	if !h.authorize(c, businessID, id) {
+		return
+	}
+
 	affected, err := h.repo.UpdateStatus(c.Request.Context(), sqlc.UpdateStatusParams{
 		BusinessID: businessID,
 		ID:         id,
@@ -887,6 +959,10 @@ func (h *EventHandler) Delete(c *gin.Context) {
 		return
 	}
 
+	if !h.authorize(c, businessID, id) {
+		return
+	}
+
 	// PLAN:
 	// 1. Get the event's recurrent_id
 	recurrentID, err := h.repo.GetEventRecurrentID(ctx, sqlc.GetEventRecurrentIDParams{
@@ -55,6 +55,10 @@ func (r *EventRepository) GetByID(ctx context.Context, arg sqlc.GetByIDParams) (
 	return r.q.GetByID(ctx, arg)
 }
 
+func (r *EventRepository) GetProfessionalID(ctx context.Context, arg sqlc.GetEventProfessionalIDParams) (pgtype.UUID, error) {
+	return r.q.GetEventProfessionalID(ctx, arg)
+}
+
 func (r *EventRepository) CheckRecurring(ctx context.Context, arg sqlc.CheckRecurringEventsParams) ([]sqlc.CheckRecurringEventsRow, error) {
 	return r.q.CheckRecurringEvents(ctx, arg)
 }
@@ -88,3 +92,7 @@ func (r *EventRepository) ClearRecurrentID(ctx context.Context, arg sqlc.ClearRe
 func (r *EventRepository) ChechSlotConflict(ctx context.Context, arg sqlc.CheckSlotConflictParams) (pgtype.UUID, error) {
 	return r.q.CheckSlotConflict(ctx, arg)
 }
+
+func (r *EventRepository) CheckBookingEmailVerified(ctx context.Context, arg sqlc.CheckBookingEmailVerifiedParams) (bool, error) {
+	return r.q.CheckBookingEmailVerified(ctx, arg)
+}
@@ -15,6 +15,8 @@ func RegisterRoutes(router *gin.RouterGroup, q *sqlc.Queries, pool *pgxpool.Pool
 	var handler *EventHandler = NewEventHandler(repo, pool, profileRepo, queueClient)
 	var events *gin.RouterGroup = router.Group("/events")
 
+	events.Use(middleware.OwnershipMiddleware(q, "events-all"))
+
 	events.POST("", middleware.PermissionMiddleware(q, "events-create"), handler.Create)
 
 	events.GET("/check-recurring", middleware.PermissionMiddleware(q, "events-view"), handler.CheckRecurring)
@@ -23,7 +25,7 @@ func RegisterRoutes(router *gin.RouterGroup, q *sqlc.Queries, pool *pgxpool.Pool
 	events.GET("/professional/:id", middleware.PermissionMiddleware(q, "events-view"), handler.GetByProfessionalID)
 	events.GET("/days-with-events/:id", middleware.PermissionMiddleware(q, "events-view"), handler.GetDaysWithEvents)
 	// events.GET("/professional/:id/date-array/:day", middleware.PermissionMiddleware(q, "events-view"), handler.GetByProfessionalDay)
-	events.GET("/patient/:patient_id", middleware.PermissionMiddleware(q, "events-view"), handler.GetByBusinessProfessionalPatient)
+	events.GET("/patient/:patient_id", middleware.PermissionMiddleware(q, "events-view"), middleware.AuditMiddleware(q, "patient_events", "patient_id", nil), handler.GetByBusinessProfessionalPatient)
 	events.GET("/professional/:id/date-array/:day", middleware.PermissionMiddleware(q, "events-view"), handler.GetByProfessionalDayArray)
 	events.GET("/:id", middleware.PermissionMiddleware(q, "events-view"), handler.GetByID)
 
@@ -40,7 +40,9 @@ func localLoc() *time.Location {
 	return time.FixedZone("ART", timeZoneOffset*3600)
 }
 
-func isWithinSchedule(candidate time.Time, profile sqlc.ProfessionalProfile) bool {
+// IsWithinSchedule reports whether a slot starting at candidate fits the
+// professional's working days and hours.
+func IsWithinSchedule(candidate time.Time, profile sqlc.ProfessionalProfile) bool {
 	loc := localLoc()
 	localTime := candidate.In(loc)
 
@@ -92,7 +94,7 @@ func areAllSlotsFree(
 	slotDuration int,
 	profile sqlc.ProfessionalProfile,
 	businessID, professionalID pgtype.UUID) (bool, error) {
-	if !isWithinSchedule(candidateStart, profile) {
+	if !IsWithinSchedule(candidateStart, profile) {
 		return false, nil
 	}
 
@@ -100,7 +102,7 @@ func areAllSlotsFree(
 	candidateDates := generateRecurringDates(candidateStart, int32(days))
 
 	for _, cd := range candidateDates {
-		if !isWithinSchedule(cd, profile) {
+		if !IsWithinSchedule(cd, profile) {
 			return false, nil
 		}
 
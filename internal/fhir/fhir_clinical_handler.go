@@ -0,0 +1,312 @@
+package fhir
+
+import (
+	"net/http"
+
+	"github.com/alanloffler/go-calth-api/internal/common/ctxkeys"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// clinicalFilters holds the search parameters shared by appointments and encounters.
+type clinicalFilters struct {
+	id             pgtype.UUID
+	patientID      pgtype.UUID
+	professionalID pgtype.UUID
+	ownerID        pgtype.UUID
+	from           pgtype.Timestamptz
+	to             pgtype.Timestamptz
+	limit          int32
+}
+
+// clinicalSearch reads _id, patient, practitioner, date and _count, and the
+// ownership restriction of the caller.
+func (h *FhirHandler) clinicalSearch(c *gin.Context) (clinicalFilters, bool) {
+	var f clinicalFilters
+	var err error
+
+	if f.limit, err = countParam(c.Query("_count")); err != nil {
+		fail(c, http.StatusBadRequest, "Error de validación de datos", err)
+		return f, false
+	}
+	if f.id, err = idParam(c.Query("_id")); err != nil {
+		fail(c, http.StatusBadRequest, "Error de validación de datos", err)
+		return f, false
+	}
+	if patient := c.Query("patient"); patient != "" {
+		if f.patientID, err = referenceParam(patient, "Patient"); err != nil {
+			fail(c, http.StatusBadRequest, "Error de validación de datos", err)
+			return f, false
+		}
+	}
+	if practitioner := c.Query("practitioner"); practitioner != "" {
+		if f.professionalID, err = referenceParam(practitioner, "Practitioner"); err != nil {
+			fail(c, http.StatusBadRequest, "Error de validación de datos", err)
+			return f, false
+		}
+	}
+	if f.from, f.to, err = dateRange(c.QueryArray("date"), h.loc); err != nil {
+		fail(c, http.StatusBadRequest, "Error de validación de datos", err)
+		return f, false
+	}
+
+	f.ownerID = ctxkeys.OwnerScope(c)
+	return f, true
+}
+
+// readFilters scopes a read by ID to what the caller may see.
+func readFilters(c *gin.Context) (clinicalFilters, bool) {
+	resourceID, err := idParam(c.Param("id"))
+	if err != nil || !resourceID.Valid {
+		fail(c, http.StatusBadRequest, "Formato de ID inválido", err)
+		return clinicalFilters{}, false
+	}
+
+	return clinicalFilters{id: resourceID, ownerID: ctxkeys.OwnerScope(c), limit: 1}, true
+}
+
+// Appointments
+
+func (h *FhirHandler) ReadAppointment(c *gin.Context) {
+	businessID, ok := tenant(c)
+	if !ok {
+		return
+	}
+
+	f, ok := readFilters(c)
+	if !ok {
+		return
+	}
+
+	appointments, ok := h.searchAppointments(c, businessID, f)
+	if !ok {
+		return
+	}
+	if len(appointments) == 0 {
+		fail(c, http.StatusNotFound, "Turno no encontrado")
+		return
+	}
+
+	write(c, http.StatusOK, toAppointment(appointments[0]))
+}
+
+func (h *FhirHandler) SearchAppointments(c *gin.Context) {
+	businessID, ok := tenant(c)
+	if !ok {
+		return
+	}
+
+	f, ok := h.clinicalSearch(c)
+	if !ok {
+		return
+	}
+
+	appointments, ok := h.searchAppointments(c, businessID, f)
+	if !ok {
+		return
+	}
+
+	items := make([]bundleItem, len(appointments))
+	for i, e := range appointments {
+		items[i] = bundleItem{resourceType: "Appointment", id: id(e.ID), resource: toAppointment(e)}
+	}
+
+	writeBundle(c, items)
+}
+
+// searchAppointments limits professionals without events-all to their own
+// appointments.
+func (h *FhirHandler) searchAppointments(c *gin.Context, businessID pgtype.UUID, f clinicalFilters) ([]sqlc.SearchFhirAppointmentsRow, bool) {
+	professionalID, ok := scopedPractitioner(c, f.professionalID)
+	if !ok {
+		return nil, false
+	}
+
+	appointments, err := h.repo.SearchAppointments(c.Request.Context(), sqlc.SearchFhirAppointmentsParams{
+		BusinessID:     businessID,
+		ID:             f.id,
+		PatientID:      f.patientID,
+		ProfessionalID: professionalID,
+		DateFrom:       f.from,
+		DateTo:         f.to,
+		QueryLimit:     f.limit,
+	})
+	if err != nil {
+		fail(c, http.StatusInternalServerError, "Error al obtener los turnos", err)
+		return nil, false
+	}
+	return appointments, true
+}
+
+// Encounters
+
+func (h *FhirHandler) ReadEncounter(c *gin.Context) {
+	businessID, ok := tenant(c)
+	if !ok {
+		return
+	}
+
+	f, ok := readFilters(c)
+	if !ok {
+		return
+	}
+
+	encounters, ok := h.searchEncounters(c, businessID, f)
+	if !ok {
+		return
+	}
+	if len(encounters) == 0 {
+		fail(c, http.StatusNotFound, "Historia médica no encontrada")
+		return
+	}
+
+	write(c, http.StatusOK, toEncounter(encounters[0]))
+}
+
+func (h *FhirHandler) SearchEncounters(c *gin.Context) {
+	businessID, ok := tenant(c)
+	if !ok {
+		return
+	}
+
+	f, ok := h.clinicalSearch(c)
+	if !ok {
+		return
+	}
+
+	encounters, ok := h.searchEncounters(c, businessID, f)
+	if !ok {
+		return
+	}
+
+	items := make([]bundleItem, len(encounters))
+	for i, mh := range encounters {
+		items[i] = bundleItem{resourceType: "Encounter", id: id(mh.ID), resource: toEncounter(mh)}
+	}
+
+	writeBundle(c, items)
+}
+
+// searchEncounters limits professionals without medical_history-all to the
+// entries they wrote and those of the patients they treat, like
+// GetMedicalHistoryAccess does for a single entry.
+func (h *FhirHandler) searchEncounters(c *gin.Context, businessID pgtype.UUID, f clinicalFilters) ([]sqlc.SearchFhirEncountersRow, bool) {
+	encounters, err := h.repo.SearchEncounters(c.Request.Context(), sqlc.SearchFhirEncountersParams{
+		BusinessID:     businessID,
+		ID:             f.id,
+		PatientID:      f.patientID,
+		ProfessionalID: f.professionalID,
+		OwnerID:        f.ownerID,
+		DateFrom:       f.from,
+		DateTo:         f.to,
+		QueryLimit:     f.limit,
+	})
+	if err != nil {
+		fail(c, http.StatusInternalServerError, "Error al obtener las historias médicas", err)
+		return nil, false
+	}
+	return encounters, true
+}
+
+// Conditions come from two sources: the problem list, and the diagnosis of each
+// medical history entry, which shares the ID of its Encounter.
+
+func (h *FhirHandler) ReadCondition(c *gin.Context) {
+	businessID, ok := tenant(c)
+	if !ok {
+		return
+	}
+
+	f, ok := readFilters(c)
+	if !ok {
+		return
+	}
+
+	problems, err := h.repo.SearchConditions(c.Request.Context(), sqlc.SearchFhirConditionsParams{
+		BusinessID: businessID,
+		ID:         f.id,
+		OwnerID:    f.ownerID,
+		QueryLimit: 1,
+	})
+	if err != nil {
+		fail(c, http.StatusInternalServerError, "Error al obtener el problema de salud", err)
+		return
+	}
+	if len(problems) > 0 {
+		write(c, http.StatusOK, toProblemCondition(problems[0]))
+		return
+	}
+
+	encounters, ok := h.searchEncounters(c, businessID, f)
+	if !ok {
+		return
+	}
+	if len(encounters) == 0 {
+		fail(c, http.StatusNotFound, "Problema de salud no encontrado")
+		return
+	}
+
+	write(c, http.StatusOK, toDiagnosisCondition(encounters[0]))
+}
+
+func (h *FhirHandler) SearchConditions(c *gin.Context) {
+	businessID, ok := tenant(c)
+	if !ok {
+		return
+	}
+
+	f, ok := h.clinicalSearch(c)
+	if !ok {
+		return
+	}
+
+	category := tokenParam(c.Query("category")).String
+	if category != "" && category != categoryProblemList && category != categoryDiagnosis {
+		fail(c, http.StatusBadRequest, "Categoría no soportada")
+		return
+	}
+
+	clinicalStatus := tokenParam(c.Query("clinical-status"))
+	if clinicalStatus.Valid && clinicalStatus.String != "active" && clinicalStatus.String != "resolved" {
+		fail(c, http.StatusBadRequest, "Estado clínico no soportado")
+		return
+	}
+
+	var items []bundleItem
+
+	if category != categoryDiagnosis {
+		problems, err := h.repo.SearchConditions(c.Request.Context(), sqlc.SearchFhirConditionsParams{
+			BusinessID: businessID,
+			ID:         f.id,
+			PatientID:  f.patientID,
+			OwnerID:    f.ownerID,
+			Status:     clinicalStatus,
+			QueryLimit: f.limit,
+		})
+		if err != nil {
+			fail(c, http.StatusInternalServerError, "Error al obtener los problemas de salud", err)
+			return
+		}
+		for _, pc := range problems {
+			items = append(items, bundleItem{resourceType: "Condition", id: id(pc.ID), resource: toProblemCondition(pc)})
+		}
+	}
+
+	// Diagnoses carry no clinical status, so filtering by it leaves them out.
+	if category != categoryProblemList && !clinicalStatus.Valid {
+		encounters, ok := h.searchEncounters(c, businessID, f)
+		if !ok {
+			return
+		}
+		for _, mh := range encounters {
+			items = append(items, bundleItem{resourceType: "Condition", id: id(mh.ID), resource: toDiagnosisCondition(mh)})
+		}
+	}
+
+	if len(items) > int(f.limit) {
+		items = items[:f.limit]
+	}
+
+	writeBundle(c, items)
+}
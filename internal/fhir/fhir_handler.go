@@ -0,0 +1,181 @@
+package fhir
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/alanloffler/go-calth-api/internal/common/ctxkeys"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const contentType = "application/fhir+json; charset=utf-8"
+
+type FhirHandler struct {
+	repo *FhirRepository
+	loc  *time.Location
+}
+
+func NewFhirHandler(repo *FhirRepository) *FhirHandler {
+	loc, err := time.LoadLocation("America/Argentina/Buenos_Aires")
+	if err != nil {
+		loc = time.UTC
+	}
+	return &FhirHandler{repo: repo, loc: loc}
+}
+
+func (h *FhirHandler) Metadata(c *gin.Context) {
+	interactions := []CapabilityInteraction{{Code: "read"}, {Code: "search-type"}}
+
+	write(c, http.StatusOK, CapabilityStatement{
+		ResourceType: "CapabilityStatement",
+		Status:       "active",
+		Date:         time.Now().UTC().Format("2006-01-02"),
+		Kind:         "instance",
+		FhirVersion:  "4.0.1",
+		Format:       []string{"json"},
+		Rest: []CapabilityRest{{
+			Mode: "server",
+			Resource: []CapabilityResource{
+				{
+					Type:        "Patient",
+					Interaction: interactions,
+					SearchParam: []CapabilitySearchParam{{Name: "_id", Type: "token"}, {Name: "identifier", Type: "token"}, {Name: "name", Type: "string"}, {Name: "birthdate", Type: "date"}},
+					Operation:   []CapabilityOperation{{Name: "everything", Definition: "http://hl7.org/fhir/OperationDefinition/Patient-everything"}},
+				},
+				{
+					Type:        "Practitioner",
+					Interaction: interactions,
+					SearchParam: []CapabilitySearchParam{{Name: "_id", Type: "token"}, {Name: "identifier", Type: "token"}, {Name: "name", Type: "string"}},
+				},
+				{
+					Type:        "Appointment",
+					Interaction: interactions,
+					SearchParam: []CapabilitySearchParam{{Name: "_id", Type: "token"}, {Name: "patient", Type: "reference"}, {Name: "practitioner", Type: "reference"}, {Name: "date", Type: "date"}},
+				},
+				{
+					Type:        "Encounter",
+					Interaction: interactions,
+					SearchParam: []CapabilitySearchParam{{Name: "_id", Type: "token"}, {Name: "patient", Type: "reference"}, {Name: "practitioner", Type: "reference"}, {Name: "date", Type: "date"}},
+				},
+				{
+					Type:        "Condition",
+					Interaction: interactions,
+					SearchParam: []CapabilitySearchParam{{Name: "_id", Type: "token"}, {Name: "patient", Type: "reference"}, {Name: "category", Type: "token"}, {Name: "clinical-status", Type: "token"}},
+				},
+			},
+		}},
+	})
+}
+
+// write sends a FHIR resource with the FHIR JSON media type.
+func write(c *gin.Context, status int, resource any) {
+	body, err := json.Marshal(resource)
+	if err != nil {
+		status = http.StatusInternalServerError
+		body, _ = json.Marshal(outcomeFor("exception", "Error al serializar el recurso: "+err.Error()))
+	}
+	c.Data(status, contentType, body)
+}
+
+func outcomeFor(code, diagnostics string) OperationOutcome {
+	return OperationOutcome{
+		ResourceType: "OperationOutcome",
+		Issue:        []OperationOutcomeIssue{{Severity: "error", Code: code, Diagnostics: diagnostics}},
+	}
+}
+
+// fail answers with an OperationOutcome, the FHIR error format.
+func fail(c *gin.Context, status int, message string, err ...error) {
+	code := "exception"
+	switch status {
+	case http.StatusBadRequest:
+		code = "invalid"
+	case http.StatusUnauthorized:
+		code = "login"
+	case http.StatusForbidden:
+		code = "forbidden"
+	case http.StatusNotFound:
+		code = "not-found"
+	}
+
+	if len(err) > 0 && err[0] != nil {
+		message += ": " + err[0].Error()
+	}
+
+	write(c, status, outcomeFor(code, message))
+}
+
+// origin is the scheme and host the client used to reach the API.
+func origin(c *gin.Context) string {
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	if proto := c.GetHeader("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	return scheme + "://" + c.Request.Host
+}
+
+// baseURL is the absolute URL of the FHIR endpoint, used for Bundle.fullUrl.
+func baseURL(c *gin.Context) string {
+	path := c.FullPath()
+	if i := strings.Index(path, "/fhir/R4"); i >= 0 {
+		path = path[:i+len("/fhir/R4")]
+	}
+	return origin(c) + path
+}
+
+type bundleItem struct {
+	resourceType string
+	id           string
+	resource     any
+}
+
+// writeBundle answers a search or $everything with a searchset bundle.
+func writeBundle(c *gin.Context, items []bundleItem) {
+	base := baseURL(c)
+
+	bundle := Bundle{
+		ResourceType: "Bundle",
+		Type:         "searchset",
+		Total:        len(items),
+		Link:         []BundleLink{{Relation: "self", URL: origin(c) + c.Request.URL.RequestURI()}},
+		Entry:        make([]BundleEntry, len(items)),
+	}
+	for i, item := range items {
+		bundle.Entry[i] = BundleEntry{
+			FullURL:  base + "/" + item.resourceType + "/" + item.id,
+			Resource: item.resource,
+			Search:   &BundleEntrySearch{Mode: "match"},
+		}
+	}
+
+	write(c, http.StatusOK, bundle)
+}
+
+// tenant reads the business of the request, answering 401 when it is missing.
+func tenant(c *gin.Context) (pgtype.UUID, bool) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		fail(c, http.StatusUnauthorized, "Usuario no autenticado")
+	}
+	return businessID, ok
+}
+
+// scopedPractitioner applies the ownership restriction to a practitioner filter:
+// professionals without elevated access only see their own records.
+func scopedPractitioner(c *gin.Context, requested pgtype.UUID) (pgtype.UUID, bool) {
+	owner := ctxkeys.OwnerScope(c)
+	if !owner.Valid {
+		return requested, true
+	}
+	if requested.Valid && requested != owner {
+		fail(c, http.StatusForbidden, "Permisos insuficientes")
+		return pgtype.UUID{}, false
+	}
+	return owner, true
+}
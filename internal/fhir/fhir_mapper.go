@@ -0,0 +1,263 @@
+package fhir
+
+import (
+	"strings"
+	"time"
+
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	systemDNI                = "http://www.renaper.gob.ar/dni"
+	systemLicense            = "urn:calth:license"
+	systemICD10              = "http://hl7.org/fhir/sid/icd-10"
+	systemActCode            = "http://terminology.hl7.org/CodeSystem/v3-ActCode"
+	systemContactRole        = "http://terminology.hl7.org/CodeSystem/v2-0131"
+	systemConditionClinical  = "http://terminology.hl7.org/CodeSystem/condition-clinical"
+	systemConditionVerStatus = "http://terminology.hl7.org/CodeSystem/condition-ver-status"
+	systemConditionCategory  = "http://terminology.hl7.org/CodeSystem/condition-category"
+)
+
+const (
+	categoryProblemList = "problem-list-item"
+	categoryDiagnosis   = "encounter-diagnosis"
+)
+
+// appointmentStatuses maps event statuses to FHIR Appointment.status.
+var appointmentStatuses = map[sqlc.EventStatus]string{
+	sqlc.EventStatusPending:    "booked",
+	sqlc.EventStatusInProgress: "arrived",
+	sqlc.EventStatusPresent:    "fulfilled",
+	sqlc.EventStatusAbsent:     "noshow",
+	sqlc.EventStatusCancelled:  "cancelled",
+}
+
+func toPatient(p sqlc.SearchFhirPatientsRow) Patient {
+	patient := Patient{
+		ResourceType: "Patient",
+		ID:           id(p.ID),
+		Meta:         meta(p.UpdatedAt),
+		Identifier:   []Identifier{{System: systemDNI, Value: p.Ic}},
+		Active:       true,
+		Name:         []HumanName{name(p.FirstName, p.LastName)},
+		Telecom:      telecom(p.PhoneNumber, p.Email),
+		Gender:       gender(p.Gender),
+		BirthDate:    date(p.BirthDay),
+	}
+
+	if p.EmergencyContactName.String != "" || p.EmergencyContactPhone.String != "" {
+		contact := PatientContact{
+			Relationship: []CodeableConcept{{
+				Coding: []Coding{{System: systemContactRole, Code: "C", Display: "Emergency Contact"}},
+			}},
+		}
+		if p.EmergencyContactName.String != "" {
+			contact.Name = &HumanName{Text: p.EmergencyContactName.String}
+		}
+		if p.EmergencyContactPhone.String != "" {
+			contact.Telecom = []ContactPoint{{System: "phone", Value: p.EmergencyContactPhone.String}}
+		}
+		patient.Contact = []PatientContact{contact}
+	}
+
+	return patient
+}
+
+func toPractitioner(p sqlc.SearchFhirPractitionersRow) Practitioner {
+	practitionerName := name(p.FirstName, p.LastName)
+	if p.ProfessionalPrefix != "" {
+		practitionerName.Prefix = []string{p.ProfessionalPrefix}
+	}
+
+	license := Identifier{System: systemLicense, Value: p.LicenseID}
+
+	return Practitioner{
+		ResourceType: "Practitioner",
+		ID:           id(p.ID),
+		Meta:         meta(p.UpdatedAt),
+		Identifier:   []Identifier{{System: systemDNI, Value: p.Ic}, license},
+		Active:       true,
+		Name:         []HumanName{practitionerName},
+		Telecom:      telecom(p.PhoneNumber, p.Email),
+		Qualification: []Qualification{{
+			Identifier: []Identifier{license},
+			Code:       CodeableConcept{Text: p.Specialty},
+		}},
+	}
+}
+
+func toAppointment(e sqlc.SearchFhirAppointmentsRow) Appointment {
+	status, ok := appointmentStatuses[e.Status]
+	if !ok {
+		status = "booked"
+	}
+
+	// Cancelled appointments no longer involve their participants.
+	participantStatus := "accepted"
+	if e.Status == sqlc.EventStatusCancelled {
+		participantStatus = "declined"
+	}
+
+	return Appointment{
+		ResourceType: "Appointment",
+		ID:           id(e.ID),
+		Meta:         meta(e.UpdatedAt),
+		Status:       status,
+		Description:  e.Title,
+		Start:        instant(e.StartDate),
+		End:          instant(e.EndDate),
+		Created:      instant(e.CreatedAt),
+		Participant: []AppointmentParticipant{
+			{Actor: reference("Patient", e.UserID, e.PatientFirstName, e.PatientLastName), Status: participantStatus},
+			{Actor: reference("Practitioner", e.ProfessionalID, e.ProfessionalFirstName, e.ProfessionalLastName), Status: participantStatus},
+		},
+	}
+}
+
+func toEncounter(mh sqlc.SearchFhirEncountersRow) Encounter {
+	encounter := Encounter{
+		ResourceType: "Encounter",
+		ID:           id(mh.ID),
+		Meta:         meta(mh.UpdatedAt),
+		Status:       "finished",
+		Class:        Coding{System: systemActCode, Code: "AMB", Display: "ambulatory"},
+		Subject:      reference("Patient", mh.UserID, mh.PatientFirstName, mh.PatientLastName),
+		Period:       Period{Start: instant(mh.Date)},
+	}
+
+	if mh.ProfessionalFirstName.Valid {
+		encounter.Participant = []EncounterParticipant{{
+			Individual: reference("Practitioner", mh.ProfessionalID, mh.ProfessionalFirstName.String, mh.ProfessionalLastName.String),
+		}}
+	}
+	if mh.EventID.Valid {
+		encounter.Appointment = []Reference{{Reference: "Appointment/" + id(mh.EventID)}}
+	}
+	if mh.Reason != "" {
+		encounter.ReasonCode = []CodeableConcept{{Text: mh.Reason}}
+	}
+
+	return encounter
+}
+
+// toProblemCondition maps an entry of the patient's problem list.
+func toProblemCondition(pc sqlc.PatientCondition) Condition {
+	clinicalStatus := "active"
+	if pc.Status == "resolved" {
+		clinicalStatus = "resolved"
+	}
+
+	code := CodeableConcept{Text: pc.Name}
+	if pc.Code != "" {
+		code.Coding = []Coding{{System: systemICD10, Code: pc.Code, Display: pc.Name}}
+	}
+
+	return Condition{
+		ResourceType: "Condition",
+		ID:           id(pc.ID),
+		Meta:         meta(pc.UpdatedAt),
+		ClinicalStatus: &CodeableConcept{
+			Coding: []Coding{{System: systemConditionClinical, Code: clinicalStatus}},
+		},
+		VerificationStatus: &CodeableConcept{
+			Coding: []Coding{{System: systemConditionVerStatus, Code: "confirmed"}},
+		},
+		Category:          []CodeableConcept{category(categoryProblemList, "Problem List Item")},
+		Code:              code,
+		Subject:           Reference{Reference: "Patient/" + id(pc.PatientID)},
+		OnsetDateTime:     date(pc.OnsetDate),
+		AbatementDateTime: date(pc.ResolvedDate),
+		RecordedDate:      instant(pc.CreatedAt),
+	}
+}
+
+// toDiagnosisCondition maps the reason of a medical history entry, which is the
+// diagnosis recorded during that encounter. It shares the ID of the entry.
+func toDiagnosisCondition(mh sqlc.SearchFhirEncountersRow) Condition {
+	condition := Condition{
+		ResourceType: "Condition",
+		ID:           id(mh.ID),
+		Meta:         meta(mh.UpdatedAt),
+		Category:     []CodeableConcept{category(categoryDiagnosis, "Encounter Diagnosis")},
+		Code:         CodeableConcept{Text: mh.Reason},
+		Subject:      reference("Patient", mh.UserID, mh.PatientFirstName, mh.PatientLastName),
+		Encounter:    &Reference{Reference: "Encounter/" + id(mh.ID)},
+		RecordedDate: instant(mh.Date),
+	}
+
+	if mh.ProfessionalFirstName.Valid {
+		recorder := reference("Practitioner", mh.ProfessionalID, mh.ProfessionalFirstName.String, mh.ProfessionalLastName.String)
+		condition.Recorder = &recorder
+	}
+
+	return condition
+}
+
+func id(u pgtype.UUID) string {
+	return uuid.UUID(u.Bytes).String()
+}
+
+func meta(updatedAt pgtype.Timestamptz) *Meta {
+	if !updatedAt.Valid {
+		return nil
+	}
+	return &Meta{LastUpdated: instant(updatedAt)}
+}
+
+func instant(t pgtype.Timestamptz) string {
+	if !t.Valid {
+		return ""
+	}
+	return t.Time.UTC().Format(time.RFC3339)
+}
+
+func date(d pgtype.Date) string {
+	if !d.Valid {
+		return ""
+	}
+	return d.Time.Format("2006-01-02")
+}
+
+func name(firstName, lastName string) HumanName {
+	return HumanName{
+		Use:    "official",
+		Text:   strings.TrimSpace(firstName + " " + lastName),
+		Family: lastName,
+		Given:  strings.Fields(firstName),
+	}
+}
+
+func telecom(phone, email string) []ContactPoint {
+	var points []ContactPoint
+	if phone != "" {
+		points = append(points, ContactPoint{System: "phone", Value: phone, Use: "mobile"})
+	}
+	if email != "" {
+		points = append(points, ContactPoint{System: "email", Value: email})
+	}
+	return points
+}
+
+func gender(g pgtype.Text) string {
+	switch g.String {
+	case "male", "female":
+		return g.String
+	case "":
+		return ""
+	default:
+		return "unknown"
+	}
+}
+
+func reference(resourceType string, u pgtype.UUID, firstName, lastName string) Reference {
+	return Reference{
+		Reference: resourceType + "/" + id(u),
+		Display:   strings.TrimSpace(firstName + " " + lastName),
+	}
+}
+
+func category(code, display string) CodeableConcept {
+	return CodeableConcept{Coding: []Coding{{System: systemConditionCategory, Code: code, Display: display}}}
+}
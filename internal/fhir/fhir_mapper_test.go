@@ -0,0 +1,128 @@
+package fhir
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testUUID(b byte) pgtype.UUID {
+	return pgtype.UUID{Bytes: [16]byte{15: b}, Valid: true}
+}
+
+func TestToPatient(t *testing.T) {
+	patient := toPatient(sqlc.SearchFhirPatientsRow{
+		ID:                    testUUID(1),
+		Ic:                    "30123456",
+		FirstName:             "Ana María",
+		LastName:              "Gómez",
+		Email:                 "ana@example.com",
+		PhoneNumber:           "1155550000",
+		Gender:                pgtype.Text{String: "female", Valid: true},
+		BirthDay:              pgtype.Date{Time: time.Date(1990, 5, 4, 0, 0, 0, 0, time.UTC), Valid: true},
+		EmergencyContactName:  pgtype.Text{String: "Juan Gómez", Valid: true},
+		EmergencyContactPhone: pgtype.Text{String: "1155551111", Valid: true},
+	})
+
+	assert.Equal(t, "Patient", patient.ResourceType)
+	assert.Equal(t, "00000000-0000-0000-0000-000000000001", patient.ID)
+	assert.Equal(t, []Identifier{{System: systemDNI, Value: "30123456"}}, patient.Identifier)
+	assert.Equal(t, "Gómez", patient.Name[0].Family)
+	assert.Equal(t, []string{"Ana", "María"}, patient.Name[0].Given)
+	assert.Equal(t, "female", patient.Gender)
+	assert.Equal(t, "1990-05-04", patient.BirthDate)
+	require.Len(t, patient.Contact, 1)
+	assert.Equal(t, "Juan Gómez", patient.Contact[0].Name.Text)
+}
+
+func TestToPatient_WithoutProfile(t *testing.T) {
+	patient := toPatient(sqlc.SearchFhirPatientsRow{ID: testUUID(1), FirstName: "Ana", LastName: "Gómez"})
+
+	assert.Empty(t, patient.Gender)
+	assert.Empty(t, patient.BirthDate)
+	assert.Nil(t, patient.Contact)
+}
+
+func TestToAppointment_Status(t *testing.T) {
+	cases := map[sqlc.EventStatus]string{
+		sqlc.EventStatusPending:    "booked",
+		sqlc.EventStatusInProgress: "arrived",
+		sqlc.EventStatusPresent:    "fulfilled",
+		sqlc.EventStatusAbsent:     "noshow",
+		sqlc.EventStatusCancelled:  "cancelled",
+	}
+
+	for status, expected := range cases {
+		appointment := toAppointment(sqlc.SearchFhirAppointmentsRow{
+			ID:             testUUID(1),
+			Status:         status,
+			UserID:         testUUID(2),
+			ProfessionalID: testUUID(3),
+		})
+		assert.Equal(t, expected, appointment.Status, status)
+	}
+}
+
+func TestToAppointment_Participants(t *testing.T) {
+	appointment := toAppointment(sqlc.SearchFhirAppointmentsRow{
+		ID:                    testUUID(1),
+		Status:                sqlc.EventStatusPending,
+		StartDate:             pgtype.Timestamptz{Time: time.Date(2026, 3, 10, 13, 0, 0, 0, time.UTC), Valid: true},
+		EndDate:               pgtype.Timestamptz{Time: time.Date(2026, 3, 10, 14, 0, 0, 0, time.UTC), Valid: true},
+		UserID:                testUUID(2),
+		ProfessionalID:        testUUID(3),
+		PatientFirstName:      "Ana",
+		PatientLastName:       "Gómez",
+		ProfessionalFirstName: "Luis",
+		ProfessionalLastName:  "Pérez",
+	})
+
+	assert.Equal(t, "2026-03-10T13:00:00Z", appointment.Start)
+	require.Len(t, appointment.Participant, 2)
+	assert.Equal(t, "Patient/00000000-0000-0000-0000-000000000002", appointment.Participant[0].Actor.Reference)
+	assert.Equal(t, "Luis Pérez", appointment.Participant[1].Actor.Display)
+}
+
+func TestToEncounter(t *testing.T) {
+	mh := sqlc.SearchFhirEncountersRow{
+		ID:             testUUID(1),
+		UserID:         testUUID(2),
+		ProfessionalID: testUUID(3),
+		EventID:        testUUID(4),
+		Date:           pgtype.Timestamptz{Time: time.Date(2026, 3, 10, 13, 0, 0, 0, time.UTC), Valid: true},
+		Reason:         "Control anual",
+	}
+
+	encounter := toEncounter(mh)
+	assert.Equal(t, "finished", encounter.Status)
+	assert.Equal(t, "AMB", encounter.Class.Code)
+	assert.Equal(t, []Reference{{Reference: "Appointment/00000000-0000-0000-0000-000000000004"}}, encounter.Appointment)
+	assert.Equal(t, "Control anual", encounter.ReasonCode[0].Text)
+	assert.Empty(t, encounter.Participant)
+
+	diagnosis := toDiagnosisCondition(mh)
+	assert.Equal(t, categoryDiagnosis, diagnosis.Category[0].Coding[0].Code)
+	assert.Equal(t, "Encounter/"+encounter.ID, diagnosis.Encounter.Reference)
+	assert.Nil(t, diagnosis.ClinicalStatus)
+}
+
+func TestToProblemCondition(t *testing.T) {
+	condition := toProblemCondition(sqlc.PatientCondition{
+		ID:        testUUID(1),
+		PatientID: testUUID(2),
+		Name:      "Hipertensión arterial",
+		Code:      "I10",
+		Status:    "active",
+		OnsetDate: pgtype.Date{Time: time.Date(2020, 1, 15, 0, 0, 0, 0, time.UTC), Valid: true},
+	})
+
+	assert.Equal(t, "active", condition.ClinicalStatus.Coding[0].Code)
+	assert.Equal(t, categoryProblemList, condition.Category[0].Coding[0].Code)
+	assert.Equal(t, []Coding{{System: systemICD10, Code: "I10", Display: "Hipertensión arterial"}}, condition.Code.Coding)
+	assert.Equal(t, "2020-01-15", condition.OnsetDateTime)
+	assert.Empty(t, condition.AbatementDateTime)
+}
@@ -0,0 +1,137 @@
+package fhir
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	defaultCount = 50
+	maxCount     = 200
+)
+
+var errInvalidParam = errors.New("parámetro de búsqueda inválido")
+
+// countParam reads _count, the page size of a search.
+func countParam(value string) (int32, error) {
+	if value == "" {
+		return defaultCount, nil
+	}
+
+	count, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || count < 1 || count > maxCount {
+		return 0, fmt.Errorf("%w: _count debe estar entre 1 y %d", errInvalidParam, maxCount)
+	}
+
+	return int32(count), nil
+}
+
+// idParam parses a logical ID. An empty value means no filter.
+func idParam(value string) (pgtype.UUID, error) {
+	var u pgtype.UUID
+	if value == "" {
+		return u, nil
+	}
+	if err := u.Scan(value); err != nil {
+		return u, fmt.Errorf("%w: ID %q inválido", errInvalidParam, value)
+	}
+	return u, nil
+}
+
+// referenceParam accepts a plain ID, "Type/id" or an absolute URL ending in
+// "Type/id", as FHIR reference search parameters do.
+func referenceParam(value, resourceType string) (pgtype.UUID, error) {
+	if i := strings.LastIndex(value, "/"); i >= 0 {
+		if !strings.HasSuffix(value[:i], resourceType) {
+			return pgtype.UUID{}, fmt.Errorf("%w: se esperaba una referencia a %s", errInvalidParam, resourceType)
+		}
+		value = value[i+1:]
+	}
+	return idParam(value)
+}
+
+// tokenParam drops the optional "system|" part of a token parameter.
+func tokenParam(value string) pgtype.Text {
+	if i := strings.LastIndex(value, "|"); i >= 0 {
+		value = value[i+1:]
+	}
+	return pgtype.Text{String: value, Valid: value != ""}
+}
+
+// dateParam parses a plain date parameter such as birthdate.
+func dateParam(value string) (pgtype.Date, error) {
+	if value == "" {
+		return pgtype.Date{}, nil
+	}
+
+	t, err := time.Parse("2006-01-02", strings.TrimPrefix(value, "eq"))
+	if err != nil {
+		return pgtype.Date{}, fmt.Errorf("%w: fecha %q inválida", errInvalidParam, value)
+	}
+
+	return pgtype.Date{Time: t, Valid: true}, nil
+}
+
+// dateRange turns the date parameters of a search into a half-open range. Each
+// value may carry one of the eq, ge, gt, le or lt prefixes and is either a day
+// (YYYY-MM-DD) or an instant (RFC 3339); days are read in loc.
+func dateRange(values []string, loc *time.Location) (from, to pgtype.Timestamptz, err error) {
+	for _, value := range values {
+		prefix := "eq"
+		if len(value) > 2 && value[0] >= 'a' && value[0] <= 'z' {
+			prefix, value = value[:2], value[2:]
+		}
+
+		start, end, err := dateBounds(value, loc)
+		if err != nil {
+			return from, to, err
+		}
+
+		switch prefix {
+		case "eq":
+			from, to = later(from, start), earlier(to, end)
+		case "ge":
+			from = later(from, start)
+		case "gt":
+			from = later(from, end)
+		case "lt":
+			to = earlier(to, start)
+		case "le":
+			to = earlier(to, end)
+		default:
+			return from, to, fmt.Errorf("%w: prefijo %q no soportado", errInvalidParam, prefix)
+		}
+	}
+
+	return from, to, nil
+}
+
+// dateBounds returns the first instant of value and the first instant after it.
+func dateBounds(value string, loc *time.Location) (time.Time, time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", value, loc); err == nil {
+		return t, t.AddDate(0, 0, 1), nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, t.Add(time.Second), nil
+	}
+	return time.Time{}, time.Time{}, fmt.Errorf("%w: fecha %q inválida", errInvalidParam, value)
+}
+
+func later(current pgtype.Timestamptz, t time.Time) pgtype.Timestamptz {
+	if current.Valid && current.Time.After(t) {
+		return current
+	}
+	return pgtype.Timestamptz{Time: t, Valid: true}
+}
+
+func earlier(current pgtype.Timestamptz, t time.Time) pgtype.Timestamptz {
+	if current.Valid && current.Time.Before(t) {
+		return current
+	}
+	return pgtype.Timestamptz{Time: t, Valid: true}
+}
@@ -0,0 +1,69 @@
+package fhir
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDateRange_Day(t *testing.T) {
+	from, to, err := dateRange([]string{"2026-03-10"}, time.UTC)
+	require.NoError(t, err)
+
+	assert.Equal(t, time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC), from.Time)
+	assert.Equal(t, time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC), to.Time)
+}
+
+func TestDateRange_Prefixes(t *testing.T) {
+	from, to, err := dateRange([]string{"ge2026-03-01", "le2026-03-31"}, time.UTC)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), from.Time)
+	assert.Equal(t, time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), to.Time)
+
+	from, to, err = dateRange([]string{"gt2026-03-01", "lt2026-03-31"}, time.UTC)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), from.Time)
+	assert.Equal(t, time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC), to.Time)
+}
+
+func TestDateRange_Open(t *testing.T) {
+	from, to, err := dateRange(nil, time.UTC)
+	require.NoError(t, err)
+	assert.False(t, from.Valid)
+	assert.False(t, to.Valid)
+}
+
+func TestDateRange_Invalid(t *testing.T) {
+	_, _, err := dateRange([]string{"ne2026-03-01"}, time.UTC)
+	assert.ErrorIs(t, err, errInvalidParam)
+
+	_, _, err = dateRange([]string{"10/03/2026"}, time.UTC)
+	assert.ErrorIs(t, err, errInvalidParam)
+}
+
+func TestReferenceParam(t *testing.T) {
+	const id = "0b6d4f0e-7c1a-4a59-9d59-3f1f6c2a8e11"
+
+	for _, value := range []string{id, "Patient/" + id, "https://example.com/fhir/R4/Patient/" + id} {
+		u, err := referenceParam(value, "Patient")
+		require.NoError(t, err, value)
+		assert.True(t, u.Valid)
+	}
+
+	_, err := referenceParam("Practitioner/"+id, "Patient")
+	assert.ErrorIs(t, err, errInvalidParam)
+}
+
+func TestCountParam(t *testing.T) {
+	count, err := countParam("")
+	require.NoError(t, err)
+	assert.Equal(t, int32(defaultCount), count)
+
+	_, err = countParam("0")
+	assert.ErrorIs(t, err, errInvalidParam)
+
+	_, err = countParam("1000")
+	assert.ErrorIs(t, err, errInvalidParam)
+}
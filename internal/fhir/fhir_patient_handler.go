@@ -0,0 +1,218 @@
+package fhir
+
+import (
+	"net/http"
+
+	"github.com/alanloffler/go-calth-api/internal/common/ctxkeys"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/alanloffler/go-calth-api/internal/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// everythingLimit caps each resource type included in a $everything bundle.
+const everythingLimit = 1000
+
+func (h *FhirHandler) ReadPatient(c *gin.Context) {
+	businessID, ok := tenant(c)
+	if !ok {
+		return
+	}
+
+	patientID, err := idParam(c.Param("id"))
+	if err != nil || !patientID.Valid {
+		fail(c, http.StatusBadRequest, "Formato de ID inválido", err)
+		return
+	}
+
+	patient, found := h.findPatient(c, businessID, patientID)
+	if !found {
+		return
+	}
+
+	write(c, http.StatusOK, toPatient(patient))
+}
+
+func (h *FhirHandler) SearchPatients(c *gin.Context) {
+	businessID, ok := tenant(c)
+	if !ok {
+		return
+	}
+
+	params := sqlc.SearchFhirPatientsParams{
+		BusinessID: businessID,
+		Identifier: tokenParam(c.Query("identifier")),
+		Name:       pgtype.Text{String: c.Query("name"), Valid: c.Query("name") != ""},
+	}
+
+	var err error
+	if params.QueryLimit, err = countParam(c.Query("_count")); err != nil {
+		fail(c, http.StatusBadRequest, "Error de validación de datos", err)
+		return
+	}
+	if params.ID, err = idParam(c.Query("_id")); err != nil {
+		fail(c, http.StatusBadRequest, "Error de validación de datos", err)
+		return
+	}
+	if params.Birthdate, err = dateParam(c.Query("birthdate")); err != nil {
+		fail(c, http.StatusBadRequest, "Error de validación de datos", err)
+		return
+	}
+
+	patients, err := h.repo.SearchPatients(c.Request.Context(), params)
+	if err != nil {
+		fail(c, http.StatusInternalServerError, "Error al buscar pacientes", err)
+		return
+	}
+
+	items := make([]bundleItem, len(patients))
+	for i, p := range patients {
+		items[i] = bundleItem{resourceType: "Patient", id: id(p.ID), resource: toPatient(p)}
+	}
+
+	writeBundle(c, items)
+}
+
+// Everything implements Patient/$everything: the patient with their problem
+// list, encounters, diagnoses, appointments and the practitioners involved.
+// The optional start and end parameters bound encounters and appointments.
+func (h *FhirHandler) Everything(c *gin.Context) {
+	businessID, ok := tenant(c)
+	if !ok {
+		return
+	}
+
+	patientID, err := idParam(c.Param("id"))
+	if err != nil || !patientID.Valid {
+		fail(c, http.StatusBadRequest, "Formato de ID inválido", err)
+		return
+	}
+
+	var dates []string
+	if start := c.Query("start"); start != "" {
+		dates = append(dates, "ge"+start)
+	}
+	if end := c.Query("end"); end != "" {
+		dates = append(dates, "le"+end)
+	}
+	from, to, err := dateRange(dates, h.loc)
+	if err != nil {
+		fail(c, http.StatusBadRequest, "Error de validación de datos", err)
+		return
+	}
+
+	// Clinical data follows the medical_history-all scope set by the route;
+	// appointments follow events-all.
+	owner := ctxkeys.OwnerScope(c)
+	appointmentOwner, err := middleware.OwnerScopeFor(c, h.repo.q, "events-all")
+	if err != nil {
+		fail(c, http.StatusInternalServerError, "Error al verificar permisos", err)
+		return
+	}
+
+	patient, found := h.findPatient(c, businessID, patientID)
+	if !found {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	conditions, err := h.repo.SearchConditions(ctx, sqlc.SearchFhirConditionsParams{
+		BusinessID: businessID,
+		PatientID:  patientID,
+		OwnerID:    owner,
+		QueryLimit: everythingLimit,
+	})
+	if err != nil {
+		fail(c, http.StatusInternalServerError, "Error al obtener los problemas de salud", err)
+		return
+	}
+
+	encounters, err := h.repo.SearchEncounters(ctx, sqlc.SearchFhirEncountersParams{
+		BusinessID: businessID,
+		PatientID:  patientID,
+		OwnerID:    owner,
+		DateFrom:   from,
+		DateTo:     to,
+		QueryLimit: everythingLimit,
+	})
+	if err != nil {
+		fail(c, http.StatusInternalServerError, "Error al obtener las historias médicas", err)
+		return
+	}
+
+	appointments, err := h.repo.SearchAppointments(ctx, sqlc.SearchFhirAppointmentsParams{
+		BusinessID:     businessID,
+		PatientID:      patientID,
+		ProfessionalID: appointmentOwner,
+		DateFrom:       from,
+		DateTo:         to,
+		QueryLimit:     everythingLimit,
+	})
+	if err != nil {
+		fail(c, http.StatusInternalServerError, "Error al obtener los turnos", err)
+		return
+	}
+
+	items := []bundleItem{{resourceType: "Patient", id: id(patient.ID), resource: toPatient(patient)}}
+
+	var practitioners []pgtype.UUID
+	seen := make(map[pgtype.UUID]bool)
+	involve := func(practitionerID pgtype.UUID) {
+		if practitionerID.Valid && !seen[practitionerID] {
+			seen[practitionerID] = true
+			practitioners = append(practitioners, practitionerID)
+		}
+	}
+
+	for _, pc := range conditions {
+		items = append(items, bundleItem{resourceType: "Condition", id: id(pc.ID), resource: toProblemCondition(pc)})
+	}
+	for _, mh := range encounters {
+		items = append(items,
+			bundleItem{resourceType: "Encounter", id: id(mh.ID), resource: toEncounter(mh)},
+			bundleItem{resourceType: "Condition", id: id(mh.ID), resource: toDiagnosisCondition(mh)},
+		)
+		involve(mh.ProfessionalID)
+	}
+	for _, e := range appointments {
+		items = append(items, bundleItem{resourceType: "Appointment", id: id(e.ID), resource: toAppointment(e)})
+		involve(e.ProfessionalID)
+	}
+
+	for _, practitionerID := range practitioners {
+		rows, err := h.repo.SearchPractitioners(ctx, sqlc.SearchFhirPractitionersParams{
+			BusinessID: businessID,
+			ID:         practitionerID,
+			QueryLimit: 1,
+		})
+		if err != nil {
+			fail(c, http.StatusInternalServerError, "Error al obtener los profesionales", err)
+			return
+		}
+		for _, p := range rows {
+			items = append(items, bundleItem{resourceType: "Practitioner", id: id(p.ID), resource: toPractitioner(p)})
+		}
+	}
+
+	writeBundle(c, items)
+}
+
+// findPatient loads a patient of the business, answering 404 when it does not exist.
+func (h *FhirHandler) findPatient(c *gin.Context, businessID, patientID pgtype.UUID) (sqlc.SearchFhirPatientsRow, bool) {
+	patients, err := h.repo.SearchPatients(c.Request.Context(), sqlc.SearchFhirPatientsParams{
+		BusinessID: businessID,
+		ID:         patientID,
+		QueryLimit: 1,
+	})
+	if err != nil {
+		fail(c, http.StatusInternalServerError, "Error al obtener el paciente", err)
+		return sqlc.SearchFhirPatientsRow{}, false
+	}
+	if len(patients) == 0 {
+		fail(c, http.StatusNotFound, "Paciente no encontrado")
+		return sqlc.SearchFhirPatientsRow{}, false
+	}
+
+	return patients[0], true
+}
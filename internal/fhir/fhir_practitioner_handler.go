@@ -0,0 +1,74 @@
+package fhir
+
+import (
+	"net/http"
+
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (h *FhirHandler) ReadPractitioner(c *gin.Context) {
+	businessID, ok := tenant(c)
+	if !ok {
+		return
+	}
+
+	practitionerID, err := idParam(c.Param("id"))
+	if err != nil || !practitionerID.Valid {
+		fail(c, http.StatusBadRequest, "Formato de ID inválido", err)
+		return
+	}
+
+	practitioners, err := h.repo.SearchPractitioners(c.Request.Context(), sqlc.SearchFhirPractitionersParams{
+		BusinessID: businessID,
+		ID:         practitionerID,
+		QueryLimit: 1,
+	})
+	if err != nil {
+		fail(c, http.StatusInternalServerError, "Error al obtener el profesional", err)
+		return
+	}
+	if len(practitioners) == 0 {
+		fail(c, http.StatusNotFound, "Profesional no encontrado")
+		return
+	}
+
+	write(c, http.StatusOK, toPractitioner(practitioners[0]))
+}
+
+func (h *FhirHandler) SearchPractitioners(c *gin.Context) {
+	businessID, ok := tenant(c)
+	if !ok {
+		return
+	}
+
+	params := sqlc.SearchFhirPractitionersParams{
+		BusinessID: businessID,
+		Identifier: tokenParam(c.Query("identifier")),
+		Name:       pgtype.Text{String: c.Query("name"), Valid: c.Query("name") != ""},
+	}
+
+	var err error
+	if params.QueryLimit, err = countParam(c.Query("_count")); err != nil {
+		fail(c, http.StatusBadRequest, "Error de validación de datos", err)
+		return
+	}
+	if params.ID, err = idParam(c.Query("_id")); err != nil {
+		fail(c, http.StatusBadRequest, "Error de validación de datos", err)
+		return
+	}
+
+	practitioners, err := h.repo.SearchPractitioners(c.Request.Context(), params)
+	if err != nil {
+		fail(c, http.StatusInternalServerError, "Error al buscar profesionales", err)
+		return
+	}
+
+	items := make([]bundleItem, len(practitioners))
+	for i, p := range practitioners {
+		items[i] = bundleItem{resourceType: "Practitioner", id: id(p.ID), resource: toPractitioner(p)}
+	}
+
+	writeBundle(c, items)
+}
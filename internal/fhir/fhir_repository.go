@@ -0,0 +1,78 @@
+package fhir
+
+import (
+	"context"
+
+	"github.com/alanloffler/go-calth-api/internal/common/encryption"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// FhirRepository reads the rows behind the FHIR resources and decrypts the
+// clinical fields that are stored encrypted.
+type FhirRepository struct {
+	q    *sqlc.Queries
+	keys *encryption.Keyring
+}
+
+func NewFhirRepository(q *sqlc.Queries, keys *encryption.Keyring) *FhirRepository {
+	return &FhirRepository{q: q, keys: keys}
+}
+
+func (r *FhirRepository) SearchPatients(ctx context.Context, arg sqlc.SearchFhirPatientsParams) ([]sqlc.SearchFhirPatientsRow, error) {
+	rows, err := r.q.SearchFhirPatients(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range rows {
+		if rows[i].EmergencyContactName, err = r.decryptText(ctx, arg.BusinessID, rows[i].EmergencyContactName); err != nil {
+			return nil, err
+		}
+		if rows[i].EmergencyContactPhone, err = r.decryptText(ctx, arg.BusinessID, rows[i].EmergencyContactPhone); err != nil {
+			return nil, err
+		}
+	}
+
+	return rows, nil
+}
+
+func (r *FhirRepository) SearchPractitioners(ctx context.Context, arg sqlc.SearchFhirPractitionersParams) ([]sqlc.SearchFhirPractitionersRow, error) {
+	return r.q.SearchFhirPractitioners(ctx, arg)
+}
+
+func (r *FhirRepository) SearchAppointments(ctx context.Context, arg sqlc.SearchFhirAppointmentsParams) ([]sqlc.SearchFhirAppointmentsRow, error) {
+	return r.q.SearchFhirAppointments(ctx, arg)
+}
+
+func (r *FhirRepository) SearchEncounters(ctx context.Context, arg sqlc.SearchFhirEncountersParams) ([]sqlc.SearchFhirEncountersRow, error) {
+	rows, err := r.q.SearchFhirEncounters(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range rows {
+		if rows[i].Reason, err = r.keys.Decrypt(ctx, rows[i].BusinessID, rows[i].Reason); err != nil {
+			return nil, err
+		}
+	}
+
+	return rows, nil
+}
+
+func (r *FhirRepository) SearchConditions(ctx context.Context, arg sqlc.SearchFhirConditionsParams) ([]sqlc.PatientCondition, error) {
+	return r.q.SearchFhirConditions(ctx, arg)
+}
+
+func (r *FhirRepository) decryptText(ctx context.Context, businessID pgtype.UUID, value pgtype.Text) (pgtype.Text, error) {
+	if !value.Valid {
+		return value, nil
+	}
+
+	plaintext, err := r.keys.Decrypt(ctx, businessID, value.String)
+	if err != nil {
+		return pgtype.Text{}, err
+	}
+
+	return pgtype.Text{String: plaintext, Valid: true}, nil
+}
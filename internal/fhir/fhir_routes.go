@@ -0,0 +1,40 @@
+package fhir
+
+import (
+	"context"
+
+	"github.com/alanloffler/go-calth-api/internal/common/encryption"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/alanloffler/go-calth-api/internal/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func RegisterRoutes(router *gin.RouterGroup, q *sqlc.Queries, keys *encryption.Keyring) {
+	var repo *FhirRepository = NewFhirRepository(q, keys)
+	var handler *FhirHandler = NewFhirHandler(repo)
+	var fhir *gin.RouterGroup = router.Group("/fhir/R4")
+	var historyPatient middleware.PatientLookup = func(ctx context.Context, businessID, id pgtype.UUID) (pgtype.UUID, error) {
+		return q.GetMedicalHistoryPatientID(ctx, sqlc.GetMedicalHistoryPatientIDParams{BusinessID: businessID, ID: id})
+	}
+
+	fhir.Use(middleware.PermissionMiddleware(q, "fhir-read"))
+
+	fhir.GET("/metadata", handler.Metadata)
+
+	fhir.GET("/Patient", handler.SearchPatients)
+	fhir.GET("/Patient/:id", middleware.AuditMiddleware(q, "fhir_patient", "id", nil), handler.ReadPatient)
+	fhir.GET("/Patient/:id/$everything", middleware.PermissionMiddleware(q, "medical_history-view"), middleware.PermissionMiddleware(q, "events-view"), middleware.OwnershipMiddleware(q, "medical_history-all"), middleware.AuditMiddleware(q, "fhir_patient_everything", "id", nil), handler.Everything)
+
+	fhir.GET("/Practitioner", handler.SearchPractitioners)
+	fhir.GET("/Practitioner/:id", handler.ReadPractitioner)
+
+	fhir.GET("/Appointment", middleware.PermissionMiddleware(q, "events-view"), middleware.OwnershipMiddleware(q, "events-all"), handler.SearchAppointments)
+	fhir.GET("/Appointment/:id", middleware.PermissionMiddleware(q, "events-view"), middleware.OwnershipMiddleware(q, "events-all"), handler.ReadAppointment)
+
+	fhir.GET("/Encounter", middleware.PermissionMiddleware(q, "medical_history-view"), middleware.OwnershipMiddleware(q, "medical_history-all"), handler.SearchEncounters)
+	fhir.GET("/Encounter/:id", middleware.PermissionMiddleware(q, "medical_history-view"), middleware.OwnershipMiddleware(q, "medical_history-all"), middleware.AuditMiddleware(q, "fhir_encounter", "id", historyPatient), handler.ReadEncounter)
+
+	fhir.GET("/Condition", middleware.PermissionMiddleware(q, "medical_history-view"), middleware.OwnershipMiddleware(q, "medical_history-all"), handler.SearchConditions)
+	fhir.GET("/Condition/:id", middleware.PermissionMiddleware(q, "medical_history-view"), middleware.OwnershipMiddleware(q, "medical_history-all"), handler.ReadCondition)
+}
@@ -0,0 +1,198 @@
+package invitation
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alanloffler/go-calth-api/internal/common/response"
+	"github.com/alanloffler/go-calth-api/internal/common/token"
+	"github.com/alanloffler/go-calth-api/internal/common/utils"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/alanloffler/go-calth-api/internal/user"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type LookupRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type AcceptRequest struct {
+	Token   string                              `json:"token" binding:"required"`
+	User    AcceptUserData                      `json:"user" binding:"required"`
+	Profile *user.CreateProfessionalProfileData `json:"profile"`
+}
+
+// AcceptUserData is the account data the invitee completes. The email comes
+// from the invitation.
+type AcceptUserData struct {
+	Ic          string `json:"ic" binding:"required,len=8"`
+	UserName    string `json:"userName" binding:"required,min=3,max=100"`
+	FirstName   string `json:"firstName" binding:"required,min=3,max=100"`
+	LastName    string `json:"lastName" binding:"required,min=3,max=100"`
+	Password    string `json:"password" binding:"required,min=8,max=100"`
+	PhoneNumber string `json:"phoneNumber" binding:"required,len=10,numeric"`
+}
+
+type LookupResponse struct {
+	Email        string `json:"email"`
+	BusinessName string `json:"businessName"`
+	RoleName     string `json:"roleName"`
+	RoleValue    string `json:"roleValue"`
+	ExpiresAt    string `json:"expiresAt"`
+	// RequiresProfile tells the invitee must also send the professional profile.
+	RequiresProfile bool `json:"requiresProfile"`
+}
+
+type AcceptResponse struct {
+	ID        string `json:"id"`
+	Email     string `json:"email"`
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+}
+
+// Lookup shows the invitee what they were invited to before accepting.
+func (h *InvitationHandler) Lookup(c *gin.Context) {
+	var req LookupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Error de validación de datos", err))
+		return
+	}
+
+	inv, err := h.repo.GetByTokenHash(c.Request.Context(), token.Hash(req.Token))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Invitación inválida o expirada"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener la invitación", err))
+		return
+	}
+
+	result := LookupResponse{
+		Email:           inv.Email,
+		BusinessName:    inv.BusinessName,
+		RoleName:        inv.RoleName,
+		RoleValue:       inv.RoleValue,
+		ExpiresAt:       inv.ExpiresAt.Time.Format(time.RFC3339),
+		RequiresProfile: inv.RoleValue == roleProfessional,
+	}
+
+	c.JSON(http.StatusOK, response.Success("Invitación encontrada", &result))
+}
+
+// Accept creates the invitee's account with the password they chose, plus the
+// professional profile when invited as a professional, and closes the
+// invitation.
+func (h *InvitationHandler) Accept(c *gin.Context) {
+	var req AcceptRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Error de validación de datos", err))
+		return
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.User.Password), bcrypt.DefaultCost)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al procesar contraseña", err))
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	tx, err := h.pool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al iniciar transacción", err))
+		return
+	}
+	defer tx.Rollback(ctx)
+
+	rtx := h.repo.WithTx(tx)
+
+	inv, err := rtx.GetByTokenHash(ctx, token.Hash(req.Token))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Invitación inválida o expirada"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener la invitación", err))
+		return
+	}
+
+	if inv.RoleValue == roleProfessional && req.Profile == nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Perfil profesional requerido"))
+		return
+	}
+
+	created, err := rtx.CreateUser(ctx, sqlc.CreateUserParams{
+		Ic:          req.User.Ic,
+		UserName:    req.User.UserName,
+		FirstName:   req.User.FirstName,
+		LastName:    req.User.LastName,
+		Email:       inv.Email,
+		Password:    string(hashedPassword),
+		PhoneNumber: req.User.PhoneNumber,
+		RoleID:      inv.RoleID,
+		BusinessID:  inv.BusinessID,
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			c.JSON(http.StatusConflict, response.Error(http.StatusConflict, "Los datos ya están registrados"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al crear usuario", err))
+		return
+	}
+
+	// The invitation link reached this inbox, which proves the email.
+	if _, err := rtx.MarkEmailVerified(ctx, sqlc.MarkEmailVerifiedParams{BusinessID: inv.BusinessID, ID: created.ID}); err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al verificar el email", err))
+		return
+	}
+
+	if inv.RoleValue == roleProfessional {
+		days := make([]string, len(req.Profile.WorkingDays))
+		for i, d := range req.Profile.WorkingDays {
+			days[i] = strconv.Itoa(d)
+		}
+
+		if _, err := rtx.CreateProfessionalProfile(ctx, sqlc.CreateProfessionalProfileParams{
+			BusinessID:          inv.BusinessID,
+			UserID:              created.ID,
+			LicenseID:           req.Profile.LicenseID,
+			ProfessionalPrefix:  req.Profile.ProfessionalPrefix,
+			Specialty:           req.Profile.Specialty,
+			WorkingDays:         strings.Join(days, ","),
+			StartHour:           req.Profile.StartHour,
+			EndHour:             req.Profile.EndHour,
+			SlotDuration:        req.Profile.SlotDuration,
+			DailyExceptionStart: utils.ToPgText(req.Profile.DailyExceptionStart),
+			DailyExceptionEnd:   utils.ToPgText(req.Profile.DailyExceptionEnd),
+		}); err != nil {
+			c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al crear perfil de profesional", err))
+			return
+		}
+	}
+
+	if _, err := rtx.Accept(ctx, sqlc.AcceptInvitationParams{AcceptedUserID: created.ID, ID: inv.ID}); err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al aceptar la invitación", err))
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al confirmar transacción", err))
+		return
+	}
+
+	result := AcceptResponse{
+		ID:        uuid.UUID(created.ID.Bytes).String(),
+		Email:     created.Email,
+		FirstName: created.FirstName,
+		LastName:  created.LastName,
+	}
+	c.JSON(http.StatusCreated, response.Created("Invitación aceptada", &result))
+}
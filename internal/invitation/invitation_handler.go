@@ -0,0 +1,404 @@
+package invitation
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/alanloffler/go-calth-api/internal/common/ctxkeys"
+	"github.com/alanloffler/go-calth-api/internal/common/response"
+	"github.com/alanloffler/go-calth-api/internal/common/token"
+	"github.com/alanloffler/go-calth-api/internal/common/utils"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/alanloffler/go-calth-api/internal/middleware"
+	"github.com/alanloffler/go-calth-api/internal/queue"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+)
+
+const (
+	roleAdmin        = "admin"
+	roleProfessional = "professional"
+)
+
+// createPermissions maps each role that can be invited to the permission
+// needed to invite, resend or revoke it.
+var createPermissions = map[string]string{
+	roleAdmin:        "users-admin-create",
+	roleProfessional: "professionals-create",
+}
+
+var statuses = map[string]bool{"pending": true, "accepted": true, "revoked": true, "expired": true}
+
+const invitationKey = "invitation"
+
+type InvitationHandler struct {
+	repo        *InvitationRepository
+	pool        *pgxpool.Pool
+	queueClient *asynq.Client
+	appDomain   string
+	ttl         time.Duration
+}
+
+type CreateInvitationRequest struct {
+	Email string `json:"email" binding:"required,email,max=100"`
+}
+
+type RoleResponse struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type InvitationResponse struct {
+	ID             string       `json:"id"`
+	Email          string       `json:"email"`
+	Role           RoleResponse `json:"role"`
+	InvitedBy      *string      `json:"invitedBy"`
+	Status         string       `json:"status"`
+	ExpiresAt      string       `json:"expiresAt"`
+	AcceptedUserID *string      `json:"acceptedUserId"`
+	AcceptedAt     *string      `json:"acceptedAt"`
+	RevokedAt      *string      `json:"revokedAt"`
+	CreatedAt      string       `json:"createdAt"`
+}
+
+func NewInvitationHandler(repo *InvitationRepository, pool *pgxpool.Pool, queueClient *asynq.Client, appDomain string, ttl time.Duration) *InvitationHandler {
+	return &InvitationHandler{repo: repo, pool: pool, queueClient: queueClient, appDomain: appDomain, ttl: ttl}
+}
+
+// Create invites email to join the business with roleValue. The invitee gets
+// a link to set their own password and complete their data.
+func (h *InvitationHandler) Create(roleValue string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		businessID, ok := ctxkeys.BusinessID(c)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+			return
+		}
+
+		userID, ok := ctxkeys.UserID(c)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+			return
+		}
+
+		var req CreateInvitationRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Error de validación de datos", err))
+			return
+		}
+
+		ctx := c.Request.Context()
+
+		if _, err := h.repo.GetUserByEmail(ctx, sqlc.GetUserByEmailParams{BusinessID: businessID, Email: req.Email}); err == nil {
+			c.JSON(http.StatusConflict, response.Error(http.StatusConflict, "El email ya está registrado"))
+			return
+		} else if !errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al buscar usuario", err))
+			return
+		}
+
+		role, err := h.repo.GetRoleByValue(ctx, roleValue)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Rol no encontrado", err))
+			return
+		}
+
+		if err := h.repo.Expire(ctx, sqlc.ExpireInvitationsParams{BusinessID: businessID, Email: req.Email}); err != nil {
+			c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al crear la invitación", err))
+			return
+		}
+
+		inviteToken, hash, err := token.Generate()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al crear la invitación", err))
+			return
+		}
+
+		inv, err := h.repo.Create(ctx, sqlc.CreateInvitationParams{
+			BusinessID: businessID,
+			Email:      req.Email,
+			RoleID:     role.ID,
+			InvitedBy:  userID,
+			TokenHash:  hash,
+			ExpiresAt:  pgtype.Timestamptz{Time: time.Now().Add(h.ttl), Valid: true},
+		})
+		if err != nil {
+			if isUniqueViolation(err) {
+				c.JSON(http.StatusConflict, response.Error(http.StatusConflict, "Ya existe una invitación pendiente para este email"))
+				return
+			}
+			c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al crear la invitación", err))
+			return
+		}
+
+		h.send(c, businessID, userID, inv, role.Name, inviteToken)
+
+		view, err := h.repo.GetByID(ctx, sqlc.GetInvitationParams{BusinessID: businessID, ID: inv.ID})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener la invitación", err))
+			return
+		}
+
+		result := toInvitationResponse(view)
+		c.JSON(http.StatusCreated, response.Created("Invitación enviada", &result))
+	}
+}
+
+// GetAll lists the business invitations, newest first, optionally filtered by
+// status.
+func (h *InvitationHandler) GetAll(c *gin.Context) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	params := sqlc.ListInvitationsParams{BusinessID: businessID, QueryLimit: defaultListLimit}
+
+	if status := c.Query("status"); status != "" {
+		if !statuses[status] {
+			c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Estado inválido"))
+			return
+		}
+		params.Status = pgtype.Text{String: status, Valid: true}
+	}
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		parsedLimit, err := strconv.ParseInt(limitStr, 10, 32)
+		if err != nil || parsedLimit < 1 || parsedLimit > maxListLimit {
+			c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Límite inválido", err))
+			return
+		}
+		params.QueryLimit = int32(parsedLimit)
+	}
+
+	invitations, err := h.repo.GetAll(c.Request.Context(), params)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener las invitaciones", err))
+		return
+	}
+
+	result := make([]InvitationResponse, len(invitations))
+	for i, inv := range invitations {
+		result[i] = toInvitationResponse(sqlc.GetInvitationRow(inv))
+	}
+
+	c.JSON(http.StatusOK, response.Success("Invitaciones encontradas", &result))
+}
+
+// Resend issues a new link, invalidating the previous one, and restarts the
+// expiration. Expired invitations become pending again.
+func (h *InvitationHandler) Resend(c *gin.Context) {
+	businessID, _ := ctxkeys.BusinessID(c)
+	userID, _ := ctxkeys.UserID(c)
+	current := c.MustGet(invitationKey).(sqlc.GetInvitationRow)
+
+	if current.Status != "pending" && current.Status != "expired" {
+		c.JSON(http.StatusConflict, response.Error(http.StatusConflict, "La invitación ya no está pendiente"))
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	inviteToken, hash, err := token.Generate()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al reenviar la invitación", err))
+		return
+	}
+
+	inv, err := h.repo.Renew(ctx, sqlc.RenewInvitationParams{
+		TokenHash:  hash,
+		ExpiresAt:  pgtype.Timestamptz{Time: time.Now().Add(h.ttl), Valid: true},
+		BusinessID: businessID,
+		ID:         current.ID,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			c.JSON(http.StatusConflict, response.Error(http.StatusConflict, "La invitación ya no está pendiente"))
+		case isUniqueViolation(err):
+			c.JSON(http.StatusConflict, response.Error(http.StatusConflict, "Ya existe una invitación pendiente para este email"))
+		default:
+			c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al reenviar la invitación", err))
+		}
+		return
+	}
+
+	h.send(c, businessID, userID, inv, current.RoleName, inviteToken)
+
+	view, err := h.repo.GetByID(ctx, sqlc.GetInvitationParams{BusinessID: businessID, ID: inv.ID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener la invitación", err))
+		return
+	}
+
+	result := toInvitationResponse(view)
+	c.JSON(http.StatusOK, response.Success("Invitación reenviada", &result))
+}
+
+func (h *InvitationHandler) Revoke(c *gin.Context) {
+	businessID, _ := ctxkeys.BusinessID(c)
+	current := c.MustGet(invitationKey).(sqlc.GetInvitationRow)
+
+	rows, err := h.repo.Revoke(c.Request.Context(), sqlc.RevokeInvitationParams{BusinessID: businessID, ID: current.ID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al revocar la invitación", err))
+		return
+	}
+	if rows == 0 {
+		c.JSON(http.StatusConflict, response.Error(http.StatusConflict, "La invitación ya no está pendiente"))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Success[any]("Invitación revocada", nil))
+}
+
+// RequireAccess loads the :id invitation and checks the caller may manage
+// users of its role, the same way inviting them is checked.
+func (h *InvitationHandler) RequireAccess(c *gin.Context) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	roleID, ok := ctxkeys.RoleID(c)
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(c.Param("id")); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID inválido", err))
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	inv, err := h.repo.GetByID(ctx, sqlc.GetInvitationParams{BusinessID: businessID, ID: id})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.AbortWithStatusJSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Invitación no encontrada"))
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener la invitación", err))
+		return
+	}
+
+	permission, ok := createPermissions[inv.RoleValue]
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Invitación no encontrada"))
+		return
+	}
+
+	has, err := middleware.HasPermission(c, h.repo.q, businessID, roleID, permission)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al verificar permisos", err))
+		return
+	}
+	if !has {
+		c.AbortWithStatusJSON(http.StatusForbidden, response.Error(http.StatusForbidden, "Permisos insuficientes"))
+		return
+	}
+
+	if !ctxkeys.IsSuperAdmin(c) {
+		exceeds, err := middleware.RoleExceeds(ctx, h.repo.q, businessID, inv.RoleID, roleID)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al verificar permisos", err))
+			return
+		}
+		if exceeds {
+			c.AbortWithStatusJSON(http.StatusForbidden, response.Error(http.StatusForbidden, "No puede gestionar usuarios con más permisos que los propios"))
+			return
+		}
+	}
+
+	c.Set(invitationKey, inv)
+	c.Next()
+}
+
+// send enqueues the invitation email. Failures are logged: the invitation
+// exists and can be resent.
+func (h *InvitationHandler) send(c *gin.Context, businessID, inviterID pgtype.UUID, inv sqlc.Invitation, roleName, inviteToken string) {
+	ctx := c.Request.Context()
+
+	business, err := h.repo.GetBusiness(ctx, businessID)
+	if err != nil {
+		log.Printf("failed to load business for invitation email: %v", err)
+		return
+	}
+
+	var inviterName string
+	if inviter, err := h.repo.GetUserByID(ctx, sqlc.GetUserByIDParams{BusinessID: businessID, ID: inviterID}); err == nil {
+		inviterName = inviter.FirstName + " " + inviter.LastName
+	} else {
+		inviterName = business.TradeName
+	}
+
+	if err := queue.EnqueueInvitation(h.queueClient, queue.InvitationPayload{
+		Email:       inv.Email,
+		CompanyName: business.TradeName,
+		InviterName: inviterName,
+		RoleName:    roleName,
+		InviteLink:  "https://" + business.Slug + "." + h.appDomain + "/invitation?token=" + url.QueryEscape(inviteToken),
+		ExpiresIn:   utils.FormatDuration(h.ttl),
+	}); err != nil {
+		log.Printf("failed to enqueue invitation email: %v", err)
+	}
+}
+
+func toInvitationResponse(inv sqlc.GetInvitationRow) InvitationResponse {
+	return InvitationResponse{
+		ID:    uuid.UUID(inv.ID.Bytes).String(),
+		Email: inv.Email,
+		Role: RoleResponse{
+			ID:    uuid.UUID(inv.RoleID.Bytes).String(),
+			Name:  inv.RoleName,
+			Value: inv.RoleValue,
+		},
+		InvitedBy:      optionalID(inv.InvitedBy),
+		Status:         inv.Status,
+		ExpiresAt:      inv.ExpiresAt.Time.Format(time.RFC3339),
+		AcceptedUserID: optionalID(inv.AcceptedUserID),
+		AcceptedAt:     optionalTime(inv.AcceptedAt),
+		RevokedAt:      optionalTime(inv.RevokedAt),
+		CreatedAt:      inv.CreatedAt.Time.Format(time.RFC3339),
+	}
+}
+
+func optionalID(id pgtype.UUID) *string {
+	if !id.Valid {
+		return nil
+	}
+	s := uuid.UUID(id.Bytes).String()
+	return &s
+}
+
+func optionalTime(t pgtype.Timestamptz) *string {
+	if !t.Valid {
+		return nil
+	}
+	s := t.Time.Format(time.RFC3339)
+	return &s
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
@@ -0,0 +1,93 @@
+package invitation
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alanloffler/go-calth-api/internal/common/response"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupTestRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	return gin.New()
+}
+
+func withBusiness(c *gin.Context) {
+	c.Set("businessID", "7f1c2a4e-3b5d-4c6e-8f90-1a2b3c4d5e6f")
+	c.Set("userID", "0e9d8c7b-6a5f-4e3d-2c1b-0a9f8e7d6c5b")
+}
+
+func TestInvitationHandler_GetAll_InvalidStatus(t *testing.T) {
+	router := setupTestRouter()
+	handler := &InvitationHandler{}
+	router.GET("/invitations", withBusiness, handler.GetAll)
+
+	req, _ := http.NewRequest("GET", "/invitations?status=unknown", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var resp response.ApiResponse[any]
+	json.Unmarshal(w.Body.Bytes(), &resp)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "Estado inválido", resp.Message)
+}
+
+func TestInvitationHandler_Create_ValidationError(t *testing.T) {
+	router := setupTestRouter()
+	handler := &InvitationHandler{}
+	router.POST("/invitations/admin", withBusiness, handler.Create(roleAdmin))
+
+	req, _ := http.NewRequest("POST", "/invitations/admin", bytes.NewBufferString(`{"email":"not-an-email"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestInvitationHandler_Accept_ValidationError(t *testing.T) {
+	router := setupTestRouter()
+	handler := &InvitationHandler{}
+	router.POST("/invitations/accept", handler.Accept)
+
+	body := `{"token":"abc","user":{"ic":"123","userName":"jp","firstName":"Juan","lastName":"Pérez","password":"short","phoneNumber":"12"}}`
+	req, _ := http.NewRequest("POST", "/invitations/accept", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var resp response.ApiResponse[any]
+	json.Unmarshal(w.Body.Bytes(), &resp)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "Error de validación de datos", resp.Message)
+}
+
+func TestToInvitationResponse(t *testing.T) {
+	expires := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	result := toInvitationResponse(sqlc.GetInvitationRow{
+		ID:        pgtype.UUID{Bytes: [16]byte{1}, Valid: true},
+		Email:     "ana@mail.com",
+		RoleID:    pgtype.UUID{Bytes: [16]byte{2}, Valid: true},
+		RoleName:  "Profesional",
+		RoleValue: roleProfessional,
+		Status:    "expired",
+		ExpiresAt: pgtype.Timestamptz{Time: expires, Valid: true},
+	})
+
+	assert.Equal(t, "expired", result.Status)
+	assert.Equal(t, roleProfessional, result.Role.Value)
+	assert.Equal(t, "2025-03-10T12:00:00Z", result.ExpiresAt)
+	assert.Nil(t, result.InvitedBy)
+	assert.Nil(t, result.AcceptedAt)
+}
@@ -0,0 +1,81 @@
+package invitation
+
+import (
+	"context"
+
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type InvitationRepository struct {
+	q *sqlc.Queries
+}
+
+func NewInvitationRepository(q *sqlc.Queries) *InvitationRepository {
+	return &InvitationRepository{q: q}
+}
+
+func (r *InvitationRepository) WithTx(tx pgx.Tx) *InvitationRepository {
+	return &InvitationRepository{q: r.q.WithTx(tx)}
+}
+
+func (r *InvitationRepository) Create(ctx context.Context, arg sqlc.CreateInvitationParams) (sqlc.Invitation, error) {
+	return r.q.CreateInvitation(ctx, arg)
+}
+
+func (r *InvitationRepository) Expire(ctx context.Context, arg sqlc.ExpireInvitationsParams) error {
+	return r.q.ExpireInvitations(ctx, arg)
+}
+
+func (r *InvitationRepository) GetByID(ctx context.Context, arg sqlc.GetInvitationParams) (sqlc.GetInvitationRow, error) {
+	return r.q.GetInvitation(ctx, arg)
+}
+
+func (r *InvitationRepository) GetAll(ctx context.Context, arg sqlc.ListInvitationsParams) ([]sqlc.ListInvitationsRow, error) {
+	return r.q.ListInvitations(ctx, arg)
+}
+
+func (r *InvitationRepository) Renew(ctx context.Context, arg sqlc.RenewInvitationParams) (sqlc.Invitation, error) {
+	return r.q.RenewInvitation(ctx, arg)
+}
+
+func (r *InvitationRepository) Revoke(ctx context.Context, arg sqlc.RevokeInvitationParams) (int64, error) {
+	return r.q.RevokeInvitation(ctx, arg)
+}
+
+func (r *InvitationRepository) GetByTokenHash(ctx context.Context, tokenHash string) (sqlc.GetInvitationByTokenHashRow, error) {
+	return r.q.GetInvitationByTokenHash(ctx, tokenHash)
+}
+
+func (r *InvitationRepository) Accept(ctx context.Context, arg sqlc.AcceptInvitationParams) (int64, error) {
+	return r.q.AcceptInvitation(ctx, arg)
+}
+
+func (r *InvitationRepository) GetRoleByValue(ctx context.Context, value string) (sqlc.Role, error) {
+	return r.q.GetRoleByValue(ctx, value)
+}
+
+func (r *InvitationRepository) GetBusiness(ctx context.Context, id pgtype.UUID) (sqlc.Business, error) {
+	return r.q.GetBusiness(ctx, id)
+}
+
+func (r *InvitationRepository) GetUserByID(ctx context.Context, arg sqlc.GetUserByIDParams) (sqlc.GetUserByIDRow, error) {
+	return r.q.GetUserByID(ctx, arg)
+}
+
+func (r *InvitationRepository) GetUserByEmail(ctx context.Context, arg sqlc.GetUserByEmailParams) (sqlc.User, error) {
+	return r.q.GetUserByEmail(ctx, arg)
+}
+
+func (r *InvitationRepository) CreateUser(ctx context.Context, arg sqlc.CreateUserParams) (sqlc.User, error) {
+	return r.q.CreateUser(ctx, arg)
+}
+
+func (r *InvitationRepository) MarkEmailVerified(ctx context.Context, arg sqlc.MarkEmailVerifiedParams) (int64, error) {
+	return r.q.MarkEmailVerified(ctx, arg)
+}
+
+func (r *InvitationRepository) CreateProfessionalProfile(ctx context.Context, arg sqlc.CreateProfessionalProfileParams) (sqlc.ProfessionalProfile, error) {
+	return r.q.CreateProfessionalProfile(ctx, arg)
+}
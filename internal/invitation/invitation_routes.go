@@ -0,0 +1,31 @@
+package invitation
+
+import (
+	"github.com/alanloffler/go-calth-api/internal/config"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/alanloffler/go-calth-api/internal/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func RegisterRoutes(router *gin.Engine, protected *gin.RouterGroup, q *sqlc.Queries, pool *pgxpool.Pool, queueClient *asynq.Client, cfg *config.Config) {
+	var repo *InvitationRepository = NewInvitationRepository(q)
+	var handler *InvitationHandler = NewInvitationHandler(repo, pool, queueClient, cfg.AppDomain, cfg.InvitationTTL)
+	var invitations *gin.RouterGroup = protected.Group("/invitations")
+
+	var managePermissions []string = []string{createPermissions[roleAdmin], createPermissions[roleProfessional]}
+
+	public := router.Group("/invitations")
+
+	public.POST("/lookup", handler.Lookup)
+	public.POST("/accept", handler.Accept)
+
+	invitations.POST("/admin", middleware.PermissionMiddleware(q, createPermissions[roleAdmin]), middleware.EscalationMiddleware(q, roleAdmin), handler.Create(roleAdmin))
+	invitations.POST("/professional", middleware.PermissionMiddleware(q, createPermissions[roleProfessional]), middleware.EscalationMiddleware(q, roleProfessional), handler.Create(roleProfessional))
+	invitations.POST("/:id/resend", handler.RequireAccess, handler.Resend)
+
+	invitations.GET("", middleware.PermissionMiddleware(q, managePermissions, middleware.PermissionSome), handler.GetAll)
+
+	invitations.DELETE("/:id", handler.RequireAccess, handler.Revoke)
+}
@@ -0,0 +1,59 @@
+package medical_history
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/alanloffler/go-calth-api/internal/common/ctxkeys"
+	"github.com/alanloffler/go-calth-api/internal/common/response"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// accessRepository looks up how the caller relates to an entry.
+type accessRepository interface {
+	GetAccess(ctx context.Context, arg sqlc.GetMedicalHistoryAccessParams) (sqlc.GetMedicalHistoryAccessRow, error)
+}
+
+type accessLevel int
+
+const (
+	// accessRead covers reading an entry and appending addenda: allowed to its
+	// author and to professionals treating the patient.
+	accessRead accessLevel = iota
+	// accessWrite covers changing the entry itself: allowed to its author only.
+	accessWrite
+)
+
+// authorize applies the ownership policy to a single entry. It writes the error
+// response and returns false when the caller may not access it.
+func (h *MedicalHistoryHandler) authorize(c *gin.Context, businessID, id pgtype.UUID, level accessLevel) bool {
+	owner := ctxkeys.OwnerScope(c)
+	if !owner.Valid {
+		return true
+	}
+
+	access, err := h.access.GetAccess(c.Request.Context(), sqlc.GetMedicalHistoryAccessParams{
+		OwnerID:    owner,
+		BusinessID: businessID,
+		ID:         id,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Historia médica no encontrada"))
+			return false
+		}
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al verificar permisos", err))
+		return false
+	}
+
+	if !access.Authored && (level == accessWrite || !access.TreatsPatient) {
+		c.JSON(http.StatusForbidden, response.Error(http.StatusForbidden, "Permisos insuficientes"))
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,120 @@
+package medical_history
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alanloffler/go-calth-api/internal/common/response"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	testBusinessID     = "00000000-0000-0000-0000-000000000001"
+	testProfessionalID = "00000000-0000-0000-0000-000000000002"
+	testHistoryID      = "00000000-0000-0000-0000-000000000003"
+)
+
+type mockAccessRepository struct {
+	mock.Mock
+}
+
+func (m *mockAccessRepository) GetAccess(ctx context.Context, arg sqlc.GetMedicalHistoryAccessParams) (sqlc.GetMedicalHistoryAccessRow, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(sqlc.GetMedicalHistoryAccessRow), args.Error(1)
+}
+
+// setupAccessRouter serves GET (read) and PATCH (write) on one entry, with the
+// caller's owner scope already set when restricted is true.
+func setupAccessRouter(repo accessRepository, restricted bool) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("businessID", testBusinessID)
+		c.Set("userID", testProfessionalID)
+		if restricted {
+			c.Set("ownerScope", testProfessionalID)
+		}
+		c.Next()
+	})
+
+	handler := &MedicalHistoryHandler{access: repo}
+	serve := func(level accessLevel) gin.HandlerFunc {
+		return func(c *gin.Context) {
+			var businessID, id pgtype.UUID
+			_ = businessID.Scan(testBusinessID)
+			_ = id.Scan(c.Param("id"))
+			if handler.authorize(c, businessID, id, level) {
+				c.JSON(http.StatusOK, response.Success[any]("ok", nil))
+			}
+		}
+	}
+	router.GET("/medical-histories/:id", serve(accessRead))
+	router.PATCH("/medical-histories/:id", serve(accessWrite))
+	return router
+}
+
+func TestMedicalHistoryHandler_Authorize(t *testing.T) {
+	cases := []struct {
+		name       string
+		restricted bool
+		access     sqlc.GetMedicalHistoryAccessRow
+		err        error
+		method     string
+		wantStatus int
+	}{
+		{name: "medical_history-all reads", restricted: false, method: http.MethodGet, wantStatus: http.StatusOK},
+		{name: "medical_history-all writes", restricted: false, method: http.MethodPatch, wantStatus: http.StatusOK},
+		{name: "author reads", restricted: true, access: sqlc.GetMedicalHistoryAccessRow{Authored: true, TreatsPatient: true}, method: http.MethodGet, wantStatus: http.StatusOK},
+		{name: "author writes", restricted: true, access: sqlc.GetMedicalHistoryAccessRow{Authored: true, TreatsPatient: true}, method: http.MethodPatch, wantStatus: http.StatusOK},
+		{name: "treating professional reads", restricted: true, access: sqlc.GetMedicalHistoryAccessRow{TreatsPatient: true}, method: http.MethodGet, wantStatus: http.StatusOK},
+		{name: "treating professional writes", restricted: true, access: sqlc.GetMedicalHistoryAccessRow{TreatsPatient: true}, method: http.MethodPatch, wantStatus: http.StatusForbidden},
+		{name: "unrelated professional reads", restricted: true, method: http.MethodGet, wantStatus: http.StatusForbidden},
+		{name: "unrelated professional writes", restricted: true, method: http.MethodPatch, wantStatus: http.StatusForbidden},
+		{name: "missing entry", restricted: true, err: pgx.ErrNoRows, method: http.MethodGet, wantStatus: http.StatusNotFound},
+		{name: "lookup error", restricted: true, err: assert.AnError, method: http.MethodGet, wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := &mockAccessRepository{}
+			mockRepo.On("GetAccess", mock.Anything, mock.Anything).Return(tc.access, tc.err)
+
+			router := setupAccessRouter(mockRepo, tc.restricted)
+
+			req, _ := http.NewRequest(tc.method, "/medical-histories/"+testHistoryID, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			var resp response.ApiResponse[any]
+			json.Unmarshal(w.Body.Bytes(), &resp)
+
+			assert.Equal(t, tc.wantStatus, w.Code)
+			if tc.wantStatus == http.StatusForbidden {
+				assert.Equal(t, "Permisos insuficientes", resp.Message)
+			}
+
+			if !tc.restricted {
+				mockRepo.AssertNotCalled(t, "GetAccess", mock.Anything, mock.Anything)
+				return
+			}
+
+			var owner, businessID, id pgtype.UUID
+			_ = owner.Scan(testProfessionalID)
+			_ = businessID.Scan(testBusinessID)
+			_ = id.Scan(testHistoryID)
+			mockRepo.AssertCalled(t, "GetAccess", mock.Anything, sqlc.GetMedicalHistoryAccessParams{
+				OwnerID:    owner,
+				BusinessID: businessID,
+				ID:         id,
+			})
+		})
+	}
+}
@@ -0,0 +1,328 @@
+package medical_history
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/alanloffler/go-calth-api/internal/common/ctxkeys"
+	"github.com/alanloffler/go-calth-api/internal/common/response"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/alanloffler/go-calth-api/internal/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// allowedAttachmentTypes maps the sniffed MIME type to the extension used in the storage key.
+var allowedAttachmentTypes = map[string]string{
+	"application/pdf": ".pdf",
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/webp":      ".webp",
+}
+
+type AttachmentResponse struct {
+	ID               string `json:"id"`
+	MedicalHistoryID string `json:"medicalHistoryId"`
+	UploadedBy       string `json:"uploadedBy"`
+	FileName         string `json:"fileName"`
+	MimeType         string `json:"mimeType"`
+	SizeBytes        int64  `json:"sizeBytes"`
+	CreatedAt        string `json:"createdAt"`
+}
+
+func toAttachmentResponse(a sqlc.MedicalHistoryAttachment) AttachmentResponse {
+	return AttachmentResponse{
+		ID:               uuid.UUID(a.ID.Bytes).String(),
+		MedicalHistoryID: uuid.UUID(a.MedicalHistoryID.Bytes).String(),
+		UploadedBy:       uuid.UUID(a.UploadedBy.Bytes).String(),
+		FileName:         a.FileName,
+		MimeType:         a.MimeType,
+		SizeBytes:        a.SizeBytes,
+		CreatedAt:        a.CreatedAt.Time.Format(time.RFC3339),
+	}
+}
+
+func (h *MedicalHistoryHandler) UploadAttachment(c *gin.Context) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	userID, ok := ctxkeys.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(c.Param("id")); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID inválido", err))
+		return
+	}
+
+	if !h.authorize(c, businessID, id, accessWrite) {
+		return
+	}
+
+	exists, err := h.repo.Exists(c.Request.Context(), sqlc.MedicalHistoryExistsParams{BusinessID: businessID, ID: id})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al buscar la historia médica", err))
+		return
+	}
+	if !exists {
+		c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Historia médica no encontrada"))
+		return
+	}
+
+	// Leave some room for the multipart envelope on top of the file itself.
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxUploadSize+1<<20)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, response.Error(http.StatusRequestEntityTooLarge, "El archivo supera el tamaño máximo permitido"))
+			return
+		}
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Archivo requerido", err))
+		return
+	}
+
+	if fileHeader.Size > h.maxUploadSize {
+		c.JSON(http.StatusRequestEntityTooLarge, response.Error(http.StatusRequestEntityTooLarge, "El archivo supera el tamaño máximo permitido"))
+		return
+	}
+
+	if fileHeader.Size == 0 {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "El archivo está vacío"))
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Error al leer el archivo", err))
+		return
+	}
+	defer file.Close()
+
+	// Trust the content, not the client supplied Content-Type.
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Error al leer el archivo", err))
+		return
+	}
+	head = head[:n]
+
+	mimeType := http.DetectContentType(head)
+	ext, ok := allowedAttachmentTypes[mimeType]
+	if !ok {
+		c.JSON(http.StatusUnsupportedMediaType, response.Error(http.StatusUnsupportedMediaType, "Tipo de archivo no permitido"))
+		return
+	}
+
+	key := attachmentKey(businessID, id, uuid.NewString()+ext)
+
+	if err := h.storage.Put(c.Request.Context(), key, io.MultiReader(bytes.NewReader(head), file), fileHeader.Size, mimeType); err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al guardar el archivo", err))
+		return
+	}
+
+	attachment, err := h.repo.CreateAttachment(c.Request.Context(), sqlc.CreateMedicalHistoryAttachmentParams{
+		BusinessID:       businessID,
+		MedicalHistoryID: id,
+		UploadedBy:       userID,
+		FileName:         sanitizeFileName(fileHeader.Filename),
+		MimeType:         mimeType,
+		SizeBytes:        fileHeader.Size,
+		StorageKey:       key,
+	})
+	if err != nil {
+		if delErr := h.storage.Delete(c.Request.Context(), key); delErr != nil {
+			log.Printf("failed to clean up attachment %s: %v", key, delErr)
+		}
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al crear el adjunto", err))
+		return
+	}
+
+	result := toAttachmentResponse(attachment)
+	c.JSON(http.StatusCreated, response.Created("Adjunto creado", &result))
+}
+
+func (h *MedicalHistoryHandler) GetAttachments(c *gin.Context) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(c.Param("id")); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID inválido", err))
+		return
+	}
+
+	if !h.authorize(c, businessID, id, accessRead) {
+		return
+	}
+
+	attachments, err := h.repo.GetAttachments(c.Request.Context(), sqlc.GetMedicalHistoryAttachmentsParams{
+		BusinessID:       businessID,
+		MedicalHistoryID: id,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener los adjuntos", err))
+		return
+	}
+
+	result := make([]AttachmentResponse, len(attachments))
+	for i, a := range attachments {
+		result[i] = toAttachmentResponse(a)
+	}
+
+	c.JSON(http.StatusOK, response.Success("Adjuntos encontrados", &result))
+}
+
+func (h *MedicalHistoryHandler) DownloadAttachment(c *gin.Context) {
+	attachment, ok := h.findAttachment(c, accessRead)
+	if !ok {
+		return
+	}
+
+	body, err := h.storage.Get(c.Request.Context(), attachment.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Archivo no encontrado"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al leer el archivo", err))
+		return
+	}
+	defer body.Close()
+
+	c.DataFromReader(http.StatusOK, attachment.SizeBytes, attachment.MimeType, body, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}),
+		"X-Content-Type-Options": "nosniff",
+		"Cache-Control":          "private, no-store",
+	})
+}
+
+func (h *MedicalHistoryHandler) DeleteAttachment(c *gin.Context) {
+	attachment, ok := h.findAttachment(c, accessWrite)
+	if !ok {
+		return
+	}
+
+	rows, err := h.repo.DeleteAttachment(c.Request.Context(), sqlc.DeleteMedicalHistoryAttachmentParams{
+		BusinessID:       attachment.BusinessID,
+		MedicalHistoryID: attachment.MedicalHistoryID,
+		ID:               attachment.ID,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al eliminar el adjunto", err))
+		return
+	}
+	if rows == 0 {
+		c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Adjunto no encontrado"))
+		return
+	}
+
+	if err := h.storage.Delete(c.Request.Context(), attachment.StorageKey); err != nil {
+		log.Printf("failed to delete attachment file %s: %v", attachment.StorageKey, err)
+	}
+
+	c.JSON(http.StatusOK, response.Success[any]("Adjunto eliminado", nil))
+}
+
+func (h *MedicalHistoryHandler) findAttachment(c *gin.Context, level accessLevel) (sqlc.MedicalHistoryAttachment, bool) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return sqlc.MedicalHistoryAttachment{}, false
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(c.Param("id")); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID inválido", err))
+		return sqlc.MedicalHistoryAttachment{}, false
+	}
+
+	var attachmentID pgtype.UUID
+	if err := attachmentID.Scan(c.Param("attachmentId")); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID del adjunto inválido", err))
+		return sqlc.MedicalHistoryAttachment{}, false
+	}
+
+	if !h.authorize(c, businessID, id, level) {
+		return sqlc.MedicalHistoryAttachment{}, false
+	}
+
+	attachment, err := h.repo.GetAttachment(c.Request.Context(), sqlc.GetMedicalHistoryAttachmentParams{
+		BusinessID:       businessID,
+		MedicalHistoryID: id,
+		ID:               attachmentID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Adjunto no encontrado"))
+			return sqlc.MedicalHistoryAttachment{}, false
+		}
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener el adjunto", err))
+		return sqlc.MedicalHistoryAttachment{}, false
+	}
+
+	return attachment, true
+}
+
+// deleteStoredFiles removes the files of attachments whose rows are already gone.
+// Failures are only logged: the database is the source of truth.
+func (h *MedicalHistoryHandler) deleteStoredFiles(c *gin.Context, attachments []sqlc.MedicalHistoryAttachment) {
+	for _, a := range attachments {
+		if err := h.storage.Delete(c.Request.Context(), a.StorageKey); err != nil {
+			log.Printf("failed to delete attachment file %s: %v", a.StorageKey, err)
+		}
+	}
+}
+
+func attachmentKey(businessID, medicalHistoryID pgtype.UUID, name string) string {
+	return uuid.UUID(businessID.Bytes).String() + "/medical-histories/" + uuid.UUID(medicalHistoryID.Bytes).String() + "/" + name
+}
+
+// maxFileNameLength matches medical_history_attachments.file_name.
+const maxFileNameLength = 255
+
+func sanitizeFileName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f || r == '"' {
+			return -1
+		}
+		return r
+	}, name)
+
+	if name == "" || name == "." || name == "/" {
+		return "archivo"
+	}
+	if len(name) > maxFileNameLength {
+		// Keep the end, which holds the extension, without splitting a
+		// multi-byte character.
+		start := len(name) - maxFileNameLength
+		for start < len(name) && !utf8.RuneStart(name[start]) {
+			start++
+		}
+		name = name[start:]
+	}
+
+	return name
+}
@@ -0,0 +1,24 @@
+package medical_history
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitizeFileName(t *testing.T) {
+	assert.Equal(t, "informe.pdf", sanitizeFileName(`C:\docs\informe.pdf`))
+	assert.Equal(t, "archivo", sanitizeFileName("/"))
+	assert.Equal(t, "ab.pdf", sanitizeFileName("a\"b\x00.pdf"))
+}
+
+func TestSanitizeFileName_TruncatesOnRuneBoundary(t *testing.T) {
+	name := strings.Repeat("ñ", 200) + ".pdf"
+
+	got := sanitizeFileName(name)
+	assert.LessOrEqual(t, len(got), maxFileNameLength)
+	assert.True(t, utf8.ValidString(got))
+	assert.True(t, strings.HasSuffix(got, "ñ.pdf"))
+}
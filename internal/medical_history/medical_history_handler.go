@@ -1,19 +1,27 @@
 package medical_history
 
 import (
+	"errors"
 	"net/http"
 	"time"
 
 	"github.com/alanloffler/go-calth-api/internal/common/ctxkeys"
 	"github.com/alanloffler/go-calth-api/internal/common/response"
 	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/alanloffler/go-calth-api/internal/storage"
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type MedicalHistoryHandler struct {
-	repo *MedicalHistoryRepository
+	repo          *MedicalHistoryRepository
+	access        accessRepository
+	pool          *pgxpool.Pool
+	storage       storage.Storage
+	maxUploadSize int64
+	lockPeriod    time.Duration
 }
 
 type CreateMedicalHistoryRequest struct {
@@ -21,9 +29,9 @@ type CreateMedicalHistoryRequest struct {
 	ProfessionalID string `json:"professionalId" binding:"required,uuid"`
 	EventID        string `json:"eventId" binding:"omitempty,uuid"`
 	Date           string `json:"date" binding:"required,datetime=2006-01-02T15:04:05Z07:00"`
-	Reason         string `json:"reason" binding:"required,min=3,max=100"`
-	Recipe         bool   `json:"recipe"`
-	Comments       string `json:"comments" binding:"required,min=3"`
+	TemplateID     string `json:"templateId" binding:"omitempty,uuid"`
+	Reason         string `json:"reason" binding:"required_without=TemplateID,omitempty,min=3,max=100"`
+	Comments       string `json:"comments" binding:"required_without=TemplateID,omitempty,min=3"`
 }
 
 type MedicalHistoryResponse struct {
@@ -36,6 +44,7 @@ type MedicalHistoryResponse struct {
 	Reason         string               `json:"reason"`
 	Recipe         bool                 `json:"recipe"`
 	Comments       string               `json:"comments"`
+	Locked         bool                 `json:"locked"`
 	CreatedAt      string               `json:"createdAt"`
 	UpdatedAt      string               `json:"updatedAt"`
 	DeletedAt      *string              `json:"deletedAt"`
@@ -49,10 +58,13 @@ type UpdateMedicalHistoryRequest struct {
 	EventID        string `json:"eventId" binding:"omitempty,uuid"`
 	Date           string `json:"date" binding:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
 	Reason         string `json:"reason" binding:"omitempty,min=3,max=100"`
-	Recipe         *bool  `json:"recipe"`
 	Comments       string `json:"comments" binding:"omitempty,min=3"`
 }
 
+type DeleteMedicalHistoryRequest struct {
+	Reason string `json:"reason" binding:"required,min=10,max=500"`
+}
+
 type UserResponse struct {
 	IC        string `json:"ic"`
 	FirstName string `json:"firstName"`
@@ -69,8 +81,8 @@ type ProfessionalProfileResponse struct {
 	ProfessionalPrefix string `json:"professionalPrefix"`
 }
 
-func NewMedicalHistoryHandler(repo *MedicalHistoryRepository) *MedicalHistoryHandler {
-	return &MedicalHistoryHandler{repo: repo}
+func NewMedicalHistoryHandler(repo *MedicalHistoryRepository, pool *pgxpool.Pool, store storage.Storage, maxUploadSize int64, lockPeriod time.Duration) *MedicalHistoryHandler {
+	return &MedicalHistoryHandler{repo: repo, access: repo, pool: pool, storage: store, maxUploadSize: maxUploadSize, lockPeriod: lockPeriod}
 }
 
 func (h *MedicalHistoryHandler) Create(c *gin.Context) {
@@ -80,6 +92,12 @@ func (h *MedicalHistoryHandler) Create(c *gin.Context) {
 		return
 	}
 
+	changedBy, ok := ctxkeys.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
 	var req CreateMedicalHistoryRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Error de validación de datos", err))
@@ -104,6 +122,11 @@ func (h *MedicalHistoryHandler) Create(c *gin.Context) {
 		return
 	}
 
+	if !ctxkeys.InOwnerScope(c, professionalID) {
+		c.JSON(http.StatusForbidden, response.Error(http.StatusForbidden, "Permisos insuficientes"))
+		return
+	}
+
 	var eventID pgtype.UUID
 	if req.EventID != "" {
 		if err := eventID.Scan(req.EventID); err != nil {
@@ -112,14 +135,32 @@ func (h *MedicalHistoryHandler) Create(c *gin.Context) {
 		}
 	}
 
-	mh, err := h.repo.Create(c.Request.Context(), sqlc.CreateMedicalHistoryParams{
+	var changes map[string]fieldChange
+	if req.TemplateID != "" {
+		if !h.applyTemplate(c, businessID, professionalID, &req) {
+			return
+		}
+		changes = map[string]fieldChange{"templateId": {To: req.TemplateID}}
+	}
+
+	ctx := c.Request.Context()
+
+	tx, err := h.pool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al iniciar transacción", err))
+		return
+	}
+	defer tx.Rollback(ctx)
+
+	rtx := h.repo.WithTx(tx)
+
+	mh, err := rtx.Create(ctx, sqlc.CreateMedicalHistoryParams{
 		BusinessID:     businessID,
 		UserID:         userID,
 		ProfessionalID: professionalID,
 		EventID:        eventID,
 		Date:           pgtype.Timestamptz{Time: date, Valid: true},
 		Reason:         req.Reason,
-		Recipe:         req.Recipe,
 		Comments:       req.Comments,
 	})
 	if err != nil {
@@ -127,71 +168,17 @@ func (h *MedicalHistoryHandler) Create(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, response.Created("Historia médica creada", &mh))
-}
-
-func (h *MedicalHistoryHandler) GetAllByPatientIDWithSoftDeleted(c *gin.Context) {
-	businessID, ok := ctxkeys.BusinessID(c)
-	if !ok {
-		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
-		return
-	}
-
-	var id pgtype.UUID
-	if err := id.Scan(c.Param("id")); err != nil {
-		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID del usuario inválido", err))
+	if err := recordRevision(ctx, rtx, mh, changedBy, revisionCreate, changes, ""); err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al registrar la revisión", err))
 		return
 	}
 
-	mhs, err := h.repo.GetAllByPatientIDWithSoftDeleted(c.Request.Context(), sqlc.GetMedicalHistoriesByPatientIDWithSoftDeletedParams{BusinessID: businessID, UserID: id})
-	if err != nil {
-		c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Historias médicas no encontradas", err))
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al confirmar transacción", err))
 		return
 	}
 
-	result := make([]MedicalHistoryResponse, len(mhs))
-	for i, mh := range mhs {
-		var eventID *string
-		if mh.EventID.Valid {
-			s := uuid.UUID(mh.EventID.Bytes).String()
-			eventID = &s
-		}
-
-		var deletedAt *string
-		if mh.DeletedAt.Valid {
-			s := mh.DeletedAt.Time.Format(time.RFC3339)
-			deletedAt = &s
-		}
-
-		result[i] = MedicalHistoryResponse{
-			ID:             uuid.UUID(mh.ID.Bytes).String(),
-			BusinessID:     uuid.UUID(mh.BusinessID.Bytes).String(),
-			UserID:         uuid.UUID(mh.UserID.Bytes).String(),
-			ProfessionalID: uuid.UUID(mh.ProfessionalID.Bytes).String(),
-			EventID:        eventID,
-			Date:           mh.Date.Time.Format(time.RFC3339),
-			Reason:         mh.Reason,
-			Recipe:         mh.Recipe,
-			Comments:       mh.Comments,
-			User: UserResponse{
-				IC:        mh.Ic.String,
-				FirstName: mh.FirstName.String,
-				LastName:  mh.LastName.String,
-			},
-			Professional: ProfessionalResponse{
-				FirstName: mh.FirstName_2.String,
-				LastName:  mh.LastName_2.String,
-				Profile: ProfessionalProfileResponse{
-					ProfessionalPrefix: mh.ProfessionalPrefix.String,
-				},
-			},
-			CreatedAt: mh.CreatedAt.Time.Format(time.RFC3339),
-			UpdatedAt: mh.UpdatedAt.Time.Format(time.RFC3339),
-			DeletedAt: deletedAt,
-		}
-	}
-
-	c.JSON(http.StatusOK, response.Success("Historias médicas encontradas", &result))
+	c.JSON(http.StatusOK, response.Created("Historia médica creada", &mh))
 }
 
 func (h *MedicalHistoryHandler) Update(c *gin.Context) {
@@ -201,6 +188,12 @@ func (h *MedicalHistoryHandler) Update(c *gin.Context) {
 		return
 	}
 
+	changedBy, ok := ctxkeys.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
 	var id pgtype.UUID
 	if err := id.Scan(c.Param("id")); err != nil {
 		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID inválido", err))
@@ -216,12 +209,8 @@ func (h *MedicalHistoryHandler) Update(c *gin.Context) {
 	params := sqlc.UpdateMedicalHistoryParams{
 		BusinessIDFilter: businessID,
 		ID:               id,
-		Reason:           pgtype.Text{String: req.Reason, Valid: true},
-		Comments:         pgtype.Text{String: req.Comments, Valid: true},
-	}
-
-	if req.Recipe != nil {
-		params.Recipe = pgtype.Bool{Bool: *req.Recipe, Valid: true}
+		Reason:           pgtype.Text{String: req.Reason, Valid: req.Reason != ""},
+		Comments:         pgtype.Text{String: req.Comments, Valid: req.Comments != ""},
 	}
 
 	if req.UserID != "" {
@@ -236,6 +225,10 @@ func (h *MedicalHistoryHandler) Update(c *gin.Context) {
 			c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID del profesional inválido", err))
 			return
 		}
+		if !ctxkeys.InOwnerScope(c, params.ProfessionalID) {
+			c.JSON(http.StatusForbidden, response.Error(http.StatusForbidden, "Permisos insuficientes"))
+			return
+		}
 	}
 
 	if req.EventID != "" {
@@ -254,67 +247,154 @@ func (h *MedicalHistoryHandler) Update(c *gin.Context) {
 		params.Date = pgtype.Timestamptz{Time: date, Valid: true}
 	}
 
-	affected, err := h.repo.Update(c.Request.Context(), params)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al actualizar la historia médica", err))
+	if !h.authorize(c, businessID, id, accessWrite) {
 		return
 	}
-	if affected == 0 {
-		c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Historia médica no encontrada"))
+
+	ctx := c.Request.Context()
+
+	tx, err := h.pool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al iniciar transacción", err))
 		return
 	}
+	defer tx.Rollback(ctx)
 
-	c.JSON(http.StatusOK, response.Success[any]("Historia médica actualizada", nil))
-}
+	rtx := h.repo.WithTx(tx)
 
-func (h *MedicalHistoryHandler) SoftDelete(c *gin.Context) {
-	businessID, ok := ctxkeys.BusinessID(c)
-	if !ok {
-		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+	before, err := rtx.GetForUpdate(ctx, sqlc.GetMedicalHistoryForUpdateParams{
+		BusinessID: businessID,
+		ID:         id,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Historia médica no encontrada"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener la historia médica", err))
+		return
+	}
+	if before.DeletedAt.Valid {
+		c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Historia médica no encontrada"))
+		return
+	}
+	if h.isLocked(before.CreatedAt) {
+		c.JSON(http.StatusConflict, response.Error(http.StatusConflict, "La historia médica está bloqueada, solo se permiten adendas"))
 		return
 	}
 
-	var id pgtype.UUID
-	if err := id.Scan(c.Param("id")); err != nil {
-		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID inválido", err))
+	if _, err := rtx.Update(ctx, params); err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al actualizar la historia médica", err))
 		return
 	}
 
-	rows, err := h.repo.SoftDelete(c.Request.Context(), sqlc.SoftDeleteMedicalHistoryParams{
+	after, err := rtx.GetForUpdate(ctx, sqlc.GetMedicalHistoryForUpdateParams{
 		BusinessID: businessID,
 		ID:         id,
 	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al eliminar historia médica", err))
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener la historia médica", err))
 		return
 	}
-	if rows == 0 {
-		c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Historia médica no encontrada"))
+
+	if err := rtx.Reindex(ctx, after); err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al indexar la historia médica", err))
 		return
 	}
 
-	c.JSON(http.StatusOK, response.Success[any]("Historia médica eliminada", nil))
+	// A request that leaves every field untouched does not produce a revision.
+	if changes := diffMedicalHistory(before, after); len(changes) > 0 {
+		if err := recordRevision(ctx, rtx, after, changedBy, revisionUpdate, changes, ""); err != nil {
+			c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al registrar la revisión", err))
+			return
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al confirmar transacción", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Success[any]("Historia médica actualizada", nil))
+}
+
+func (h *MedicalHistoryHandler) SoftDelete(c *gin.Context) {
+	h.changeState(c, revisionSoftDelete, "Error al eliminar historia médica", "Historia médica eliminada")
 }
 
 func (h *MedicalHistoryHandler) Restore(c *gin.Context) {
+	h.changeState(c, revisionRestore, "Error al restaurar la historia médica", "Historia médica restaurada")
+}
+
+// changeState soft deletes or restores an entry and records the matching
+// revision in the same transaction. Once the edit window has passed only a
+// superadmin may do either.
+func (h *MedicalHistoryHandler) changeState(c *gin.Context, action, errorMessage, successMessage string) {
 	businessID, ok := ctxkeys.BusinessID(c)
 	if !ok {
 		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
 		return
 	}
 
+	changedBy, ok := ctxkeys.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
 	var id pgtype.UUID
 	if err := id.Scan(c.Param("id")); err != nil {
 		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID inválido", err))
 		return
 	}
 
-	rows, err := h.repo.Restore(c.Request.Context(), sqlc.RestoreMedicalHistoryParams{
+	if !h.authorize(c, businessID, id, accessWrite) {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	tx, err := h.pool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al iniciar transacción", err))
+		return
+	}
+	defer tx.Rollback(ctx)
+
+	rtx := h.repo.WithTx(tx)
+
+	before, err := rtx.GetForUpdate(ctx, sqlc.GetMedicalHistoryForUpdateParams{
 		BusinessID: businessID,
 		ID:         id,
 	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al restaurar la historia médica", err))
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Historia médica no encontrada"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener la historia médica", err))
+		return
+	}
+	// Hiding a locked entry would change the record as much as editing it.
+	if h.isLocked(before.CreatedAt) && !ctxkeys.IsSuperAdmin(c) {
+		c.JSON(http.StatusConflict, response.Error(http.StatusConflict, "La historia médica está bloqueada, solo se permiten adendas"))
+		return
+	}
+
+	var rows int64
+	if action == revisionSoftDelete {
+		rows, err = rtx.SoftDelete(ctx, sqlc.SoftDeleteMedicalHistoryParams{
+			BusinessID: businessID,
+			ID:         id,
+		})
+	} else {
+		rows, err = rtx.Restore(ctx, sqlc.RestoreMedicalHistoryParams{
+			BusinessID: businessID,
+			ID:         id,
+		})
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, errorMessage, err))
 		return
 	}
 	if rows == 0 {
@@ -322,7 +402,26 @@ func (h *MedicalHistoryHandler) Restore(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, response.Success[any]("Historia médica restaurada", nil))
+	mh, err := rtx.GetForUpdate(ctx, sqlc.GetMedicalHistoryForUpdateParams{
+		BusinessID: businessID,
+		ID:         id,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener la historia médica", err))
+		return
+	}
+
+	if err := recordRevision(ctx, rtx, mh, changedBy, action, nil, ""); err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al registrar la revisión", err))
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al confirmar transacción", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Success[any](successMessage, nil))
 }
 
 func (h *MedicalHistoryHandler) Delete(c *gin.Context) {
@@ -332,24 +431,83 @@ func (h *MedicalHistoryHandler) Delete(c *gin.Context) {
 		return
 	}
 
+	changedBy, ok := ctxkeys.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
 	var id pgtype.UUID
 	if err := id.Scan(c.Param("id")); err != nil {
 		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID inválido", err))
 		return
 	}
 
-	rows, err := h.repo.Delete(c.Request.Context(), sqlc.DeleteMedicalHistoryParams{
+	var req DeleteMedicalHistoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Error de validación de datos", err))
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	tx, err := h.pool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al iniciar transacción", err))
+		return
+	}
+	defer tx.Rollback(ctx)
+
+	rtx := h.repo.WithTx(tx)
+
+	mh, err := rtx.GetForUpdate(ctx, sqlc.GetMedicalHistoryForUpdateParams{
 		BusinessID: businessID,
 		ID:         id,
 	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error eliminando historia médica", err))
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Historia médica no encontrada"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener la historia médica", err))
 		return
 	}
-	if rows == 0 {
+	if mh.DeletedAt.Valid {
 		c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Historia médica no encontrada"))
 		return
 	}
 
+	attachments, err := rtx.GetAttachments(ctx, sqlc.GetMedicalHistoryAttachmentsParams{
+		BusinessID:       businessID,
+		MedicalHistoryID: id,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener los adjuntos", err))
+		return
+	}
+
+	// The revision table has no foreign key to the entry, so the final
+	// snapshot survives the hard delete.
+	if err := recordRevision(ctx, rtx, mh, changedBy, revisionDelete, nil, req.Reason); err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al registrar la revisión", err))
+		return
+	}
+
+	if _, err := rtx.Delete(ctx, sqlc.DeleteMedicalHistoryParams{
+		BusinessID: businessID,
+		ID:         id,
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error eliminando historia médica", err))
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al confirmar transacción", err))
+		return
+	}
+
+	// Attachment rows cascade with the entry; the stored files have to be removed by hand.
+	h.deleteStoredFiles(c, attachments)
+
 	c.JSON(http.StatusOK, response.Success[any]("Historia médica eliminada", nil))
 }
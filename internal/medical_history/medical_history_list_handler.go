@@ -0,0 +1,242 @@
+package medical_history
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alanloffler/go-calth-api/internal/common/ctxkeys"
+	"github.com/alanloffler/go-calth-api/internal/common/response"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+)
+
+// Values of the deleted filter of ListPatientMedicalHistories.
+const (
+	deletedExclude = "exclude"
+	deletedInclude = "include"
+	deletedOnly    = "only"
+)
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// GetAllByPatientID lists the active medical histories of a patient. Removed
+// entries are added with includeDeleted=true.
+func (h *MedicalHistoryHandler) GetAllByPatientID(c *gin.Context) {
+	deleted := deletedExclude
+	if c.Query("includeDeleted") == "true" {
+		deleted = deletedInclude
+	}
+	h.listByPatientID(c, deleted)
+}
+
+// GetRemovedByPatientID lists only the soft deleted medical histories of a
+// patient.
+func (h *MedicalHistoryHandler) GetRemovedByPatientID(c *gin.Context) {
+	h.listByPatientID(c, deletedOnly)
+}
+
+// listByPatientID supports the query parameters from/to (YYYY-MM-DD, both
+// inclusive), professionalId, q (words searched in reason and comments),
+// sort (date_desc or date_asc), limit and the cursor returned by the previous
+// page.
+func (h *MedicalHistoryHandler) listByPatientID(c *gin.Context, deleted string) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(c.Param("id")); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID del usuario inválido", err))
+		return
+	}
+
+	params := sqlc.ListPatientMedicalHistoriesParams{
+		BusinessID: businessID,
+		UserID:     id,
+		OwnerID:    ctxkeys.OwnerScope(c),
+		Deleted:    deleted,
+	}
+
+	limit := int32(defaultListLimit)
+	if limitStr := c.Query("limit"); limitStr != "" {
+		parsedLimit, err := strconv.ParseInt(limitStr, 10, 32)
+		if err != nil || parsedLimit < 1 || parsedLimit > maxListLimit {
+			c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Límite inválido", err))
+			return
+		}
+		limit = int32(parsedLimit)
+	}
+	// One extra row tells whether there is a next page.
+	params.QueryLimit = limit + 1
+
+	switch c.DefaultQuery("sort", "date_desc") {
+	case "date_desc":
+	case "date_asc":
+		params.SortAsc = true
+	default:
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Orden inválido"))
+		return
+	}
+
+	if cursor := c.Query("cursor"); cursor != "" {
+		date, cursorID, err := decodeCursor(cursor)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Cursor inválido", err))
+			return
+		}
+		params.CursorDate = date
+		params.CursorID = cursorID
+	}
+
+	if professionalIDStr := c.Query("professionalId"); professionalIDStr != "" {
+		if err := params.ProfessionalID.Scan(professionalIDStr); err != nil {
+			c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID del profesional inválido", err))
+			return
+		}
+	}
+
+	if query := strings.TrimSpace(c.Query("q")); query != "" {
+		params.Tokens = h.repo.QueryTokens(businessID, query)
+		if len(params.Tokens) == 0 {
+			c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "La búsqueda debe contener palabras de al menos 3 caracteres"))
+			return
+		}
+	}
+
+	if c.Query("from") != "" || c.Query("to") != "" {
+		loc, err := time.LoadLocation("America/Argentina/Buenos_Aires")
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error de zona horaria", err))
+			return
+		}
+
+		if fromStr := c.Query("from"); fromStr != "" {
+			from, err := time.ParseInLocation("2006-01-02", fromStr, loc)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de fecha inválido", err))
+				return
+			}
+			params.DateFrom = pgtype.Timestamptz{Time: from, Valid: true}
+		}
+
+		if toStr := c.Query("to"); toStr != "" {
+			to, err := time.ParseInLocation("2006-01-02", toStr, loc)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de fecha inválido", err))
+				return
+			}
+			// The upper bound is exclusive, so the whole "to" day is included.
+			params.DateTo = pgtype.Timestamptz{Time: to.AddDate(0, 0, 1), Valid: true}
+		}
+	}
+
+	mhs, err := h.repo.ListByPatientID(c.Request.Context(), params)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener las historias médicas", err))
+		return
+	}
+
+	var nextCursor *string
+	if len(mhs) > int(limit) {
+		mhs = mhs[:limit]
+		last := mhs[len(mhs)-1]
+		s := encodeCursor(last.Date, last.ID)
+		nextCursor = &s
+	}
+
+	result := response.CursorData[MedicalHistoryResponse]{
+		Result:     make([]MedicalHistoryResponse, len(mhs)),
+		NextCursor: nextCursor,
+	}
+	for i, mh := range mhs {
+		result.Result[i] = h.toMedicalHistoryResponse(mh)
+	}
+
+	c.JSON(http.StatusOK, response.Success("Historias médicas encontradas", &result))
+}
+
+func (h *MedicalHistoryHandler) toMedicalHistoryResponse(mh sqlc.ListPatientMedicalHistoriesRow) MedicalHistoryResponse {
+	var eventID *string
+	if mh.EventID.Valid {
+		s := uuid.UUID(mh.EventID.Bytes).String()
+		eventID = &s
+	}
+
+	var deletedAt *string
+	if mh.DeletedAt.Valid {
+		s := mh.DeletedAt.Time.Format(time.RFC3339)
+		deletedAt = &s
+	}
+
+	return MedicalHistoryResponse{
+		ID:             uuid.UUID(mh.ID.Bytes).String(),
+		BusinessID:     uuid.UUID(mh.BusinessID.Bytes).String(),
+		UserID:         uuid.UUID(mh.UserID.Bytes).String(),
+		ProfessionalID: uuid.UUID(mh.ProfessionalID.Bytes).String(),
+		EventID:        eventID,
+		Date:           mh.Date.Time.Format(time.RFC3339),
+		Reason:         mh.Reason,
+		Recipe:         mh.Recipe,
+		Comments:       mh.Comments,
+		Locked:         h.isLocked(mh.CreatedAt),
+		User: UserResponse{
+			IC:        mh.Ic.String,
+			FirstName: mh.FirstName.String,
+			LastName:  mh.LastName.String,
+		},
+		Professional: ProfessionalResponse{
+			FirstName: mh.FirstName_2.String,
+			LastName:  mh.LastName_2.String,
+			Profile: ProfessionalProfileResponse{
+				ProfessionalPrefix: mh.ProfessionalPrefix.String,
+			},
+		},
+		CreatedAt: mh.CreatedAt.Time.Format(time.RFC3339),
+		UpdatedAt: mh.UpdatedAt.Time.Format(time.RFC3339),
+		DeletedAt: deletedAt,
+	}
+}
+
+// encodeCursor builds the opaque cursor pointing after the given row. The date
+// keeps full precision so rows sharing a second are not skipped.
+func encodeCursor(date pgtype.Timestamptz, id pgtype.UUID) string {
+	raw := date.Time.UTC().Format(time.RFC3339Nano) + "|" + uuid.UUID(id.Bytes).String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeCursor(cursor string) (pgtype.Timestamptz, pgtype.UUID, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return pgtype.Timestamptz{}, pgtype.UUID{}, errInvalidCursor
+	}
+
+	dateStr, idStr, found := strings.Cut(string(raw), "|")
+	if !found {
+		return pgtype.Timestamptz{}, pgtype.UUID{}, errInvalidCursor
+	}
+
+	date, err := time.Parse(time.RFC3339Nano, dateStr)
+	if err != nil {
+		return pgtype.Timestamptz{}, pgtype.UUID{}, errInvalidCursor
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(idStr); err != nil {
+		return pgtype.Timestamptz{}, pgtype.UUID{}, errInvalidCursor
+	}
+
+	return pgtype.Timestamptz{Time: date, Valid: true}, id, nil
+}
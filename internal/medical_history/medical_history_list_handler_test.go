@@ -0,0 +1,28 @@
+package medical_history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor_RoundTrip(t *testing.T) {
+	date := pgtype.Timestamptz{Time: time.Date(2026, 3, 14, 9, 30, 0, 123456000, time.UTC), Valid: true}
+	id := pgtype.UUID{Bytes: [16]byte{1, 2, 3}, Valid: true}
+
+	gotDate, gotID, err := decodeCursor(encodeCursor(date, id))
+	require.NoError(t, err)
+	assert.True(t, date.Time.Equal(gotDate.Time))
+	assert.True(t, gotDate.Valid)
+	assert.Equal(t, id, gotID)
+}
+
+func TestDecodeCursor_Invalid(t *testing.T) {
+	for _, cursor := range []string{"%%%", "bm8tc2VwYXJhdG9y", "MjAyNi0wMy0xNHxub3QtYS11dWlk"} {
+		_, _, err := decodeCursor(cursor)
+		assert.ErrorIs(t, err, errInvalidCursor, cursor)
+	}
+}
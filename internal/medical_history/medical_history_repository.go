@@ -3,26 +3,114 @@ package medical_history
 import (
 	"context"
 
+	"github.com/alanloffler/go-calth-api/internal/common/encryption"
 	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+// MedicalHistoryRepository encrypts the clinical free text (reason, comments,
+// addenda and revision payloads) on the way in and decrypts it on the way out,
+// so handlers only ever see plaintext.
 type MedicalHistoryRepository struct {
-	q *sqlc.Queries
+	q    *sqlc.Queries
+	keys *encryption.Keyring
 }
 
-func NewMedicalHistoryRepository(q *sqlc.Queries) *MedicalHistoryRepository {
-	return &MedicalHistoryRepository{q: q}
+func NewMedicalHistoryRepository(q *sqlc.Queries, keys *encryption.Keyring) *MedicalHistoryRepository {
+	return &MedicalHistoryRepository{q: q, keys: keys}
+}
+
+func (r *MedicalHistoryRepository) WithTx(tx pgx.Tx) *MedicalHistoryRepository {
+	return &MedicalHistoryRepository{q: r.q.WithTx(tx), keys: r.keys}
+}
+
+func (r *MedicalHistoryRepository) decrypt(ctx context.Context, mh *sqlc.MedicalHistory) error {
+	var err error
+	if mh.Reason, err = r.keys.Decrypt(ctx, mh.BusinessID, mh.Reason); err != nil {
+		return err
+	}
+	mh.Comments, err = r.keys.Decrypt(ctx, mh.BusinessID, mh.Comments)
+	return err
 }
 
 func (r *MedicalHistoryRepository) Create(ctx context.Context, arg sqlc.CreateMedicalHistoryParams) (sqlc.MedicalHistory, error) {
-	return r.q.CreateMedicalHistory(ctx, arg)
+	var err error
+	if arg.Reason, err = r.keys.Encrypt(ctx, arg.BusinessID, arg.Reason); err != nil {
+		return sqlc.MedicalHistory{}, err
+	}
+	if arg.Comments, err = r.keys.Encrypt(ctx, arg.BusinessID, arg.Comments); err != nil {
+		return sqlc.MedicalHistory{}, err
+	}
+
+	mh, err := r.q.CreateMedicalHistory(ctx, arg)
+	if err != nil {
+		return mh, err
+	}
+
+	if err := r.decrypt(ctx, &mh); err != nil {
+		return mh, err
+	}
+
+	return mh, r.Reindex(ctx, mh)
 }
 
-func (r *MedicalHistoryRepository) GetAllByPatientIDWithSoftDeleted(ctx context.Context, arg sqlc.GetMedicalHistoriesByPatientIDWithSoftDeletedParams) ([]sqlc.GetMedicalHistoriesByPatientIDWithSoftDeletedRow, error) {
-	return r.q.GetMedicalHistoriesByPatientIDWithSoftDeleted(ctx, arg)
+// ListByPatientID lists a patient's medical histories. Search terms in
+// arg.Tokens must already be blind index tokens (see Keyring.QueryTokens).
+func (r *MedicalHistoryRepository) ListByPatientID(ctx context.Context, arg sqlc.ListPatientMedicalHistoriesParams) ([]sqlc.ListPatientMedicalHistoriesRow, error) {
+	rows, err := r.q.ListPatientMedicalHistories(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range rows {
+		if rows[i].Reason, err = r.keys.Decrypt(ctx, rows[i].BusinessID, rows[i].Reason); err != nil {
+			return nil, err
+		}
+		if rows[i].Comments, err = r.keys.Decrypt(ctx, rows[i].BusinessID, rows[i].Comments); err != nil {
+			return nil, err
+		}
+	}
+
+	return rows, nil
+}
+
+// QueryTokens turns a free text search into blind index tokens.
+func (r *MedicalHistoryRepository) QueryTokens(businessID pgtype.UUID, query string) []string {
+	return r.keys.QueryTokens(businessID, query)
+}
+
+// Reindex refreshes the search tokens of a decrypted medical history.
+func (r *MedicalHistoryRepository) Reindex(ctx context.Context, mh sqlc.MedicalHistory) error {
+	return r.q.UpsertMedicalHistorySearchTokens(ctx, sqlc.UpsertMedicalHistorySearchTokensParams{
+		MedicalHistoryID: mh.ID,
+		BusinessID:       mh.BusinessID,
+		Tokens:           r.keys.SearchTokens(mh.BusinessID, mh.Reason, mh.Comments),
+	})
+}
+
+func (r *MedicalHistoryRepository) GetAccess(ctx context.Context, arg sqlc.GetMedicalHistoryAccessParams) (sqlc.GetMedicalHistoryAccessRow, error) {
+	return r.q.GetMedicalHistoryAccess(ctx, arg)
+}
+
+func (r *MedicalHistoryRepository) GetForUpdate(ctx context.Context, arg sqlc.GetMedicalHistoryForUpdateParams) (sqlc.MedicalHistory, error) {
+	mh, err := r.q.GetMedicalHistoryForUpdate(ctx, arg)
+	if err != nil {
+		return mh, err
+	}
+
+	return mh, r.decrypt(ctx, &mh)
 }
 
 func (r *MedicalHistoryRepository) Update(ctx context.Context, arg sqlc.UpdateMedicalHistoryParams) (int64, error) {
+	var err error
+	if arg.Reason, err = r.keys.EncryptText(ctx, arg.BusinessIDFilter, arg.Reason); err != nil {
+		return 0, err
+	}
+	if arg.Comments, err = r.keys.EncryptText(ctx, arg.BusinessIDFilter, arg.Comments); err != nil {
+		return 0, err
+	}
+
 	return r.q.UpdateMedicalHistory(ctx, arg)
 }
 
@@ -37,3 +125,101 @@ func (r *MedicalHistoryRepository) Restore(ctx context.Context, arg sqlc.Restore
 func (r *MedicalHistoryRepository) Delete(ctx context.Context, arg sqlc.DeleteMedicalHistoryParams) (int64, error) {
 	return r.q.DeleteMedicalHistory(ctx, arg)
 }
+
+func (r *MedicalHistoryRepository) Exists(ctx context.Context, arg sqlc.MedicalHistoryExistsParams) (bool, error) {
+	return r.q.MedicalHistoryExists(ctx, arg)
+}
+
+func (r *MedicalHistoryRepository) CreateAttachment(ctx context.Context, arg sqlc.CreateMedicalHistoryAttachmentParams) (sqlc.MedicalHistoryAttachment, error) {
+	return r.q.CreateMedicalHistoryAttachment(ctx, arg)
+}
+
+func (r *MedicalHistoryRepository) GetAttachments(ctx context.Context, arg sqlc.GetMedicalHistoryAttachmentsParams) ([]sqlc.MedicalHistoryAttachment, error) {
+	return r.q.GetMedicalHistoryAttachments(ctx, arg)
+}
+
+func (r *MedicalHistoryRepository) GetAttachment(ctx context.Context, arg sqlc.GetMedicalHistoryAttachmentParams) (sqlc.MedicalHistoryAttachment, error) {
+	return r.q.GetMedicalHistoryAttachment(ctx, arg)
+}
+
+func (r *MedicalHistoryRepository) DeleteAttachment(ctx context.Context, arg sqlc.DeleteMedicalHistoryAttachmentParams) (int64, error) {
+	return r.q.DeleteMedicalHistoryAttachment(ctx, arg)
+}
+
+func (r *MedicalHistoryRepository) CreateRevision(ctx context.Context, arg sqlc.CreateMedicalHistoryRevisionParams) (sqlc.MedicalHistoryRevision, error) {
+	var err error
+	if arg.Changes, err = r.keys.EncryptJSON(ctx, arg.BusinessID, arg.Changes); err != nil {
+		return sqlc.MedicalHistoryRevision{}, err
+	}
+	if arg.Snapshot, err = r.keys.EncryptJSON(ctx, arg.BusinessID, arg.Snapshot); err != nil {
+		return sqlc.MedicalHistoryRevision{}, err
+	}
+
+	return r.q.CreateMedicalHistoryRevision(ctx, arg)
+}
+
+func (r *MedicalHistoryRepository) GetRevisions(ctx context.Context, arg sqlc.GetMedicalHistoryRevisionsParams) ([]sqlc.GetMedicalHistoryRevisionsRow, error) {
+	rows, err := r.q.GetMedicalHistoryRevisions(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range rows {
+		if rows[i].Changes, err = r.keys.DecryptJSON(ctx, rows[i].BusinessID, rows[i].Changes); err != nil {
+			return nil, err
+		}
+		if rows[i].Snapshot, err = r.keys.DecryptJSON(ctx, rows[i].BusinessID, rows[i].Snapshot); err != nil {
+			return nil, err
+		}
+	}
+
+	return rows, nil
+}
+
+func (r *MedicalHistoryRepository) CreateAddendum(ctx context.Context, arg sqlc.CreateMedicalHistoryAddendumParams) (sqlc.MedicalHistoryAddenda, error) {
+	plaintext := arg.Content
+
+	var err error
+	if arg.Content, err = r.keys.Encrypt(ctx, arg.BusinessID, arg.Content); err != nil {
+		return sqlc.MedicalHistoryAddenda{}, err
+	}
+
+	addendum, err := r.q.CreateMedicalHistoryAddendum(ctx, arg)
+	addendum.Content = plaintext
+	return addendum, err
+}
+
+func (r *MedicalHistoryRepository) GetAddenda(ctx context.Context, arg sqlc.GetMedicalHistoryAddendaParams) ([]sqlc.GetMedicalHistoryAddendaRow, error) {
+	rows, err := r.q.GetMedicalHistoryAddenda(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range rows {
+		if rows[i].Content, err = r.keys.Decrypt(ctx, rows[i].BusinessID, rows[i].Content); err != nil {
+			return nil, err
+		}
+	}
+
+	return rows, nil
+}
+
+func (r *MedicalHistoryRepository) GetPatientID(ctx context.Context, arg sqlc.GetMedicalHistoryPatientIDParams) (pgtype.UUID, error) {
+	return r.q.GetMedicalHistoryPatientID(ctx, arg)
+}
+
+func (r *MedicalHistoryRepository) GetTemplate(ctx context.Context, arg sqlc.GetMedicalHistoryTemplateByIDParams) (sqlc.MedicalHistoryTemplate, error) {
+	return r.q.GetMedicalHistoryTemplateByID(ctx, arg)
+}
+
+func (r *MedicalHistoryRepository) GetProfessionalProfile(ctx context.Context, arg sqlc.GetProfessionalProfileByUserIDParams) (sqlc.ProfessionalProfile, error) {
+	return r.q.GetProfessionalProfileByUserID(ctx, arg)
+}
+
+func (r *MedicalHistoryRepository) Share(ctx context.Context, arg sqlc.ShareMedicalHistoryParams) (int64, error) {
+	return r.q.ShareMedicalHistory(ctx, arg)
+}
+
+func (r *MedicalHistoryRepository) Unshare(ctx context.Context, arg sqlc.UnshareMedicalHistoryParams) (int64, error) {
+	return r.q.UnshareMedicalHistory(ctx, arg)
+}
@@ -0,0 +1,289 @@
+package medical_history
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/alanloffler/go-calth-api/internal/common/ctxkeys"
+	"github.com/alanloffler/go-calth-api/internal/common/response"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	revisionCreate     = "create"
+	revisionUpdate     = "update"
+	revisionAddendum   = "addendum"
+	revisionSoftDelete = "soft_delete"
+	revisionRestore    = "restore"
+	revisionDelete     = "delete"
+)
+
+type CreateAddendumRequest struct {
+	Content string `json:"content" binding:"required,min=3"`
+}
+
+type RevisionResponse struct {
+	ID        string          `json:"id"`
+	Revision  int32           `json:"revision"`
+	Action    string          `json:"action"`
+	ChangedBy UserResponse    `json:"changedBy"`
+	Changes   json.RawMessage `json:"changes"`
+	Snapshot  json.RawMessage `json:"snapshot"`
+	Reason    string          `json:"reason"`
+	CreatedAt string          `json:"createdAt"`
+}
+
+type AddendumResponse struct {
+	ID        string       `json:"id"`
+	Content   string       `json:"content"`
+	Author    UserResponse `json:"author"`
+	CreatedAt string       `json:"createdAt"`
+}
+
+type fieldChange struct {
+	From any `json:"from"`
+	To   any `json:"to"`
+}
+
+// diffMedicalHistory lists the clinical fields that differ between two versions of an entry.
+func diffMedicalHistory(before, after sqlc.MedicalHistory) map[string]fieldChange {
+	changes := make(map[string]fieldChange)
+
+	if before.UserID != after.UserID {
+		changes["userId"] = fieldChange{From: before.UserID, To: after.UserID}
+	}
+	if before.ProfessionalID != after.ProfessionalID {
+		changes["professionalId"] = fieldChange{From: before.ProfessionalID, To: after.ProfessionalID}
+	}
+	if before.EventID != after.EventID {
+		changes["eventId"] = fieldChange{From: before.EventID, To: after.EventID}
+	}
+	if !before.Date.Time.Equal(after.Date.Time) {
+		changes["date"] = fieldChange{From: before.Date, To: after.Date}
+	}
+	if before.Reason != after.Reason {
+		changes["reason"] = fieldChange{From: before.Reason, To: after.Reason}
+	}
+	if before.Comments != after.Comments {
+		changes["comments"] = fieldChange{From: before.Comments, To: after.Comments}
+	}
+
+	return changes
+}
+
+// recordRevision appends a revision for the entry, storing its current state as the snapshot.
+func recordRevision(ctx context.Context, repo *MedicalHistoryRepository, mh sqlc.MedicalHistory, changedBy pgtype.UUID, action string, changes map[string]fieldChange, reason string) error {
+	if changes == nil {
+		changes = map[string]fieldChange{}
+	}
+
+	changesJSON, err := json.Marshal(changes)
+	if err != nil {
+		return err
+	}
+
+	snapshot, err := json.Marshal(mh)
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.CreateRevision(ctx, sqlc.CreateMedicalHistoryRevisionParams{
+		BusinessID:       mh.BusinessID,
+		MedicalHistoryID: mh.ID,
+		Action:           action,
+		ChangedBy:        changedBy,
+		Changes:          changesJSON,
+		Snapshot:         snapshot,
+		Reason:           pgtype.Text{String: reason, Valid: reason != ""},
+	})
+	return err
+}
+
+// isLocked reports whether the entry is past the edit window and only accepts addenda.
+func (h *MedicalHistoryHandler) isLocked(createdAt pgtype.Timestamptz) bool {
+	return createdAt.Valid && time.Since(createdAt.Time) > h.lockPeriod
+}
+
+func (h *MedicalHistoryHandler) GetRevisions(c *gin.Context) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(c.Param("id")); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID inválido", err))
+		return
+	}
+
+	if !h.authorize(c, businessID, id, accessRead) {
+		return
+	}
+
+	revisions, err := h.repo.GetRevisions(c.Request.Context(), sqlc.GetMedicalHistoryRevisionsParams{
+		BusinessID:       businessID,
+		MedicalHistoryID: id,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener las revisiones", err))
+		return
+	}
+	if len(revisions) == 0 {
+		c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Historia médica no encontrada"))
+		return
+	}
+
+	result := make([]RevisionResponse, len(revisions))
+	for i, r := range revisions {
+		result[i] = RevisionResponse{
+			ID:       uuid.UUID(r.ID.Bytes).String(),
+			Revision: r.Revision,
+			Action:   r.Action,
+			ChangedBy: UserResponse{
+				FirstName: r.FirstName.String,
+				LastName:  r.LastName.String,
+			},
+			Changes:   json.RawMessage(r.Changes),
+			Snapshot:  json.RawMessage(r.Snapshot),
+			Reason:    r.Reason.String,
+			CreatedAt: r.CreatedAt.Time.Format(time.RFC3339),
+		}
+		if len(r.Snapshot) == 0 {
+			result[i].Snapshot = json.RawMessage("null")
+		}
+	}
+
+	c.JSON(http.StatusOK, response.Success("Revisiones encontradas", &result))
+}
+
+func (h *MedicalHistoryHandler) CreateAddendum(c *gin.Context) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	authorID, ok := ctxkeys.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(c.Param("id")); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID inválido", err))
+		return
+	}
+
+	var req CreateAddendumRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Error de validación de datos", err))
+		return
+	}
+
+	if !h.authorize(c, businessID, id, accessRead) {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	tx, err := h.pool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al iniciar transacción", err))
+		return
+	}
+	defer tx.Rollback(ctx)
+
+	rtx := h.repo.WithTx(tx)
+
+	mh, err := rtx.GetForUpdate(ctx, sqlc.GetMedicalHistoryForUpdateParams{
+		BusinessID: businessID,
+		ID:         id,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Historia médica no encontrada"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener la historia médica", err))
+		return
+	}
+	if mh.DeletedAt.Valid {
+		c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Historia médica no encontrada"))
+		return
+	}
+
+	addendum, err := rtx.CreateAddendum(ctx, sqlc.CreateMedicalHistoryAddendumParams{
+		BusinessID:       businessID,
+		MedicalHistoryID: id,
+		AuthorID:         authorID,
+		Content:          req.Content,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al crear la adenda", err))
+		return
+	}
+
+	changes := map[string]fieldChange{"addendum": {To: addendum.Content}}
+	if err := recordRevision(ctx, rtx, mh, authorID, revisionAddendum, changes, ""); err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al registrar la revisión", err))
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al confirmar transacción", err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.Created("Adenda creada", &addendum))
+}
+
+func (h *MedicalHistoryHandler) GetAddenda(c *gin.Context) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(c.Param("id")); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID inválido", err))
+		return
+	}
+
+	if !h.authorize(c, businessID, id, accessRead) {
+		return
+	}
+
+	addenda, err := h.repo.GetAddenda(c.Request.Context(), sqlc.GetMedicalHistoryAddendaParams{
+		BusinessID:       businessID,
+		MedicalHistoryID: id,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener las adendas", err))
+		return
+	}
+
+	result := make([]AddendumResponse, len(addenda))
+	for i, a := range addenda {
+		result[i] = AddendumResponse{
+			ID:      uuid.UUID(a.ID.Bytes).String(),
+			Content: a.Content,
+			Author: UserResponse{
+				FirstName: a.FirstName.String,
+				LastName:  a.LastName.String,
+			},
+			CreatedAt: a.CreatedAt.Time.Format(time.RFC3339),
+		}
+	}
+
+	c.JSON(http.StatusOK, response.Success("Adendas encontradas", &result))
+}
@@ -1,24 +1,47 @@
 package medical_history
 
 import (
+	"context"
+
+	"github.com/alanloffler/go-calth-api/internal/common/encryption"
+	"github.com/alanloffler/go-calth-api/internal/config"
 	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
 	"github.com/alanloffler/go-calth-api/internal/middleware"
+	"github.com/alanloffler/go-calth-api/internal/storage"
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-func RegisterRoutes(router *gin.RouterGroup, q *sqlc.Queries) {
-	var repo *MedicalHistoryRepository = NewMedicalHistoryRepository(q)
-	var handler *MedicalHistoryHandler = NewMedicalHistoryHandler(repo)
+func RegisterRoutes(router *gin.RouterGroup, q *sqlc.Queries, pool *pgxpool.Pool, store storage.Storage, keys *encryption.Keyring, cfg *config.Config) {
+	var repo *MedicalHistoryRepository = NewMedicalHistoryRepository(q, keys)
+	var handler *MedicalHistoryHandler = NewMedicalHistoryHandler(repo, pool, store, cfg.UploadMaxSize, cfg.MedicalHistoryLockPeriod)
 	var medical_histories *gin.RouterGroup = router.Group("/medical-history")
+	var historyPatient middleware.PatientLookup = func(ctx context.Context, businessID, id pgtype.UUID) (pgtype.UUID, error) {
+		return repo.GetPatientID(ctx, sqlc.GetMedicalHistoryPatientIDParams{BusinessID: businessID, ID: id})
+	}
+
+	medical_histories.Use(middleware.OwnershipMiddleware(q, "medical_history-all"))
 
 	medical_histories.POST("", middleware.PermissionMiddleware(q, "medical_history-create"), handler.Create)
+	medical_histories.POST("/:id/addenda", middleware.PermissionMiddleware(q, "medical_history-update"), handler.CreateAddendum)
+	medical_histories.POST("/:id/attachments", middleware.PermissionMiddleware(q, "medical_history-update"), handler.UploadAttachment)
 
-	medical_histories.GET("/:id/patient/removed", middleware.PermissionMiddleware(q, "medical_history-view"), handler.GetAllByPatientIDWithSoftDeleted)
-	medical_histories.GET("/:id/patient", middleware.PermissionMiddleware(q, "medical_history-view"), handler.GetAllByPatientIDWithSoftDeleted)
+	medical_histories.GET("/:id/addenda", middleware.PermissionMiddleware(q, "medical_history-view"), middleware.AuditMiddleware(q, "medical_history", "id", historyPatient), handler.GetAddenda)
+	medical_histories.GET("/:id/revisions", middleware.PermissionMiddleware(q, "medical_history-view"), middleware.AuditMiddleware(q, "medical_history", "id", historyPatient), handler.GetRevisions)
+	medical_histories.GET("/:id/attachments", middleware.PermissionMiddleware(q, "medical_history-view"), middleware.AuditMiddleware(q, "medical_history", "id", historyPatient), handler.GetAttachments)
+	medical_histories.GET("/:id/attachments/:attachmentId", middleware.PermissionMiddleware(q, "medical_history-view"), middleware.AuditMiddleware(q, "medical_history", "id", historyPatient), handler.DownloadAttachment)
+
+	medical_histories.GET("/:id/patient/removed", middleware.PermissionMiddleware(q, "medical_history-view"), middleware.AuditMiddleware(q, "patient_medical_histories", "id", nil), handler.GetRemovedByPatientID)
+	medical_histories.GET("/:id/patient", middleware.PermissionMiddleware(q, "medical_history-view"), middleware.AuditMiddleware(q, "patient_medical_histories", "id", nil), handler.GetAllByPatientID)
 
 	medical_histories.PATCH("/:id", middleware.PermissionMiddleware(q, "medical_history-update"), handler.Update)
 	medical_histories.PATCH("/:id/restore", middleware.PermissionMiddleware(q, "medical_history-restore"), handler.Restore)
 
+	medical_histories.PUT("/:id/share", middleware.PermissionMiddleware(q, "medical_history-update"), handler.Share)
+	medical_histories.DELETE("/:id/share", middleware.PermissionMiddleware(q, "medical_history-update"), handler.Unshare)
+
 	medical_histories.DELETE("/:id/soft", middleware.PermissionMiddleware(q, "medical_history-delete"), handler.SoftDelete)
-	medical_histories.DELETE("/:id", middleware.PermissionMiddleware(q, "medical_history-delete-hard"), handler.Delete)
+	medical_histories.DELETE("/:id/attachments/:attachmentId", middleware.PermissionMiddleware(q, "medical_history-update"), handler.DeleteAttachment)
+	medical_histories.DELETE("/:id", middleware.SuperAdminMiddleware(), handler.Delete)
 }
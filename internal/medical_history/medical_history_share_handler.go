@@ -0,0 +1,91 @@
+package medical_history
+
+import (
+	"net/http"
+
+	"github.com/alanloffler/go-calth-api/internal/common/ctxkeys"
+	"github.com/alanloffler/go-calth-api/internal/common/response"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Share makes the entry visible to the patient in the portal. Sharing an
+// already shared entry is a no-op.
+func (h *MedicalHistoryHandler) Share(c *gin.Context) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	userID, ok := ctxkeys.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(c.Param("id")); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID inválido", err))
+		return
+	}
+
+	if !h.authorize(c, businessID, id, accessWrite) {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	exists, err := h.repo.Exists(ctx, sqlc.MedicalHistoryExistsParams{BusinessID: businessID, ID: id})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al compartir la historia médica", err))
+		return
+	}
+	if !exists {
+		c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Historia médica no encontrada"))
+		return
+	}
+
+	if _, err := h.repo.Share(ctx, sqlc.ShareMedicalHistoryParams{
+		SharedBy:         userID,
+		BusinessID:       businessID,
+		MedicalHistoryID: id,
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al compartir la historia médica", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Success[any]("Historia médica compartida con el paciente", nil))
+}
+
+// Unshare hides the entry from the patient portal again.
+func (h *MedicalHistoryHandler) Unshare(c *gin.Context) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(c.Param("id")); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID inválido", err))
+		return
+	}
+
+	if !h.authorize(c, businessID, id, accessWrite) {
+		return
+	}
+
+	rows, err := h.repo.Unshare(c.Request.Context(), sqlc.UnshareMedicalHistoryParams{BusinessID: businessID, MedicalHistoryID: id})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al dejar de compartir la historia médica", err))
+		return
+	}
+	if rows == 0 {
+		c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "La historia médica no está compartida"))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Success[any]("La historia médica ya no se comparte con el paciente", nil))
+}
@@ -0,0 +1,67 @@
+package medical_history
+
+import (
+	"errors"
+	"net/http"
+	"unicode/utf8"
+
+	"github.com/alanloffler/go-calth-api/internal/common/response"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/alanloffler/go-calth-api/internal/medical_history_template"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// applyTemplate seeds the reason and comments of req from the requested
+// template. The template must be shared or match the professional's specialty.
+func (h *MedicalHistoryHandler) applyTemplate(c *gin.Context, businessID, professionalID pgtype.UUID, req *CreateMedicalHistoryRequest) bool {
+	var templateID pgtype.UUID
+	if err := templateID.Scan(req.TemplateID); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID de la plantilla inválido", err))
+		return false
+	}
+
+	ctx := c.Request.Context()
+
+	template, err := h.repo.GetTemplate(ctx, sqlc.GetMedicalHistoryTemplateByIDParams{
+		BusinessID: businessID,
+		ID:         templateID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Plantilla no encontrada"))
+			return false
+		}
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener la plantilla", err))
+		return false
+	}
+
+	if template.Specialty != "" {
+		profile, err := h.repo.GetProfessionalProfile(ctx, sqlc.GetProfessionalProfileByUserIDParams{
+			BusinessID: businessID,
+			UserID:     professionalID,
+		})
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener el profesional", err))
+			return false
+		}
+		if !medical_history_template.AppliesTo(template, profile.Specialty) {
+			c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "La plantilla no corresponde a la especialidad del profesional"))
+			return false
+		}
+	}
+
+	if req.Reason, req.Comments, err = medical_history_template.Seed(template, req.Reason, req.Comments); err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al aplicar la plantilla", err))
+		return false
+	}
+
+	// The template may leave out the reason or comments the entry still requires.
+	if n := utf8.RuneCountInString(req.Reason); n < 3 || n > 100 || utf8.RuneCountInString(req.Comments) < 3 {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Complete el motivo y los comentarios de la historia médica"))
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,341 @@
+package medical_history_template
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/alanloffler/go-calth-api/internal/common/ctxkeys"
+	"github.com/alanloffler/go-calth-api/internal/common/response"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type MedicalHistoryTemplateHandler struct {
+	repo *MedicalHistoryTemplateRepository
+}
+
+func NewMedicalHistoryTemplateHandler(repo *MedicalHistoryTemplateRepository) *MedicalHistoryTemplateHandler {
+	return &MedicalHistoryTemplateHandler{repo: repo}
+}
+
+type CreateTemplateRequest struct {
+	Title     string  `json:"title" binding:"required,min=3,max=100"`
+	Specialty string  `json:"specialty" binding:"omitempty,max=100"`
+	Reason    string  `json:"reason" binding:"omitempty,max=100"`
+	Comments  string  `json:"comments" binding:"omitempty,max=10000"`
+	Fields    []Field `json:"fields" binding:"omitempty,max=30,dive"`
+}
+
+type UpdateTemplateRequest struct {
+	Title     *string  `json:"title" binding:"omitempty,min=3,max=100"`
+	Specialty *string  `json:"specialty" binding:"omitempty,max=100"`
+	Reason    *string  `json:"reason" binding:"omitempty,max=100"`
+	Comments  *string  `json:"comments" binding:"omitempty,max=10000"`
+	Fields    *[]Field `json:"fields" binding:"omitempty,max=30,dive"`
+}
+
+type TemplateResponse struct {
+	ID        string  `json:"id"`
+	Title     string  `json:"title"`
+	Specialty string  `json:"specialty"`
+	Reason    string  `json:"reason"`
+	Comments  string  `json:"comments"`
+	Fields    []Field `json:"fields"`
+	CreatedAt string  `json:"createdAt"`
+	UpdatedAt string  `json:"updatedAt"`
+}
+
+func toTemplateResponse(t sqlc.MedicalHistoryTemplate) TemplateResponse {
+	fields := []Field{}
+	_ = json.Unmarshal(t.Fields, &fields)
+
+	return TemplateResponse{
+		ID:        uuid.UUID(t.ID.Bytes).String(),
+		Title:     t.Title,
+		Specialty: t.Specialty,
+		Reason:    t.Reason,
+		Comments:  t.Comments,
+		Fields:    fields,
+		CreatedAt: t.CreatedAt.Time.Format(time.RFC3339),
+		UpdatedAt: t.UpdatedAt.Time.Format(time.RFC3339),
+	}
+}
+
+func (h *MedicalHistoryTemplateHandler) Create(c *gin.Context) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	var req CreateTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Error de validación de datos", err))
+		return
+	}
+
+	if !h.checkSpecialty(c, businessID, req.Specialty) {
+		return
+	}
+
+	if req.Fields == nil {
+		req.Fields = []Field{}
+	}
+	fields, err := json.Marshal(req.Fields)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Error de validación de datos", err))
+		return
+	}
+
+	createdBy, _ := ctxkeys.UserID(c)
+
+	template, err := h.repo.Create(c.Request.Context(), sqlc.CreateMedicalHistoryTemplateParams{
+		BusinessID: businessID,
+		Title:      req.Title,
+		Specialty:  req.Specialty,
+		Reason:     req.Reason,
+		Comments:   req.Comments,
+		Fields:     fields,
+		CreatedBy:  createdBy,
+	})
+	if err != nil {
+		if isDuplicate(err) {
+			c.JSON(http.StatusConflict, response.Error(http.StatusConflict, "Ya existe una plantilla con ese título"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al crear la plantilla", err))
+		return
+	}
+
+	result := toTemplateResponse(template)
+	c.JSON(http.StatusCreated, response.Created("Plantilla creada", &result))
+}
+
+// GetAll lists the templates of the business. With ?specialty= or
+// ?professionalId= it narrows the list to the templates that apply to that
+// specialty, including the ones shared by every specialty.
+func (h *MedicalHistoryTemplateHandler) GetAll(c *gin.Context) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	params := sqlc.GetMedicalHistoryTemplatesParams{BusinessID: businessID}
+
+	if specialty := c.Query("specialty"); specialty != "" {
+		params.Specialty = pgtype.Text{String: specialty, Valid: true}
+	}
+
+	if professionalIDStr := c.Query("professionalId"); professionalIDStr != "" {
+		var professionalID pgtype.UUID
+		if err := professionalID.Scan(professionalIDStr); err != nil {
+			c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID del profesional inválido", err))
+			return
+		}
+
+		profile, err := h.repo.GetProfessionalProfile(c.Request.Context(), sqlc.GetProfessionalProfileByUserIDParams{
+			BusinessID: businessID,
+			UserID:     professionalID,
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Profesional no encontrado"))
+				return
+			}
+			c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener el profesional", err))
+			return
+		}
+		params.Specialty = pgtype.Text{String: profile.Specialty, Valid: true}
+	}
+
+	templates, err := h.repo.GetAll(c.Request.Context(), params)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener las plantillas", err))
+		return
+	}
+
+	result := make([]TemplateResponse, len(templates))
+	for i, t := range templates {
+		result[i] = toTemplateResponse(t)
+	}
+
+	c.JSON(http.StatusOK, response.Success("Plantillas encontradas", &result))
+}
+
+func (h *MedicalHistoryTemplateHandler) GetSpecialties(c *gin.Context) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	specialties, err := h.repo.GetSpecialties(c.Request.Context(), businessID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener las especialidades", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Success("Especialidades encontradas", &specialties))
+}
+
+func (h *MedicalHistoryTemplateHandler) GetByID(c *gin.Context) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(c.Param("id")); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID inválido", err))
+		return
+	}
+
+	template, err := h.repo.GetByID(c.Request.Context(), sqlc.GetMedicalHistoryTemplateByIDParams{
+		BusinessID: businessID,
+		ID:         id,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Plantilla no encontrada"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener la plantilla", err))
+		return
+	}
+
+	result := toTemplateResponse(template)
+	c.JSON(http.StatusOK, response.Success("Plantilla encontrada", &result))
+}
+
+func (h *MedicalHistoryTemplateHandler) Update(c *gin.Context) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(c.Param("id")); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID inválido", err))
+		return
+	}
+
+	var req UpdateTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Error de validación de datos", err))
+		return
+	}
+
+	if req.Specialty != nil && !h.checkSpecialty(c, businessID, *req.Specialty) {
+		return
+	}
+
+	params := sqlc.UpdateMedicalHistoryTemplateParams{
+		Title:      optionalText(req.Title),
+		Specialty:  optionalText(req.Specialty),
+		Reason:     optionalText(req.Reason),
+		Comments:   optionalText(req.Comments),
+		BusinessID: businessID,
+		ID:         id,
+	}
+	if req.Fields != nil {
+		fields := *req.Fields
+		if fields == nil {
+			fields = []Field{}
+		}
+		var err error
+		if params.Fields, err = json.Marshal(fields); err != nil {
+			c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Error de validación de datos", err))
+			return
+		}
+	}
+
+	template, err := h.repo.Update(c.Request.Context(), params)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Plantilla no encontrada"))
+			return
+		}
+		if isDuplicate(err) {
+			c.JSON(http.StatusConflict, response.Error(http.StatusConflict, "Ya existe una plantilla con ese título"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al actualizar la plantilla", err))
+		return
+	}
+
+	result := toTemplateResponse(template)
+	c.JSON(http.StatusOK, response.Success("Plantilla actualizada", &result))
+}
+
+func (h *MedicalHistoryTemplateHandler) Delete(c *gin.Context) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(c.Param("id")); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID inválido", err))
+		return
+	}
+
+	rows, err := h.repo.SoftDelete(c.Request.Context(), sqlc.SoftDeleteMedicalHistoryTemplateParams{
+		BusinessID: businessID,
+		ID:         id,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al eliminar la plantilla", err))
+		return
+	}
+	if rows == 0 {
+		c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Plantilla no encontrada"))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Success[any]("Plantilla eliminada", nil))
+}
+
+// checkSpecialty accepts an empty specialty (shared template) or one held by a
+// professional of the business.
+func (h *MedicalHistoryTemplateHandler) checkSpecialty(c *gin.Context, businessID pgtype.UUID, specialty string) bool {
+	if specialty == "" {
+		return true
+	}
+
+	specialties, err := h.repo.GetSpecialties(c.Request.Context(), businessID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener las especialidades", err))
+		return false
+	}
+
+	if !slices.ContainsFunc(specialties, func(s string) bool { return strings.EqualFold(s, specialty) }) {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Ningún profesional tiene esa especialidad"))
+		return false
+	}
+
+	return true
+}
+
+func optionalText(value *string) pgtype.Text {
+	if value == nil {
+		return pgtype.Text{}
+	}
+	return pgtype.Text{String: *value, Valid: true}
+}
+
+func isDuplicate(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
@@ -0,0 +1,48 @@
+package medical_history_template
+
+import (
+	"context"
+
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type MedicalHistoryTemplateRepository struct {
+	q *sqlc.Queries
+}
+
+func NewMedicalHistoryTemplateRepository(q *sqlc.Queries) *MedicalHistoryTemplateRepository {
+	return &MedicalHistoryTemplateRepository{q: q}
+}
+
+func (r *MedicalHistoryTemplateRepository) Create(ctx context.Context, arg sqlc.CreateMedicalHistoryTemplateParams) (sqlc.MedicalHistoryTemplate, error) {
+	return r.q.CreateMedicalHistoryTemplate(ctx, arg)
+}
+
+func (r *MedicalHistoryTemplateRepository) GetAll(ctx context.Context, arg sqlc.GetMedicalHistoryTemplatesParams) ([]sqlc.MedicalHistoryTemplate, error) {
+	return r.q.GetMedicalHistoryTemplates(ctx, arg)
+}
+
+func (r *MedicalHistoryTemplateRepository) GetByID(ctx context.Context, arg sqlc.GetMedicalHistoryTemplateByIDParams) (sqlc.MedicalHistoryTemplate, error) {
+	return r.q.GetMedicalHistoryTemplateByID(ctx, arg)
+}
+
+func (r *MedicalHistoryTemplateRepository) Update(ctx context.Context, arg sqlc.UpdateMedicalHistoryTemplateParams) (sqlc.MedicalHistoryTemplate, error) {
+	return r.q.UpdateMedicalHistoryTemplate(ctx, arg)
+}
+
+func (r *MedicalHistoryTemplateRepository) SoftDelete(ctx context.Context, arg sqlc.SoftDeleteMedicalHistoryTemplateParams) (int64, error) {
+	return r.q.SoftDeleteMedicalHistoryTemplate(ctx, arg)
+}
+
+func (r *MedicalHistoryTemplateRepository) GetSpecialties(ctx context.Context, businessID pgtype.UUID) ([]string, error) {
+	specialties, err := r.q.GetBusinessSpecialties(ctx, businessID)
+	if specialties == nil {
+		specialties = []string{}
+	}
+	return specialties, err
+}
+
+func (r *MedicalHistoryTemplateRepository) GetProfessionalProfile(ctx context.Context, arg sqlc.GetProfessionalProfileByUserIDParams) (sqlc.ProfessionalProfile, error) {
+	return r.q.GetProfessionalProfileByUserID(ctx, arg)
+}
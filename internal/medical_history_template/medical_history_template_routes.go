@@ -0,0 +1,23 @@
+package medical_history_template
+
+import (
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/alanloffler/go-calth-api/internal/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(router *gin.RouterGroup, q *sqlc.Queries) {
+	var repo *MedicalHistoryTemplateRepository = NewMedicalHistoryTemplateRepository(q)
+	var handler *MedicalHistoryTemplateHandler = NewMedicalHistoryTemplateHandler(repo)
+	var templates *gin.RouterGroup = router.Group("/medical-history-templates")
+
+	templates.POST("", middleware.PermissionMiddleware(q, "medical_history_templates-create"), handler.Create)
+
+	templates.GET("", middleware.PermissionMiddleware(q, "medical_history_templates-view"), handler.GetAll)
+	templates.GET("/specialties", middleware.PermissionMiddleware(q, "medical_history_templates-view"), handler.GetSpecialties)
+	templates.GET("/:id", middleware.PermissionMiddleware(q, "medical_history_templates-view"), handler.GetByID)
+
+	templates.PATCH("/:id", middleware.PermissionMiddleware(q, "medical_history_templates-update"), handler.Update)
+
+	templates.DELETE("/:id", middleware.PermissionMiddleware(q, "medical_history_templates-delete"), handler.Delete)
+}
@@ -0,0 +1,52 @@
+package medical_history_template
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+)
+
+// Field is a default field of a template. Fields are appended to the comments
+// of the seeded entry as "Label: value" lines for the professional to fill in.
+type Field struct {
+	Label string `json:"label" binding:"required,min=1,max=100"`
+	Value string `json:"value" binding:"max=500"`
+}
+
+// Seed returns the reason and comments of a new entry created from tpl. Values
+// the professional already wrote win over the template.
+func Seed(tpl sqlc.MedicalHistoryTemplate, reason, comments string) (string, string, error) {
+	if reason == "" {
+		reason = tpl.Reason
+	}
+	if comments != "" {
+		return reason, comments, nil
+	}
+
+	var fields []Field
+	if len(tpl.Fields) > 0 {
+		if err := json.Unmarshal(tpl.Fields, &fields); err != nil {
+			return "", "", err
+		}
+	}
+
+	var sections []string
+	if tpl.Comments != "" {
+		sections = append(sections, tpl.Comments)
+	}
+	if len(fields) > 0 {
+		lines := make([]string, len(fields))
+		for i, f := range fields {
+			lines[i] = strings.TrimSpace(f.Label + ": " + f.Value)
+		}
+		sections = append(sections, strings.Join(lines, "\n"))
+	}
+
+	return reason, strings.Join(sections, "\n\n"), nil
+}
+
+// AppliesTo reports whether tpl can be used by a professional of specialty.
+func AppliesTo(tpl sqlc.MedicalHistoryTemplate, specialty string) bool {
+	return tpl.Specialty == "" || strings.EqualFold(tpl.Specialty, specialty)
+}
@@ -0,0 +1,53 @@
+package medical_history_template
+
+import (
+	"testing"
+
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testTemplate() sqlc.MedicalHistoryTemplate {
+	return sqlc.MedicalHistoryTemplate{
+		Title:     "Control pediátrico",
+		Specialty: "Pediatría",
+		Reason:    "Control de niño sano",
+		Comments:  "Anamnesis:\n\nExamen físico:",
+		Fields:    []byte(`[{"label":"Peso","value":""},{"label":"Vacunas al día","value":"Sí"}]`),
+	}
+}
+
+func TestSeed_FromTemplate(t *testing.T) {
+	reason, comments, err := Seed(testTemplate(), "", "")
+	require.NoError(t, err)
+
+	assert.Equal(t, "Control de niño sano", reason)
+	assert.Equal(t, "Anamnesis:\n\nExamen físico:\n\nPeso:\nVacunas al día: Sí", comments)
+}
+
+func TestSeed_RequestWins(t *testing.T) {
+	reason, comments, err := Seed(testTemplate(), "Fiebre", "Consulta por fiebre de 48 hs")
+	require.NoError(t, err)
+
+	assert.Equal(t, "Fiebre", reason)
+	assert.Equal(t, "Consulta por fiebre de 48 hs", comments)
+}
+
+func TestSeed_WithoutFields(t *testing.T) {
+	tpl := testTemplate()
+	tpl.Fields = []byte(`[]`)
+
+	_, comments, err := Seed(tpl, "", "")
+	require.NoError(t, err)
+	assert.Equal(t, "Anamnesis:\n\nExamen físico:", comments)
+}
+
+func TestAppliesTo(t *testing.T) {
+	tpl := testTemplate()
+	assert.True(t, AppliesTo(tpl, "pediatría"))
+	assert.False(t, AppliesTo(tpl, "Cardiología"))
+
+	tpl.Specialty = ""
+	assert.True(t, AppliesTo(tpl, "Cardiología"))
+}
@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"context"
+	"log"
+
+	"github.com/alanloffler/go-calth-api/internal/common/ctxkeys"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const maxAuditUserAgentLength = 500
+
+// PatientLookup resolves the patient a resource belongs to. A nil lookup means
+// the route parameter already holds the patient ID.
+type PatientLookup func(ctx context.Context, businessID, resourceID pgtype.UUID) (pgtype.UUID, error)
+
+// AuditMiddleware records an access_audit_logs row for every request that reaches
+// the handler. Write failures are logged and never affect the response.
+func AuditMiddleware(q *sqlc.Queries, resource, param string, lookup PatientLookup) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		businessID, ok := ctxkeys.BusinessID(c)
+		if !ok {
+			return
+		}
+
+		var resourceID pgtype.UUID
+		if param != "" {
+			_ = resourceID.Scan(c.Param(param))
+		}
+
+		ctx := context.WithoutCancel(c.Request.Context())
+
+		patientID := resourceID
+		if lookup != nil && resourceID.Valid {
+			id, err := lookup(ctx, businessID, resourceID)
+			if err != nil {
+				id = pgtype.UUID{}
+			}
+			patientID = id
+		}
+
+		recordAccess(ctx, c, q, resource, resourceID, patientID)
+	}
+}
+
+// ImpersonationAuditMiddleware records every request made while a superadmin
+// impersonates a user, so the log shows everything done in their name. Routes
+// already audited by AuditMiddleware are not recorded twice.
+func ImpersonationAuditMiddleware(q *sqlc.Queries) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if _, ok := ctxkeys.ImpersonatorID(c); !ok || c.GetBool("accessAudited") {
+			return
+		}
+
+		recordAccess(context.WithoutCancel(c.Request.Context()), c, q, "impersonation", pgtype.UUID{}, pgtype.UUID{})
+	}
+}
+
+func recordAccess(ctx context.Context, c *gin.Context, q *sqlc.Queries, resource string, resourceID, patientID pgtype.UUID) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := ctxkeys.UserID(c)
+	if !ok {
+		return
+	}
+
+	roleID, _ := ctxkeys.RoleID(c)
+	impersonatorID, _ := ctxkeys.ImpersonatorID(c)
+
+	userAgent := c.Request.UserAgent()
+	if len(userAgent) > maxAuditUserAgentLength {
+		userAgent = userAgent[:maxAuditUserAgentLength]
+	}
+
+	c.Set("accessAudited", true)
+
+	if err := q.CreateAccessAuditLog(ctx, sqlc.CreateAccessAuditLogParams{
+		BusinessID:     businessID,
+		UserID:         userID,
+		RoleID:         roleID,
+		Resource:       resource,
+		ResourceID:     resourceID,
+		PatientID:      patientID,
+		Method:         c.Request.Method,
+		Path:           c.FullPath(),
+		StatusCode:     int32(c.Writer.Status()),
+		IpAddress:      c.ClientIP(),
+		UserAgent:      userAgent,
+		ImpersonatorID: impersonatorID,
+	}); err != nil {
+		log.Printf("failed to record %s access audit: %v", resource, err)
+	}
+}
@@ -1,18 +1,38 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/alanloffler/go-calth-api/internal/auth"
+	"github.com/alanloffler/go-calth-api/internal/common/apikey"
 	"github.com/alanloffler/go-calth-api/internal/common/response"
+	"github.com/alanloffler/go-calth-api/internal/common/token"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 )
 
-func AuthMiddleware(service *auth.AuthService) gin.HandlerFunc {
+// AuthMiddleware authenticates the request with an Authorization: Bearer
+// header or, failing that, the access_token cookie. A bearer token is either
+// an access token or an API key; the key acts as the user who created it,
+// limited to its scopes.
+func AuthMiddleware(service *auth.AuthService, q *sqlc.Queries) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		tokenStr, err := c.Cookie("access_token")
-		if err != nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Token requerido"))
+		tokenStr, ok := bearerToken(c)
+		if !ok {
+			cookie, err := c.Cookie("access_token")
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Token requerido"))
+				return
+			}
+			tokenStr = cookie
+		}
+
+		if apikey.IsKey(tokenStr) {
+			authenticateAPIKey(c, q, tokenStr)
 			return
 		}
 
@@ -26,7 +46,65 @@ func AuthMiddleware(service *auth.AuthService) gin.HandlerFunc {
 		c.Set("businessID", claims.BusinessID)
 		c.Set("roleID", claims.RoleID)
 		c.Set("isSuperAdmin", claims.IsSuperAdmin)
+		c.Set("sessionID", claims.SessionID)
+		if claims.ImpersonatorID != "" {
+			c.Set("impersonatorID", claims.ImpersonatorID)
+		}
+
+		c.Next()
+	}
+}
+
+// AccountOwnerMiddleware rejects requests made on behalf of the user, with an
+// API key or by an impersonating superadmin. It guards the routes that manage
+// the account itself, such as sessions, two-factor authentication and API keys,
+// which only the account holder may change.
+func AccountOwnerMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, exists := c.Get("apiKeyID"); exists {
+			c.AbortWithStatusJSON(http.StatusForbidden, response.Error(http.StatusForbidden, "Esta acción no está disponible con una clave de API"))
+			return
+		}
+
+		if _, exists := c.Get("impersonatorID"); exists {
+			c.AbortWithStatusJSON(http.StatusForbidden, response.Error(http.StatusForbidden, "Esta acción no está disponible mientras se suplanta a un usuario"))
+			return
+		}
 
 		c.Next()
 	}
 }
+
+// authenticateAPIKey sets the caller from the key, which must be neither
+// revoked nor expired and belong to an active user. The key takes the role of
+// that user, so PermissionMiddleware checks both the role and the scopes.
+func authenticateAPIKey(c *gin.Context, q *sqlc.Queries, key string) {
+	k, err := q.AuthenticateAPIKey(c.Request.Context(), token.Hash(key))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Clave de API inválida, revocada o expirada"))
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al verificar la clave de API", err))
+		return
+	}
+
+	c.Set("userID", uuid.UUID(k.UserID.Bytes).String())
+	c.Set("businessID", uuid.UUID(k.BusinessID.Bytes).String())
+	c.Set("roleID", uuid.UUID(k.RoleID.Bytes).String())
+	c.Set("isSuperAdmin", false)
+	c.Set("apiKeyID", uuid.UUID(k.ID.Bytes).String())
+	c.Set("apiKeyScopes", k.Scopes)
+
+	c.Next()
+}
+
+func bearerToken(c *gin.Context) (string, bool) {
+	scheme, tokenStr, found := strings.Cut(c.GetHeader("Authorization"), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+
+	tokenStr = strings.TrimSpace(tokenStr)
+	return tokenStr, tokenStr != ""
+}
@@ -0,0 +1,119 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/alanloffler/go-calth-api/internal/common/ctxkeys"
+	"github.com/alanloffler/go-calth-api/internal/common/response"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// EscalationMiddleware rejects the request when the role identified by
+// roleValue holds, in the current business, a permission the caller's role does
+// not. It guards every route that creates or controls accounts of that role, so
+// nobody can set up or take over an account more powerful than their own.
+func EscalationMiddleware(q *sqlc.Queries, roleValue string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if ctxkeys.IsSuperAdmin(c) {
+			c.Next()
+			return
+		}
+
+		businessID, ok := ctxkeys.BusinessID(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+			return
+		}
+
+		roleID, ok := ctxkeys.RoleID(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+			return
+		}
+
+		role, err := q.GetRoleByValue(c.Request.Context(), roleValue)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Rol no encontrado", err))
+			return
+		}
+
+		exceeds, err := RoleExceeds(c.Request.Context(), q, businessID, role.ID, roleID)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al verificar permisos", err))
+			return
+		}
+		if exceeds {
+			c.AbortWithStatusJSON(http.StatusForbidden, response.Error(http.StatusForbidden, "No puede gestionar usuarios con más permisos que los propios"))
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RoleExceeds reports whether roleID holds an effective permission in the
+// business that callerRoleID lacks.
+func RoleExceeds(ctx context.Context, q *sqlc.Queries, businessID, roleID, callerRoleID pgtype.UUID) (bool, error) {
+	if roleID == callerRoleID {
+		return false, nil
+	}
+
+	target, err := q.ListEffectivePermissions(ctx, sqlc.ListEffectivePermissionsParams{BusinessID: businessID, RoleID: roleID})
+	if err != nil {
+		return false, err
+	}
+
+	held, err := heldPermissions(ctx, q, businessID, callerRoleID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, p := range target {
+		if p.IsEffective && !held[p.ID] {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// CallerHolds reports whether callerRoleID holds every one of permissionIDs as
+// an effective permission in the business. Nobody may grant a permission they
+// do not hold themselves.
+func CallerHolds(ctx context.Context, q *sqlc.Queries, businessID, callerRoleID pgtype.UUID, permissionIDs ...pgtype.UUID) (bool, error) {
+	if len(permissionIDs) == 0 {
+		return true, nil
+	}
+
+	held, err := heldPermissions(ctx, q, businessID, callerRoleID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, permissionID := range permissionIDs {
+		if !held[permissionID] {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// heldPermissions returns the effective permissions of roleID in the business.
+func heldPermissions(ctx context.Context, q *sqlc.Queries, businessID, roleID pgtype.UUID) (map[pgtype.UUID]bool, error) {
+	rows, err := q.ListEffectivePermissions(ctx, sqlc.ListEffectivePermissionsParams{BusinessID: businessID, RoleID: roleID})
+	if err != nil {
+		return nil, err
+	}
+
+	held := make(map[pgtype.UUID]bool, len(rows))
+	for _, row := range rows {
+		if row.IsEffective {
+			held[row.ID] = true
+		}
+	}
+	return held, nil
+}
@@ -0,0 +1,176 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/alanloffler/go-calth-api/internal/common/ctxkeys"
+	"github.com/alanloffler/go-calth-api/internal/common/response"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// OwnershipMiddleware restricts the request to the caller's own resources unless
+// the role holds the elevated permission. Handlers read the restriction with
+// ctxkeys.OwnerScope and apply it to their queries.
+func OwnershipMiddleware(q *sqlc.Queries, elevatedKey string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if ctxkeys.IsSuperAdmin(c) {
+			c.Next()
+			return
+		}
+
+		businessID, ok := ctxkeys.BusinessID(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+			return
+		}
+
+		roleID, ok := ctxkeys.RoleID(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+			return
+		}
+
+		userID, exists := c.Get("userID")
+		if !exists {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+			return
+		}
+
+		elevated, err := HasPermission(c, q, businessID, roleID, elevatedKey)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al verificar permisos", err))
+			return
+		}
+
+		if !elevated {
+			c.Set("ownerScope", userID)
+		}
+
+		c.Next()
+	}
+}
+
+// OwnerScopeFor returns the user the caller is restricted to for resources
+// guarded by elevatedKey, or an invalid UUID when the caller sees them all. It
+// applies the rule of OwnershipMiddleware for handlers that need a second scope
+// in the same request.
+func OwnerScopeFor(c *gin.Context, q *sqlc.Queries, elevatedKey string) (pgtype.UUID, error) {
+	if ctxkeys.IsSuperAdmin(c) {
+		return pgtype.UUID{}, nil
+	}
+
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		return pgtype.UUID{}, errors.New("missing business in context")
+	}
+
+	roleID, ok := ctxkeys.RoleID(c)
+	if !ok {
+		return pgtype.UUID{}, errors.New("missing role in context")
+	}
+
+	userID, ok := ctxkeys.UserID(c)
+	if !ok {
+		return pgtype.UUID{}, errors.New("missing user in context")
+	}
+
+	elevated, err := HasPermission(c, q, businessID, roleID, elevatedKey)
+	if err != nil || elevated {
+		return pgtype.UUID{}, err
+	}
+
+	return userID, nil
+}
+
+// PatientScopeMiddleware rejects requests on a patient outside the caller's
+// ownership scope: a restricted professional only reaches the patients they
+// treat or have written a medical history for. It runs after
+// OwnershipMiddleware and reads the patient from the route parameter.
+func PatientScopeMiddleware(q *sqlc.Queries, param string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		inScope, ok := patientInScope(c, q, param)
+		if !ok {
+			return
+		}
+
+		if !inScope {
+			c.AbortWithStatusJSON(http.StatusForbidden, response.Error(http.StatusForbidden, "Permisos insuficientes"))
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// ClinicalAccessMiddleware records whether the caller may see the clinical data
+// of the patient in the route parameter: the role needs medical_history-view
+// and the patient has to be in its ownership scope. It never rejects the
+// request; handlers read the result with ctxkeys.HasClinicalAccess.
+func ClinicalAccessMiddleware(q *sqlc.Queries, param string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		businessID, ok := ctxkeys.BusinessID(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+			return
+		}
+
+		roleID, ok := ctxkeys.RoleID(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+			return
+		}
+
+		canView, err := HasPermission(c, q, businessID, roleID, "medical_history-view")
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al verificar permisos", err))
+			return
+		}
+
+		if canView {
+			inScope, ok := patientInScope(c, q, param)
+			if !ok {
+				return
+			}
+			c.Set("clinicalAccess", inScope)
+		}
+
+		c.Next()
+	}
+}
+
+// patientInScope reports whether the patient in the route parameter is in the
+// caller's ownership scope. It aborts the request and returns ok false when the
+// check itself fails.
+func patientInScope(c *gin.Context, q *sqlc.Queries, param string) (inScope bool, ok bool) {
+	owner := ctxkeys.OwnerScope(c)
+	if !owner.Valid {
+		return true, true
+	}
+
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return false, false
+	}
+
+	var patientID pgtype.UUID
+	if err := patientID.Scan(c.Param(param)); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID inválido", err))
+		return false, false
+	}
+
+	inScope, err := q.IsPatientInOwnerScope(c.Request.Context(), sqlc.IsPatientInOwnerScopeParams{
+		BusinessID: businessID,
+		PatientID:  patientID,
+		OwnerID:    owner,
+	})
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al verificar permisos", err))
+		return false, false
+	}
+
+	return inScope, true
+}
@@ -0,0 +1,199 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alanloffler/go-calth-api/internal/common/ctxkeys"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testBusinessID     = "00000000-0000-0000-0000-000000000001"
+	testRoleID         = "00000000-0000-0000-0000-000000000002"
+	testProfessionalID = "00000000-0000-0000-0000-000000000003"
+	testPatientID      = "00000000-0000-0000-0000-000000000004"
+)
+
+// stubDB answers the permission lookup from permissions and the patient scope
+// lookup with inScope.
+type stubDB struct {
+	permissions  map[string]bool
+	inScope      bool
+	scopeLookups int
+}
+
+func (db *stubDB) Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, nil
+}
+
+func (db *stubDB) Query(context.Context, string, ...interface{}) (pgx.Rows, error) {
+	return nil, pgx.ErrNoRows
+}
+
+func (db *stubDB) QueryRow(_ context.Context, _ string, args ...interface{}) pgx.Row {
+	if actionKey, ok := args[len(args)-1].(string); ok {
+		return boolRow(db.permissions[actionKey])
+	}
+	db.scopeLookups++
+	return boolRow(db.inScope)
+}
+
+type boolRow bool
+
+func (r boolRow) Scan(dest ...any) error {
+	*dest[0].(*bool) = bool(r)
+	return nil
+}
+
+// serveOwnership runs the middleware chain for a request on the test patient
+// and returns the response and the context the last handler saw.
+func serveOwnership(db *stubDB, superAdmin bool, patientID string, chain ...func(q *sqlc.Queries) gin.HandlerFunc) (*httptest.ResponseRecorder, *gin.Context) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	q := sqlc.New(db)
+
+	var seen *gin.Context
+	handlers := []gin.HandlerFunc{func(c *gin.Context) {
+		c.Set("businessID", testBusinessID)
+		c.Set("roleID", testRoleID)
+		c.Set("userID", testProfessionalID)
+		c.Set("isSuperAdmin", superAdmin)
+		c.Next()
+	}}
+	for _, m := range chain {
+		handlers = append(handlers, m(q))
+	}
+	handlers = append(handlers, func(c *gin.Context) {
+		seen = c.Copy()
+		c.Status(http.StatusOK)
+	})
+	router.GET("/users/:id/patient", handlers...)
+
+	req, _ := http.NewRequest(http.MethodGet, "/users/"+patientID+"/patient", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w, seen
+}
+
+func ownership(q *sqlc.Queries) gin.HandlerFunc {
+	return OwnershipMiddleware(q, "medical_history-all")
+}
+
+func patientScope(q *sqlc.Queries) gin.HandlerFunc {
+	return PatientScopeMiddleware(q, "id")
+}
+
+func clinicalAccess(q *sqlc.Queries) gin.HandlerFunc {
+	return ClinicalAccessMiddleware(q, "id")
+}
+
+func TestOwnershipMiddleware(t *testing.T) {
+	cases := []struct {
+		name        string
+		superAdmin  bool
+		permissions map[string]bool
+		wantScoped  bool
+	}{
+		{name: "role with medical_history-all", permissions: map[string]bool{"medical_history-all": true}},
+		{name: "role without medical_history-all", permissions: map[string]bool{"medical_history-view": true}, wantScoped: true},
+		{name: "super admin", superAdmin: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			w, c := serveOwnership(&stubDB{permissions: tc.permissions}, tc.superAdmin, testPatientID, ownership)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			owner := ctxkeys.OwnerScope(c)
+			assert.Equal(t, tc.wantScoped, owner.Valid)
+			if tc.wantScoped {
+				assert.Equal(t, testProfessionalID, owner.String())
+			}
+		})
+	}
+}
+
+func TestPatientScopeMiddleware(t *testing.T) {
+	cases := []struct {
+		name        string
+		permissions map[string]bool
+		inScope     bool
+		patientID   string
+		wantStatus  int
+		wantLookups int
+	}{
+		{name: "role with medical_history-all", permissions: map[string]bool{"medical_history-all": true}, patientID: testPatientID, wantStatus: http.StatusOK},
+		{name: "professional treating the patient", inScope: true, patientID: testPatientID, wantStatus: http.StatusOK, wantLookups: 1},
+		{name: "unrelated professional", patientID: testPatientID, wantStatus: http.StatusForbidden, wantLookups: 1},
+		{name: "invalid patient ID", patientID: "not-a-uuid", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := &stubDB{permissions: tc.permissions, inScope: tc.inScope}
+			w, _ := serveOwnership(db, false, tc.patientID, ownership, patientScope)
+
+			assert.Equal(t, tc.wantStatus, w.Code)
+			assert.Equal(t, tc.wantLookups, db.scopeLookups)
+		})
+	}
+}
+
+func TestClinicalAccessMiddleware(t *testing.T) {
+	cases := []struct {
+		name        string
+		permissions map[string]bool
+		inScope     bool
+		want        bool
+	}{
+		{name: "without medical_history-view", permissions: map[string]bool{"medical_history-all": true}},
+		{name: "with medical_history-all", permissions: map[string]bool{"medical_history-view": true, "medical_history-all": true}, want: true},
+		{name: "treating the patient", permissions: map[string]bool{"medical_history-view": true}, inScope: true, want: true},
+		{name: "not treating the patient", permissions: map[string]bool{"medical_history-view": true}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := &stubDB{permissions: tc.permissions, inScope: tc.inScope}
+			w, c := serveOwnership(db, false, testPatientID, ownership, clinicalAccess)
+
+			// The profile is still served; only its clinical part depends on access.
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tc.want, ctxkeys.HasClinicalAccess(c))
+		})
+	}
+}
+
+func TestOwnerScopeFor(t *testing.T) {
+	cases := []struct {
+		name        string
+		superAdmin  bool
+		permissions map[string]bool
+		wantScoped  bool
+	}{
+		{name: "role with events-all", permissions: map[string]bool{"events-all": true}},
+		{name: "role without events-all", permissions: map[string]bool{"medical_history-all": true}, wantScoped: true},
+		{name: "super admin", superAdmin: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := &stubDB{permissions: tc.permissions}
+			_, c := serveOwnership(db, tc.superAdmin, testPatientID)
+
+			owner, err := OwnerScopeFor(c, sqlc.New(db), "events-all")
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantScoped, owner.Valid)
+			if tc.wantScoped {
+				assert.Equal(t, testProfessionalID, owner.String())
+			}
+		})
+	}
+}
@@ -3,10 +3,12 @@ package middleware
 import (
 	"net/http"
 
+	"github.com/alanloffler/go-calth-api/internal/common/apikey"
 	"github.com/alanloffler/go-calth-api/internal/common/ctxkeys"
 	"github.com/alanloffler/go-calth-api/internal/common/response"
 	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type PermissionMode string
@@ -45,11 +47,7 @@ func PermissionMiddleware(q *sqlc.Queries, permissions any, mode ...PermissionMo
 
 		var matched int
 		for _, key := range keys {
-			has, err := q.HasEffectivePermission(c.Request.Context(), sqlc.HasEffectivePermissionParams{
-				BusinessID: businessID,
-				RoleID:     roleID,
-				ActionKey:  key,
-			})
+			has, err := HasPermission(c, q, businessID, roleID, key)
 			if err != nil {
 				c.AbortWithStatusJSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al verificar permisos", err))
 				return
@@ -75,3 +73,17 @@ func PermissionMiddleware(q *sqlc.Queries, permissions any, mode ...PermissionMo
 		c.Next()
 	}
 }
+
+// HasPermission checks that the caller's role holds the permission and, for
+// requests made with an API key, that the key is scoped to it.
+func HasPermission(c *gin.Context, q *sqlc.Queries, businessID, roleID pgtype.UUID, actionKey string) (bool, error) {
+	if scopes, ok := ctxkeys.APIKeyScopes(c); ok && !apikey.Allows(scopes, actionKey) {
+		return false, nil
+	}
+
+	return q.HasEffectivePermission(c.Request.Context(), sqlc.HasEffectivePermissionParams{
+		BusinessID: businessID,
+		RoleID:     roleID,
+		ActionKey:  actionKey,
+	})
+}
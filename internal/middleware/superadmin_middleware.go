@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/alanloffler/go-calth-api/internal/common/ctxkeys"
+	"github.com/alanloffler/go-calth-api/internal/common/response"
+	"github.com/gin-gonic/gin"
+)
+
+func SuperAdminMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !ctxkeys.IsSuperAdmin(c) {
+			c.AbortWithStatusJSON(http.StatusForbidden, response.Error(http.StatusForbidden, "Acceso restringido a superadministradores"))
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,389 @@
+package patient_import
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/alanloffler/go-calth-api/internal/user"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// maxImportRows bounds a single file so validation stays within a request.
+const maxImportRows = 10000
+
+// existingLookupBatch is the number of rows checked against the database per
+// query when looking for identifiers that are already taken.
+const existingLookupBatch = 500
+
+var (
+	ErrEmptyFile   = errors.New("el archivo no contiene pacientes")
+	ErrTooManyRows = fmt.Errorf("el archivo supera el máximo de %d pacientes", maxImportRows)
+)
+
+// columns lists the accepted CSV headers. Headers are matched ignoring case,
+// spaces, underscores and dashes, so "first_name" and "First Name" both map to
+// firstName. Only password is optional: a random one is generated when it is
+// missing and the patient sets their own later.
+var columns = []string{
+	"ic",
+	"userName",
+	"firstName",
+	"lastName",
+	"email",
+	"password",
+	"phoneNumber",
+	"gender",
+	"birthDay",
+	"bloodType",
+	"weight",
+	"height",
+	"emergencyContactName",
+	"emergencyContactPhone",
+}
+
+var optionalColumns = map[string]bool{"password": true}
+
+// MissingColumnsError reports required headers that are not in the file.
+type MissingColumnsError struct {
+	Columns []string
+}
+
+func (e *MissingColumnsError) Error() string {
+	return "faltan columnas obligatorias: " + strings.Join(e.Columns, ", ")
+}
+
+// RowError is one problem found in a row. Row is the line number in the file,
+// counting the header as line 1.
+type RowError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+type row struct {
+	line    int
+	request user.CreatePatientRequest
+	// errors found while converting the raw values, before struct validation.
+	errors []RowError
+}
+
+// Report summarizes the validation of a file.
+type Report struct {
+	TotalRows   int        `json:"totalRows"`
+	ValidRows   int        `json:"validRows"`
+	InvalidRows int        `json:"invalidRows"`
+	Errors      []RowError `json:"errors"`
+}
+
+func normalizeHeader(h string) string {
+	h = strings.TrimPrefix(h, "\ufeff")
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '_', '-':
+			return -1
+		}
+		return r
+	}, strings.ToLower(strings.TrimSpace(h)))
+}
+
+// parseCSV reads the rows of an import file. Both comma and semicolon
+// separated files are accepted, the latter being what spreadsheets export with
+// a Spanish locale.
+func parseCSV(data []byte) ([]row, error) {
+	firstLine, _, _ := bytes.Cut(data, []byte("\n"))
+
+	r := csv.NewReader(bytes.NewReader(data))
+	if bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		r.Comma = ';'
+	}
+	r.TrimLeadingSpace = true
+	r.FieldsPerRecord = -1
+
+	header, err := r.Read()
+	if err == io.EOF {
+		return nil, ErrEmptyFile
+	}
+	if err != nil {
+		return nil, fmt.Errorf("CSV inválido: %w", err)
+	}
+
+	index := make(map[string]int, len(header))
+	for i, h := range header {
+		index[normalizeHeader(h)] = i
+	}
+
+	positions := make(map[string]int, len(columns))
+	var missing []string
+	for _, col := range columns {
+		i, ok := index[normalizeHeader(col)]
+		if !ok {
+			if !optionalColumns[col] {
+				missing = append(missing, col)
+			}
+			continue
+		}
+		positions[col] = i
+	}
+	if len(missing) > 0 {
+		return nil, &MissingColumnsError{Columns: missing}
+	}
+
+	var rows []row
+	for line := 2; ; line++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("CSV inválido en la línea %d: %w", line, err)
+		}
+		if isBlank(record) {
+			continue
+		}
+		if len(rows) == maxImportRows {
+			return nil, ErrTooManyRows
+		}
+
+		get := func(col string) string {
+			i, ok := positions[col]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+		rows = append(rows, toRow(line, get))
+	}
+
+	if len(rows) == 0 {
+		return nil, ErrEmptyFile
+	}
+
+	return rows, nil
+}
+
+func isBlank(record []string) bool {
+	for _, v := range record {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func toRow(line int, get func(string) string) row {
+	rw := row{line: line}
+	req := &rw.request
+
+	req.User = user.CreateUserData{
+		Ic:          get("ic"),
+		UserName:    get("userName"),
+		FirstName:   get("firstName"),
+		LastName:    get("lastName"),
+		Email:       strings.ToLower(get("email")),
+		Password:    get("password"),
+		PhoneNumber: get("phoneNumber"),
+	}
+	if req.User.Password == "" {
+		req.User.Password = randomPassword()
+	}
+
+	req.Profile = user.CreatePatientProfileData{
+		Gender:                user.Gender(strings.ToLower(get("gender"))),
+		BirthDay:              get("birthDay"),
+		BloodType:             strings.ToUpper(get("bloodType")),
+		EmergencyContactName:  get("emergencyContactName"),
+		EmergencyContactPhone: get("emergencyContactPhone"),
+	}
+
+	req.Profile.Weight = rw.parseNumber("weight", get("weight"))
+	req.Profile.Height = rw.parseNumber("height", get("height"))
+
+	return rw
+}
+
+// parseNumber accepts both decimal points and decimal commas. Empty values are
+// left at zero so the required rule reports them.
+func (rw *row) parseNumber(field, value string) float64 {
+	if value == "" {
+		return 0
+	}
+
+	n, err := strconv.ParseFloat(strings.Replace(value, ",", ".", 1), 64)
+	if err != nil {
+		rw.errors = append(rw.errors, RowError{Row: rw.line, Field: field, Message: "Debe ser un número"})
+		return 0
+	}
+
+	return n
+}
+
+func randomPassword() string {
+	b := make([]byte, 18)
+	_, _ = rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// validateRows applies the CreatePatient rules to every row and flags
+// identifiers repeated inside the file. It returns the errors per line.
+func validateRows(rows []row) map[int][]RowError {
+	found := make(map[int][]RowError)
+	seen := map[string]map[string]int{"ic": {}, "email": {}, "userName": {}}
+
+	for _, rw := range rows {
+		errs := append([]RowError(nil), rw.errors...)
+
+		if err := binding.Validator.ValidateStruct(&rw.request); err != nil {
+			var verrs validator.ValidationErrors
+			if !errors.As(err, &verrs) {
+				errs = append(errs, RowError{Row: rw.line, Message: err.Error()})
+			}
+			for _, fe := range verrs {
+				field := lowerFirst(fe.Field())
+				if hasField(errs, field) {
+					continue
+				}
+				errs = append(errs, RowError{Row: rw.line, Field: field, Message: ruleMessage(fe)})
+			}
+		}
+
+		for field, value := range map[string]string{"ic": rw.request.User.Ic, "email": rw.request.User.Email, "userName": rw.request.User.UserName} {
+			if value == "" {
+				continue
+			}
+			if first, dup := seen[field][value]; dup {
+				errs = append(errs, RowError{Row: rw.line, Field: field, Message: fmt.Sprintf("Repetido en la línea %d", first)})
+				continue
+			}
+			seen[field][value] = rw.line
+		}
+
+		if len(errs) > 0 {
+			found[rw.line] = errs
+		}
+	}
+
+	return found
+}
+
+// checkExisting flags rows whose IC, email or user name is already registered
+// in the business.
+func checkExisting(ctx context.Context, q *sqlc.Queries, businessID pgtype.UUID, rows []row, found map[int][]RowError) error {
+	for start := 0; start < len(rows); start += existingLookupBatch {
+		batch := rows[start:min(start+existingLookupBatch, len(rows))]
+
+		params := sqlc.GetExistingUserIdentifiersParams{BusinessID: businessID}
+		for _, rw := range batch {
+			params.Ics = append(params.Ics, rw.request.User.Ic)
+			params.Emails = append(params.Emails, rw.request.User.Email)
+			params.UserNames = append(params.UserNames, rw.request.User.UserName)
+		}
+
+		existing, err := q.GetExistingUserIdentifiers(ctx, params)
+		if err != nil {
+			return err
+		}
+		if len(existing) == 0 {
+			continue
+		}
+
+		taken := map[string]map[string]bool{"ic": {}, "email": {}, "userName": {}}
+		for _, e := range existing {
+			taken["ic"][e.Ic] = true
+			taken["email"][e.Email] = true
+			taken["userName"][e.UserName] = true
+		}
+
+		for _, rw := range batch {
+			for field, value := range map[string]string{"ic": rw.request.User.Ic, "email": rw.request.User.Email, "userName": rw.request.User.UserName} {
+				if taken[field][value] {
+					found[rw.line] = append(found[rw.line], RowError{Row: rw.line, Field: field, Message: "Ya está registrado"})
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// validate runs every check and builds the report. Errors are sorted by line
+// and field so reports are stable.
+func validate(ctx context.Context, q *sqlc.Queries, businessID pgtype.UUID, rows []row) (Report, map[int][]RowError, error) {
+	found := validateRows(rows)
+	if err := checkExisting(ctx, q, businessID, rows, found); err != nil {
+		return Report{}, nil, err
+	}
+
+	report := Report{TotalRows: len(rows), Errors: []RowError{}}
+	for _, rw := range rows {
+		errs := found[rw.line]
+		if len(errs) == 0 {
+			report.ValidRows++
+			continue
+		}
+		report.InvalidRows++
+		sortErrors(errs)
+		report.Errors = append(report.Errors, errs...)
+	}
+
+	return report, found, nil
+}
+
+func sortErrors(errs []RowError) {
+	for i := 1; i < len(errs); i++ {
+		for j := i; j > 0 && errs[j].Field < errs[j-1].Field; j-- {
+			errs[j], errs[j-1] = errs[j-1], errs[j]
+		}
+	}
+}
+
+func hasField(errs []RowError, field string) bool {
+	for _, e := range errs {
+		if e.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+func ruleMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "Campo obligatorio"
+	case "len":
+		return fmt.Sprintf("Debe tener %s caracteres", fe.Param())
+	case "min":
+		return fmt.Sprintf("Debe tener al menos %s caracteres", fe.Param())
+	case "max":
+		return fmt.Sprintf("Debe tener como máximo %s caracteres", fe.Param())
+	case "email":
+		return "Email inválido"
+	case "numeric":
+		return "Debe contener solo números"
+	case "oneof":
+		return "Valor inválido, opciones: " + strings.ReplaceAll(fe.Param(), " ", ", ")
+	case "datetime":
+		return "Formato de fecha inválido, use AAAA-MM-DD"
+	case "gt", "lt":
+		return "Valor fuera de rango"
+	default:
+		return "Valor inválido"
+	}
+}
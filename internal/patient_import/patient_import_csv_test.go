@@ -0,0 +1,93 @@
+package patient_import
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const header = "ic,user_name,first_name,last_name,email,password,phone_number,gender,birth_day,blood_type,weight,height,emergency_contact_name,emergency_contact_phone\n"
+
+func validLine(ic, email, userName string) string {
+	return ic + "," + userName + ",Juan,Pérez," + email + ",secreto123,1122334455,male,1990-05-20,A+,70.5,175,María Pérez,1133445566\n"
+}
+
+func TestParseCSV(t *testing.T) {
+	rows, err := parseCSV([]byte(header + validLine("12345678", "Juan@Mail.com", "jperez") + ",,,,,,,,,,,,,\n"))
+
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, 2, rows[0].line)
+	assert.Equal(t, "juan@mail.com", rows[0].request.User.Email)
+	assert.Equal(t, 70.5, rows[0].request.Profile.Weight)
+}
+
+func TestParseCSV_SemicolonAndDecimalComma(t *testing.T) {
+	data := "IC;User Name;First Name;Last Name;Email;Phone Number;Gender;Birth Day;Blood Type;Weight;Height;Emergency Contact Name;Emergency Contact Phone\n" +
+		"12345678;jperez;Juan;Pérez;juan@mail.com;1122334455;Male;1990-05-20;a+;70,5;175;María;1133445566\n"
+
+	rows, err := parseCSV([]byte(data))
+
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, 70.5, rows[0].request.Profile.Weight)
+	assert.Equal(t, "A+", rows[0].request.Profile.BloodType)
+	assert.NotEmpty(t, rows[0].request.User.Password)
+	assert.Empty(t, validateRows(rows))
+}
+
+func TestParseCSV_MissingColumns(t *testing.T) {
+	_, err := parseCSV([]byte("ic,email\n12345678,juan@mail.com\n"))
+
+	var missing *MissingColumnsError
+	require.ErrorAs(t, err, &missing)
+	assert.Contains(t, missing.Columns, "userName")
+	assert.NotContains(t, missing.Columns, "password")
+}
+
+func TestParseCSV_Empty(t *testing.T) {
+	_, err := parseCSV([]byte(header))
+	assert.ErrorIs(t, err, ErrEmptyFile)
+
+	_, err = parseCSV(nil)
+	assert.ErrorIs(t, err, ErrEmptyFile)
+}
+
+func TestParseCSV_TooManyRows(t *testing.T) {
+	var b strings.Builder
+	b.WriteString(header)
+	for i := 0; i <= maxImportRows; i++ {
+		b.WriteString(validLine("12345678", "juan@mail.com", "jperez"))
+	}
+
+	_, err := parseCSV([]byte(b.String()))
+	assert.ErrorIs(t, err, ErrTooManyRows)
+}
+
+func TestValidateRows(t *testing.T) {
+	data := header +
+		validLine("12345678", "juan@mail.com", "jperez") +
+		"123,jgomez,Juan,Gómez,no-es-email,secreto123,1122334455,otro,20-05-1990,X,abc,175,María,11\n" +
+		validLine("87654321", "juan@mail.com", "jperez2")
+
+	rows, err := parseCSV([]byte(data))
+	require.NoError(t, err)
+
+	found := validateRows(rows)
+
+	assert.NotContains(t, found, 2)
+
+	fields := map[string]bool{}
+	for _, e := range found[3] {
+		assert.Equal(t, 3, e.Row)
+		fields[e.Field] = true
+	}
+	for _, field := range []string{"ic", "email", "gender", "birthDay", "bloodType", "weight", "emergencyContactPhone"} {
+		assert.True(t, fields[field], field)
+	}
+
+	require.Len(t, found[4], 1)
+	assert.Equal(t, RowError{Row: 4, Field: "email", Message: "Repetido en la línea 2"}, found[4][0])
+}
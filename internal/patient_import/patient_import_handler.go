@@ -0,0 +1,269 @@
+package patient_import
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alanloffler/go-calth-api/internal/common/ctxkeys"
+	"github.com/alanloffler/go-calth-api/internal/common/encryption"
+	"github.com/alanloffler/go-calth-api/internal/common/response"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/alanloffler/go-calth-api/internal/queue"
+	"github.com/alanloffler/go-calth-api/internal/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+)
+
+type PatientImportHandler struct {
+	repo          *PatientImportRepository
+	keys          *encryption.Keyring
+	storage       storage.Storage
+	queueClient   *asynq.Client
+	maxUploadSize int64
+}
+
+type ImportResponse struct {
+	ID            string     `json:"id"`
+	RequestedBy   string     `json:"requestedBy"`
+	FileName      string     `json:"fileName"`
+	Status        string     `json:"status"`
+	TotalRows     int32      `json:"totalRows"`
+	ProcessedRows int32      `json:"processedRows"`
+	ImportedRows  int32      `json:"importedRows"`
+	FailedRows    int32      `json:"failedRows"`
+	Errors        []RowError `json:"errors"`
+	Error         *string    `json:"error"`
+	CreatedAt     string     `json:"createdAt"`
+	CompletedAt   *string    `json:"completedAt"`
+}
+
+func NewPatientImportHandler(repo *PatientImportRepository, keys *encryption.Keyring, store storage.Storage, queueClient *asynq.Client, maxUploadSize int64) *PatientImportHandler {
+	return &PatientImportHandler{repo: repo, keys: keys, storage: store, queueClient: queueClient, maxUploadSize: maxUploadSize}
+}
+
+// Create validates an uploaded CSV of patients. With dryRun=true only the
+// validation report is returned. Otherwise a file without errors is stored
+// encrypted and imported by the worker; its progress is read with GetByID.
+func (h *PatientImportHandler) Create(c *gin.Context) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	userID, ok := ctxkeys.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	dryRun := c.Query("dryRun") == "true"
+
+	// Leave some room for the multipart envelope on top of the file itself.
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxUploadSize+1<<20)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, response.Error(http.StatusRequestEntityTooLarge, "El archivo supera el tamaño máximo permitido"))
+			return
+		}
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Archivo requerido", err))
+		return
+	}
+
+	if fileHeader.Size > h.maxUploadSize {
+		c.JSON(http.StatusRequestEntityTooLarge, response.Error(http.StatusRequestEntityTooLarge, "El archivo supera el tamaño máximo permitido"))
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Error al leer el archivo", err))
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Error al leer el archivo", err))
+		return
+	}
+
+	rows, err := parseCSV(data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Archivo CSV inválido", err))
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	report, err := h.repo.Validate(ctx, businessID, rows)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al validar el archivo", err))
+		return
+	}
+
+	if dryRun {
+		c.JSON(http.StatusOK, response.Success("Validación completada", &report))
+		return
+	}
+
+	if report.InvalidRows > 0 {
+		c.JSON(http.StatusUnprocessableEntity, response.ApiResponse[Report]{
+			StatusCode: http.StatusUnprocessableEntity,
+			Message:    "El archivo contiene errores, no se importó ningún paciente",
+			Data:       &report,
+		})
+		return
+	}
+
+	// The file holds personal data, so it is kept encrypted until the worker
+	// has imported it.
+	encrypted, err := h.keys.Encrypt(ctx, businessID, string(data))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al cifrar el archivo", err))
+		return
+	}
+
+	key := fmt.Sprintf("%s/patient-imports/%s.csv", uuid.UUID(businessID.Bytes), uuid.New())
+	if err := h.storage.Put(ctx, key, strings.NewReader(encrypted), int64(len(encrypted)), "text/csv"); err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al guardar el archivo", err))
+		return
+	}
+
+	imp, err := h.repo.Create(ctx, sqlc.CreatePatientImportParams{
+		BusinessID:  businessID,
+		RequestedBy: userID,
+		FileName:    filepath.Base(fileHeader.Filename),
+		StorageKey:  key,
+		TotalRows:   int32(len(rows)),
+	})
+	if err != nil {
+		_ = h.storage.Delete(ctx, key)
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al crear la importación", err))
+		return
+	}
+
+	if err := queue.EnqueuePatientImport(h.queueClient, queue.PatientImportPayload{
+		ImportID:   uuid.UUID(imp.ID.Bytes).String(),
+		BusinessID: uuid.UUID(businessID.Bytes).String(),
+	}); err != nil {
+		_ = h.repo.UpdateStatus(ctx, sqlc.UpdatePatientImportStatusParams{
+			ID:     imp.ID,
+			Status: "failed",
+			Error:  pgtype.Text{String: "No se pudo encolar la importación", Valid: true},
+		})
+		_ = h.storage.Delete(ctx, key)
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al encolar la importación", err))
+		return
+	}
+
+	result := toImportResponse(imp)
+	c.JSON(http.StatusAccepted, response.Success("Importación en proceso", &result))
+}
+
+func (h *PatientImportHandler) GetAll(c *gin.Context) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	limit := int32(defaultListLimit)
+	if limitStr := c.Query("limit"); limitStr != "" {
+		parsedLimit, err := strconv.ParseInt(limitStr, 10, 32)
+		if err != nil || parsedLimit < 1 || parsedLimit > maxListLimit {
+			c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Límite inválido", err))
+			return
+		}
+		limit = int32(parsedLimit)
+	}
+
+	imports, err := h.repo.GetAll(c.Request.Context(), sqlc.GetPatientImportsParams{BusinessID: businessID, QueryLimit: limit})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener las importaciones", err))
+		return
+	}
+
+	result := make([]ImportResponse, len(imports))
+	for i, imp := range imports {
+		result[i] = toImportResponse(imp)
+	}
+
+	c.JSON(http.StatusOK, response.Success("Importaciones encontradas", &result))
+}
+
+func (h *PatientImportHandler) GetByID(c *gin.Context) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(c.Param("id")); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID inválido", err))
+		return
+	}
+
+	imp, err := h.repo.GetByID(c.Request.Context(), sqlc.GetPatientImportParams{BusinessID: businessID, ID: id})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Importación no encontrada"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener la importación", err))
+		return
+	}
+
+	result := toImportResponse(imp)
+	c.JSON(http.StatusOK, response.Success("Importación encontrada", &result))
+}
+
+func toImportResponse(imp sqlc.PatientImport) ImportResponse {
+	var importErr *string
+	if imp.Error.Valid {
+		importErr = &imp.Error.String
+	}
+
+	var completedAt *string
+	if imp.CompletedAt.Valid {
+		s := imp.CompletedAt.Time.Format(time.RFC3339)
+		completedAt = &s
+	}
+
+	rowErrors := []RowError{}
+	_ = json.Unmarshal(imp.Errors, &rowErrors)
+
+	return ImportResponse{
+		ID:            uuid.UUID(imp.ID.Bytes).String(),
+		RequestedBy:   uuid.UUID(imp.RequestedBy.Bytes).String(),
+		FileName:      imp.FileName,
+		Status:        imp.Status,
+		TotalRows:     imp.TotalRows,
+		ProcessedRows: imp.ProcessedRows,
+		ImportedRows:  imp.ImportedRows,
+		FailedRows:    imp.FailedRows,
+		Errors:        rowErrors,
+		Error:         importErr,
+		CreatedAt:     imp.CreatedAt.Time.Format(time.RFC3339),
+		CompletedAt:   completedAt,
+	}
+}
@@ -0,0 +1,243 @@
+package patient_import
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/alanloffler/go-calth-api/internal/common/encryption"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/alanloffler/go-calth-api/internal/patient_profile"
+	"github.com/alanloffler/go-calth-api/internal/storage"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// importBatchSize is the number of rows committed per transaction. Progress is
+// saved in the same transaction, so a retried task resumes after the last
+// committed batch.
+const importBatchSize = 100
+
+// maxStoredErrors bounds the row errors kept on the import.
+const maxStoredErrors = 500
+
+// ProcessImport creates the patients of a queued import. It runs in the worker
+// and is safe to retry: completed imports are left untouched and partially
+// processed ones continue where they stopped.
+func ProcessImport(ctx context.Context, pool *pgxpool.Pool, keys *encryption.Keyring, store storage.Storage, businessID, importID pgtype.UUID) error {
+	q := sqlc.New(pool)
+
+	imp, err := q.GetPatientImport(ctx, sqlc.GetPatientImportParams{BusinessID: businessID, ID: importID})
+	if err != nil {
+		return fmt.Errorf("get import: %w", err)
+	}
+
+	if imp.Status == "completed" {
+		return nil
+	}
+
+	if err := q.UpdatePatientImportStatus(ctx, sqlc.UpdatePatientImportStatusParams{ID: imp.ID, Status: "processing"}); err != nil {
+		return fmt.Errorf("mark import processing: %w", err)
+	}
+
+	rows, err := loadRows(ctx, keys, store, imp)
+	if err != nil {
+		markFailed(ctx, q, imp.ID, "Error al leer el archivo")
+		if errors.Is(err, storage.ErrNotFound) {
+			// Retrying cannot bring back a missing file.
+			return nil
+		}
+		return err
+	}
+
+	role, err := q.GetRoleByValue(ctx, "patient")
+	if err != nil {
+		markFailed(ctx, q, imp.ID, "Rol de paciente no encontrado")
+		return fmt.Errorf("get patient role: %w", err)
+	}
+
+	rowErrors := []RowError{}
+	_ = json.Unmarshal(imp.Errors, &rowErrors)
+
+	processed := int(imp.ProcessedRows)
+	imported := imp.ImportedRows
+	failed := imp.FailedRows
+
+	// The file was validated on upload, but patients may have been created
+	// since then, so the remaining rows are checked again.
+	pending := rows[min(processed, len(rows)):]
+	_, found, err := validate(ctx, q, businessID, pending)
+	if err != nil {
+		return fmt.Errorf("validate rows: %w", err)
+	}
+
+	profiles := patient_profile.NewPatientProfileRepository(q, keys)
+
+	for start := 0; start < len(pending); start += importBatchSize {
+		batch := pending[start:min(start+importBatchSize, len(pending))]
+
+		tx, err := pool.Begin(ctx)
+		if err != nil {
+			return fmt.Errorf("begin batch: %w", err)
+		}
+
+		for _, rw := range batch {
+			if errs := found[rw.line]; len(errs) > 0 {
+				failed++
+				rowErrors = append(rowErrors, errs...)
+				continue
+			}
+
+			if err := importRow(ctx, tx, q, profiles, businessID, role.ID, rw); err != nil {
+				if ctx.Err() != nil {
+					_ = tx.Rollback(ctx)
+					return ctx.Err()
+				}
+				failed++
+				rowErrors = append(rowErrors, rowErrorFor(rw.line, err))
+				continue
+			}
+			imported++
+		}
+
+		processed += len(batch)
+		if len(rowErrors) > maxStoredErrors {
+			rowErrors = rowErrors[:maxStoredErrors]
+		}
+
+		errorsJSON, err := json.Marshal(rowErrors)
+		if err != nil {
+			_ = tx.Rollback(ctx)
+			return fmt.Errorf("marshal errors: %w", err)
+		}
+
+		if err := q.WithTx(tx).UpdatePatientImportProgress(ctx, sqlc.UpdatePatientImportProgressParams{
+			ID:            imp.ID,
+			ProcessedRows: int32(processed),
+			ImportedRows:  imported,
+			FailedRows:    failed,
+			Errors:        errorsJSON,
+		}); err != nil {
+			_ = tx.Rollback(ctx)
+			return fmt.Errorf("update progress: %w", err)
+		}
+
+		if err := tx.Commit(ctx); err != nil {
+			return fmt.Errorf("commit batch: %w", err)
+		}
+	}
+
+	if err := q.UpdatePatientImportStatus(ctx, sqlc.UpdatePatientImportStatusParams{ID: imp.ID, Status: "completed"}); err != nil {
+		return fmt.Errorf("mark import completed: %w", err)
+	}
+
+	// The file is no longer needed once every row has been processed.
+	_ = store.Delete(ctx, imp.StorageKey)
+
+	return nil
+}
+
+func loadRows(ctx context.Context, keys *encryption.Keyring, store storage.Storage, imp sqlc.PatientImport) ([]row, error) {
+	body, err := store.Get(ctx, imp.StorageKey)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	encrypted, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := keys.Decrypt(ctx, imp.BusinessID, string(encrypted))
+	if err != nil {
+		return nil, err
+	}
+
+	return parseCSV([]byte(data))
+}
+
+// importRow creates the user and patient profile of one row inside a
+// savepoint, so a failing row does not discard the rest of its batch.
+func importRow(ctx context.Context, tx pgx.Tx, q *sqlc.Queries, profiles *patient_profile.PatientProfileRepository, businessID, roleID pgtype.UUID, rw row) error {
+	req := rw.request
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.User.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	birthDay, err := time.Parse("2006-01-02", req.Profile.BirthDay)
+	if err != nil {
+		return err
+	}
+
+	var weight pgtype.Numeric
+	if err := weight.Scan(fmt.Sprintf("%g", req.Profile.Weight)); err != nil {
+		return err
+	}
+
+	var height pgtype.Numeric
+	if err := height.Scan(fmt.Sprintf("%g", req.Profile.Height)); err != nil {
+		return err
+	}
+
+	sp, err := tx.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer sp.Rollback(ctx)
+
+	user, err := q.WithTx(sp).CreateUser(ctx, sqlc.CreateUserParams{
+		Ic:          req.User.Ic,
+		UserName:    req.User.UserName,
+		FirstName:   req.User.FirstName,
+		LastName:    req.User.LastName,
+		Email:       req.User.Email,
+		Password:    string(hashedPassword),
+		PhoneNumber: req.User.PhoneNumber,
+		RoleID:      roleID,
+		BusinessID:  businessID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := profiles.WithTx(sp).Create(ctx, sqlc.CreatePatientProfileParams{
+		BusinessID:            businessID,
+		UserID:                user.ID,
+		Gender:                string(req.Profile.Gender),
+		BirthDay:              pgtype.Date{Time: birthDay, Valid: true},
+		BloodType:             req.Profile.BloodType,
+		Weight:                weight,
+		Height:                height,
+		EmergencyContactName:  req.Profile.EmergencyContactName,
+		EmergencyContactPhone: req.Profile.EmergencyContactPhone,
+	}); err != nil {
+		return err
+	}
+
+	return sp.Commit(ctx)
+}
+
+func rowErrorFor(line int, err error) RowError {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return RowError{Row: line, Message: "El paciente ya está registrado"}
+	}
+	return RowError{Row: line, Message: "Error al crear el paciente"}
+}
+
+func markFailed(ctx context.Context, q *sqlc.Queries, id pgtype.UUID, msg string) {
+	_ = q.UpdatePatientImportStatus(ctx, sqlc.UpdatePatientImportStatusParams{
+		ID:     id,
+		Status: "failed",
+		Error:  pgtype.Text{String: msg, Valid: true},
+	})
+}
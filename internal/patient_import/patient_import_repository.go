@@ -0,0 +1,37 @@
+package patient_import
+
+import (
+	"context"
+
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type PatientImportRepository struct {
+	q *sqlc.Queries
+}
+
+func NewPatientImportRepository(q *sqlc.Queries) *PatientImportRepository {
+	return &PatientImportRepository{q: q}
+}
+
+func (r *PatientImportRepository) Validate(ctx context.Context, businessID pgtype.UUID, rows []row) (Report, error) {
+	report, _, err := validate(ctx, r.q, businessID, rows)
+	return report, err
+}
+
+func (r *PatientImportRepository) Create(ctx context.Context, arg sqlc.CreatePatientImportParams) (sqlc.PatientImport, error) {
+	return r.q.CreatePatientImport(ctx, arg)
+}
+
+func (r *PatientImportRepository) GetByID(ctx context.Context, arg sqlc.GetPatientImportParams) (sqlc.PatientImport, error) {
+	return r.q.GetPatientImport(ctx, arg)
+}
+
+func (r *PatientImportRepository) GetAll(ctx context.Context, arg sqlc.GetPatientImportsParams) ([]sqlc.PatientImport, error) {
+	return r.q.GetPatientImports(ctx, arg)
+}
+
+func (r *PatientImportRepository) UpdateStatus(ctx context.Context, arg sqlc.UpdatePatientImportStatusParams) error {
+	return r.q.UpdatePatientImportStatus(ctx, arg)
+}
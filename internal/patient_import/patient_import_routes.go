@@ -0,0 +1,22 @@
+package patient_import
+
+import (
+	"github.com/alanloffler/go-calth-api/internal/common/encryption"
+	"github.com/alanloffler/go-calth-api/internal/config"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/alanloffler/go-calth-api/internal/middleware"
+	"github.com/alanloffler/go-calth-api/internal/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/hibiken/asynq"
+)
+
+func RegisterRoutes(router *gin.RouterGroup, q *sqlc.Queries, store storage.Storage, keys *encryption.Keyring, queueClient *asynq.Client, cfg *config.Config) {
+	var repo *PatientImportRepository = NewPatientImportRepository(q)
+	var handler *PatientImportHandler = NewPatientImportHandler(repo, keys, store, queueClient, cfg.UploadMaxSize)
+	var imports *gin.RouterGroup = router.Group("/patient-imports")
+
+	imports.POST("", middleware.PermissionMiddleware(q, "patients-create"), middleware.EscalationMiddleware(q, "patient"), handler.Create)
+
+	imports.GET("", middleware.PermissionMiddleware(q, "patients-create"), handler.GetAll)
+	imports.GET("/:id", middleware.PermissionMiddleware(q, "patients-create"), handler.GetByID)
+}
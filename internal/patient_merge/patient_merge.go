@@ -0,0 +1,167 @@
+package patient_merge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// nameSimilarityThreshold matches the default pg_trgm.similarity_threshold used
+// by the % operator when looking for candidates.
+const nameSimilarityThreshold = 0.3
+
+// Reasons a pair of patients is reported as a possible duplicate.
+const (
+	reasonIC    = "ic"
+	reasonPhone = "phone"
+	reasonName  = "name"
+)
+
+// What happened to the patient profile during a merge.
+const (
+	profileKept   = "kept"
+	profileMoved  = "moved"
+	profileCopied = "copied"
+)
+
+var (
+	ErrPatientNotFound = errors.New("patient merge: patient not found")
+	ErrNotPatient      = errors.New("patient merge: user is not a patient")
+	ErrPatientDeleted  = errors.New("patient merge: patient already deleted")
+)
+
+// MergeSummary counts the records moved to the surviving patient. It is stored
+// with the merge so the operation can be reviewed later.
+type MergeSummary struct {
+	Events           int64  `json:"events"`
+	MedicalHistories int64  `json:"medicalHistories"`
+	Prescriptions    int64  `json:"prescriptions"`
+	VitalSigns       int64  `json:"vitalSigns"`
+	Allergies        int64  `json:"allergies"`
+	Conditions       int64  `json:"conditions"`
+	Medications      int64  `json:"medications"`
+	Profile          string `json:"profile"`
+}
+
+type mergeParams struct {
+	businessID           pgtype.UUID
+	survivorID           pgtype.UUID
+	duplicateID          pgtype.UUID
+	mergedBy             pgtype.UUID
+	keepDuplicateProfile bool
+}
+
+func matchReasons(d sqlc.FindPatientDuplicatesRow) []string {
+	reasons := []string{}
+	if d.SameIc {
+		reasons = append(reasons, reasonIC)
+	}
+	if d.SamePhone {
+		reasons = append(reasons, reasonPhone)
+	}
+	if d.NameSimilarity >= nameSimilarityThreshold {
+		reasons = append(reasons, reasonName)
+	}
+	return reasons
+}
+
+// merge moves every record of the duplicate to the survivor, records the merge
+// and soft deletes the duplicate. q must be bound to a transaction.
+func merge(ctx context.Context, q *sqlc.Queries, p mergeParams) (sqlc.PatientMerge, error) {
+	// Lock both users in a fixed order so concurrent merges of the same pair
+	// cannot deadlock.
+	first, second := p.survivorID, p.duplicateID
+	if bytes.Compare(second.Bytes[:], first.Bytes[:]) < 0 {
+		first, second = second, first
+	}
+	for _, id := range []pgtype.UUID{first, second} {
+		if err := lockPatient(ctx, q, p.businessID, id); err != nil {
+			return sqlc.PatientMerge{}, err
+		}
+	}
+
+	ids := sqlc.MergePatientEventsParams{BusinessID: p.businessID, SurvivorID: p.survivorID, DuplicateID: p.duplicateID}
+	var summary MergeSummary
+	var err error
+
+	if summary.Events, err = q.MergePatientEvents(ctx, ids); err != nil {
+		return sqlc.PatientMerge{}, fmt.Errorf("move events: %w", err)
+	}
+	if summary.MedicalHistories, err = q.MergePatientMedicalHistories(ctx, sqlc.MergePatientMedicalHistoriesParams(ids)); err != nil {
+		return sqlc.PatientMerge{}, fmt.Errorf("move medical histories: %w", err)
+	}
+	if summary.Prescriptions, err = q.MergePatientPrescriptions(ctx, sqlc.MergePatientPrescriptionsParams(ids)); err != nil {
+		return sqlc.PatientMerge{}, fmt.Errorf("move prescriptions: %w", err)
+	}
+	if summary.VitalSigns, err = q.MergePatientVitalSigns(ctx, sqlc.MergePatientVitalSignsParams(ids)); err != nil {
+		return sqlc.PatientMerge{}, fmt.Errorf("move vital signs: %w", err)
+	}
+	if summary.Allergies, err = q.MergePatientAllergies(ctx, sqlc.MergePatientAllergiesParams(ids)); err != nil {
+		return sqlc.PatientMerge{}, fmt.Errorf("move allergies: %w", err)
+	}
+	if summary.Conditions, err = q.MergePatientConditions(ctx, sqlc.MergePatientConditionsParams(ids)); err != nil {
+		return sqlc.PatientMerge{}, fmt.Errorf("move conditions: %w", err)
+	}
+	if summary.Medications, err = q.MergePatientMedications(ctx, sqlc.MergePatientMedicationsParams(ids)); err != nil {
+		return sqlc.PatientMerge{}, fmt.Errorf("move medications: %w", err)
+	}
+
+	// A survivor without a profile takes the duplicate's one. Otherwise the
+	// survivor keeps its own unless the duplicate's data was asked for.
+	summary.Profile = profileKept
+	moved, err := q.MovePatientProfile(ctx, sqlc.MovePatientProfileParams(ids))
+	if err != nil {
+		return sqlc.PatientMerge{}, fmt.Errorf("move profile: %w", err)
+	}
+	if moved > 0 {
+		summary.Profile = profileMoved
+	} else if p.keepDuplicateProfile {
+		copied, err := q.CopyPatientProfile(ctx, sqlc.CopyPatientProfileParams{BusinessID: p.businessID, SurvivorID: p.survivorID, DuplicateID: p.duplicateID})
+		if err != nil {
+			return sqlc.PatientMerge{}, fmt.Errorf("copy profile: %w", err)
+		}
+		if copied > 0 {
+			summary.Profile = profileCopied
+		}
+	}
+
+	if _, err := q.SoftDeleteUser(ctx, sqlc.SoftDeleteUserParams{BusinessID: p.businessID, ID: p.duplicateID}); err != nil {
+		return sqlc.PatientMerge{}, fmt.Errorf("soft delete duplicate: %w", err)
+	}
+
+	summaryJSON, err := json.Marshal(summary)
+	if err != nil {
+		return sqlc.PatientMerge{}, fmt.Errorf("marshal summary: %w", err)
+	}
+
+	return q.CreatePatientMerge(ctx, sqlc.CreatePatientMergeParams{
+		BusinessID:  p.businessID,
+		SurvivorID:  p.survivorID,
+		DuplicateID: p.duplicateID,
+		MergedBy:    p.mergedBy,
+		Summary:     summaryJSON,
+	})
+}
+
+func lockPatient(ctx context.Context, q *sqlc.Queries, businessID, id pgtype.UUID) error {
+	user, err := q.GetPatientForMerge(ctx, sqlc.GetPatientForMergeParams{BusinessID: businessID, ID: id})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrPatientNotFound
+		}
+		return fmt.Errorf("lock patient: %w", err)
+	}
+	if user.RoleValue != "patient" {
+		return ErrNotPatient
+	}
+	if user.DeletedAt.Valid {
+		return ErrPatientDeleted
+	}
+	return nil
+}
@@ -0,0 +1,249 @@
+package patient_merge
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/alanloffler/go-calth-api/internal/common/ctxkeys"
+	"github.com/alanloffler/go-calth-api/internal/common/response"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+)
+
+type PatientMergeHandler struct {
+	repo *PatientMergeRepository
+	pool *pgxpool.Pool
+}
+
+type MergeRequest struct {
+	SurvivorID  string `json:"survivorId" binding:"required,uuid"`
+	DuplicateID string `json:"duplicateId" binding:"required,uuid"`
+	// KeepDuplicateProfile replaces the survivor's patient profile with the
+	// duplicate's one when both exist.
+	KeepDuplicateProfile bool `json:"keepDuplicateProfile"`
+}
+
+type PatientResponse struct {
+	ID          string `json:"id"`
+	IC          string `json:"ic"`
+	FirstName   string `json:"firstName"`
+	LastName    string `json:"lastName"`
+	Email       string `json:"email"`
+	PhoneNumber string `json:"phoneNumber"`
+}
+
+type DuplicateResponse struct {
+	Patient        PatientResponse `json:"patient"`
+	Candidate      PatientResponse `json:"candidate"`
+	Reasons        []string        `json:"reasons"`
+	NameSimilarity float64         `json:"nameSimilarity"`
+}
+
+type MergeResponse struct {
+	ID          string       `json:"id"`
+	SurvivorID  string       `json:"survivorId"`
+	DuplicateID string       `json:"duplicateId"`
+	MergedBy    string       `json:"mergedBy"`
+	Summary     MergeSummary `json:"summary"`
+	CreatedAt   string       `json:"createdAt"`
+}
+
+func NewPatientMergeHandler(repo *PatientMergeRepository, pool *pgxpool.Pool) *PatientMergeHandler {
+	return &PatientMergeHandler{repo: repo, pool: pool}
+}
+
+// GetDuplicates lists pairs of active patients sharing the IC or phone number,
+// or with similar names. With patientId only the candidates of that patient
+// are listed.
+func (h *PatientMergeHandler) GetDuplicates(c *gin.Context) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	params := sqlc.FindPatientDuplicatesParams{BusinessID: businessID}
+	if !parseListQuery(c, &params.PatientID, &params.QueryLimit) {
+		return
+	}
+
+	duplicates, err := h.repo.FindDuplicates(c.Request.Context(), params)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al buscar pacientes duplicados", err))
+		return
+	}
+
+	result := make([]DuplicateResponse, len(duplicates))
+	for i, d := range duplicates {
+		result[i] = DuplicateResponse{
+			Patient: PatientResponse{
+				ID:          uuid.UUID(d.PatientID.Bytes).String(),
+				IC:          d.PatientIc,
+				FirstName:   d.PatientFirstName,
+				LastName:    d.PatientLastName,
+				Email:       d.PatientEmail,
+				PhoneNumber: d.PatientPhoneNumber,
+			},
+			Candidate: PatientResponse{
+				ID:          uuid.UUID(d.CandidateID.Bytes).String(),
+				IC:          d.CandidateIc,
+				FirstName:   d.CandidateFirstName,
+				LastName:    d.CandidateLastName,
+				Email:       d.CandidateEmail,
+				PhoneNumber: d.CandidatePhoneNumber,
+			},
+			Reasons:        matchReasons(d),
+			NameSimilarity: d.NameSimilarity,
+		}
+	}
+
+	c.JSON(http.StatusOK, response.Success("Posibles duplicados encontrados", &result))
+}
+
+// Merge moves events, medical histories, prescriptions, vital signs and the
+// clinical summary of the duplicate to the survivor and soft deletes the
+// duplicate, all in one transaction.
+func (h *PatientMergeHandler) Merge(c *gin.Context) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	userID, ok := ctxkeys.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	var req MergeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Error de validación de datos", err))
+		return
+	}
+
+	var survivorID, duplicateID pgtype.UUID
+	if err := survivorID.Scan(req.SurvivorID); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID inválido", err))
+		return
+	}
+	if err := duplicateID.Scan(req.DuplicateID); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID inválido", err))
+		return
+	}
+	if survivorID == duplicateID {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "El paciente y el duplicado deben ser distintos"))
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	tx, err := h.pool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al iniciar transacción", err))
+		return
+	}
+	defer tx.Rollback(ctx)
+
+	pm, err := merge(ctx, sqlc.New(tx), mergeParams{
+		businessID:           businessID,
+		survivorID:           survivorID,
+		duplicateID:          duplicateID,
+		mergedBy:             userID,
+		keepDuplicateProfile: req.KeepDuplicateProfile,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrPatientNotFound):
+			c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Paciente no encontrado"))
+		case errors.Is(err, ErrNotPatient):
+			c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Solo se pueden fusionar pacientes"))
+		case errors.Is(err, ErrPatientDeleted):
+			c.JSON(http.StatusConflict, response.Error(http.StatusConflict, "No se puede fusionar un paciente eliminado"))
+		default:
+			c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al fusionar los pacientes", err))
+		}
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al confirmar transacción", err))
+		return
+	}
+
+	result := toMergeResponse(pm)
+	c.JSON(http.StatusCreated, response.Created("Pacientes fusionados", &result))
+}
+
+// GetMerges lists past merges, optionally only those involving patientId.
+func (h *PatientMergeHandler) GetMerges(c *gin.Context) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	params := sqlc.GetPatientMergesParams{BusinessID: businessID}
+	if !parseListQuery(c, &params.PatientID, &params.QueryLimit) {
+		return
+	}
+
+	merges, err := h.repo.GetAll(c.Request.Context(), params)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener las fusiones", err))
+		return
+	}
+
+	result := make([]MergeResponse, len(merges))
+	for i, pm := range merges {
+		result[i] = toMergeResponse(pm)
+	}
+
+	c.JSON(http.StatusOK, response.Success("Fusiones encontradas", &result))
+}
+
+func parseListQuery(c *gin.Context, patientID *pgtype.UUID, limit *int32) bool {
+	if patientIDStr := c.Query("patientId"); patientIDStr != "" {
+		if err := patientID.Scan(patientIDStr); err != nil {
+			c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID del paciente inválido", err))
+			return false
+		}
+	}
+
+	*limit = defaultListLimit
+	if limitStr := c.Query("limit"); limitStr != "" {
+		parsedLimit, err := strconv.ParseInt(limitStr, 10, 32)
+		if err != nil || parsedLimit < 1 || parsedLimit > maxListLimit {
+			c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Límite inválido", err))
+			return false
+		}
+		*limit = int32(parsedLimit)
+	}
+
+	return true
+}
+
+func toMergeResponse(pm sqlc.PatientMerge) MergeResponse {
+	var summary MergeSummary
+	_ = json.Unmarshal(pm.Summary, &summary)
+
+	return MergeResponse{
+		ID:          uuid.UUID(pm.ID.Bytes).String(),
+		SurvivorID:  uuid.UUID(pm.SurvivorID.Bytes).String(),
+		DuplicateID: uuid.UUID(pm.DuplicateID.Bytes).String(),
+		MergedBy:    uuid.UUID(pm.MergedBy.Bytes).String(),
+		Summary:     summary,
+		CreatedAt:   pm.CreatedAt.Time.Format(time.RFC3339),
+	}
+}
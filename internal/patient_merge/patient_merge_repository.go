@@ -0,0 +1,23 @@
+package patient_merge
+
+import (
+	"context"
+
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+)
+
+type PatientMergeRepository struct {
+	q *sqlc.Queries
+}
+
+func NewPatientMergeRepository(q *sqlc.Queries) *PatientMergeRepository {
+	return &PatientMergeRepository{q: q}
+}
+
+func (r *PatientMergeRepository) FindDuplicates(ctx context.Context, arg sqlc.FindPatientDuplicatesParams) ([]sqlc.FindPatientDuplicatesRow, error) {
+	return r.q.FindPatientDuplicates(ctx, arg)
+}
+
+func (r *PatientMergeRepository) GetAll(ctx context.Context, arg sqlc.GetPatientMergesParams) ([]sqlc.PatientMerge, error) {
+	return r.q.GetPatientMerges(ctx, arg)
+}
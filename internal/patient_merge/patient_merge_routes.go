@@ -0,0 +1,18 @@
+package patient_merge
+
+import (
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/alanloffler/go-calth-api/internal/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func RegisterRoutes(router *gin.RouterGroup, q *sqlc.Queries, pool *pgxpool.Pool) {
+	var repo *PatientMergeRepository = NewPatientMergeRepository(q)
+	var handler *PatientMergeHandler = NewPatientMergeHandler(repo, pool)
+
+	router.GET("/patient-duplicates", middleware.PermissionMiddleware(q, "patients-view"), handler.GetDuplicates)
+
+	router.GET("/patient-merges", middleware.PermissionMiddleware(q, "patients-view"), handler.GetMerges)
+	router.POST("/patient-merges", middleware.PermissionMiddleware(q, "patients-merge"), middleware.EscalationMiddleware(q, "patient"), handler.Merge)
+}
@@ -0,0 +1,15 @@
+package patient_merge
+
+import (
+	"testing"
+
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchReasons(t *testing.T) {
+	assert.Equal(t, []string{reasonIC, reasonPhone, reasonName}, matchReasons(sqlc.FindPatientDuplicatesRow{SameIc: true, SamePhone: true, NameSimilarity: 0.8}))
+	assert.Equal(t, []string{reasonPhone}, matchReasons(sqlc.FindPatientDuplicatesRow{SamePhone: true, NameSimilarity: 0.1}))
+	assert.Equal(t, []string{reasonName}, matchReasons(sqlc.FindPatientDuplicatesRow{NameSimilarity: nameSimilarityThreshold}))
+	assert.Empty(t, matchReasons(sqlc.FindPatientDuplicatesRow{}))
+}
@@ -3,25 +3,68 @@ package patient_profile
 import (
 	"context"
 
+	"github.com/alanloffler/go-calth-api/internal/common/encryption"
 	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/jackc/pgx/v5"
 )
 
+// PatientProfileRepository keeps the emergency contact encrypted at rest.
 type PatientProfileRepository struct {
-	q *sqlc.Queries
+	q    *sqlc.Queries
+	keys *encryption.Keyring
 }
 
-func NewPatientProfileRepository(q *sqlc.Queries) *PatientProfileRepository {
-	return &PatientProfileRepository{q: q}
+func NewPatientProfileRepository(q *sqlc.Queries, keys *encryption.Keyring) *PatientProfileRepository {
+	return &PatientProfileRepository{q: q, keys: keys}
+}
+
+func (r *PatientProfileRepository) WithTx(tx pgx.Tx) *PatientProfileRepository {
+	return &PatientProfileRepository{q: r.q.WithTx(tx), keys: r.keys}
+}
+
+func (r *PatientProfileRepository) decrypt(ctx context.Context, profile *sqlc.PatientProfile) error {
+	var err error
+	if profile.EmergencyContactName, err = r.keys.Decrypt(ctx, profile.BusinessID, profile.EmergencyContactName); err != nil {
+		return err
+	}
+	profile.EmergencyContactPhone, err = r.keys.Decrypt(ctx, profile.BusinessID, profile.EmergencyContactPhone)
+	return err
 }
 
 func (r *PatientProfileRepository) Create(ctx context.Context, arg sqlc.CreatePatientProfileParams) (sqlc.PatientProfile, error) {
-	return r.q.CreatePatientProfile(ctx, arg)
+	var err error
+	if arg.EmergencyContactName, err = r.keys.Encrypt(ctx, arg.BusinessID, arg.EmergencyContactName); err != nil {
+		return sqlc.PatientProfile{}, err
+	}
+	if arg.EmergencyContactPhone, err = r.keys.Encrypt(ctx, arg.BusinessID, arg.EmergencyContactPhone); err != nil {
+		return sqlc.PatientProfile{}, err
+	}
+
+	profile, err := r.q.CreatePatientProfile(ctx, arg)
+	if err != nil {
+		return profile, err
+	}
+
+	return profile, r.decrypt(ctx, &profile)
 }
 
 func (r *PatientProfileRepository) GetPatientProfileByUserID(ctx context.Context, arg sqlc.GetPatientProfileByUserIDParams) (sqlc.PatientProfile, error) {
-	return r.q.GetPatientProfileByUserID(ctx, arg)
+	profile, err := r.q.GetPatientProfileByUserID(ctx, arg)
+	if err != nil {
+		return profile, err
+	}
+
+	return profile, r.decrypt(ctx, &profile)
 }
 
 func (r *PatientProfileRepository) Update(ctx context.Context, arg sqlc.UpdatePatientProfileParams) (int64, error) {
+	var err error
+	if arg.EmergencyContactName, err = r.keys.EncryptText(ctx, arg.BusinessID, arg.EmergencyContactName); err != nil {
+		return 0, err
+	}
+	if arg.EmergencyContactPhone, err = r.keys.EncryptText(ctx, arg.BusinessID, arg.EmergencyContactPhone); err != nil {
+		return 0, err
+	}
+
 	return r.q.UpdatePatientProfile(ctx, arg)
 }
@@ -0,0 +1,125 @@
+package patient_summary
+
+import (
+	"net/http"
+
+	"github.com/alanloffler/go-calth-api/internal/common/ctxkeys"
+	"github.com/alanloffler/go-calth-api/internal/common/response"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/gin-gonic/gin"
+)
+
+type CreateAllergyRequest struct {
+	Substance string `json:"substance" binding:"required,min=2,max=150"`
+	Reaction  string `json:"reaction" binding:"omitempty,max=255"`
+	Severity  string `json:"severity" binding:"required,oneof=mild moderate severe life_threatening"`
+}
+
+type UpdateAllergyRequest struct {
+	Substance *string `json:"substance" binding:"omitempty,min=2,max=150"`
+	Reaction  *string `json:"reaction" binding:"omitempty,max=255"`
+	Severity  *string `json:"severity" binding:"omitempty,oneof=mild moderate severe life_threatening"`
+}
+
+func (h *PatientSummaryHandler) CreateAllergy(c *gin.Context) {
+	businessID, patientID, ok := patientParams(c)
+	if !ok {
+		return
+	}
+
+	var req CreateAllergyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Error de validación de datos", err))
+		return
+	}
+
+	if !h.checkPatient(c, businessID, patientID) {
+		return
+	}
+
+	createdBy, _ := ctxkeys.UserID(c)
+
+	allergy, err := h.repo.CreateAllergy(c.Request.Context(), sqlc.CreatePatientAllergyParams{
+		BusinessID: businessID,
+		PatientID:  patientID,
+		Substance:  req.Substance,
+		Reaction:   req.Reaction,
+		Severity:   req.Severity,
+		CreatedBy:  createdBy,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al crear la alergia", err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.Created("Alergia creada", &allergy))
+}
+
+func (h *PatientSummaryHandler) GetAllergies(c *gin.Context) {
+	businessID, patientID, ok := patientParams(c)
+	if !ok {
+		return
+	}
+
+	allergies, err := h.repo.GetAllergies(c.Request.Context(), sqlc.GetPatientAllergiesByPatientIDParams{
+		BusinessID: businessID,
+		PatientID:  patientID,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener las alergias", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Success("Alergias encontradas", &allergies))
+}
+
+func (h *PatientSummaryHandler) UpdateAllergy(c *gin.Context) {
+	businessID, patientID, id, ok := itemParams(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateAllergyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Error de validación de datos", err))
+		return
+	}
+
+	allergy, err := h.repo.UpdateAllergy(c.Request.Context(), sqlc.UpdatePatientAllergyParams{
+		Substance:  optionalText(req.Substance),
+		Reaction:   optionalText(req.Reaction),
+		Severity:   optionalText(req.Severity),
+		BusinessID: businessID,
+		PatientID:  patientID,
+		ID:         id,
+	})
+	if err != nil {
+		notFoundOrError(c, err, "Alergia no encontrada", "Error al actualizar la alergia")
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Success("Alergia actualizada", &allergy))
+}
+
+func (h *PatientSummaryHandler) DeleteAllergy(c *gin.Context) {
+	businessID, patientID, id, ok := itemParams(c)
+	if !ok {
+		return
+	}
+
+	rows, err := h.repo.DeleteAllergy(c.Request.Context(), sqlc.SoftDeletePatientAllergyParams{
+		BusinessID: businessID,
+		PatientID:  patientID,
+		ID:         id,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al eliminar la alergia", err))
+		return
+	}
+	if rows == 0 {
+		c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Alergia no encontrada"))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Success[any]("Alergia eliminada", nil))
+}
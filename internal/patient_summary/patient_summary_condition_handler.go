@@ -0,0 +1,167 @@
+package patient_summary
+
+import (
+	"net/http"
+
+	"github.com/alanloffler/go-calth-api/internal/common/ctxkeys"
+	"github.com/alanloffler/go-calth-api/internal/common/response"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/gin-gonic/gin"
+)
+
+type CreateConditionRequest struct {
+	Name         string `json:"name" binding:"required,min=2,max=150"`
+	Code         string `json:"code" binding:"omitempty,max=20"`
+	Status       string `json:"status" binding:"omitempty,oneof=active resolved"`
+	OnsetDate    string `json:"onsetDate" binding:"omitempty,datetime=2006-01-02"`
+	ResolvedDate string `json:"resolvedDate" binding:"omitempty,datetime=2006-01-02"`
+}
+
+type UpdateConditionRequest struct {
+	Name         *string `json:"name" binding:"omitempty,min=2,max=150"`
+	Code         *string `json:"code" binding:"omitempty,max=20"`
+	Status       *string `json:"status" binding:"omitempty,oneof=active resolved"`
+	OnsetDate    *string `json:"onsetDate" binding:"omitempty,datetime=2006-01-02"`
+	ResolvedDate *string `json:"resolvedDate" binding:"omitempty,datetime=2006-01-02"`
+}
+
+func (h *PatientSummaryHandler) CreateCondition(c *gin.Context) {
+	businessID, patientID, ok := patientParams(c)
+	if !ok {
+		return
+	}
+
+	var req CreateConditionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Error de validación de datos", err))
+		return
+	}
+
+	onsetDate, err := parseDate(req.OnsetDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de fecha inválido", err))
+		return
+	}
+
+	resolvedDate, err := parseDate(req.ResolvedDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de fecha inválido", err))
+		return
+	}
+
+	if req.Status == "" {
+		req.Status = "active"
+	}
+
+	if !h.checkPatient(c, businessID, patientID) {
+		return
+	}
+
+	createdBy, _ := ctxkeys.UserID(c)
+
+	condition, err := h.repo.CreateCondition(c.Request.Context(), sqlc.CreatePatientConditionParams{
+		BusinessID:   businessID,
+		PatientID:    patientID,
+		Name:         req.Name,
+		Code:         req.Code,
+		Status:       req.Status,
+		OnsetDate:    onsetDate,
+		ResolvedDate: resolvedDate,
+		CreatedBy:    createdBy,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al crear el problema de salud", err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.Created("Problema de salud creado", &condition))
+}
+
+func (h *PatientSummaryHandler) GetConditions(c *gin.Context) {
+	businessID, patientID, ok := patientParams(c)
+	if !ok {
+		return
+	}
+
+	conditions, err := h.repo.GetConditions(c.Request.Context(), sqlc.GetPatientConditionsByPatientIDParams{
+		BusinessID: businessID,
+		PatientID:  patientID,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener los problemas de salud", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Success("Problemas de salud encontrados", &conditions))
+}
+
+func (h *PatientSummaryHandler) UpdateCondition(c *gin.Context) {
+	businessID, patientID, id, ok := itemParams(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateConditionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Error de validación de datos", err))
+		return
+	}
+
+	params := sqlc.UpdatePatientConditionParams{
+		Name:       optionalText(req.Name),
+		Code:       optionalText(req.Code),
+		Status:     optionalText(req.Status),
+		BusinessID: businessID,
+		PatientID:  patientID,
+		ID:         id,
+	}
+
+	if req.OnsetDate != nil {
+		date, err := parseDate(*req.OnsetDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de fecha inválido", err))
+			return
+		}
+		params.OnsetDate = date
+	}
+
+	if req.ResolvedDate != nil {
+		date, err := parseDate(*req.ResolvedDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de fecha inválido", err))
+			return
+		}
+		params.ResolvedDate = date
+	}
+
+	condition, err := h.repo.UpdateCondition(c.Request.Context(), params)
+	if err != nil {
+		notFoundOrError(c, err, "Problema de salud no encontrado", "Error al actualizar el problema de salud")
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Success("Problema de salud actualizado", &condition))
+}
+
+func (h *PatientSummaryHandler) DeleteCondition(c *gin.Context) {
+	businessID, patientID, id, ok := itemParams(c)
+	if !ok {
+		return
+	}
+
+	rows, err := h.repo.DeleteCondition(c.Request.Context(), sqlc.SoftDeletePatientConditionParams{
+		BusinessID: businessID,
+		PatientID:  patientID,
+		ID:         id,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al eliminar el problema de salud", err))
+		return
+	}
+	if rows == 0 {
+		c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Problema de salud no encontrado"))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Success[any]("Problema de salud eliminado", nil))
+}
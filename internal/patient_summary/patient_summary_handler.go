@@ -0,0 +1,122 @@
+package patient_summary
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/alanloffler/go-calth-api/internal/common/ctxkeys"
+	"github.com/alanloffler/go-calth-api/internal/common/response"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type PatientSummaryHandler struct {
+	repo *PatientSummaryRepository
+}
+
+func NewPatientSummaryHandler(repo *PatientSummaryRepository) *PatientSummaryHandler {
+	return &PatientSummaryHandler{repo: repo}
+}
+
+func (h *PatientSummaryHandler) GetSummary(c *gin.Context) {
+	businessID, patientID, ok := patientParams(c)
+	if !ok {
+		return
+	}
+
+	summary, err := h.repo.GetSummary(c.Request.Context(), sqlc.GetPatientAllergiesByPatientIDParams{
+		BusinessID: businessID,
+		PatientID:  patientID,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener el resumen clínico", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Success("Resumen clínico encontrado", &summary))
+}
+
+// patientParams reads the business and patient of the request.
+func patientParams(c *gin.Context) (pgtype.UUID, pgtype.UUID, bool) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return pgtype.UUID{}, pgtype.UUID{}, false
+	}
+
+	var patientID pgtype.UUID
+	if err := patientID.Scan(c.Param("id")); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID inválido", err))
+		return pgtype.UUID{}, pgtype.UUID{}, false
+	}
+
+	return businessID, patientID, true
+}
+
+// itemParams reads the business, patient and summary item of the request.
+func itemParams(c *gin.Context) (pgtype.UUID, pgtype.UUID, pgtype.UUID, bool) {
+	businessID, patientID, ok := patientParams(c)
+	if !ok {
+		return pgtype.UUID{}, pgtype.UUID{}, pgtype.UUID{}, false
+	}
+
+	var itemID pgtype.UUID
+	if err := itemID.Scan(c.Param("itemId")); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID inválido", err))
+		return pgtype.UUID{}, pgtype.UUID{}, pgtype.UUID{}, false
+	}
+
+	return businessID, patientID, itemID, true
+}
+
+// checkPatient makes sure the patient belongs to the business before adding to
+// their summary.
+func (h *PatientSummaryHandler) checkPatient(c *gin.Context, businessID, patientID pgtype.UUID) bool {
+	patient, err := h.repo.GetPatient(c.Request.Context(), sqlc.GetUserByIDParams{BusinessID: businessID, ID: patientID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Paciente no encontrado"))
+			return false
+		}
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener el paciente", err))
+		return false
+	}
+	if patient.RoleValue.String != "patient" {
+		c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Paciente no encontrado"))
+		return false
+	}
+
+	return true
+}
+
+func parseDate(value string) (pgtype.Date, error) {
+	if value == "" {
+		return pgtype.Date{}, nil
+	}
+
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return pgtype.Date{}, err
+	}
+
+	return pgtype.Date{Time: t, Valid: true}, nil
+}
+
+func optionalText(value *string) pgtype.Text {
+	if value == nil {
+		return pgtype.Text{}
+	}
+	return pgtype.Text{String: *value, Valid: true}
+}
+
+// notFoundOrError answers an update that matched no row with 404.
+func notFoundOrError(c *gin.Context, err error, notFound, failure string) {
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, notFound))
+		return
+	}
+	c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, failure, err))
+}
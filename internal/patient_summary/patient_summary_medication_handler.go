@@ -0,0 +1,171 @@
+package patient_summary
+
+import (
+	"net/http"
+
+	"github.com/alanloffler/go-calth-api/internal/common/ctxkeys"
+	"github.com/alanloffler/go-calth-api/internal/common/response"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/gin-gonic/gin"
+)
+
+type CreateMedicationRequest struct {
+	Name      string `json:"name" binding:"required,min=2,max=150"`
+	Dose      string `json:"dose" binding:"omitempty,max=100"`
+	Frequency string `json:"frequency" binding:"omitempty,max=100"`
+	Status    string `json:"status" binding:"omitempty,oneof=active discontinued"`
+	StartDate string `json:"startDate" binding:"omitempty,datetime=2006-01-02"`
+	EndDate   string `json:"endDate" binding:"omitempty,datetime=2006-01-02"`
+}
+
+type UpdateMedicationRequest struct {
+	Name      *string `json:"name" binding:"omitempty,min=2,max=150"`
+	Dose      *string `json:"dose" binding:"omitempty,max=100"`
+	Frequency *string `json:"frequency" binding:"omitempty,max=100"`
+	Status    *string `json:"status" binding:"omitempty,oneof=active discontinued"`
+	StartDate *string `json:"startDate" binding:"omitempty,datetime=2006-01-02"`
+	EndDate   *string `json:"endDate" binding:"omitempty,datetime=2006-01-02"`
+}
+
+func (h *PatientSummaryHandler) CreateMedication(c *gin.Context) {
+	businessID, patientID, ok := patientParams(c)
+	if !ok {
+		return
+	}
+
+	var req CreateMedicationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Error de validación de datos", err))
+		return
+	}
+
+	startDate, err := parseDate(req.StartDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de fecha inválido", err))
+		return
+	}
+
+	endDate, err := parseDate(req.EndDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de fecha inválido", err))
+		return
+	}
+
+	if req.Status == "" {
+		req.Status = "active"
+	}
+
+	if !h.checkPatient(c, businessID, patientID) {
+		return
+	}
+
+	createdBy, _ := ctxkeys.UserID(c)
+
+	medication, err := h.repo.CreateMedication(c.Request.Context(), sqlc.CreatePatientMedicationParams{
+		BusinessID: businessID,
+		PatientID:  patientID,
+		Name:       req.Name,
+		Dose:       req.Dose,
+		Frequency:  req.Frequency,
+		Status:     req.Status,
+		StartDate:  startDate,
+		EndDate:    endDate,
+		CreatedBy:  createdBy,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al crear el medicamento", err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.Created("Medicamento creado", &medication))
+}
+
+func (h *PatientSummaryHandler) GetMedications(c *gin.Context) {
+	businessID, patientID, ok := patientParams(c)
+	if !ok {
+		return
+	}
+
+	medications, err := h.repo.GetMedications(c.Request.Context(), sqlc.GetPatientMedicationsByPatientIDParams{
+		BusinessID: businessID,
+		PatientID:  patientID,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener los medicamentos", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Success("Medicamentos encontrados", &medications))
+}
+
+func (h *PatientSummaryHandler) UpdateMedication(c *gin.Context) {
+	businessID, patientID, id, ok := itemParams(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateMedicationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Error de validación de datos", err))
+		return
+	}
+
+	params := sqlc.UpdatePatientMedicationParams{
+		Name:       optionalText(req.Name),
+		Dose:       optionalText(req.Dose),
+		Frequency:  optionalText(req.Frequency),
+		Status:     optionalText(req.Status),
+		BusinessID: businessID,
+		PatientID:  patientID,
+		ID:         id,
+	}
+
+	if req.StartDate != nil {
+		date, err := parseDate(*req.StartDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de fecha inválido", err))
+			return
+		}
+		params.StartDate = date
+	}
+
+	if req.EndDate != nil {
+		date, err := parseDate(*req.EndDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de fecha inválido", err))
+			return
+		}
+		params.EndDate = date
+	}
+
+	medication, err := h.repo.UpdateMedication(c.Request.Context(), params)
+	if err != nil {
+		notFoundOrError(c, err, "Medicamento no encontrado", "Error al actualizar el medicamento")
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Success("Medicamento actualizado", &medication))
+}
+
+func (h *PatientSummaryHandler) DeleteMedication(c *gin.Context) {
+	businessID, patientID, id, ok := itemParams(c)
+	if !ok {
+		return
+	}
+
+	rows, err := h.repo.DeleteMedication(c.Request.Context(), sqlc.SoftDeletePatientMedicationParams{
+		BusinessID: businessID,
+		PatientID:  patientID,
+		ID:         id,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al eliminar el medicamento", err))
+		return
+	}
+	if rows == 0 {
+		c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Medicamento no encontrado"))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Success[any]("Medicamento eliminado", nil))
+}
@@ -0,0 +1,111 @@
+package patient_summary
+
+import (
+	"context"
+
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+)
+
+type PatientSummaryRepository struct {
+	q *sqlc.Queries
+}
+
+func NewPatientSummaryRepository(q *sqlc.Queries) *PatientSummaryRepository {
+	return &PatientSummaryRepository{q: q}
+}
+
+// Summary is the clinical context a professional should see before a consultation.
+type Summary struct {
+	Allergies   []sqlc.PatientAllergy    `json:"allergies"`
+	Conditions  []sqlc.PatientCondition  `json:"conditions"`
+	Medications []sqlc.PatientMedication `json:"medications"`
+}
+
+func (r *PatientSummaryRepository) GetPatient(ctx context.Context, arg sqlc.GetUserByIDParams) (sqlc.GetUserByIDRow, error) {
+	return r.q.GetUserByID(ctx, arg)
+}
+
+func (r *PatientSummaryRepository) GetSummary(ctx context.Context, arg sqlc.GetPatientAllergiesByPatientIDParams) (Summary, error) {
+	allergies, err := r.GetAllergies(ctx, arg)
+	if err != nil {
+		return Summary{}, err
+	}
+
+	conditions, err := r.GetConditions(ctx, sqlc.GetPatientConditionsByPatientIDParams{BusinessID: arg.BusinessID, PatientID: arg.PatientID})
+	if err != nil {
+		return Summary{}, err
+	}
+
+	medications, err := r.GetMedications(ctx, sqlc.GetPatientMedicationsByPatientIDParams{BusinessID: arg.BusinessID, PatientID: arg.PatientID})
+	if err != nil {
+		return Summary{}, err
+	}
+
+	return Summary{Allergies: allergies, Conditions: conditions, Medications: medications}, nil
+}
+
+// Allergies
+
+func (r *PatientSummaryRepository) CreateAllergy(ctx context.Context, arg sqlc.CreatePatientAllergyParams) (sqlc.PatientAllergy, error) {
+	return r.q.CreatePatientAllergy(ctx, arg)
+}
+
+func (r *PatientSummaryRepository) GetAllergies(ctx context.Context, arg sqlc.GetPatientAllergiesByPatientIDParams) ([]sqlc.PatientAllergy, error) {
+	items, err := r.q.GetPatientAllergiesByPatientID(ctx, arg)
+	if items == nil {
+		items = []sqlc.PatientAllergy{}
+	}
+	return items, err
+}
+
+func (r *PatientSummaryRepository) UpdateAllergy(ctx context.Context, arg sqlc.UpdatePatientAllergyParams) (sqlc.PatientAllergy, error) {
+	return r.q.UpdatePatientAllergy(ctx, arg)
+}
+
+func (r *PatientSummaryRepository) DeleteAllergy(ctx context.Context, arg sqlc.SoftDeletePatientAllergyParams) (int64, error) {
+	return r.q.SoftDeletePatientAllergy(ctx, arg)
+}
+
+// Conditions
+
+func (r *PatientSummaryRepository) CreateCondition(ctx context.Context, arg sqlc.CreatePatientConditionParams) (sqlc.PatientCondition, error) {
+	return r.q.CreatePatientCondition(ctx, arg)
+}
+
+func (r *PatientSummaryRepository) GetConditions(ctx context.Context, arg sqlc.GetPatientConditionsByPatientIDParams) ([]sqlc.PatientCondition, error) {
+	items, err := r.q.GetPatientConditionsByPatientID(ctx, arg)
+	if items == nil {
+		items = []sqlc.PatientCondition{}
+	}
+	return items, err
+}
+
+func (r *PatientSummaryRepository) UpdateCondition(ctx context.Context, arg sqlc.UpdatePatientConditionParams) (sqlc.PatientCondition, error) {
+	return r.q.UpdatePatientCondition(ctx, arg)
+}
+
+func (r *PatientSummaryRepository) DeleteCondition(ctx context.Context, arg sqlc.SoftDeletePatientConditionParams) (int64, error) {
+	return r.q.SoftDeletePatientCondition(ctx, arg)
+}
+
+// Medications
+
+func (r *PatientSummaryRepository) CreateMedication(ctx context.Context, arg sqlc.CreatePatientMedicationParams) (sqlc.PatientMedication, error) {
+	return r.q.CreatePatientMedication(ctx, arg)
+}
+
+func (r *PatientSummaryRepository) GetMedications(ctx context.Context, arg sqlc.GetPatientMedicationsByPatientIDParams) ([]sqlc.PatientMedication, error) {
+	items, err := r.q.GetPatientMedicationsByPatientID(ctx, arg)
+	if items == nil {
+		items = []sqlc.PatientMedication{}
+	}
+	return items, err
+}
+
+func (r *PatientSummaryRepository) UpdateMedication(ctx context.Context, arg sqlc.UpdatePatientMedicationParams) (sqlc.PatientMedication, error) {
+	return r.q.UpdatePatientMedication(ctx, arg)
+}
+
+func (r *PatientSummaryRepository) DeleteMedication(ctx context.Context, arg sqlc.SoftDeletePatientMedicationParams) (int64, error) {
+	return r.q.SoftDeletePatientMedication(ctx, arg)
+}
@@ -0,0 +1,32 @@
+package patient_summary
+
+import (
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/alanloffler/go-calth-api/internal/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(router *gin.RouterGroup, q *sqlc.Queries) {
+	var repo *PatientSummaryRepository = NewPatientSummaryRepository(q)
+	var handler *PatientSummaryHandler = NewPatientSummaryHandler(repo)
+	var patient *gin.RouterGroup = router.Group("/users/:id/patient")
+
+	patient.Use(middleware.OwnershipMiddleware(q, "medical_history-all"), middleware.PatientScopeMiddleware(q, "id"))
+
+	patient.POST("/allergies", middleware.PermissionMiddleware(q, "medical_history-update"), handler.CreateAllergy)
+	patient.POST("/conditions", middleware.PermissionMiddleware(q, "medical_history-update"), handler.CreateCondition)
+	patient.POST("/medications", middleware.PermissionMiddleware(q, "medical_history-update"), handler.CreateMedication)
+
+	patient.GET("/summary", middleware.PermissionMiddleware(q, "medical_history-view"), middleware.AuditMiddleware(q, "patient_summary", "id", nil), handler.GetSummary)
+	patient.GET("/allergies", middleware.PermissionMiddleware(q, "medical_history-view"), middleware.AuditMiddleware(q, "patient_summary", "id", nil), handler.GetAllergies)
+	patient.GET("/conditions", middleware.PermissionMiddleware(q, "medical_history-view"), middleware.AuditMiddleware(q, "patient_summary", "id", nil), handler.GetConditions)
+	patient.GET("/medications", middleware.PermissionMiddleware(q, "medical_history-view"), middleware.AuditMiddleware(q, "patient_summary", "id", nil), handler.GetMedications)
+
+	patient.PATCH("/allergies/:itemId", middleware.PermissionMiddleware(q, "medical_history-update"), handler.UpdateAllergy)
+	patient.PATCH("/conditions/:itemId", middleware.PermissionMiddleware(q, "medical_history-update"), handler.UpdateCondition)
+	patient.PATCH("/medications/:itemId", middleware.PermissionMiddleware(q, "medical_history-update"), handler.UpdateMedication)
+
+	patient.DELETE("/allergies/:itemId", middleware.PermissionMiddleware(q, "medical_history-update"), handler.DeleteAllergy)
+	patient.DELETE("/conditions/:itemId", middleware.PermissionMiddleware(q, "medical_history-update"), handler.DeleteCondition)
+	patient.DELETE("/medications/:itemId", middleware.PermissionMiddleware(q, "medical_history-update"), handler.DeleteMedication)
+}
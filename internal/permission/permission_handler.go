@@ -135,15 +135,20 @@ func (h *PermissionHandler) GetAllGrouped(c *gin.Context) {
 func getCategoryName(category string) string {
 	categoryNames := map[string]string{
 		"admin":           "Administradores",
+		"api_keys":        "Claves de API",
+		"audit":           "Auditoría",
 		"business":        "Negocio",
 		"calendar":        "Agenda",
 		"events":          "Turnos",
 		"medical_history": "Historias médicas",
 		"patient":         "Pacientes",
 		"permissions":     "Permisos",
+		"prescriptions":   "Recetas",
 		"professional":    "Profesionales",
 		"roles":           "Roles",
+		"sessions":        "Sesiones",
 		"settings":        "Configuraciones",
+		"two_factor":      "Verificación en dos pasos",
 	}
 
 	if name, ok := categoryNames[category]; ok {
@@ -0,0 +1,226 @@
+package portal
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/alanloffler/go-calth-api/internal/common/ctxkeys"
+	"github.com/alanloffler/go-calth-api/internal/common/response"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/alanloffler/go-calth-api/internal/event"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type RescheduleRequest struct {
+	StartDate string `json:"startDate" binding:"required"`
+}
+
+type AppointmentResponse struct {
+	ID           string               `json:"id"`
+	Title        string               `json:"title"`
+	StartDate    string               `json:"startDate"`
+	EndDate      string               `json:"endDate"`
+	Status       string               `json:"status"`
+	Professional ProfessionalResponse `json:"professional"`
+	// CanChange tells whether the patient may still cancel or reschedule it.
+	CanChange bool `json:"canChange"`
+}
+
+// GetAppointments lists the caller's upcoming appointments, soonest first, or
+// past ones, latest first, with scope=past.
+func (h *PortalHandler) GetAppointments(c *gin.Context) {
+	businessID, _ := ctxkeys.BusinessID(c)
+	patient := currentPatient(c)
+
+	scope := c.DefaultQuery("scope", scopeUpcoming)
+	if scope != scopeUpcoming && scope != scopePast {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Alcance inválido"))
+		return
+	}
+
+	limit, ok := parseLimit(c)
+	if !ok {
+		return
+	}
+
+	appointments, err := h.repo.GetAppointments(c.Request.Context(), sqlc.GetPortalAppointmentsParams{
+		BusinessID: businessID,
+		UserID:     patient.ID,
+		Upcoming:   scope == scopeUpcoming,
+		QueryLimit: limit,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener los turnos", err))
+		return
+	}
+
+	now := time.Now()
+	result := make([]AppointmentResponse, len(appointments))
+	for i, a := range appointments {
+		result[i] = AppointmentResponse{
+			ID:           uuid.UUID(a.ID.Bytes).String(),
+			Title:        a.Title,
+			StartDate:    a.StartDate.Time.Format(time.RFC3339),
+			EndDate:      a.EndDate.Time.Format(time.RFC3339),
+			Status:       string(a.Status),
+			Professional: toProfessional(a.ProfessionalFirstName, a.ProfessionalLastName, a.ProfessionalPrefix),
+			CanChange:    a.Status == sqlc.EventStatusPending && changeAllowed(a.StartDate.Time, now, h.notice),
+		}
+	}
+
+	c.JSON(http.StatusOK, response.Success("Turnos obtenidos", &result))
+}
+
+func (h *PortalHandler) CancelAppointment(c *gin.Context) {
+	businessID, _ := ctxkeys.BusinessID(c)
+	patient := currentPatient(c)
+
+	appointment, ok := h.findChangeable(c, businessID, patient.ID)
+	if !ok {
+		return
+	}
+
+	rows, err := h.repo.CancelAppointment(c.Request.Context(), sqlc.CancelPortalAppointmentParams{
+		BusinessID: businessID,
+		UserID:     patient.ID,
+		ID:         appointment.ID,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al cancelar el turno", err))
+		return
+	}
+	if rows == 0 {
+		c.JSON(http.StatusConflict, response.Error(http.StatusConflict, "Solo se pueden modificar turnos pendientes"))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Success[any]("Turno cancelado", nil))
+}
+
+// RescheduleAppointment moves a pending appointment to a new start, keeping
+// its duration. The new slot must fit the professional's schedule and be free.
+func (h *PortalHandler) RescheduleAppointment(c *gin.Context) {
+	businessID, _ := ctxkeys.BusinessID(c)
+	patient := currentPatient(c)
+
+	var req RescheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Error de validación de datos", err))
+		return
+	}
+
+	startDate, err := time.Parse(time.RFC3339, req.StartDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de fecha de inicio inválido", err))
+		return
+	}
+
+	appointment, ok := h.findChangeable(c, businessID, patient.ID)
+	if !ok {
+		return
+	}
+
+	if !changeAllowed(startDate, time.Now(), h.notice) {
+		c.JSON(http.StatusConflict, response.Error(http.StatusConflict, "La nueva fecha no respeta la anticipación mínima"))
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	profile, err := h.repo.GetProfessionalProfile(ctx, sqlc.GetProfessionalProfileByUserIDParams{
+		BusinessID: businessID,
+		UserID:     appointment.ProfessionalID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Perfil profesional no encontrado"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener el perfil profesional", err))
+		return
+	}
+
+	if !event.IsWithinSchedule(startDate, profile) {
+		c.JSON(http.StatusConflict, response.Error(http.StatusConflict, "El horario está fuera de la agenda del profesional"))
+		return
+	}
+
+	endDate := startDate.Add(appointment.EndDate.Time.Sub(appointment.StartDate.Time))
+	start := pgtype.Timestamptz{Time: startDate, Valid: true}
+	end := pgtype.Timestamptz{Time: endDate, Valid: true}
+
+	taken, err := h.repo.IsSlotTaken(ctx, sqlc.IsPortalSlotTakenParams{
+		BusinessID:     businessID,
+		ProfessionalID: appointment.ProfessionalID,
+		EventID:        appointment.ID,
+		EndDate:        end,
+		StartDate:      start,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al verificar disponibilidad", err))
+		return
+	}
+	if taken {
+		c.JSON(http.StatusConflict, response.Error(http.StatusConflict, "El horario ya fue ocupado por otro usuario"))
+		return
+	}
+
+	rows, err := h.repo.RescheduleAppointment(ctx, sqlc.ReschedulePortalAppointmentParams{
+		StartDate:  start,
+		EndDate:    end,
+		BusinessID: businessID,
+		UserID:     patient.ID,
+		ID:         appointment.ID,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al reprogramar el turno", err))
+		return
+	}
+	if rows == 0 {
+		c.JSON(http.StatusConflict, response.Error(http.StatusConflict, "Solo se pueden modificar turnos pendientes"))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Success[any]("Turno reprogramado", nil))
+}
+
+// findChangeable loads the caller's appointment from the id param and checks
+// it can still be changed. It writes the error response and returns false
+// otherwise.
+func (h *PortalHandler) findChangeable(c *gin.Context, businessID, userID pgtype.UUID) (sqlc.GetPortalAppointmentRow, bool) {
+	var id pgtype.UUID
+	if err := id.Scan(c.Param("id")); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID inválido", err))
+		return sqlc.GetPortalAppointmentRow{}, false
+	}
+
+	appointment, err := h.repo.GetAppointment(c.Request.Context(), sqlc.GetPortalAppointmentParams{
+		BusinessID: businessID,
+		UserID:     userID,
+		ID:         id,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Turno no encontrado"))
+			return sqlc.GetPortalAppointmentRow{}, false
+		}
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener el turno", err))
+		return sqlc.GetPortalAppointmentRow{}, false
+	}
+
+	if appointment.Status != sqlc.EventStatusPending {
+		c.JSON(http.StatusConflict, response.Error(http.StatusConflict, "Solo se pueden modificar turnos pendientes"))
+		return sqlc.GetPortalAppointmentRow{}, false
+	}
+
+	if !changeAllowed(appointment.StartDate.Time, time.Now(), h.notice) {
+		c.JSON(http.StatusConflict, response.Error(http.StatusConflict, "El turno ya no puede modificarse desde el portal"))
+		return sqlc.GetPortalAppointmentRow{}, false
+	}
+
+	return appointment, true
+}
@@ -0,0 +1,135 @@
+package portal
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/alanloffler/go-calth-api/internal/common/ctxkeys"
+	"github.com/alanloffler/go-calth-api/internal/common/response"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type MedicalHistorySummaryResponse struct {
+	ID           string               `json:"id"`
+	Date         string               `json:"date"`
+	Reason       string               `json:"reason"`
+	SharedAt     string               `json:"sharedAt"`
+	Professional ProfessionalResponse `json:"professional"`
+}
+
+type PrescriptionResponse struct {
+	ID           string                 `json:"id"`
+	Number       int32                  `json:"number"`
+	Date         string                 `json:"date"`
+	Notes        *string                `json:"notes"`
+	Professional ProfessionalResponse   `json:"professional"`
+	Items        []PrescriptionItemData `json:"items"`
+}
+
+type PrescriptionItemData struct {
+	Medication   string `json:"medication"`
+	Presentation string `json:"presentation"`
+	Dose         string `json:"dose"`
+	Frequency    string `json:"frequency"`
+	DurationDays *int32 `json:"durationDays"`
+}
+
+// GetMedicalHistories lists the entries a professional has shared with the
+// caller. Only the date, reason and professional are exposed.
+func (h *PortalHandler) GetMedicalHistories(c *gin.Context) {
+	businessID, _ := ctxkeys.BusinessID(c)
+	patient := currentPatient(c)
+
+	limit, ok := parseLimit(c)
+	if !ok {
+		return
+	}
+
+	histories, err := h.repo.GetMedicalHistories(c.Request.Context(), sqlc.GetPortalMedicalHistoriesParams{
+		BusinessID: businessID,
+		UserID:     patient.ID,
+		QueryLimit: limit,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener las historias médicas", err))
+		return
+	}
+
+	result := make([]MedicalHistorySummaryResponse, len(histories))
+	for i, mh := range histories {
+		result[i] = MedicalHistorySummaryResponse{
+			ID:           uuid.UUID(mh.ID.Bytes).String(),
+			Date:         mh.Date.Time.Format(time.RFC3339),
+			Reason:       mh.Reason,
+			SharedAt:     mh.SharedAt.Time.Format(time.RFC3339),
+			Professional: toProfessional(mh.ProfessionalFirstName, mh.ProfessionalLastName, mh.ProfessionalPrefix),
+		}
+	}
+
+	c.JSON(http.StatusOK, response.Success("Historias médicas obtenidas", &result))
+}
+
+func (h *PortalHandler) GetPrescriptions(c *gin.Context) {
+	businessID, _ := ctxkeys.BusinessID(c)
+	patient := currentPatient(c)
+	ctx := c.Request.Context()
+
+	prescriptions, err := h.repo.GetPrescriptions(ctx, sqlc.GetPrescriptionsByPatientIDParams{BusinessID: businessID, UserID: patient.ID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener las recetas", err))
+		return
+	}
+
+	prescriptionIDs := make([]pgtype.UUID, len(prescriptions))
+	for i, pr := range prescriptions {
+		prescriptionIDs[i] = pr.ID
+	}
+
+	items, err := h.repo.GetPrescriptionItems(ctx, prescriptionIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener las recetas", err))
+		return
+	}
+
+	itemsByPrescription := make(map[pgtype.UUID][]PrescriptionItemData)
+	for _, item := range items {
+		var duration *int32
+		if item.DurationDays.Valid {
+			duration = &item.DurationDays.Int32
+		}
+		itemsByPrescription[item.PrescriptionID] = append(itemsByPrescription[item.PrescriptionID], PrescriptionItemData{
+			Medication:   item.Medication,
+			Presentation: item.Presentation,
+			Dose:         item.Dose,
+			Frequency:    item.Frequency,
+			DurationDays: duration,
+		})
+	}
+
+	result := make([]PrescriptionResponse, len(prescriptions))
+	for i, pr := range prescriptions {
+		var notes *string
+		if pr.Notes.Valid {
+			notes = &pr.Notes.String
+		}
+
+		data := itemsByPrescription[pr.ID]
+		if data == nil {
+			data = []PrescriptionItemData{}
+		}
+
+		result[i] = PrescriptionResponse{
+			ID:           uuid.UUID(pr.ID.Bytes).String(),
+			Number:       pr.Number,
+			Date:         pr.Date.Time.Format(time.RFC3339),
+			Notes:        notes,
+			Professional: toProfessional(pr.FirstName, pr.LastName, pr.ProfessionalPrefix),
+			Items:        data,
+		}
+	}
+
+	c.JSON(http.StatusOK, response.Success("Recetas obtenidas", &result))
+}
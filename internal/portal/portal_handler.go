@@ -0,0 +1,237 @@
+package portal
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/alanloffler/go-calth-api/internal/common/ctxkeys"
+	"github.com/alanloffler/go-calth-api/internal/common/response"
+	"github.com/alanloffler/go-calth-api/internal/common/utils"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+)
+
+const patientKey = "portalPatient"
+
+type PortalHandler struct {
+	repo   *PortalRepository
+	pool   *pgxpool.Pool
+	notice time.Duration
+}
+
+type UpdateProfileRequest struct {
+	Email                 *string `json:"email" binding:"omitempty,email,max=100"`
+	PhoneNumber           *string `json:"phoneNumber" binding:"omitempty,len=10,numeric"`
+	EmergencyContactName  *string `json:"emergencyContactName" binding:"omitempty,min=3,max=100"`
+	EmergencyContactPhone *string `json:"emergencyContactPhone" binding:"omitempty,len=10,numeric"`
+}
+
+type ProfileResponse struct {
+	ID                    string  `json:"id"`
+	IC                    string  `json:"ic"`
+	UserName              string  `json:"userName"`
+	FirstName             string  `json:"firstName"`
+	LastName              string  `json:"lastName"`
+	Email                 string  `json:"email"`
+	PhoneNumber           string  `json:"phoneNumber"`
+	EmergencyContactName  *string `json:"emergencyContactName"`
+	EmergencyContactPhone *string `json:"emergencyContactPhone"`
+}
+
+type ProfessionalResponse struct {
+	FirstName          string `json:"firstName"`
+	LastName           string `json:"lastName"`
+	ProfessionalPrefix string `json:"professionalPrefix"`
+}
+
+func NewPortalHandler(repo *PortalRepository, pool *pgxpool.Pool, notice time.Duration) *PortalHandler {
+	return &PortalHandler{repo: repo, pool: pool, notice: notice}
+}
+
+// RequirePatient lets only patients into the portal and keeps the caller's
+// record in the context for the handlers.
+func (h *PortalHandler) RequirePatient(c *gin.Context) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	userID, ok := ctxkeys.UserID(c)
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	patient, err := h.repo.GetPatient(c.Request.Context(), sqlc.GetPortalPatientParams{BusinessID: businessID, ID: userID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al verificar el usuario", err))
+		return
+	}
+
+	if patient.RoleValue != patientRole {
+		c.AbortWithStatusJSON(http.StatusForbidden, response.Error(http.StatusForbidden, "Solo los pacientes pueden acceder al portal"))
+		return
+	}
+
+	c.Set(patientKey, patient)
+	c.Next()
+}
+
+func currentPatient(c *gin.Context) sqlc.GetPortalPatientRow {
+	patient, _ := c.MustGet(patientKey).(sqlc.GetPortalPatientRow)
+	return patient
+}
+
+func (h *PortalHandler) GetProfile(c *gin.Context) {
+	businessID, _ := ctxkeys.BusinessID(c)
+	patient := currentPatient(c)
+
+	result, err := h.profileResponse(c, businessID, patient)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener el perfil", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Success("Perfil obtenido", &result))
+}
+
+// UpdateProfile changes the contact data the patient is allowed to maintain:
+// email, phone number and emergency contact.
+func (h *PortalHandler) UpdateProfile(c *gin.Context) {
+	businessID, _ := ctxkeys.BusinessID(c)
+	patient := currentPatient(c)
+
+	var req UpdateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Error de validación de datos", err))
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	tx, err := h.pool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al iniciar transacción", err))
+		return
+	}
+	defer tx.Rollback(ctx)
+
+	rtx := h.repo.WithTx(tx)
+
+	if req.Email != nil || req.PhoneNumber != nil {
+		if _, err := rtx.UpdateContact(ctx, sqlc.UpdateUserParams{
+			BusinessID:  businessID,
+			ID:          patient.ID,
+			Email:       utils.ToPgText(req.Email),
+			PhoneNumber: utils.ToPgText(req.PhoneNumber),
+		}); err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+				c.JSON(http.StatusConflict, response.Error(http.StatusConflict, "El email ya está en uso"))
+				return
+			}
+			c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al actualizar el perfil", err))
+			return
+		}
+	}
+
+	if req.EmergencyContactName != nil || req.EmergencyContactPhone != nil {
+		affected, err := rtx.UpdateProfile(ctx, sqlc.UpdatePatientProfileParams{
+			BusinessID:            businessID,
+			UserID:                patient.ID,
+			EmergencyContactName:  utils.ToPgText(req.EmergencyContactName),
+			EmergencyContactPhone: utils.ToPgText(req.EmergencyContactPhone),
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al actualizar el perfil", err))
+			return
+		}
+		if affected == 0 {
+			c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Perfil de paciente no encontrado"))
+			return
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al confirmar transacción", err))
+		return
+	}
+
+	updated, err := h.repo.GetPatient(ctx, sqlc.GetPortalPatientParams{BusinessID: businessID, ID: patient.ID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener el perfil", err))
+		return
+	}
+
+	result, err := h.profileResponse(c, businessID, updated)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener el perfil", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Success("Perfil actualizado", &result))
+}
+
+func (h *PortalHandler) profileResponse(c *gin.Context, businessID pgtype.UUID, patient sqlc.GetPortalPatientRow) (ProfileResponse, error) {
+	result := ProfileResponse{
+		ID:          uuid.UUID(patient.ID.Bytes).String(),
+		IC:          patient.Ic,
+		UserName:    patient.UserName,
+		FirstName:   patient.FirstName,
+		LastName:    patient.LastName,
+		Email:       patient.Email,
+		PhoneNumber: patient.PhoneNumber,
+	}
+
+	profile, err := h.repo.GetProfile(c.Request.Context(), sqlc.GetPatientProfileByUserIDParams{BusinessID: businessID, UserID: patient.ID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return result, nil
+		}
+		return result, err
+	}
+
+	result.EmergencyContactName = &profile.EmergencyContactName
+	result.EmergencyContactPhone = &profile.EmergencyContactPhone
+	return result, nil
+}
+
+func parseLimit(c *gin.Context) (int32, bool) {
+	limitStr := c.Query("limit")
+	if limitStr == "" {
+		return defaultListLimit, true
+	}
+
+	parsedLimit, err := strconv.ParseInt(limitStr, 10, 32)
+	if err != nil || parsedLimit < 1 || parsedLimit > maxListLimit {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Límite inválido", err))
+		return 0, false
+	}
+
+	return int32(parsedLimit), true
+}
+
+func toProfessional(firstName, lastName, prefix pgtype.Text) ProfessionalResponse {
+	return ProfessionalResponse{
+		FirstName:          firstName.String,
+		LastName:           lastName.String,
+		ProfessionalPrefix: prefix.String,
+	}
+}
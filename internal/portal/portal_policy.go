@@ -0,0 +1,18 @@
+package portal
+
+import "time"
+
+const patientRole = "patient"
+
+// Scopes of the appointment list.
+const (
+	scopeUpcoming = "upcoming"
+	scopePast     = "past"
+)
+
+// changeAllowed reports whether an appointment starting at start may still be
+// cancelled or rescheduled by the patient: changes must be made at least
+// notice before it begins.
+func changeAllowed(start, now time.Time, notice time.Duration) bool {
+	return !now.Add(notice).After(start)
+}
@@ -0,0 +1,37 @@
+package portal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangeAllowed(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	notice := 24 * time.Hour
+
+	tests := []struct {
+		name  string
+		start time.Time
+		want  bool
+	}{
+		{"well ahead", now.Add(48 * time.Hour), true},
+		{"exactly at notice", now.Add(notice), true},
+		{"inside notice", now.Add(23 * time.Hour), false},
+		{"already started", now.Add(-time.Hour), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, changeAllowed(tt.start, now, notice))
+		})
+	}
+}
+
+func TestChangeAllowed_NoNotice(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	assert.True(t, changeAllowed(now.Add(time.Minute), now, 0))
+	assert.False(t, changeAllowed(now.Add(-time.Minute), now, 0))
+}
@@ -0,0 +1,90 @@
+package portal
+
+import (
+	"context"
+
+	"github.com/alanloffler/go-calth-api/internal/common/encryption"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/alanloffler/go-calth-api/internal/patient_profile"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// PortalRepository reads and changes the records of the patient signed in to
+// the portal. Every query is bound to the patient's own user ID.
+type PortalRepository struct {
+	q        *sqlc.Queries
+	keys     *encryption.Keyring
+	profiles *patient_profile.PatientProfileRepository
+}
+
+func NewPortalRepository(q *sqlc.Queries, keys *encryption.Keyring) *PortalRepository {
+	return &PortalRepository{q: q, keys: keys, profiles: patient_profile.NewPatientProfileRepository(q, keys)}
+}
+
+func (r *PortalRepository) WithTx(tx pgx.Tx) *PortalRepository {
+	return &PortalRepository{q: r.q.WithTx(tx), keys: r.keys, profiles: r.profiles.WithTx(tx)}
+}
+
+func (r *PortalRepository) GetPatient(ctx context.Context, arg sqlc.GetPortalPatientParams) (sqlc.GetPortalPatientRow, error) {
+	return r.q.GetPortalPatient(ctx, arg)
+}
+
+func (r *PortalRepository) GetProfile(ctx context.Context, arg sqlc.GetPatientProfileByUserIDParams) (sqlc.PatientProfile, error) {
+	return r.profiles.GetPatientProfileByUserID(ctx, arg)
+}
+
+func (r *PortalRepository) UpdateContact(ctx context.Context, arg sqlc.UpdateUserParams) (int64, error) {
+	return r.q.UpdateUser(ctx, arg)
+}
+
+func (r *PortalRepository) UpdateProfile(ctx context.Context, arg sqlc.UpdatePatientProfileParams) (int64, error) {
+	return r.profiles.Update(ctx, arg)
+}
+
+func (r *PortalRepository) GetAppointments(ctx context.Context, arg sqlc.GetPortalAppointmentsParams) ([]sqlc.GetPortalAppointmentsRow, error) {
+	return r.q.GetPortalAppointments(ctx, arg)
+}
+
+func (r *PortalRepository) GetAppointment(ctx context.Context, arg sqlc.GetPortalAppointmentParams) (sqlc.GetPortalAppointmentRow, error) {
+	return r.q.GetPortalAppointment(ctx, arg)
+}
+
+func (r *PortalRepository) CancelAppointment(ctx context.Context, arg sqlc.CancelPortalAppointmentParams) (int64, error) {
+	return r.q.CancelPortalAppointment(ctx, arg)
+}
+
+func (r *PortalRepository) RescheduleAppointment(ctx context.Context, arg sqlc.ReschedulePortalAppointmentParams) (int64, error) {
+	return r.q.ReschedulePortalAppointment(ctx, arg)
+}
+
+func (r *PortalRepository) IsSlotTaken(ctx context.Context, arg sqlc.IsPortalSlotTakenParams) (bool, error) {
+	return r.q.IsPortalSlotTaken(ctx, arg)
+}
+
+func (r *PortalRepository) GetProfessionalProfile(ctx context.Context, arg sqlc.GetProfessionalProfileByUserIDParams) (sqlc.ProfessionalProfile, error) {
+	return r.q.GetProfessionalProfileByUserID(ctx, arg)
+}
+
+func (r *PortalRepository) GetMedicalHistories(ctx context.Context, arg sqlc.GetPortalMedicalHistoriesParams) ([]sqlc.GetPortalMedicalHistoriesRow, error) {
+	rows, err := r.q.GetPortalMedicalHistories(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range rows {
+		if rows[i].Reason, err = r.keys.Decrypt(ctx, rows[i].BusinessID, rows[i].Reason); err != nil {
+			return nil, err
+		}
+	}
+
+	return rows, nil
+}
+
+func (r *PortalRepository) GetPrescriptions(ctx context.Context, arg sqlc.GetPrescriptionsByPatientIDParams) ([]sqlc.GetPrescriptionsByPatientIDRow, error) {
+	return r.q.GetPrescriptionsByPatientID(ctx, arg)
+}
+
+func (r *PortalRepository) GetPrescriptionItems(ctx context.Context, prescriptionIDs []pgtype.UUID) ([]sqlc.PrescriptionItem, error) {
+	return r.q.GetPrescriptionItemsByPrescriptionIDs(ctx, prescriptionIDs)
+}
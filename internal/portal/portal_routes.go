@@ -0,0 +1,30 @@
+package portal
+
+import (
+	"github.com/alanloffler/go-calth-api/internal/common/encryption"
+	"github.com/alanloffler/go-calth-api/internal/config"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// RegisterRoutes mounts the patient portal. Access is granted by the caller's
+// patient role instead of role permissions, and every route works on the
+// caller's own records only.
+func RegisterRoutes(router *gin.RouterGroup, q *sqlc.Queries, pool *pgxpool.Pool, keys *encryption.Keyring, cfg *config.Config) {
+	var repo *PortalRepository = NewPortalRepository(q, keys)
+	var handler *PortalHandler = NewPortalHandler(repo, pool, cfg.PortalChangeNotice)
+	var portal *gin.RouterGroup = router.Group("/portal")
+
+	portal.Use(handler.RequirePatient)
+
+	portal.GET("/profile", handler.GetProfile)
+	portal.PATCH("/profile", handler.UpdateProfile)
+
+	portal.GET("/appointments", handler.GetAppointments)
+	portal.POST("/appointments/:id/cancel", handler.CancelAppointment)
+	portal.POST("/appointments/:id/reschedule", handler.RescheduleAppointment)
+
+	portal.GET("/medical-histories", handler.GetMedicalHistories)
+	portal.GET("/prescriptions", handler.GetPrescriptions)
+}
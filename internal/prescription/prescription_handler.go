@@ -0,0 +1,480 @@
+package prescription
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/alanloffler/go-calth-api/internal/common/ctxkeys"
+	"github.com/alanloffler/go-calth-api/internal/common/response"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PrescriptionHandler struct {
+	repo *PrescriptionRepository
+	pool *pgxpool.Pool
+}
+
+type CreatePrescriptionRequest struct {
+	MedicalHistoryID string                    `json:"medicalHistoryId" binding:"required,uuid"`
+	Date             string                    `json:"date" binding:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	Notes            *string                   `json:"notes" binding:"omitempty,max=500"`
+	Items            []PrescriptionItemRequest `json:"items" binding:"required,min=1,max=20,dive"`
+}
+
+type PrescriptionItemRequest struct {
+	Medication   string `json:"medication" binding:"required,min=2,max=150"`
+	Presentation string `json:"presentation" binding:"required,min=1,max=100"`
+	Dose         string `json:"dose" binding:"required,min=1,max=100"`
+	Frequency    string `json:"frequency" binding:"required,min=1,max=100"`
+	DurationDays *int32 `json:"durationDays" binding:"omitempty,min=1,max=3650"`
+}
+
+type PrescriptionResponse struct {
+	ID               string                 `json:"id"`
+	Number           int32                  `json:"number"`
+	MedicalHistoryID string                 `json:"medicalHistoryId"`
+	UserID           string                 `json:"userId"`
+	ProfessionalID   string                 `json:"professionalId"`
+	Date             string                 `json:"date"`
+	Notes            *string                `json:"notes"`
+	CreatedAt        string                 `json:"createdAt"`
+	DeletedAt        *string                `json:"deletedAt"`
+	Professional     *ProfessionalResponse  `json:"professional,omitempty"`
+	Items            []PrescriptionItemData `json:"items"`
+}
+
+type PrescriptionItemData struct {
+	ID           string `json:"id"`
+	Medication   string `json:"medication"`
+	Presentation string `json:"presentation"`
+	Dose         string `json:"dose"`
+	Frequency    string `json:"frequency"`
+	DurationDays *int32 `json:"durationDays"`
+}
+
+type ProfessionalResponse struct {
+	FirstName          string `json:"firstName"`
+	LastName           string `json:"lastName"`
+	ProfessionalPrefix string `json:"professionalPrefix"`
+}
+
+type MedicationResponse struct {
+	PrescriptionItemData
+	PrescriptionID     string               `json:"prescriptionId"`
+	PrescriptionNumber int32                `json:"prescriptionNumber"`
+	MedicalHistoryID   string               `json:"medicalHistoryId"`
+	Date               string               `json:"date"`
+	EndsAt             *string              `json:"endsAt"`
+	Active             bool                 `json:"active"`
+	Professional       ProfessionalResponse `json:"professional"`
+}
+
+func NewPrescriptionHandler(repo *PrescriptionRepository, pool *pgxpool.Pool) *PrescriptionHandler {
+	return &PrescriptionHandler{repo: repo, pool: pool}
+}
+
+func (h *PrescriptionHandler) Create(c *gin.Context) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	var req CreatePrescriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Error de validación de datos", err))
+		return
+	}
+
+	var medicalHistoryID pgtype.UUID
+	if err := medicalHistoryID.Scan(req.MedicalHistoryID); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID de la historia médica inválido", err))
+		return
+	}
+
+	date := time.Now()
+	if req.Date != "" {
+		parsed, err := time.Parse(time.RFC3339, req.Date)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de fecha inválido", err))
+			return
+		}
+		date = parsed
+	}
+
+	ctx := c.Request.Context()
+
+	tx, err := h.pool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al iniciar transacción", err))
+		return
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := sqlc.New(tx)
+
+	mh, err := qtx.GetMedicalHistoryByID(ctx, sqlc.GetMedicalHistoryByIDParams{BusinessID: businessID, ID: medicalHistoryID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Historia médica no encontrada"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al buscar la historia médica", err))
+		return
+	}
+	// The prescription is issued under the history's author, so only they may issue it.
+	if !ctxkeys.InOwnerScope(c, mh.ProfessionalID) {
+		c.JSON(http.StatusForbidden, response.Error(http.StatusForbidden, "Permisos insuficientes"))
+		return
+	}
+
+	// Numbers are allocated inside the transaction, so a failed creation does not burn one.
+	number, err := qtx.NextPrescriptionNumber(ctx, businessID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al generar el número de receta", err))
+		return
+	}
+
+	var notes pgtype.Text
+	if req.Notes != nil {
+		notes = pgtype.Text{String: *req.Notes, Valid: true}
+	}
+
+	prescription, err := qtx.CreatePrescription(ctx, sqlc.CreatePrescriptionParams{
+		BusinessID:       businessID,
+		Number:           number,
+		MedicalHistoryID: mh.ID,
+		UserID:           mh.UserID,
+		ProfessionalID:   mh.ProfessionalID,
+		Date:             pgtype.Timestamptz{Time: date, Valid: true},
+		Notes:            notes,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al crear la receta", err))
+		return
+	}
+
+	items := make([]sqlc.PrescriptionItem, len(req.Items))
+	for i, item := range req.Items {
+		var duration pgtype.Int4
+		if item.DurationDays != nil {
+			duration = pgtype.Int4{Int32: *item.DurationDays, Valid: true}
+		}
+
+		items[i], err = qtx.CreatePrescriptionItem(ctx, sqlc.CreatePrescriptionItemParams{
+			PrescriptionID: prescription.ID,
+			Position:       int32(i + 1),
+			Medication:     item.Medication,
+			Presentation:   item.Presentation,
+			Dose:           item.Dose,
+			Frequency:      item.Frequency,
+			DurationDays:   duration,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al crear los medicamentos de la receta", err))
+			return
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al confirmar transacción", err))
+		return
+	}
+
+	result := toPrescriptionResponse(prescription, items)
+	c.JSON(http.StatusCreated, response.Created("Receta creada", &result))
+}
+
+func (h *PrescriptionHandler) GetByID(c *gin.Context) {
+	pr, items, ok := h.find(c)
+	if !ok {
+		return
+	}
+
+	result := toPrescriptionResponse(sqlc.Prescription{
+		ID:               pr.ID,
+		BusinessID:       pr.BusinessID,
+		Number:           pr.Number,
+		MedicalHistoryID: pr.MedicalHistoryID,
+		UserID:           pr.UserID,
+		ProfessionalID:   pr.ProfessionalID,
+		Date:             pr.Date,
+		Notes:            pr.Notes,
+		CreatedAt:        pr.CreatedAt,
+		DeletedAt:        pr.DeletedAt,
+	}, items)
+	result.Professional = &ProfessionalResponse{
+		FirstName:          pr.FirstName_2.String,
+		LastName:           pr.LastName_2.String,
+		ProfessionalPrefix: pr.ProfessionalPrefix.String,
+	}
+
+	c.JSON(http.StatusOK, response.Success("Receta encontrada", &result))
+}
+
+func (h *PrescriptionHandler) GetPDF(c *gin.Context) {
+	pr, items, ok := h.find(c)
+	if !ok {
+		return
+	}
+
+	business, err := h.repo.GetBusiness(c.Request.Context(), pr.BusinessID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener el negocio", err))
+		return
+	}
+
+	data, err := renderPrescriptionPDF(business, pr, items)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al generar el PDF", err))
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="receta-%06d.pdf"`, pr.Number))
+	c.Header("Cache-Control", "private, no-store")
+	c.Data(http.StatusOK, "application/pdf", data)
+}
+
+func (h *PrescriptionHandler) GetAllByPatientID(c *gin.Context) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	var userID pgtype.UUID
+	if err := userID.Scan(c.Param("id")); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID del paciente inválido", err))
+		return
+	}
+
+	params := sqlc.GetPrescriptionsByPatientIDParams{BusinessID: businessID, UserID: userID, OwnerID: ctxkeys.OwnerScope(c)}
+	if mhID := c.Query("medicalHistoryId"); mhID != "" {
+		if err := params.MedicalHistoryID.Scan(mhID); err != nil {
+			c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID de la historia médica inválido", err))
+			return
+		}
+	}
+
+	ctx := c.Request.Context()
+
+	prescriptions, err := h.repo.GetAllByPatientID(ctx, params)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener las recetas", err))
+		return
+	}
+
+	ids := make([]pgtype.UUID, len(prescriptions))
+	for i, pr := range prescriptions {
+		ids[i] = pr.ID
+	}
+
+	items, err := h.repo.GetItems(ctx, ids)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener los medicamentos de las recetas", err))
+		return
+	}
+
+	byPrescription := make(map[pgtype.UUID][]sqlc.PrescriptionItem)
+	for _, item := range items {
+		byPrescription[item.PrescriptionID] = append(byPrescription[item.PrescriptionID], item)
+	}
+
+	result := make([]PrescriptionResponse, len(prescriptions))
+	for i, pr := range prescriptions {
+		result[i] = toPrescriptionResponse(sqlc.Prescription{
+			ID:               pr.ID,
+			BusinessID:       pr.BusinessID,
+			Number:           pr.Number,
+			MedicalHistoryID: pr.MedicalHistoryID,
+			UserID:           pr.UserID,
+			ProfessionalID:   pr.ProfessionalID,
+			Date:             pr.Date,
+			Notes:            pr.Notes,
+			CreatedAt:        pr.CreatedAt,
+		}, byPrescription[pr.ID])
+		result[i].Professional = &ProfessionalResponse{
+			FirstName:          pr.FirstName.String,
+			LastName:           pr.LastName.String,
+			ProfessionalPrefix: pr.ProfessionalPrefix.String,
+		}
+	}
+
+	c.JSON(http.StatusOK, response.Success("Recetas encontradas", &result))
+}
+
+func (h *PrescriptionHandler) GetPatientMedications(c *gin.Context) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	var userID pgtype.UUID
+	if err := userID.Scan(c.Param("id")); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID del paciente inválido", err))
+		return
+	}
+
+	medications, err := h.repo.GetPatientMedications(c.Request.Context(), sqlc.GetPatientMedicationsParams{
+		BusinessID: businessID,
+		UserID:     userID,
+		ActiveOnly: c.Query("active") == "true",
+		OwnerID:    ctxkeys.OwnerScope(c),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener la medicación del paciente", err))
+		return
+	}
+
+	now := time.Now()
+	result := make([]MedicationResponse, len(medications))
+	for i, m := range medications {
+		var endsAt *string
+		active := true
+		if m.DurationDays.Valid {
+			end := m.Date.Time.AddDate(0, 0, int(m.DurationDays.Int32))
+			s := end.Format(time.RFC3339)
+			endsAt = &s
+			active = !end.Before(now)
+		}
+
+		result[i] = MedicationResponse{
+			PrescriptionItemData: toItemData(sqlc.PrescriptionItem{
+				ID:           m.ID,
+				Medication:   m.Medication,
+				Presentation: m.Presentation,
+				Dose:         m.Dose,
+				Frequency:    m.Frequency,
+				DurationDays: m.DurationDays,
+			}),
+			PrescriptionID:     uuid.UUID(m.PrescriptionID.Bytes).String(),
+			PrescriptionNumber: m.Number,
+			MedicalHistoryID:   uuid.UUID(m.MedicalHistoryID.Bytes).String(),
+			Date:               m.Date.Time.Format(time.RFC3339),
+			EndsAt:             endsAt,
+			Active:             active,
+			Professional: ProfessionalResponse{
+				FirstName:          m.FirstName.String,
+				LastName:           m.LastName.String,
+				ProfessionalPrefix: m.ProfessionalPrefix.String,
+			},
+		}
+	}
+
+	c.JSON(http.StatusOK, response.Success("Medicación encontrada", &result))
+}
+
+func (h *PrescriptionHandler) SoftDelete(c *gin.Context) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(c.Param("id")); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID inválido", err))
+		return
+	}
+
+	rows, err := h.repo.SoftDelete(c.Request.Context(), sqlc.SoftDeletePrescriptionParams{BusinessID: businessID, ID: id, OwnerID: ctxkeys.OwnerScope(c)})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al anular la receta", err))
+		return
+	}
+	if rows == 0 {
+		c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Receta no encontrada"))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Success[any]("Receta anulada", nil))
+}
+
+func (h *PrescriptionHandler) find(c *gin.Context) (sqlc.GetPrescriptionByIDRow, []sqlc.PrescriptionItem, bool) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return sqlc.GetPrescriptionByIDRow{}, nil, false
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(c.Param("id")); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID inválido", err))
+		return sqlc.GetPrescriptionByIDRow{}, nil, false
+	}
+
+	ctx := c.Request.Context()
+
+	pr, err := h.repo.GetByID(ctx, sqlc.GetPrescriptionByIDParams{BusinessID: businessID, ID: id, OwnerID: ctxkeys.OwnerScope(c)})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Receta no encontrada"))
+			return sqlc.GetPrescriptionByIDRow{}, nil, false
+		}
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener la receta", err))
+		return sqlc.GetPrescriptionByIDRow{}, nil, false
+	}
+
+	items, err := h.repo.GetItems(ctx, []pgtype.UUID{pr.ID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener los medicamentos de la receta", err))
+		return sqlc.GetPrescriptionByIDRow{}, nil, false
+	}
+
+	return pr, items, true
+}
+
+func toPrescriptionResponse(pr sqlc.Prescription, items []sqlc.PrescriptionItem) PrescriptionResponse {
+	var notes *string
+	if pr.Notes.Valid {
+		notes = &pr.Notes.String
+	}
+
+	var deletedAt *string
+	if pr.DeletedAt.Valid {
+		s := pr.DeletedAt.Time.Format(time.RFC3339)
+		deletedAt = &s
+	}
+
+	data := make([]PrescriptionItemData, len(items))
+	for i, item := range items {
+		data[i] = toItemData(item)
+	}
+
+	return PrescriptionResponse{
+		ID:               uuid.UUID(pr.ID.Bytes).String(),
+		Number:           pr.Number,
+		MedicalHistoryID: uuid.UUID(pr.MedicalHistoryID.Bytes).String(),
+		UserID:           uuid.UUID(pr.UserID.Bytes).String(),
+		ProfessionalID:   uuid.UUID(pr.ProfessionalID.Bytes).String(),
+		Date:             pr.Date.Time.Format(time.RFC3339),
+		Notes:            notes,
+		CreatedAt:        pr.CreatedAt.Time.Format(time.RFC3339),
+		DeletedAt:        deletedAt,
+		Items:            data,
+	}
+}
+
+func toItemData(item sqlc.PrescriptionItem) PrescriptionItemData {
+	var duration *int32
+	if item.DurationDays.Valid {
+		duration = &item.DurationDays.Int32
+	}
+
+	return PrescriptionItemData{
+		ID:           uuid.UUID(item.ID.Bytes).String(),
+		Medication:   item.Medication,
+		Presentation: item.Presentation,
+		Dose:         item.Dose,
+		Frequency:    item.Frequency,
+		DurationDays: duration,
+	}
+}
@@ -0,0 +1,79 @@
+package prescription
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alanloffler/go-calth-api/internal/common/pdf"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+)
+
+func renderPrescriptionPDF(business sqlc.Business, pr sqlc.GetPrescriptionByIDRow, items []sqlc.PrescriptionItem) ([]byte, error) {
+	loc, err := time.LoadLocation(business.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	doc := pdf.New(fmt.Sprintf("Receta N° %06d", pr.Number))
+	margin := doc.Margin()
+	right := pdf.PageWidth - margin
+
+	doc.SetHeader(func(d *pdf.Document) {
+		d.Text(margin, margin+14, pdf.Bold, 16, business.TradeName)
+		d.Text(margin, margin+30, pdf.Regular, 9, fmt.Sprintf("%s, %s, %s", business.Street, business.City, business.Province))
+		d.Text(margin, margin+42, pdf.Regular, 9, fmt.Sprintf("Tel: %s - %s", business.PhoneNumber, business.Email))
+		d.TextRight(right, margin+14, pdf.Bold, 12, fmt.Sprintf("Receta N° %06d", pr.Number))
+		d.TextRight(right, margin+30, pdf.Regular, 9, "Fecha: "+pr.Date.Time.In(loc).Format("02/01/2006"))
+		d.Line(margin, margin+52, right, margin+52, 1)
+		d.SetY(margin + 64)
+	})
+	doc.SetFooter(20, func(d *pdf.Document, page, total int) {
+		d.TextRight(right, pdf.PageHeight-margin+10, pdf.Regular, 8, fmt.Sprintf("Página %d de %d", page, total))
+	})
+	doc.AddPage()
+
+	if pr.DeletedAt.Valid {
+		doc.Paragraph(pdf.Bold, 14, "RECETA ANULADA")
+		doc.MoveDown(6)
+	}
+
+	doc.Paragraph(pdf.Bold, 11, "Paciente")
+	doc.Paragraph(pdf.Regular, 10, fmt.Sprintf("%s %s - DNI %s", pr.FirstName.String, pr.LastName.String, pr.Ic.String))
+	doc.MoveDown(10)
+
+	doc.Paragraph(pdf.Bold, 11, "Rp/")
+	doc.MoveDown(4)
+
+	for i, item := range items {
+		doc.EnsureSpace(48)
+		doc.Paragraph(pdf.Bold, 10, fmt.Sprintf("%d. %s - %s", i+1, item.Medication, item.Presentation))
+
+		detail := fmt.Sprintf("%s, %s", item.Dose, item.Frequency)
+		if item.DurationDays.Valid {
+			detail += fmt.Sprintf(", durante %d días", item.DurationDays.Int32)
+		}
+		doc.ParagraphAt(margin+14, doc.ContentWidth()-14, pdf.Regular, 10, detail)
+		doc.MoveDown(6)
+	}
+
+	if pr.Notes.Valid && strings.TrimSpace(pr.Notes.String) != "" {
+		doc.MoveDown(6)
+		doc.Paragraph(pdf.Bold, 11, "Indicaciones")
+		doc.Paragraph(pdf.Regular, 10, pr.Notes.String)
+	}
+
+	// Signature block, kept together at the bottom of the content.
+	doc.EnsureSpace(80)
+	doc.MoveDown(50)
+	lineStart := right - 200
+	doc.Line(lineStart, doc.Y(), right, doc.Y(), 0.5)
+	doc.MoveDown(12)
+	doc.Text(lineStart, doc.Y(), pdf.Bold, 10, strings.TrimSpace(fmt.Sprintf("%s %s %s", pr.ProfessionalPrefix.String, pr.FirstName_2.String, pr.LastName_2.String)))
+	doc.MoveDown(12)
+	doc.Text(lineStart, doc.Y(), pdf.Regular, 9, pr.Specialty.String)
+	doc.MoveDown(12)
+	doc.Text(lineStart, doc.Y(), pdf.Regular, 9, "Matrícula: "+pr.LicenseID.String)
+
+	return doc.Bytes()
+}
@@ -0,0 +1,40 @@
+package prescription
+
+import (
+	"context"
+
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type PrescriptionRepository struct {
+	q *sqlc.Queries
+}
+
+func NewPrescriptionRepository(q *sqlc.Queries) *PrescriptionRepository {
+	return &PrescriptionRepository{q: q}
+}
+
+func (r *PrescriptionRepository) GetByID(ctx context.Context, arg sqlc.GetPrescriptionByIDParams) (sqlc.GetPrescriptionByIDRow, error) {
+	return r.q.GetPrescriptionByID(ctx, arg)
+}
+
+func (r *PrescriptionRepository) GetAllByPatientID(ctx context.Context, arg sqlc.GetPrescriptionsByPatientIDParams) ([]sqlc.GetPrescriptionsByPatientIDRow, error) {
+	return r.q.GetPrescriptionsByPatientID(ctx, arg)
+}
+
+func (r *PrescriptionRepository) GetItems(ctx context.Context, prescriptionIDs []pgtype.UUID) ([]sqlc.PrescriptionItem, error) {
+	return r.q.GetPrescriptionItemsByPrescriptionIDs(ctx, prescriptionIDs)
+}
+
+func (r *PrescriptionRepository) GetPatientMedications(ctx context.Context, arg sqlc.GetPatientMedicationsParams) ([]sqlc.GetPatientMedicationsRow, error) {
+	return r.q.GetPatientMedications(ctx, arg)
+}
+
+func (r *PrescriptionRepository) GetBusiness(ctx context.Context, id pgtype.UUID) (sqlc.Business, error) {
+	return r.q.GetBusiness(ctx, id)
+}
+
+func (r *PrescriptionRepository) SoftDelete(ctx context.Context, arg sqlc.SoftDeletePrescriptionParams) (int64, error) {
+	return r.q.SoftDeletePrescription(ctx, arg)
+}
@@ -0,0 +1,25 @@
+package prescription
+
+import (
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/alanloffler/go-calth-api/internal/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func RegisterRoutes(router *gin.RouterGroup, q *sqlc.Queries, pool *pgxpool.Pool) {
+	var repo *PrescriptionRepository = NewPrescriptionRepository(q)
+	var handler *PrescriptionHandler = NewPrescriptionHandler(repo, pool)
+	var prescriptions *gin.RouterGroup = router.Group("/prescriptions")
+
+	prescriptions.Use(middleware.OwnershipMiddleware(q, "medical_history-all"))
+
+	prescriptions.POST("", middleware.PermissionMiddleware(q, "prescriptions-create"), handler.Create)
+
+	prescriptions.GET("/patient/:id", middleware.PermissionMiddleware(q, "prescriptions-view"), handler.GetAllByPatientID)
+	prescriptions.GET("/patient/:id/medications", middleware.PermissionMiddleware(q, "prescriptions-view"), handler.GetPatientMedications)
+	prescriptions.GET("/:id", middleware.PermissionMiddleware(q, "prescriptions-view"), handler.GetByID)
+	prescriptions.GET("/:id/pdf", middleware.PermissionMiddleware(q, "prescriptions-view"), handler.GetPDF)
+
+	prescriptions.DELETE("/:id", middleware.PermissionMiddleware(q, "prescriptions-delete"), handler.SoftDelete)
+}
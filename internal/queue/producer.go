@@ -2,6 +2,7 @@ package queue
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/hibiken/asynq"
@@ -36,3 +37,111 @@ func EnqueueEventCreated(client *asynq.Client, payload EventCreatedPayload) erro
 
 	return nil
 }
+
+func EnqueueClinicalRecordExport(client *asynq.Client, payload ClinicalRecordExportPayload) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal clinical_record_export payload: %w", err)
+	}
+
+	task := asynq.NewTask("clinical_record:export", data)
+
+	if _, err := client.Enqueue(task, asynq.MaxRetry(2), asynq.Queue("default")); err != nil {
+		return fmt.Errorf("enqueue clinical_record_export: %w", err)
+	}
+
+	return nil
+}
+
+func EnqueueBusinessReencryption(client *asynq.Client, payload BusinessReencryptionPayload) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal business_reencryption payload: %w", err)
+	}
+
+	task := asynq.NewTask("encryption:reencrypt", data)
+
+	if _, err := client.Enqueue(task, asynq.MaxRetry(2), asynq.Queue("default")); err != nil {
+		return fmt.Errorf("enqueue business_reencryption: %w", err)
+	}
+
+	return nil
+}
+
+// EnqueueSearchIndexBackfill queues the search index backfill of a business.
+// The task ID is per business, so workers starting together queue it once.
+func EnqueueSearchIndexBackfill(client *asynq.Client, payload SearchIndexBackfillPayload) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal search_index_backfill payload: %w", err)
+	}
+
+	task := asynq.NewTask("search:backfill", data)
+
+	_, err = client.Enqueue(task, asynq.MaxRetry(2), asynq.Queue("default"), asynq.TaskID("search:backfill:"+payload.BusinessID))
+	if err != nil && !errors.Is(err, asynq.ErrTaskIDConflict) {
+		return fmt.Errorf("enqueue search_index_backfill: %w", err)
+	}
+
+	return nil
+}
+
+func EnqueuePatientImport(client *asynq.Client, payload PatientImportPayload) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal patient_import payload: %w", err)
+	}
+
+	task := asynq.NewTask("patient_import:process", data)
+
+	if _, err := client.Enqueue(task, asynq.MaxRetry(2), asynq.Queue("default")); err != nil {
+		return fmt.Errorf("enqueue patient_import: %w", err)
+	}
+
+	return nil
+}
+
+func EnqueuePasswordReset(client *asynq.Client, payload PasswordResetPayload) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal password_reset payload: %w", err)
+	}
+
+	task := asynq.NewTask("email:password_reset", data)
+
+	if _, err := client.Enqueue(task, asynq.MaxRetry(2), asynq.Queue("default")); err != nil {
+		return fmt.Errorf("enqueue password_reset: %w", err)
+	}
+
+	return nil
+}
+
+func EnqueueInvitation(client *asynq.Client, payload InvitationPayload) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal invitation payload: %w", err)
+	}
+
+	task := asynq.NewTask("email:invitation", data)
+
+	if _, err := client.Enqueue(task, asynq.MaxRetry(2), asynq.Queue("default")); err != nil {
+		return fmt.Errorf("enqueue invitation: %w", err)
+	}
+
+	return nil
+}
+
+func EnqueueEmailVerification(client *asynq.Client, payload EmailVerificationPayload) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal email_verification payload: %w", err)
+	}
+
+	task := asynq.NewTask("email:email_verification", data)
+
+	if _, err := client.Enqueue(task, asynq.MaxRetry(2), asynq.Queue("default")); err != nil {
+		return fmt.Errorf("enqueue email_verification: %w", err)
+	}
+
+	return nil
+}
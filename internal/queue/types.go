@@ -13,3 +13,46 @@ type EventCreatedPayload struct {
 	Title       string `json:"title"`
 	StartDate   string `json:"startDate"`
 }
+
+type ClinicalRecordExportPayload struct {
+	ExportID   string `json:"exportId"`
+	BusinessID string `json:"businessId"`
+}
+
+type BusinessReencryptionPayload struct {
+	BusinessID string `json:"businessId"`
+}
+
+type SearchIndexBackfillPayload struct {
+	BusinessID string `json:"businessId"`
+}
+
+type PatientImportPayload struct {
+	ImportID   string `json:"importId"`
+	BusinessID string `json:"businessId"`
+}
+
+type PasswordResetPayload struct {
+	Email       string `json:"email"`
+	CompanyName string `json:"companyName"`
+	FullName    string `json:"fullName"`
+	ResetLink   string `json:"resetLink"`
+	ExpiresIn   string `json:"expiresIn"`
+}
+
+type InvitationPayload struct {
+	Email       string `json:"email"`
+	CompanyName string `json:"companyName"`
+	InviterName string `json:"inviterName"`
+	RoleName    string `json:"roleName"`
+	InviteLink  string `json:"inviteLink"`
+	ExpiresIn   string `json:"expiresIn"`
+}
+
+type EmailVerificationPayload struct {
+	Email       string `json:"email"`
+	CompanyName string `json:"companyName"`
+	FullName    string `json:"fullName"`
+	VerifyLink  string `json:"verifyLink"`
+	ExpiresIn   string `json:"expiresIn"`
+}
@@ -3,9 +3,11 @@ package role
 import (
 	"net/http"
 
+	"github.com/alanloffler/go-calth-api/internal/common/ctxkeys"
 	"github.com/alanloffler/go-calth-api/internal/common/response"
 	"github.com/alanloffler/go-calth-api/internal/common/utils"
 	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/alanloffler/go-calth-api/internal/middleware"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -107,6 +109,10 @@ func (h *RoleHandler) Create(c *gin.Context) {
 		}
 	}
 
+	if !ctxkeys.IsSuperAdmin(c) && !h.callerHolds(c, permissionIDs) {
+		return
+	}
+
 	// No permissions, create without transaction
 	if len(permissionIDs) == 0 {
 		role, err := h.repo.Create(ctx, sqlc.CreateRoleParams{
@@ -328,6 +334,10 @@ func (h *RoleHandler) Update(c *gin.Context) {
 		}
 	}
 
+	if !ctxkeys.IsSuperAdmin(c) && !h.callerHolds(c, permissionIDs) {
+		return
+	}
+
 	tx, err := h.pool.Begin(ctx)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al iniciar transacción", err))
@@ -415,6 +425,35 @@ func (h *RoleHandler) Update(c *gin.Context) {
 	c.JSON(http.StatusOK, response.Success("Rol actualizado", &role))
 }
 
+// callerHolds writes the error response and returns false unless the caller's
+// own role effectively has every permission, so a role cannot be given more
+// than the caller already has.
+func (h *RoleHandler) callerHolds(c *gin.Context, permissionIDs []pgtype.UUID) bool {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return false
+	}
+
+	callerRoleID, ok := ctxkeys.RoleID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return false
+	}
+
+	holds, err := middleware.CallerHolds(c.Request.Context(), h.repo.q, businessID, callerRoleID, permissionIDs...)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al verificar permisos", err))
+		return false
+	}
+	if !holds {
+		c.JSON(http.StatusForbidden, response.Error(http.StatusForbidden, "No puede otorgar un permiso que no posee"))
+		return false
+	}
+
+	return true
+}
+
 func (h *RoleHandler) Delete(c *gin.Context) {
 	var id pgtype.UUID
 	if err := id.Scan(c.Param("id")); err != nil {
@@ -0,0 +1,205 @@
+package session
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/alanloffler/go-calth-api/internal/common/ctxkeys"
+	"github.com/alanloffler/go-calth-api/internal/common/response"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/alanloffler/go-calth-api/internal/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Revoking a session stops it from refreshing. Its access token is still
+// accepted until it expires.
+type SessionHandler struct {
+	repo *SessionRepository
+	q    *sqlc.Queries
+}
+
+type SessionResponse struct {
+	ID         string `json:"id"`
+	UserAgent  string `json:"userAgent"`
+	IPAddress  string `json:"ipAddress"`
+	CreatedAt  string `json:"createdAt"`
+	LastUsedAt string `json:"lastUsedAt"`
+	ExpiresAt  string `json:"expiresAt"`
+	Current    bool   `json:"current"`
+}
+
+func NewSessionHandler(repo *SessionRepository, q *sqlc.Queries) *SessionHandler {
+	return &SessionHandler{repo: repo, q: q}
+}
+
+// GetMine lists the open sessions of the caller, marking the one making the
+// request.
+func (h *SessionHandler) GetMine(c *gin.Context) {
+	userID, ok := ctxkeys.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	current, _ := ctxkeys.SessionID(c)
+	h.list(c, userID, current)
+}
+
+// DeleteMine closes one session of the caller, which may be the current one.
+func (h *SessionHandler) DeleteMine(c *gin.Context) {
+	userID, ok := ctxkeys.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	h.delete(c, userID)
+}
+
+// DeleteOthers closes every session of the caller except the current one.
+func (h *SessionHandler) DeleteOthers(c *gin.Context) {
+	userID, ok := ctxkeys.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	current, ok := ctxkeys.SessionID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Sesión actual desconocida, iniciá sesión nuevamente"))
+		return
+	}
+
+	closed, err := h.repo.DeleteOthers(c.Request.Context(), sqlc.DeleteOtherUserSessionsParams{UserID: userID, KeepID: current})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al cerrar las sesiones", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Success("Sesiones cerradas", &closed))
+}
+
+// GetByUser lists the open sessions of the :userId user.
+func (h *SessionHandler) GetByUser(c *gin.Context) {
+	h.list(c, targetUser(c), pgtype.UUID{})
+}
+
+// DeleteByUser closes one session of the :userId user.
+func (h *SessionHandler) DeleteByUser(c *gin.Context) {
+	h.delete(c, targetUser(c))
+}
+
+// DeleteAllByUser closes every session of the :userId user.
+func (h *SessionHandler) DeleteAllByUser(c *gin.Context) {
+	closed, err := h.repo.DeleteAll(c.Request.Context(), targetUser(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al cerrar las sesiones", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Success("Sesiones cerradas", &closed))
+}
+
+// RequireTarget aborts unless the :userId user belongs to the caller's
+// business and has no more permissions than the caller.
+func (h *SessionHandler) RequireTarget(c *gin.Context) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	roleID, ok := ctxkeys.RoleID(c)
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(c.Param("userId")); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID inválido", err))
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	user, err := h.repo.GetUser(ctx, sqlc.GetUserByIDParams{BusinessID: businessID, ID: id})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.AbortWithStatusJSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Usuario no encontrado"))
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener usuario", err))
+		return
+	}
+
+	if !ctxkeys.IsSuperAdmin(c) {
+		exceeds, err := middleware.RoleExceeds(ctx, h.q, businessID, user.RoleID, roleID)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al verificar permisos", err))
+			return
+		}
+		if exceeds {
+			c.AbortWithStatusJSON(http.StatusForbidden, response.Error(http.StatusForbidden, "No puede gestionar usuarios con más permisos que los propios"))
+			return
+		}
+	}
+
+	c.Set("sessionTarget", user.ID)
+	c.Next()
+}
+
+func (h *SessionHandler) list(c *gin.Context, userID, current pgtype.UUID) {
+	sessions, err := h.repo.GetAll(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener las sesiones", err))
+		return
+	}
+
+	result := make([]SessionResponse, len(sessions))
+	for i, s := range sessions {
+		result[i] = toSessionResponse(s, current)
+	}
+
+	c.JSON(http.StatusOK, response.Success("Sesiones encontradas", &result))
+}
+
+func (h *SessionHandler) delete(c *gin.Context, userID pgtype.UUID) {
+	var id pgtype.UUID
+	if err := id.Scan(c.Param("id")); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID inválido", err))
+		return
+	}
+
+	closed, err := h.repo.Delete(c.Request.Context(), sqlc.DeleteUserSessionParams{ID: id, UserID: userID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al cerrar la sesión", err))
+		return
+	}
+	if closed == 0 {
+		c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Sesión no encontrada"))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Success[any]("Sesión cerrada", nil))
+}
+
+func targetUser(c *gin.Context) pgtype.UUID {
+	return c.MustGet("sessionTarget").(pgtype.UUID)
+}
+
+func toSessionResponse(s sqlc.UserSession, current pgtype.UUID) SessionResponse {
+	return SessionResponse{
+		ID:         uuid.UUID(s.ID.Bytes).String(),
+		UserAgent:  s.UserAgent,
+		IPAddress:  s.IpAddress,
+		CreatedAt:  s.CreatedAt.Time.Format(time.RFC3339),
+		LastUsedAt: s.LastUsedAt.Time.Format(time.RFC3339),
+		ExpiresAt:  s.ExpiresAt.Time.Format(time.RFC3339),
+		Current:    current.Valid && s.ID == current,
+	}
+}
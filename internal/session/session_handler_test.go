@@ -0,0 +1,25 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToSessionResponse(t *testing.T) {
+	id := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	at := pgtype.Timestamptz{Time: time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC), Valid: true}
+	s := sqlc.UserSession{ID: id, UserAgent: "Firefox", IpAddress: "10.0.0.1", CreatedAt: at, LastUsedAt: at, ExpiresAt: at}
+
+	got := toSessionResponse(s, id)
+	assert.True(t, got.Current)
+	assert.Equal(t, uuid.UUID(id.Bytes).String(), got.ID)
+	assert.Equal(t, "2026-03-10T12:00:00Z", got.LastUsedAt)
+
+	assert.False(t, toSessionResponse(s, pgtype.UUID{Bytes: uuid.New(), Valid: true}).Current)
+	assert.False(t, toSessionResponse(s, pgtype.UUID{}).Current)
+}
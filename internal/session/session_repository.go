@@ -0,0 +1,36 @@
+package session
+
+import (
+	"context"
+
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type SessionRepository struct {
+	q *sqlc.Queries
+}
+
+func NewSessionRepository(q *sqlc.Queries) *SessionRepository {
+	return &SessionRepository{q: q}
+}
+
+func (r *SessionRepository) GetAll(ctx context.Context, userID pgtype.UUID) ([]sqlc.UserSession, error) {
+	return r.q.ListUserSessions(ctx, userID)
+}
+
+func (r *SessionRepository) Delete(ctx context.Context, arg sqlc.DeleteUserSessionParams) (int64, error) {
+	return r.q.DeleteUserSession(ctx, arg)
+}
+
+func (r *SessionRepository) DeleteAll(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	return r.q.DeleteUserSessions(ctx, userID)
+}
+
+func (r *SessionRepository) DeleteOthers(ctx context.Context, arg sqlc.DeleteOtherUserSessionsParams) (int64, error) {
+	return r.q.DeleteOtherUserSessions(ctx, arg)
+}
+
+func (r *SessionRepository) GetUser(ctx context.Context, arg sqlc.GetUserByIDParams) (sqlc.GetUserByIDRow, error) {
+	return r.q.GetUserByID(ctx, arg)
+}
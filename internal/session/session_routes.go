@@ -0,0 +1,21 @@
+package session
+
+import (
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/alanloffler/go-calth-api/internal/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(router *gin.RouterGroup, q *sqlc.Queries) {
+	var repo *SessionRepository = NewSessionRepository(q)
+	var handler *SessionHandler = NewSessionHandler(repo, q)
+	var sessions *gin.RouterGroup = router.Group("/sessions", middleware.AccountOwnerMiddleware())
+
+	sessions.GET("", handler.GetMine)
+	sessions.GET("/users/:userId", middleware.PermissionMiddleware(q, "sessions-view"), handler.RequireTarget, handler.GetByUser)
+
+	sessions.DELETE("", handler.DeleteOthers)
+	sessions.DELETE("/:id", handler.DeleteMine)
+	sessions.DELETE("/users/:userId", middleware.PermissionMiddleware(q, "sessions-delete"), handler.RequireTarget, handler.DeleteAllByUser)
+	sessions.DELETE("/users/:userId/:id", middleware.PermissionMiddleware(q, "sessions-delete"), handler.RequireTarget, handler.DeleteByUser)
+}
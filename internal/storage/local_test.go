@@ -0,0 +1,43 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStorage_PutGetDelete(t *testing.T) {
+	store, err := NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	key := "business/medical-histories/abc/file.pdf"
+
+	require.NoError(t, store.Put(ctx, key, strings.NewReader("content"), 7, "application/pdf"))
+
+	rc, err := store.Get(ctx, key)
+	require.NoError(t, err)
+	data, err := io.ReadAll(rc)
+	rc.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "content", string(data))
+
+	require.NoError(t, store.Delete(ctx, key))
+
+	_, err = store.Get(ctx, key)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	assert.NoError(t, store.Delete(ctx, key))
+}
+
+func TestLocalStorage_RejectsTraversal(t *testing.T) {
+	store, err := NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+
+	err = store.Put(context.Background(), "../outside.txt", strings.NewReader("x"), 1, "text/plain")
+	assert.Error(t, err)
+}
@@ -0,0 +1,121 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeS3 is a minimal in-memory stand-in for an S3-compatible server that
+// verifies request signatures with the same credentials as the client.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string]string
+	signer  *S3Storage
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !f.validSignature(r) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		f.objects[r.URL.Path] = string(body)
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		body, ok := f.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		io.WriteString(w, body)
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (f *fakeS3) validSignature(r *http.Request) bool {
+	signedAt, err := time.Parse("20060102T150405Z", r.Header.Get("X-Amz-Date"))
+	if err != nil {
+		return false
+	}
+
+	clone := r.Clone(context.Background())
+	clone.URL.Host = r.Host
+	clone.Header.Del("Authorization")
+	f.signer.sign(clone, signedAt)
+
+	return clone.Header.Get("Authorization") == r.Header.Get("Authorization")
+}
+
+func newTestS3(t *testing.T) (*S3Storage, *fakeS3) {
+	fake := &fakeS3{objects: map[string]string{}}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	store, err := NewS3Storage(S3Options{
+		Endpoint:     server.URL,
+		Region:       "us-east-1",
+		Bucket:       "calth",
+		AccessKey:    "AKIDEXAMPLE",
+		SecretKey:    "secret",
+		UsePathStyle: true,
+	})
+	require.NoError(t, err)
+	fake.signer = store
+
+	return store, fake
+}
+
+func TestS3Storage_PutGetDelete(t *testing.T) {
+	store, fake := newTestS3(t)
+	ctx := context.Background()
+	key := "business/medical-histories/abc/lab result.pdf"
+
+	require.NoError(t, store.Put(ctx, key, strings.NewReader("content"), 7, "application/pdf"))
+	assert.Contains(t, fake.objects, "/calth/"+key)
+
+	rc, err := store.Get(ctx, key)
+	require.NoError(t, err)
+	data, err := io.ReadAll(rc)
+	rc.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "content", string(data))
+
+	require.NoError(t, store.Delete(ctx, key))
+
+	_, err = store.Get(ctx, key)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestS3Storage_InvalidCredentials(t *testing.T) {
+	store, fake := newTestS3(t)
+
+	other, err := NewS3Storage(S3Options{
+		Endpoint:     "http://" + store.endpoint.Host,
+		Bucket:       "calth",
+		AccessKey:    "AKIDEXAMPLE",
+		SecretKey:    "wrong",
+		UsePathStyle: true,
+	})
+	require.NoError(t, err)
+
+	err = other.Put(context.Background(), "key", strings.NewReader("x"), 1, "text/plain")
+	assert.Error(t, err)
+	assert.Empty(t, fake.objects)
+}
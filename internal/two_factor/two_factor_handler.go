@@ -0,0 +1,353 @@
+package two_factor
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/alanloffler/go-calth-api/internal/common/ctxkeys"
+	"github.com/alanloffler/go-calth-api/internal/common/mfa"
+	"github.com/alanloffler/go-calth-api/internal/common/response"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/alanloffler/go-calth-api/internal/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TwoFactorHandler struct {
+	repo    *TwoFactorRepository
+	service *mfa.Service
+	pool    *pgxpool.Pool
+	q       *sqlc.Queries
+}
+
+func NewTwoFactorHandler(repo *TwoFactorRepository, service *mfa.Service, pool *pgxpool.Pool, q *sqlc.Queries) *TwoFactorHandler {
+	return &TwoFactorHandler{repo: repo, service: service, pool: pool, q: q}
+}
+
+type CodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type UpdatePolicyRequest struct {
+	RoleIDs []string `json:"roleIds" binding:"omitempty,dive,uuid"`
+}
+
+type StatusResponse struct {
+	Enabled           bool  `json:"enabled"`
+	Pending           bool  `json:"pending"`
+	Required          bool  `json:"required"`
+	RecoveryCodesLeft int32 `json:"recoveryCodesLeft"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+type PolicyRole struct {
+	ID    pgtype.UUID `json:"id"`
+	Name  string      `json:"name"`
+	Value string      `json:"value"`
+}
+
+type PolicyResponse struct {
+	// RequireAll is businesses.require_two_factor, changed with the business.
+	RequireAll bool         `json:"requireAll"`
+	Roles      []PolicyRole `json:"roles"`
+}
+
+// GetStatus returns the two-factor state of the caller.
+func (h *TwoFactorHandler) GetStatus(c *gin.Context) {
+	userID, ok := ctxkeys.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	status, err := h.service.Status(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener la verificación en dos pasos", err))
+		return
+	}
+
+	result := StatusResponse{
+		Enabled:           status.EnabledAt.Valid,
+		Pending:           status.Secret.Valid && !status.EnabledAt.Valid,
+		Required:          status.Required,
+		RecoveryCodesLeft: status.RecoveryCodesLeft,
+	}
+	c.JSON(http.StatusOK, response.Success("Verificación en dos pasos encontrada", &result))
+}
+
+// Enroll issues a new secret for the caller's authenticator app.
+func (h *TwoFactorHandler) Enroll(c *gin.Context) {
+	userID, ok := ctxkeys.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	enrollment, err := h.service.Enroll(c.Request.Context(), userID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Success("Escaneá el código con tu aplicación de autenticación", &enrollment))
+}
+
+// Enable confirms the enrollment of the caller with a code of the new secret.
+func (h *TwoFactorHandler) Enable(c *gin.Context) {
+	userID, ok := ctxkeys.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	var req CodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Error de validación de datos", err))
+		return
+	}
+
+	codes, err := h.service.Enable(c.Request.Context(), userID, req.Code)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	result := RecoveryCodesResponse{RecoveryCodes: codes}
+	c.JSON(http.StatusOK, response.Success("Verificación en dos pasos activada", &result))
+}
+
+// Disable turns off two-factor for the caller, unless the policy requires it.
+func (h *TwoFactorHandler) Disable(c *gin.Context) {
+	userID, ok := ctxkeys.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	var req CodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Error de validación de datos", err))
+		return
+	}
+
+	if err := h.service.Disable(c.Request.Context(), userID, req.Code); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Success[any]("Verificación en dos pasos desactivada", nil))
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of the caller.
+func (h *TwoFactorHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	userID, ok := ctxkeys.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	var req CodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Error de validación de datos", err))
+		return
+	}
+
+	codes, err := h.service.RegenerateRecoveryCodes(c.Request.Context(), userID, req.Code)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	result := RecoveryCodesResponse{RecoveryCodes: codes}
+	c.JSON(http.StatusOK, response.Success("Códigos de recuperación generados", &result))
+}
+
+// GetPolicy returns which users of the business must use two-factor.
+func (h *TwoFactorHandler) GetPolicy(c *gin.Context) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	h.writePolicy(c, businessID, "Política encontrada")
+}
+
+// UpdatePolicy replaces the roles that must use two-factor in the business.
+// Patients are never required to.
+func (h *TwoFactorHandler) UpdatePolicy(c *gin.Context) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	var req UpdatePolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Error de validación de datos", err))
+		return
+	}
+
+	seen := make(map[string]bool, len(req.RoleIDs))
+	roleIDs := make([]pgtype.UUID, 0, len(req.RoleIDs))
+	for _, raw := range req.RoleIDs {
+		var id pgtype.UUID
+		if err := id.Scan(raw); err != nil {
+			c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID inválido", err))
+			return
+		}
+		if !seen[id.String()] {
+			seen[id.String()] = true
+			roleIDs = append(roleIDs, id)
+		}
+	}
+
+	ctx := c.Request.Context()
+
+	tx, err := h.pool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al iniciar transacción", err))
+		return
+	}
+	defer tx.Rollback(ctx)
+
+	rtx := h.repo.WithTx(tx)
+
+	if err := rtx.DeleteRoles(ctx, businessID); err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al actualizar la política", err))
+		return
+	}
+
+	if len(roleIDs) > 0 {
+		if err := rtx.AddRoles(ctx, sqlc.AddTwoFactorRolesParams{BusinessID: businessID, RoleIds: roleIDs}); err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+				c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Rol no encontrado"))
+				return
+			}
+			c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al actualizar la política", err))
+			return
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al confirmar transacción", err))
+		return
+	}
+
+	h.writePolicy(c, businessID, "Política actualizada")
+}
+
+// Reset removes the two-factor setup of the :userId user, for example after
+// losing the phone and the recovery codes.
+func (h *TwoFactorHandler) Reset(c *gin.Context) {
+	removed, err := h.service.Reset(c.Request.Context(), c.MustGet("twoFactorTarget").(pgtype.UUID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al restablecer la verificación en dos pasos", err))
+		return
+	}
+	if removed == 0 {
+		c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "El usuario no tiene la verificación en dos pasos configurada"))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Success[any]("Verificación en dos pasos restablecida", nil))
+}
+
+// RequireTarget aborts unless the :userId user belongs to the caller's
+// business and has no more permissions than the caller.
+func (h *TwoFactorHandler) RequireTarget(c *gin.Context) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	roleID, ok := ctxkeys.RoleID(c)
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(c.Param("userId")); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID inválido", err))
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	user, err := h.repo.GetUser(ctx, sqlc.GetUserByIDParams{BusinessID: businessID, ID: id})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.AbortWithStatusJSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Usuario no encontrado"))
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener usuario", err))
+		return
+	}
+
+	if !ctxkeys.IsSuperAdmin(c) {
+		exceeds, err := middleware.RoleExceeds(ctx, h.q, businessID, user.RoleID, roleID)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al verificar permisos", err))
+			return
+		}
+		if exceeds {
+			c.AbortWithStatusJSON(http.StatusForbidden, response.Error(http.StatusForbidden, "No puede gestionar usuarios con más permisos que los propios"))
+			return
+		}
+	}
+
+	c.Set("twoFactorTarget", user.ID)
+	c.Next()
+}
+
+func (h *TwoFactorHandler) writePolicy(c *gin.Context, businessID pgtype.UUID, message string) {
+	ctx := c.Request.Context()
+
+	business, err := h.repo.GetBusiness(ctx, businessID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener la política", err))
+		return
+	}
+
+	roles, err := h.repo.GetRoles(ctx, businessID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener la política", err))
+		return
+	}
+
+	result := PolicyResponse{RequireAll: business.RequireTwoFactor, Roles: make([]PolicyRole, len(roles))}
+	for i, r := range roles {
+		result.Roles[i] = PolicyRole{ID: r.ID, Name: r.Name, Value: r.Value}
+	}
+
+	c.JSON(http.StatusOK, response.Success(message, &result))
+}
+
+// writeError maps the errors of Service to responses.
+func writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, mfa.ErrNotStaff):
+		c.JSON(http.StatusForbidden, response.Error(http.StatusForbidden, "La verificación en dos pasos es solo para el personal"))
+	case errors.Is(err, mfa.ErrAlreadyEnabled):
+		c.JSON(http.StatusConflict, response.Error(http.StatusConflict, "La verificación en dos pasos ya está activada"))
+	case errors.Is(err, mfa.ErrNotEnrolled):
+		c.JSON(http.StatusConflict, response.Error(http.StatusConflict, "Primero configurá la aplicación de autenticación"))
+	case errors.Is(err, mfa.ErrNotEnabled):
+		c.JSON(http.StatusConflict, response.Error(http.StatusConflict, "La verificación en dos pasos no está activada"))
+	case errors.Is(err, mfa.ErrRequired):
+		c.JSON(http.StatusForbidden, response.Error(http.StatusForbidden, "Tu negocio exige la verificación en dos pasos"))
+	case errors.Is(err, mfa.ErrInvalidCode):
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Código inválido"))
+	default:
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error en la verificación en dos pasos", err))
+	}
+}
@@ -0,0 +1,41 @@
+package two_factor
+
+import (
+	"context"
+
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type TwoFactorRepository struct {
+	q *sqlc.Queries
+}
+
+func NewTwoFactorRepository(q *sqlc.Queries) *TwoFactorRepository {
+	return &TwoFactorRepository{q: q}
+}
+
+func (r *TwoFactorRepository) WithTx(tx pgx.Tx) *TwoFactorRepository {
+	return &TwoFactorRepository{q: r.q.WithTx(tx)}
+}
+
+func (r *TwoFactorRepository) GetBusiness(ctx context.Context, id pgtype.UUID) (sqlc.Business, error) {
+	return r.q.GetBusiness(ctx, id)
+}
+
+func (r *TwoFactorRepository) GetRoles(ctx context.Context, businessID pgtype.UUID) ([]sqlc.ListTwoFactorRolesRow, error) {
+	return r.q.ListTwoFactorRoles(ctx, businessID)
+}
+
+func (r *TwoFactorRepository) DeleteRoles(ctx context.Context, businessID pgtype.UUID) error {
+	return r.q.DeleteTwoFactorRoles(ctx, businessID)
+}
+
+func (r *TwoFactorRepository) AddRoles(ctx context.Context, arg sqlc.AddTwoFactorRolesParams) error {
+	return r.q.AddTwoFactorRoles(ctx, arg)
+}
+
+func (r *TwoFactorRepository) GetUser(ctx context.Context, arg sqlc.GetUserByIDParams) (sqlc.GetUserByIDRow, error) {
+	return r.q.GetUserByID(ctx, arg)
+}
@@ -0,0 +1,29 @@
+package two_factor
+
+import (
+	"github.com/alanloffler/go-calth-api/internal/common/encryption"
+	"github.com/alanloffler/go-calth-api/internal/common/mfa"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/alanloffler/go-calth-api/internal/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func RegisterRoutes(router *gin.RouterGroup, q *sqlc.Queries, pool *pgxpool.Pool, keys *encryption.Keyring) {
+	var repo *TwoFactorRepository = NewTwoFactorRepository(q)
+	var service *mfa.Service = mfa.NewService(pool, q, keys)
+	var handler *TwoFactorHandler = NewTwoFactorHandler(repo, service, pool, q)
+	var twoFactor *gin.RouterGroup = router.Group("/two-factor", middleware.AccountOwnerMiddleware())
+
+	twoFactor.GET("", handler.GetStatus)
+	twoFactor.GET("/policy", middleware.PermissionMiddleware(q, "two-factor-view"), handler.GetPolicy)
+
+	twoFactor.POST("/enroll", handler.Enroll)
+	twoFactor.POST("/enable", handler.Enable)
+	twoFactor.POST("/disable", handler.Disable)
+	twoFactor.POST("/recovery-codes", handler.RegenerateRecoveryCodes)
+
+	twoFactor.PUT("/policy", middleware.PermissionMiddleware(q, "two-factor-update"), handler.UpdatePolicy)
+
+	twoFactor.DELETE("/users/:userId", middleware.PermissionMiddleware(q, "two-factor-reset"), handler.RequireTarget, handler.Reset)
+}
@@ -67,13 +67,13 @@ func (m *MockUserRepository) Delete(ctx context.Context, arg sqlc.DeleteUserPara
 	return args.Get(0).(int64), args.Error(1)
 }
 
-func (m *MockUserRepository) SoftDelete(ctx context.Context, id pgtype.UUID) (int64, error) {
-	args := m.Called(ctx, id)
+func (m *MockUserRepository) SoftDelete(ctx context.Context, arg sqlc.SoftDeleteUserParams) (int64, error) {
+	args := m.Called(ctx, arg)
 	return args.Get(0).(int64), args.Error(1)
 }
 
-func (m *MockUserRepository) Restore(ctx context.Context, id pgtype.UUID) (int64, error) {
-	args := m.Called(ctx, id)
+func (m *MockUserRepository) Restore(ctx context.Context, arg sqlc.RestoreUserParams) (int64, error) {
+	args := m.Called(ctx, arg)
 	return args.Get(0).(int64), args.Error(1)
 }
 
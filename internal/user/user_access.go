@@ -0,0 +1,88 @@
+package user
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/alanloffler/go-calth-api/internal/common/ctxkeys"
+	"github.com/alanloffler/go-calth-api/internal/common/response"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/alanloffler/go-calth-api/internal/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	roleAdmin        = "admin"
+	rolePatient      = "patient"
+	roleProfessional = "professional"
+)
+
+// permissionPrefixes maps each kind of user to the prefix of its permission
+// keys, e.g. "patients-create".
+var permissionPrefixes = map[string]string{
+	roleAdmin:        "users-admin",
+	rolePatient:      "patients",
+	roleProfessional: "professionals",
+}
+
+func permissionKey(role, action string) string {
+	return permissionPrefixes[role] + "-" + action
+}
+
+// rolePermission checks the permission matching the :role path parameter.
+func rolePermission(q *sqlc.Queries, action string) gin.HandlerFunc {
+	checks := make(map[string]gin.HandlerFunc, len(permissionPrefixes))
+	for role := range permissionPrefixes {
+		checks[role] = middleware.PermissionMiddleware(q, permissionKey(role, action))
+	}
+
+	return func(c *gin.Context) {
+		check, ok := checks[c.Param("role")]
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Rol inválido"))
+			return
+		}
+		check(c)
+	}
+}
+
+// RequireRole aborts with 404 unless the :id user belongs to the caller's
+// business and has the given role. It keeps a permission for one kind of user
+// from being used on another through a mismatched route, e.g. deleting an
+// administrator via /users/:id/patient.
+func (h *UserHandler) RequireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		businessID, ok := ctxkeys.BusinessID(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+			return
+		}
+
+		var id pgtype.UUID
+		if err := id.Scan(c.Param("id")); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID inválido", err))
+			return
+		}
+
+		user, err := h.repo.GetByIDWithSoftDeleted(c.Request.Context(), sqlc.GetUserByIDWithSoftDeletedParams{
+			BusinessID: businessID,
+			ID:         id,
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				c.AbortWithStatusJSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Usuario no encontrado"))
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener usuario", err))
+			return
+		}
+		if user.RoleValue.String != role {
+			c.AbortWithStatusJSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Usuario no encontrado"))
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -53,7 +53,7 @@ func (h *UserHandler) CreateAdmin(c *gin.Context) {
 
 	ctx := c.Request.Context()
 
-	role, err := h.repo.GetQueries().GetRoleByValue(ctx, "admin")
+	role, err := h.repo.GetQueries().GetRoleByValue(ctx, roleAdmin)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Rol de administrador no encontrado", err))
 		return
@@ -75,6 +75,8 @@ func (h *UserHandler) CreateAdmin(c *gin.Context) {
 		return
 	}
 
+	h.sendVerification(ctx, businessID, user.ID)
+
 	c.JSON(http.StatusOK, response.Created("Administrador creado", &user))
 }
 
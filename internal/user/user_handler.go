@@ -7,9 +7,12 @@ import (
 
 	"github.com/alanloffler/go-calth-api/internal/common/ctxkeys"
 	"github.com/alanloffler/go-calth-api/internal/common/response"
+	"github.com/alanloffler/go-calth-api/internal/common/throttle"
 	"github.com/alanloffler/go-calth-api/internal/common/utils"
 	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/alanloffler/go-calth-api/internal/email_verification"
 	"github.com/alanloffler/go-calth-api/internal/patient_profile"
+	"github.com/alanloffler/go-calth-api/internal/patient_summary"
 	"github.com/alanloffler/go-calth-api/internal/professional_profile"
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -22,6 +25,9 @@ type UserHandler struct {
 	pool                    *pgxpool.Pool
 	patientProfileRepo      *patient_profile.PatientProfileRepository
 	professionalProfileRepo *professional_profile.ProfessionalProfileRepository
+	summaryRepo             *patient_summary.PatientSummaryRepository
+	verification            *email_verification.Sender
+	guard                   *throttle.Guard
 }
 
 func NewUserHandler(
@@ -29,8 +35,11 @@ func NewUserHandler(
 	pool *pgxpool.Pool,
 	patientProfileRepo *patient_profile.PatientProfileRepository,
 	professionalProfileRepo *professional_profile.ProfessionalProfileRepository,
+	summaryRepo *patient_summary.PatientSummaryRepository,
+	verification *email_verification.Sender,
+	guard *throttle.Guard,
 ) *UserHandler {
-	return &UserHandler{repo: repo, pool: pool, patientProfileRepo: patientProfileRepo, professionalProfileRepo: professionalProfileRepo}
+	return &UserHandler{repo: repo, pool: pool, patientProfileRepo: patientProfileRepo, professionalProfileRepo: professionalProfileRepo, summaryRepo: summaryRepo, verification: verification, guard: guard}
 }
 
 type CreateUserRequest struct {
@@ -457,13 +466,22 @@ func (h *UserHandler) Delete(c *gin.Context) {
 }
 
 func (h *UserHandler) SoftDelete(c *gin.Context) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
 	var id pgtype.UUID
 	if err := id.Scan(c.Param("id")); err != nil {
 		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID inválido", err))
 		return
 	}
 
-	rows, err := h.repo.SoftDelete(c.Request.Context(), id)
+	rows, err := h.repo.SoftDelete(c.Request.Context(), sqlc.SoftDeleteUserParams{
+		BusinessID: businessID,
+		ID:         id,
+	})
 	if err != nil {
 		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Error al eliminar usuario"))
 		return
@@ -477,13 +495,22 @@ func (h *UserHandler) SoftDelete(c *gin.Context) {
 }
 
 func (h *UserHandler) Restore(c *gin.Context) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
 	var id pgtype.UUID
 	if err := id.Scan(c.Param("id")); err != nil {
 		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID inválido", err))
 		return
 	}
 
-	rows, err := h.repo.Restore(c.Request.Context(), id)
+	rows, err := h.repo.Restore(c.Request.Context(), sqlc.RestoreUserParams{
+		BusinessID: businessID,
+		ID:         id,
+	})
 	if err != nil {
 		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Error al restaurar usuario"))
 		return
@@ -9,7 +9,10 @@ import (
 
 	"github.com/alanloffler/go-calth-api/internal/common/response"
 	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/alanloffler/go-calth-api/internal/middleware"
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -113,3 +116,81 @@ func TestUserHandler_Delete_InvalidID(t *testing.T) {
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
+
+func TestUserHandler_RequireRole(t *testing.T) {
+	tests := []struct {
+		name       string
+		roleValue  string
+		err        error
+		wantStatus int
+	}{
+		{name: "matching role", roleValue: rolePatient, wantStatus: http.StatusOK},
+		{name: "other role", roleValue: roleAdmin, wantStatus: http.StatusNotFound},
+		{name: "other business", err: pgx.ErrNoRows, wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupTestRouter()
+
+			mockRepo := &MockUserRepository{}
+			mockRepo.On("GetByIDWithSoftDeleted", mock.Anything, mock.Anything).Return(sqlc.GetUserByIDWithSoftDeletedRow{
+				RoleValue: pgtype.Text{String: tt.roleValue, Valid: tt.roleValue != ""},
+			}, tt.err)
+
+			handler := &UserHandler{repo: mockRepo}
+			router.DELETE("/users/:id/patient/soft", func(c *gin.Context) {
+				c.Set("businessID", "550e8400-e29b-41d4-a716-446655440001")
+			}, handler.RequireRole(rolePatient), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			req, _ := http.NewRequest("DELETE", "/users/550e8400-e29b-41d4-a716-446655440000/patient/soft", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestUserHandler_UpdateProfile_AccountOwnerOnly(t *testing.T) {
+	tests := []struct {
+		name       string
+		key        string
+		wantStatus int
+	}{
+		{name: "account holder", wantStatus: http.StatusOK},
+		{name: "api key", key: "apiKeyID", wantStatus: http.StatusForbidden},
+		{name: "impersonating superadmin", key: "impersonatorID", wantStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupTestRouter()
+
+			mockRepo := &MockUserRepository{}
+			mockRepo.On("Update", mock.Anything, mock.Anything).Return(int64(1), nil)
+
+			handler := &UserHandler{repo: mockRepo}
+			router.PATCH("/users/profile", func(c *gin.Context) {
+				c.Set("businessID", "550e8400-e29b-41d4-a716-446655440001")
+				c.Set("userID", "550e8400-e29b-41d4-a716-446655440000")
+				if tt.key != "" {
+					c.Set(tt.key, "550e8400-e29b-41d4-a716-446655440002")
+				}
+			}, middleware.AccountOwnerMiddleware(), handler.UpdateProfile)
+
+			body, _ := json.Marshal(map[string]any{"user": map[string]any{"email": "nuevo@example.com", "password": "Nueva#Clave123"}})
+			req, _ := http.NewRequest("PATCH", "/users/profile", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusForbidden {
+				mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
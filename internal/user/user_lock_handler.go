@@ -0,0 +1,47 @@
+package user
+
+import (
+	"net/http"
+
+	"github.com/alanloffler/go-calth-api/internal/common/ctxkeys"
+	"github.com/alanloffler/go-calth-api/internal/common/response"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Unlock lifts the login lockout of the :id user and forgets their failed
+// attempts.
+func (h *UserHandler) Unlock(c *gin.Context) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(c.Param("id")); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID inválido", err))
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	user, err := h.repo.GetByIDWithSoftDeleted(ctx, sqlc.GetUserByIDWithSoftDeletedParams{BusinessID: businessID, ID: id})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener usuario", err))
+		return
+	}
+
+	locked, err := h.guard.Unlock(ctx, businessID, user.Email)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al desbloquear la cuenta", err))
+		return
+	}
+	if !locked {
+		c.JSON(http.StatusConflict, response.Error(http.StatusConflict, "La cuenta no está bloqueada"))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Success[any]("Cuenta desbloqueada", nil))
+}
@@ -2,7 +2,6 @@ package user
 
 import (
 	"fmt"
-	"log"
 	"math"
 	"math/big"
 	"net/http"
@@ -12,6 +11,7 @@ import (
 	"github.com/alanloffler/go-calth-api/internal/common/response"
 	"github.com/alanloffler/go-calth-api/internal/common/utils"
 	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/alanloffler/go-calth-api/internal/patient_summary"
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgtype"
 	"golang.org/x/crypto/bcrypt"
@@ -40,9 +40,9 @@ type UpdatePatientResponse struct {
 }
 
 type CreatePatientProfileData struct {
-	Gender                Gender  `json:"gender" binding:"required"`
-	BirthDay              string  `json:"birthDay" binding:"required"`
-	BloodType             string  `json:"bloodType" binding:"required"`
+	Gender                Gender  `json:"gender" binding:"required,oneof=male female"`
+	BirthDay              string  `json:"birthDay" binding:"required,datetime=2006-01-02"`
+	BloodType             string  `json:"bloodType" binding:"required,oneof=A+ A- B+ B- AB+ AB- O+ O-"`
 	Weight                float64 `json:"weight" binding:"required,gt=0,lt=999.99"`
 	Height                float64 `json:"height" binding:"required,gt=0,lt=300"`
 	EmergencyContactName  string  `json:"emergencyContactName" binding:"required"`
@@ -50,9 +50,9 @@ type CreatePatientProfileData struct {
 }
 
 type UpdatePatientProfileData struct {
-	Gender                *Gender  `json:"gender" binding:"omitempty"`
-	BirthDay              *string  `json:"birthDay" binding:"omitempty"`
-	BloodType             *string  `json:"bloodType" binding:"omitempty"`
+	Gender                *Gender  `json:"gender" binding:"omitempty,oneof=male female"`
+	BirthDay              *string  `json:"birthDay" binding:"omitempty,datetime=2006-01-02"`
+	BloodType             *string  `json:"bloodType" binding:"omitempty,oneof=A+ A- B+ B- AB+ AB- O+ O-"`
 	Weight                *float64 `json:"weight" binding:"omitempty,gt=0,lt=999.99"`
 	Height                *float64 `json:"height" binding:"omitempty,gt=0,lt=300"`
 	EmergencyContactName  *string  `json:"emergencyContactName" binding:"omitempty"`
@@ -60,19 +60,20 @@ type UpdatePatientProfileData struct {
 }
 
 type userWithPatientProfile struct {
-	ID             pgtype.UUID            `json:"id"`
-	Ic             string                 `json:"ic"`
-	UserName       string                 `json:"userName"`
-	FirstName      string                 `json:"firstName"`
-	LastName       string                 `json:"lastName"`
-	Email          string                 `json:"email"`
-	PhoneNumber    string                 `json:"phoneNumber"`
-	Role           *userRole              `json:"role"`
-	BusinessID     pgtype.UUID            `json:"businessId"`
-	CreatedAt      pgtype.Timestamptz     `json:"createdAt"`
-	UpdatedAt      pgtype.Timestamptz     `json:"updatedAt"`
-	DeletedAt      pgtype.Timestamptz     `json:"deletedAt"`
-	PatientProfile patientProfileResponse `json:"patientProfile"`
+	ID              pgtype.UUID              `json:"id"`
+	Ic              string                   `json:"ic"`
+	UserName        string                   `json:"userName"`
+	FirstName       string                   `json:"firstName"`
+	LastName        string                   `json:"lastName"`
+	Email           string                   `json:"email"`
+	PhoneNumber     string                   `json:"phoneNumber"`
+	Role            *userRole                `json:"role"`
+	BusinessID      pgtype.UUID              `json:"businessId"`
+	CreatedAt       pgtype.Timestamptz       `json:"createdAt"`
+	UpdatedAt       pgtype.Timestamptz       `json:"updatedAt"`
+	DeletedAt       pgtype.Timestamptz       `json:"deletedAt"`
+	PatientProfile  patientProfileResponse   `json:"patientProfile"`
+	ClinicalSummary *patient_summary.Summary `json:"clinicalSummary"`
 }
 
 type patientProfileResponse struct {
@@ -106,7 +107,7 @@ func (h *UserHandler) CreatePatient(c *gin.Context) {
 
 	ctx := c.Request.Context()
 
-	role, err := h.repo.GetQueries().GetRoleByValue(ctx, "patient")
+	role, err := h.repo.GetQueries().GetRoleByValue(ctx, rolePatient)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Rol de paciente no encontrado", err))
 		return
@@ -166,7 +167,7 @@ func (h *UserHandler) CreatePatient(c *gin.Context) {
 		return
 	}
 
-	_, err = qtx.CreatePatientProfile(ctx, sqlc.CreatePatientProfileParams{
+	_, err = h.patientProfileRepo.WithTx(tx).Create(ctx, sqlc.CreatePatientProfileParams{
 		BusinessID:            businessID,
 		UserID:                user.ID,
 		Gender:                string(req.Profile.Gender),
@@ -187,6 +188,8 @@ func (h *UserHandler) CreatePatient(c *gin.Context) {
 		return
 	}
 
+	h.sendVerification(ctx, businessID, user.ID)
+
 	c.JSON(http.StatusCreated, response.Created("Usuario creado", &user))
 }
 
@@ -210,7 +213,6 @@ func (h *UserHandler) getPatientByID(c *gin.Context, withSoftDeleted bool) {
 		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID inválido", err))
 		return
 	}
-	log.Println(id)
 
 	ctx := c.Request.Context()
 
@@ -299,7 +301,18 @@ func (h *UserHandler) getPatientByID(c *gin.Context, withSoftDeleted bool) {
 	}
 
 	user.PatientProfile = profResponse
-	log.Print(user)
+
+	if ctxkeys.HasClinicalAccess(c) {
+		summary, err := h.summaryRepo.GetSummary(ctx, sqlc.GetPatientAllergiesByPatientIDParams{
+			BusinessID: businessID,
+			PatientID:  id,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener el resumen clínico", err))
+			return
+		}
+		user.ClinicalSummary = &summary
+	}
 
 	c.JSON(http.StatusOK, response.Success("Paciente encontrado", &user))
 }
@@ -394,7 +407,7 @@ func (h *UserHandler) UpdatePatient(c *gin.Context) {
 		return
 	}
 
-	affected, err := qtx.UpdatePatientProfile(ctx, sqlc.UpdatePatientProfileParams{
+	affected, err := h.patientProfileRepo.WithTx(tx).Update(ctx, sqlc.UpdatePatientProfileParams{
 		BusinessID:            businessID,
 		UserID:                id,
 		Gender:                utils.ToPgText((*string)(req.Profile.Gender)),
@@ -105,7 +105,7 @@ func (h *UserHandler) CreateProfessional(c *gin.Context) {
 
 	ctx := c.Request.Context()
 
-	role, err := h.repo.GetQueries().GetRoleByValue(ctx, "professional")
+	role, err := h.repo.GetQueries().GetRoleByValue(ctx, roleProfessional)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Rol de profesional no encontrado", err))
 		return
@@ -165,6 +165,8 @@ func (h *UserHandler) CreateProfessional(c *gin.Context) {
 		return
 	}
 
+	h.sendVerification(ctx, businessID, user.ID)
+
 	c.JSON(http.StatusCreated, response.Created("Profesional creado", &user))
 }
 
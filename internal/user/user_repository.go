@@ -19,8 +19,8 @@ type UserRepositoryInterface interface {
 	GetByBusinessID(ctx context.Context, businessID pgtype.UUID) ([]sqlc.GetUsersByBusinessIDRow, error)
 	Update(ctx context.Context, arg sqlc.UpdateUserParams) (int64, error)
 	Delete(ctx context.Context, arg sqlc.DeleteUserParams) (int64, error)
-	SoftDelete(ctx context.Context, id pgtype.UUID) (int64, error)
-	Restore(ctx context.Context, id pgtype.UUID) (int64, error)
+	SoftDelete(ctx context.Context, arg sqlc.SoftDeleteUserParams) (int64, error)
+	Restore(ctx context.Context, arg sqlc.RestoreUserParams) (int64, error)
 	CheckIcAvailability(ctx context.Context, arg sqlc.CheckIcAvailabilityParams) (bool, error)
 	CheckEmailAvailability(ctx context.Context, arg sqlc.CheckEmailAvailabilityParams) (bool, error)
 	CheckUsernameAvailability(ctx context.Context, arg sqlc.CheckUsernameAvailabilityParams) (bool, error)
@@ -78,12 +78,12 @@ func (r *UserRepository) Delete(ctx context.Context, arg sqlc.DeleteUserParams)
 	return r.q.DeleteUser(ctx, arg)
 }
 
-func (r *UserRepository) SoftDelete(ctx context.Context, id pgtype.UUID) (int64, error) {
-	return r.q.SoftDeleteUser(ctx, id)
+func (r *UserRepository) SoftDelete(ctx context.Context, arg sqlc.SoftDeleteUserParams) (int64, error) {
+	return r.q.SoftDeleteUser(ctx, arg)
 }
 
-func (r *UserRepository) Restore(ctx context.Context, id pgtype.UUID) (int64, error) {
-	return r.q.RestoreUser(ctx, id)
+func (r *UserRepository) Restore(ctx context.Context, arg sqlc.RestoreUserParams) (int64, error) {
+	return r.q.RestoreUser(ctx, arg)
 }
 
 // Checks
@@ -1,52 +1,74 @@
 package user
 
 import (
+	"github.com/alanloffler/go-calth-api/internal/common/encryption"
+	"github.com/alanloffler/go-calth-api/internal/common/throttle"
+	"github.com/alanloffler/go-calth-api/internal/config"
 	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/alanloffler/go-calth-api/internal/email_verification"
+	"github.com/alanloffler/go-calth-api/internal/middleware"
 	"github.com/alanloffler/go-calth-api/internal/patient_profile"
+	"github.com/alanloffler/go-calth-api/internal/patient_summary"
 	"github.com/alanloffler/go-calth-api/internal/professional_profile"
 	"github.com/gin-gonic/gin"
+	"github.com/hibiken/asynq"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-func RegisterRoutes(router *gin.RouterGroup, q *sqlc.Queries, pool *pgxpool.Pool) {
+func RegisterRoutes(router *gin.RouterGroup, q *sqlc.Queries, pool *pgxpool.Pool, keys *encryption.Keyring, queueClient *asynq.Client, guard *throttle.Guard, cfg *config.Config) {
 	var repo *UserRepository = NewUserRepository(q)
-	var ppRepo *patient_profile.PatientProfileRepository = patient_profile.NewPatientProfileRepository(q)
+	var ppRepo *patient_profile.PatientProfileRepository = patient_profile.NewPatientProfileRepository(q, keys)
 	var prpRepo *professional_profile.ProfessionalProfileRepository = professional_profile.NewProfessionalProfileRepository(q)
-	var handler *UserHandler = NewUserHandler(repo, pool, ppRepo, prpRepo)
+	var summaryRepo *patient_summary.PatientSummaryRepository = patient_summary.NewPatientSummaryRepository(q)
+	var verification *email_verification.Sender = email_verification.NewSender(q, queueClient, cfg)
+	var handler *UserHandler = NewUserHandler(repo, pool, ppRepo, prpRepo, summaryRepo, verification, guard)
 	var users *gin.RouterGroup = router.Group("/users")
 
-	users.POST("/admin", handler.CreateAdmin)
-	users.POST("/patient", handler.CreatePatient)
-	users.POST("/professional", handler.CreateProfessional)
+	var checkPermissions []string
+	for _, role := range []string{roleAdmin, rolePatient, roleProfessional} {
+		checkPermissions = append(checkPermissions, permissionKey(role, "create"), permissionKey(role, "update"))
+	}
 
-	users.GET("", handler.GetAll)
+	users.POST("/admin", middleware.PermissionMiddleware(q, "users-admin-create"), middleware.EscalationMiddleware(q, roleAdmin), handler.CreateAdmin)
+	users.POST("/patient", middleware.PermissionMiddleware(q, "patients-create"), middleware.EscalationMiddleware(q, rolePatient), handler.CreatePatient)
+	users.POST("/professional", middleware.PermissionMiddleware(q, "professionals-create"), middleware.EscalationMiddleware(q, roleProfessional), handler.CreateProfessional)
+
+	// Both listings span every business.
+	users.GET("", middleware.SuperAdminMiddleware(), handler.GetAll)
 	users.GET("/profile", handler.GetProfile)
-	users.GET("/soft", handler.GetAllWithSoftDeleted)
-	users.GET("/role/:role", handler.GetAllByRole)
-	users.GET("/role/:role/soft", handler.GetAllByRoleWithSoftDeleted)
-	users.GET("/:id/admin/profile", handler.GetByID)
-	users.GET("/:id/admin/profile/soft", handler.GetByIDWithSoftDeleted)
-	users.GET("/:id/patient/profile", handler.GetPatientByID)
-	users.GET("/:id/patient/profile/soft", handler.GetPatientByIDWithSoftDeleted)
-	users.GET("/:id/professional/profile", handler.GetProfessionalByID)
-	users.GET("/:id/professional/profile/soft", handler.GetProfessionalByIDWithSoftDeleted)
-
-	users.GET("/check/email/:email", handler.CheckEmailAvailability)
-	users.GET("/check/ic/:ic", handler.CheckIcAvailability)
-	users.GET("/check/username/:userName", handler.CheckUsernameAvailability)
-
-	users.PATCH("/profile", handler.UpdateProfile)
-	users.PATCH("/:id/admin", handler.UpdateAdmin)
-	users.PATCH("/:id/patient", handler.UpdatePatient)
-	users.PATCH("/:id/professional", handler.UpdateProfessional)
-	users.PATCH("/:id/admin/restore", handler.Restore)
-	users.PATCH("/:id/patient/restore", handler.Restore)
-	users.PATCH("/:id/professional/restore", handler.Restore)
-
-	users.DELETE("/:id/admin", handler.Delete)
-	users.DELETE("/:id/admin/soft", handler.SoftDelete)
-	users.DELETE("/:id/patient", handler.Delete)
-	users.DELETE("/:id/patient/soft", handler.SoftDelete)
-	users.DELETE("/:id/professional", handler.Delete)
-	users.DELETE("/:id/professional/soft", handler.SoftDelete)
+	users.GET("/soft", middleware.SuperAdminMiddleware(), handler.GetAllWithSoftDeleted)
+	users.GET("/role/:role", rolePermission(q, "view"), handler.GetAllByRole)
+	users.GET("/role/:role/soft", rolePermission(q, "view"), handler.GetAllByRoleWithSoftDeleted)
+	users.GET("/:id/admin/profile", middleware.PermissionMiddleware(q, "users-admin-view"), handler.RequireRole(roleAdmin), handler.GetByID)
+	users.GET("/:id/admin/profile/soft", middleware.PermissionMiddleware(q, "users-admin-view"), handler.RequireRole(roleAdmin), handler.GetByIDWithSoftDeleted)
+	users.GET("/:id/patient/profile", middleware.PermissionMiddleware(q, "patients-view"), handler.RequireRole(rolePatient), middleware.OwnershipMiddleware(q, "medical_history-all"), middleware.ClinicalAccessMiddleware(q, "id"), middleware.AuditMiddleware(q, "patient_profile", "id", nil), handler.GetPatientByID)
+	users.GET("/:id/patient/profile/soft", middleware.PermissionMiddleware(q, "patients-view"), handler.RequireRole(rolePatient), middleware.OwnershipMiddleware(q, "medical_history-all"), middleware.ClinicalAccessMiddleware(q, "id"), middleware.AuditMiddleware(q, "patient_profile", "id", nil), handler.GetPatientByIDWithSoftDeleted)
+	users.GET("/:id/professional/profile", middleware.PermissionMiddleware(q, "professionals-view"), handler.RequireRole(roleProfessional), handler.GetProfessionalByID)
+	users.GET("/:id/professional/profile/soft", middleware.PermissionMiddleware(q, "professionals-view"), handler.RequireRole(roleProfessional), handler.GetProfessionalByIDWithSoftDeleted)
+
+	users.POST("/:id/admin/verification", middleware.PermissionMiddleware(q, "users-admin-update"), handler.RequireRole(roleAdmin), middleware.EscalationMiddleware(q, roleAdmin), handler.ResendVerification)
+	users.POST("/:id/patient/verification", middleware.PermissionMiddleware(q, "patients-update"), handler.RequireRole(rolePatient), middleware.EscalationMiddleware(q, rolePatient), handler.ResendVerification)
+	users.POST("/:id/professional/verification", middleware.PermissionMiddleware(q, "professionals-update"), handler.RequireRole(roleProfessional), middleware.EscalationMiddleware(q, roleProfessional), handler.ResendVerification)
+
+	users.GET("/check/email/:email", middleware.PermissionMiddleware(q, checkPermissions, middleware.PermissionSome), handler.CheckEmailAvailability)
+	users.GET("/check/ic/:ic", middleware.PermissionMiddleware(q, checkPermissions, middleware.PermissionSome), handler.CheckIcAvailability)
+	users.GET("/check/username/:userName", middleware.PermissionMiddleware(q, checkPermissions, middleware.PermissionSome), handler.CheckUsernameAvailability)
+
+	users.PATCH("/profile", middleware.AccountOwnerMiddleware(), handler.UpdateProfile)
+	users.PATCH("/:id/admin", middleware.PermissionMiddleware(q, "users-admin-update"), handler.RequireRole(roleAdmin), middleware.EscalationMiddleware(q, roleAdmin), handler.UpdateAdmin)
+	users.PATCH("/:id/patient", middleware.PermissionMiddleware(q, "patients-update"), handler.RequireRole(rolePatient), middleware.EscalationMiddleware(q, rolePatient), handler.UpdatePatient)
+	users.PATCH("/:id/professional", middleware.PermissionMiddleware(q, "professionals-update"), handler.RequireRole(roleProfessional), middleware.EscalationMiddleware(q, roleProfessional), handler.UpdateProfessional)
+	users.PATCH("/:id/admin/restore", middleware.PermissionMiddleware(q, "users-admin-restore"), handler.RequireRole(roleAdmin), middleware.EscalationMiddleware(q, roleAdmin), handler.Restore)
+	users.PATCH("/:id/patient/restore", middleware.PermissionMiddleware(q, "patients-restore"), handler.RequireRole(rolePatient), middleware.EscalationMiddleware(q, rolePatient), handler.Restore)
+	users.PATCH("/:id/professional/restore", middleware.PermissionMiddleware(q, "professionals-restore"), handler.RequireRole(roleProfessional), middleware.EscalationMiddleware(q, roleProfessional), handler.Restore)
+
+	users.DELETE("/:id/admin/lock", middleware.PermissionMiddleware(q, "users-admin-update"), handler.RequireRole(roleAdmin), middleware.EscalationMiddleware(q, roleAdmin), handler.Unlock)
+	users.DELETE("/:id/patient/lock", middleware.PermissionMiddleware(q, "patients-update"), handler.RequireRole(rolePatient), middleware.EscalationMiddleware(q, rolePatient), handler.Unlock)
+	users.DELETE("/:id/professional/lock", middleware.PermissionMiddleware(q, "professionals-update"), handler.RequireRole(roleProfessional), middleware.EscalationMiddleware(q, roleProfessional), handler.Unlock)
+	users.DELETE("/:id/admin", middleware.PermissionMiddleware(q, "users-admin-delete-hard"), handler.RequireRole(roleAdmin), middleware.EscalationMiddleware(q, roleAdmin), handler.Delete)
+	users.DELETE("/:id/admin/soft", middleware.PermissionMiddleware(q, "users-admin-delete"), handler.RequireRole(roleAdmin), middleware.EscalationMiddleware(q, roleAdmin), handler.SoftDelete)
+	users.DELETE("/:id/patient", middleware.PermissionMiddleware(q, "patients-delete-hard"), handler.RequireRole(rolePatient), middleware.EscalationMiddleware(q, rolePatient), handler.Delete)
+	users.DELETE("/:id/patient/soft", middleware.PermissionMiddleware(q, "patients-delete"), handler.RequireRole(rolePatient), middleware.EscalationMiddleware(q, rolePatient), handler.SoftDelete)
+	users.DELETE("/:id/professional", middleware.PermissionMiddleware(q, "professionals-delete-hard"), handler.RequireRole(roleProfessional), middleware.EscalationMiddleware(q, roleProfessional), handler.Delete)
+	users.DELETE("/:id/professional/soft", middleware.PermissionMiddleware(q, "professionals-delete"), handler.RequireRole(roleProfessional), middleware.EscalationMiddleware(q, roleProfessional), handler.SoftDelete)
 }
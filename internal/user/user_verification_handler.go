@@ -0,0 +1,57 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/alanloffler/go-calth-api/internal/common/ctxkeys"
+	"github.com/alanloffler/go-calth-api/internal/common/response"
+	"github.com/alanloffler/go-calth-api/internal/common/utils"
+	"github.com/alanloffler/go-calth-api/internal/email_verification"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// sendVerification emails the verification link to a user just created. A
+// failure does not undo the creation: the link can be sent again.
+func (h *UserHandler) sendVerification(ctx context.Context, businessID, userID pgtype.UUID) {
+	if h.verification == nil {
+		return
+	}
+	if err := h.verification.Send(ctx, businessID, userID); err != nil {
+		log.Printf("failed to send email verification: %v", err)
+	}
+}
+
+// ResendVerification emails a new verification link to the :id user.
+func (h *UserHandler) ResendVerification(c *gin.Context) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(c.Param("id")); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID inválido", err))
+		return
+	}
+
+	err := h.verification.Send(c.Request.Context(), businessID, id)
+	if err != nil {
+		var limit *email_verification.ResendLimitError
+		switch {
+		case errors.Is(err, email_verification.ErrAlreadyVerified):
+			c.JSON(http.StatusConflict, response.Error(http.StatusConflict, "El email ya está verificado"))
+		case errors.As(err, &limit):
+			c.JSON(http.StatusTooManyRequests, response.Error(http.StatusTooManyRequests, "Podrás reenviar el email de verificación en "+utils.FormatDuration(limit.Wait)))
+		default:
+			c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al enviar el email de verificación", err))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Success[any]("Email de verificación enviado", nil))
+}
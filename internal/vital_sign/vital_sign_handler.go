@@ -0,0 +1,332 @@
+package vital_sign
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/alanloffler/go-calth-api/internal/common/ctxkeys"
+	"github.com/alanloffler/go-calth-api/internal/common/response"
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	defaultLimit = 50
+	maxLimit     = 500
+	// statsLimit caps how many measurements feed the aggregates of one request.
+	statsLimit = 5000
+	// futureTolerance absorbs clock skew between the client and the server.
+	futureTolerance = 5 * time.Minute
+)
+
+type CreateVitalSignRequest struct {
+	MedicalHistoryID *string    `json:"medicalHistoryId" binding:"omitempty,uuid"`
+	EventID          *string    `json:"eventId" binding:"omitempty,uuid"`
+	MeasuredAt       *time.Time `json:"measuredAt"`
+	WeightKg         *float64   `json:"weightKg" binding:"omitempty,gt=0,lt=1000"`
+	HeightCm         *float64   `json:"heightCm" binding:"omitempty,gt=0,lt=300"`
+	SystolicBp       *int16     `json:"systolicBp" binding:"omitempty,min=30,max=300,required_with=DiastolicBp"`
+	DiastolicBp      *int16     `json:"diastolicBp" binding:"omitempty,min=20,max=200,required_with=SystolicBp"`
+	HeartRate        *int16     `json:"heartRate" binding:"omitempty,min=20,max=300"`
+	TemperatureC     *float64   `json:"temperatureC" binding:"omitempty,min=25,max=45"`
+	Spo2             *int16     `json:"spo2" binding:"omitempty,min=50,max=100"`
+	Notes            string     `json:"notes" binding:"omitempty,max=1000"`
+}
+
+func (r CreateVitalSignRequest) hasMeasurement() bool {
+	return r.WeightKg != nil || r.HeightCm != nil || r.SystolicBp != nil || r.DiastolicBp != nil ||
+		r.HeartRate != nil || r.TemperatureC != nil || r.Spo2 != nil
+}
+
+type VitalSignHandler struct {
+	repo *VitalSignRepository
+	pool *pgxpool.Pool
+}
+
+func NewVitalSignHandler(repo *VitalSignRepository, pool *pgxpool.Pool) *VitalSignHandler {
+	return &VitalSignHandler{repo: repo, pool: pool}
+}
+
+func (h *VitalSignHandler) Create(c *gin.Context) {
+	businessID, patientID, ok := patientParams(c)
+	if !ok {
+		return
+	}
+
+	var req CreateVitalSignRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Error de validación de datos", err))
+		return
+	}
+	if !req.hasMeasurement() {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Debe registrar al menos un signo vital"))
+		return
+	}
+
+	measuredAt := time.Now()
+	if req.MeasuredAt != nil {
+		if req.MeasuredAt.After(measuredAt.Add(futureTolerance)) {
+			c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "La fecha de medición no puede ser futura"))
+			return
+		}
+		measuredAt = *req.MeasuredAt
+	}
+
+	params := sqlc.CreateVitalSignParams{
+		BusinessID:  businessID,
+		PatientID:   patientID,
+		MeasuredAt:  pgtype.Timestamptz{Time: measuredAt, Valid: true},
+		SystolicBp:  optionalInt2(req.SystolicBp),
+		DiastolicBp: optionalInt2(req.DiastolicBp),
+		HeartRate:   optionalInt2(req.HeartRate),
+		Spo2:        optionalInt2(req.Spo2),
+		Notes:       req.Notes,
+	}
+	params.RecordedBy, _ = ctxkeys.UserID(c)
+
+	if req.MedicalHistoryID != nil {
+		if err := params.MedicalHistoryID.Scan(*req.MedicalHistoryID); err != nil {
+			c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID inválido", err))
+			return
+		}
+	}
+	if req.EventID != nil {
+		if err := params.EventID.Scan(*req.EventID); err != nil {
+			c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID inválido", err))
+			return
+		}
+	}
+
+	var err error
+	if params.WeightKg, err = optionalNumeric(req.WeightKg); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Error de validación de datos", err))
+		return
+	}
+	if params.HeightCm, err = optionalNumeric(req.HeightCm); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Error de validación de datos", err))
+		return
+	}
+	if params.TemperatureC, err = optionalNumeric(req.TemperatureC); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Error de validación de datos", err))
+		return
+	}
+
+	if !h.checkPatient(c, businessID, patientID) {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	tx, err := h.pool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al iniciar transacción", err))
+		return
+	}
+	defer tx.Rollback(ctx)
+
+	rtx := h.repo.WithTx(tx)
+
+	sign, err := rtx.Create(ctx, params)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "La historia médica o el turno no pertenecen al paciente"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al registrar los signos vitales", err))
+		return
+	}
+
+	// The profile keeps the latest weight and height for the screens that still read it.
+	if sign.WeightKg.Valid || sign.HeightCm.Valid {
+		if err := rtx.SyncProfileMeasurements(ctx, sqlc.SyncPatientProfileMeasurementsParams{
+			Weight:     sign.WeightKg,
+			MeasuredAt: sign.MeasuredAt,
+			Height:     sign.HeightCm,
+			BusinessID: businessID,
+			UserID:     patientID,
+		}); err != nil {
+			c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al actualizar el perfil del paciente", err))
+			return
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al confirmar transacción", err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.Created("Signos vitales registrados", &sign))
+}
+
+func (h *VitalSignHandler) GetByPatientID(c *gin.Context) {
+	params, ok := rangeParams(c)
+	if !ok {
+		return
+	}
+
+	params.QueryLimit = defaultLimit
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err := strconv.ParseInt(limitStr, 10, 32)
+		if err != nil || limit < 1 || limit > maxLimit {
+			c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Límite inválido", err))
+			return
+		}
+		params.QueryLimit = int32(limit)
+	}
+
+	signs, err := h.repo.GetByPatientID(c.Request.Context(), params)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener los signos vitales", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Success("Signos vitales encontrados", &signs))
+}
+
+func (h *VitalSignHandler) GetStats(c *gin.Context) {
+	params, ok := rangeParams(c)
+	if !ok {
+		return
+	}
+	params.QueryLimit = statsLimit
+
+	signs, err := h.repo.GetByPatientID(c.Request.Context(), params)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener los signos vitales", err))
+		return
+	}
+
+	stats := summarize(signs)
+	c.JSON(http.StatusOK, response.Success("Estadísticas de signos vitales", &stats))
+}
+
+func (h *VitalSignHandler) Delete(c *gin.Context) {
+	businessID, patientID, ok := patientParams(c)
+	if !ok {
+		return
+	}
+
+	var id pgtype.UUID
+	if err := id.Scan(c.Param("itemId")); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID inválido", err))
+		return
+	}
+
+	rows, err := h.repo.Delete(c.Request.Context(), sqlc.SoftDeleteVitalSignParams{
+		BusinessID: businessID,
+		PatientID:  patientID,
+		ID:         id,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al eliminar los signos vitales", err))
+		return
+	}
+	if rows == 0 {
+		c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Signos vitales no encontrados"))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Success[any]("Signos vitales eliminados", nil))
+}
+
+// patientParams reads the business and patient of the request.
+func patientParams(c *gin.Context) (pgtype.UUID, pgtype.UUID, bool) {
+	businessID, ok := ctxkeys.BusinessID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error(http.StatusUnauthorized, "Usuario no autenticado"))
+		return pgtype.UUID{}, pgtype.UUID{}, false
+	}
+
+	var patientID pgtype.UUID
+	if err := patientID.Scan(c.Param("id")); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de ID inválido", err))
+		return pgtype.UUID{}, pgtype.UUID{}, false
+	}
+
+	return businessID, patientID, true
+}
+
+// rangeParams reads the patient and the optional from/to dates of the request.
+// Both dates are whole days; "to" is inclusive.
+func rangeParams(c *gin.Context) (sqlc.GetVitalSignsByPatientIDParams, bool) {
+	businessID, patientID, ok := patientParams(c)
+	if !ok {
+		return sqlc.GetVitalSignsByPatientIDParams{}, false
+	}
+
+	params := sqlc.GetVitalSignsByPatientIDParams{
+		BusinessID: businessID,
+		PatientID:  patientID,
+	}
+
+	if c.Query("from") == "" && c.Query("to") == "" {
+		return params, true
+	}
+
+	loc, err := time.LoadLocation("America/Argentina/Buenos_Aires")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error de zona horaria", err))
+		return params, false
+	}
+
+	if fromStr := c.Query("from"); fromStr != "" {
+		from, err := time.ParseInLocation("2006-01-02", fromStr, loc)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de fecha inválido", err))
+			return params, false
+		}
+		params.MeasuredFrom = pgtype.Timestamptz{Time: from, Valid: true}
+	}
+
+	if toStr := c.Query("to"); toStr != "" {
+		to, err := time.ParseInLocation("2006-01-02", toStr, loc)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, response.Error(http.StatusBadRequest, "Formato de fecha inválido", err))
+			return params, false
+		}
+		params.MeasuredTo = pgtype.Timestamptz{Time: to.AddDate(0, 0, 1), Valid: true}
+	}
+
+	return params, true
+}
+
+// checkPatient makes sure the patient belongs to the business before recording
+// their measurements.
+func (h *VitalSignHandler) checkPatient(c *gin.Context, businessID, patientID pgtype.UUID) bool {
+	patient, err := h.repo.GetPatient(c.Request.Context(), sqlc.GetUserByIDParams{BusinessID: businessID, ID: patientID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Paciente no encontrado"))
+			return false
+		}
+		c.JSON(http.StatusInternalServerError, response.Error(http.StatusInternalServerError, "Error al obtener el paciente", err))
+		return false
+	}
+	if patient.RoleValue.String != "patient" {
+		c.JSON(http.StatusNotFound, response.Error(http.StatusNotFound, "Paciente no encontrado"))
+		return false
+	}
+
+	return true
+}
+
+func optionalInt2(value *int16) pgtype.Int2 {
+	if value == nil {
+		return pgtype.Int2{}
+	}
+	return pgtype.Int2{Int16: *value, Valid: true}
+}
+
+func optionalNumeric(value *float64) (pgtype.Numeric, error) {
+	var n pgtype.Numeric
+	if value == nil {
+		return n, nil
+	}
+	err := n.Scan(strconv.FormatFloat(*value, 'f', -1, 64))
+	return n, err
+}
@@ -0,0 +1,44 @@
+package vital_sign
+
+import (
+	"context"
+
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/jackc/pgx/v5"
+)
+
+type VitalSignRepository struct {
+	q *sqlc.Queries
+}
+
+func NewVitalSignRepository(q *sqlc.Queries) *VitalSignRepository {
+	return &VitalSignRepository{q: q}
+}
+
+func (r *VitalSignRepository) WithTx(tx pgx.Tx) *VitalSignRepository {
+	return &VitalSignRepository{q: r.q.WithTx(tx)}
+}
+
+func (r *VitalSignRepository) GetPatient(ctx context.Context, arg sqlc.GetUserByIDParams) (sqlc.GetUserByIDRow, error) {
+	return r.q.GetUserByID(ctx, arg)
+}
+
+func (r *VitalSignRepository) Create(ctx context.Context, arg sqlc.CreateVitalSignParams) (sqlc.VitalSign, error) {
+	return r.q.CreateVitalSign(ctx, arg)
+}
+
+func (r *VitalSignRepository) GetByPatientID(ctx context.Context, arg sqlc.GetVitalSignsByPatientIDParams) ([]sqlc.VitalSign, error) {
+	items, err := r.q.GetVitalSignsByPatientID(ctx, arg)
+	if items == nil {
+		items = []sqlc.VitalSign{}
+	}
+	return items, err
+}
+
+func (r *VitalSignRepository) Delete(ctx context.Context, arg sqlc.SoftDeleteVitalSignParams) (int64, error) {
+	return r.q.SoftDeleteVitalSign(ctx, arg)
+}
+
+func (r *VitalSignRepository) SyncProfileMeasurements(ctx context.Context, arg sqlc.SyncPatientProfileMeasurementsParams) error {
+	return r.q.SyncPatientProfileMeasurements(ctx, arg)
+}
@@ -0,0 +1,23 @@
+package vital_sign
+
+import (
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/alanloffler/go-calth-api/internal/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func RegisterRoutes(router *gin.RouterGroup, q *sqlc.Queries, pool *pgxpool.Pool) {
+	var repo *VitalSignRepository = NewVitalSignRepository(q)
+	var handler *VitalSignHandler = NewVitalSignHandler(repo, pool)
+	var vitalSigns *gin.RouterGroup = router.Group("/users/:id/patient/vital-signs")
+
+	vitalSigns.Use(middleware.OwnershipMiddleware(q, "medical_history-all"), middleware.PatientScopeMiddleware(q, "id"))
+
+	vitalSigns.POST("", middleware.PermissionMiddleware(q, "medical_history-update"), handler.Create)
+
+	vitalSigns.GET("", middleware.PermissionMiddleware(q, "medical_history-view"), middleware.AuditMiddleware(q, "vital_signs", "id", nil), handler.GetByPatientID)
+	vitalSigns.GET("/stats", middleware.PermissionMiddleware(q, "medical_history-view"), middleware.AuditMiddleware(q, "vital_signs", "id", nil), handler.GetStats)
+
+	vitalSigns.DELETE("/:itemId", middleware.PermissionMiddleware(q, "medical_history-update"), handler.Delete)
+}
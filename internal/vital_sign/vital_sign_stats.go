@@ -0,0 +1,129 @@
+package vital_sign
+
+import (
+	"math"
+	"time"
+
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// daysPerMonth is the average month length used to express growth rates.
+const daysPerMonth = 30.4375
+
+// MetricStats aggregates one measurement over a range. Change and
+// ChangePerMonth compare the first and last measurement, which is what growth
+// tracking looks at.
+type MetricStats struct {
+	Count          int     `json:"count"`
+	Min            float64 `json:"min"`
+	Max            float64 `json:"max"`
+	Avg            float64 `json:"avg"`
+	First          float64 `json:"first"`
+	Last           float64 `json:"last"`
+	Change         float64 `json:"change"`
+	ChangePerMonth float64 `json:"changePerMonth"`
+	FirstAt        string  `json:"firstAt"`
+	LastAt         string  `json:"lastAt"`
+}
+
+// Stats holds one entry per measurement; metrics without data are null.
+type Stats struct {
+	Count        int          `json:"count"`
+	WeightKg     *MetricStats `json:"weightKg"`
+	HeightCm     *MetricStats `json:"heightCm"`
+	Bmi          *MetricStats `json:"bmi"`
+	SystolicBp   *MetricStats `json:"systolicBp"`
+	DiastolicBp  *MetricStats `json:"diastolicBp"`
+	HeartRate    *MetricStats `json:"heartRate"`
+	TemperatureC *MetricStats `json:"temperatureC"`
+	Spo2         *MetricStats `json:"spo2"`
+}
+
+type sample struct {
+	value float64
+	at    time.Time
+}
+
+// summarize computes the stats of a series ordered from newest to oldest, as
+// returned by GetVitalSignsByPatientID.
+func summarize(signs []sqlc.VitalSign) Stats {
+	series := make(map[string][]sample)
+	add := func(metric string, value float64, ok bool, at time.Time) {
+		if ok {
+			series[metric] = append(series[metric], sample{value: value, at: at})
+		}
+	}
+
+	for i := len(signs) - 1; i >= 0; i-- {
+		s := signs[i]
+		at := s.MeasuredAt.Time
+
+		v, ok := numericValue(s.WeightKg)
+		add("weightKg", v, ok, at)
+		v, ok = numericValue(s.HeightCm)
+		add("heightCm", v, ok, at)
+		v, ok = numericValue(s.Bmi)
+		add("bmi", v, ok, at)
+		add("systolicBp", float64(s.SystolicBp.Int16), s.SystolicBp.Valid, at)
+		add("diastolicBp", float64(s.DiastolicBp.Int16), s.DiastolicBp.Valid, at)
+		add("heartRate", float64(s.HeartRate.Int16), s.HeartRate.Valid, at)
+		v, ok = numericValue(s.TemperatureC)
+		add("temperatureC", v, ok, at)
+		add("spo2", float64(s.Spo2.Int16), s.Spo2.Valid, at)
+	}
+
+	return Stats{
+		Count:        len(signs),
+		WeightKg:     aggregate(series["weightKg"]),
+		HeightCm:     aggregate(series["heightCm"]),
+		Bmi:          aggregate(series["bmi"]),
+		SystolicBp:   aggregate(series["systolicBp"]),
+		DiastolicBp:  aggregate(series["diastolicBp"]),
+		HeartRate:    aggregate(series["heartRate"]),
+		TemperatureC: aggregate(series["temperatureC"]),
+		Spo2:         aggregate(series["spo2"]),
+	}
+}
+
+// aggregate expects samples ordered from oldest to newest.
+func aggregate(samples []sample) *MetricStats {
+	if len(samples) == 0 {
+		return nil
+	}
+
+	first, last := samples[0], samples[len(samples)-1]
+	stats := &MetricStats{
+		Count:   len(samples),
+		Min:     first.value,
+		Max:     first.value,
+		First:   first.value,
+		Last:    last.value,
+		Change:  round(last.value - first.value),
+		FirstAt: first.at.Format(time.RFC3339),
+		LastAt:  last.at.Format(time.RFC3339),
+	}
+
+	var sum float64
+	for _, s := range samples {
+		sum += s.value
+		stats.Min = math.Min(stats.Min, s.value)
+		stats.Max = math.Max(stats.Max, s.value)
+	}
+	stats.Avg = round(sum / float64(len(samples)))
+
+	if months := last.at.Sub(first.at).Hours() / 24 / daysPerMonth; months >= 1 {
+		stats.ChangePerMonth = round(stats.Change / months)
+	}
+
+	return stats
+}
+
+func numericValue(n pgtype.Numeric) (float64, bool) {
+	f, err := n.Float64Value()
+	return f.Float64, err == nil && f.Valid
+}
+
+func round(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,69 @@
+package vital_sign
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alanloffler/go-calth-api/internal/database/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testNumeric(t *testing.T, value string) pgtype.Numeric {
+	t.Helper()
+	var n pgtype.Numeric
+	require.NoError(t, n.Scan(value))
+	return n
+}
+
+func testSign(at time.Time) sqlc.VitalSign {
+	return sqlc.VitalSign{MeasuredAt: pgtype.Timestamptz{Time: at, Valid: true}}
+}
+
+func TestSummarize_Empty(t *testing.T) {
+	stats := summarize([]sqlc.VitalSign{})
+
+	assert.Equal(t, 0, stats.Count)
+	assert.Nil(t, stats.WeightKg)
+	assert.Nil(t, stats.Spo2)
+}
+
+func TestSummarize_Growth(t *testing.T) {
+	start := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	oldest := testSign(start)
+	oldest.WeightKg = testNumeric(t, "10.5")
+	oldest.HeightCm = testNumeric(t, "80")
+	oldest.HeartRate = pgtype.Int2{Int16: 110, Valid: true}
+
+	middle := testSign(start.AddDate(0, 1, 0))
+	middle.HeartRate = pgtype.Int2{Int16: 100, Valid: true}
+
+	newest := testSign(start.AddDate(0, 3, 0))
+	newest.WeightKg = testNumeric(t, "12")
+	newest.HeightCm = testNumeric(t, "84.5")
+
+	// Newest first, as the query returns them.
+	stats := summarize([]sqlc.VitalSign{newest, middle, oldest})
+
+	assert.Equal(t, 3, stats.Count)
+
+	require.NotNil(t, stats.WeightKg)
+	assert.Equal(t, 2, stats.WeightKg.Count)
+	assert.Equal(t, 10.5, stats.WeightKg.First)
+	assert.Equal(t, 12.0, stats.WeightKg.Last)
+	assert.Equal(t, 1.5, stats.WeightKg.Change)
+	assert.InDelta(t, 0.5, stats.WeightKg.ChangePerMonth, 0.02)
+	assert.Equal(t, 11.25, stats.WeightKg.Avg)
+
+	require.NotNil(t, stats.HeightCm)
+	assert.Equal(t, 4.5, stats.HeightCm.Change)
+
+	require.NotNil(t, stats.HeartRate)
+	assert.Equal(t, 100.0, stats.HeartRate.Min)
+	assert.Equal(t, 110.0, stats.HeartRate.Max)
+	assert.Equal(t, -10.0, stats.HeartRate.Change)
+
+	assert.Nil(t, stats.SystolicBp)
+}
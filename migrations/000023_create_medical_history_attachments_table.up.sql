@@ -0,0 +1,13 @@
+CREATE TABLE medical_history_attachments (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  business_id UUID NOT NULL REFERENCES businesses (id) ON DELETE CASCADE,
+  medical_history_id UUID NOT NULL REFERENCES medical_histories (id) ON DELETE CASCADE,
+  uploaded_by UUID NOT NULL REFERENCES users (id) ON DELETE RESTRICT,
+  file_name VARCHAR(255) NOT NULL,
+  mime_type VARCHAR(100) NOT NULL,
+  size_bytes BIGINT NOT NULL,
+  storage_key VARCHAR(500) NOT NULL UNIQUE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_mh_attachments_business_mh ON medical_history_attachments (business_id, medical_history_id);
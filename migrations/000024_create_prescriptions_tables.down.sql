@@ -0,0 +1,40 @@
+ALTER TABLE medical_histories
+ADD COLUMN recipe BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE medical_histories mh
+SET
+  recipe = TRUE
+WHERE
+  EXISTS (
+    SELECT
+      1
+    FROM
+      prescriptions pr
+    WHERE
+      pr.medical_history_id = mh.id
+      AND pr.deleted_at IS NULL
+  )
+  OR EXISTS (
+    SELECT
+      1
+    FROM
+      medical_history_legacy_recipes lr
+    WHERE
+      lr.medical_history_id = mh.id
+  );
+
+DROP TABLE IF EXISTS medical_history_legacy_recipes;
+
+DELETE FROM permissions
+WHERE
+  action_key IN (
+    'prescriptions-create',
+    'prescriptions-view',
+    'prescriptions-delete'
+  );
+
+DROP TABLE IF EXISTS prescription_items;
+
+DROP TABLE IF EXISTS prescriptions;
+
+DROP TABLE IF EXISTS prescription_sequences;
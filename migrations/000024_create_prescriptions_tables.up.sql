@@ -0,0 +1,78 @@
+CREATE TABLE prescription_sequences (
+  business_id UUID PRIMARY KEY REFERENCES businesses (id) ON DELETE CASCADE,
+  last_number INT NOT NULL DEFAULT 0
+);
+
+CREATE TABLE prescriptions (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  business_id UUID NOT NULL REFERENCES businesses (id) ON DELETE CASCADE,
+  number INT NOT NULL,
+  medical_history_id UUID NOT NULL REFERENCES medical_histories (id) ON DELETE CASCADE,
+  user_id UUID NOT NULL REFERENCES users (id) ON DELETE RESTRICT,
+  professional_id UUID NOT NULL REFERENCES users (id) ON DELETE RESTRICT,
+  date TIMESTAMPTZ NOT NULL,
+  notes VARCHAR,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  deleted_at TIMESTAMPTZ,
+  CONSTRAINT uq_prescriptions_business_number UNIQUE (business_id, number)
+);
+
+CREATE INDEX idx_prescriptions_business_user ON prescriptions (business_id, user_id, date);
+
+CREATE INDEX idx_prescriptions_medical_history ON prescriptions (medical_history_id);
+
+CREATE TABLE prescription_items (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  prescription_id UUID NOT NULL REFERENCES prescriptions (id) ON DELETE CASCADE,
+  position INT NOT NULL,
+  medication VARCHAR(150) NOT NULL,
+  presentation VARCHAR(100) NOT NULL,
+  dose VARCHAR(100) NOT NULL,
+  frequency VARCHAR(100) NOT NULL,
+  duration_days INT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_prescription_items_prescription ON prescription_items (prescription_id, position);
+
+-- Entries flagged before prescriptions existed keep reporting a prescription.
+CREATE TABLE medical_history_legacy_recipes (
+  medical_history_id UUID PRIMARY KEY REFERENCES medical_histories (id) ON DELETE CASCADE
+);
+
+INSERT INTO
+  medical_history_legacy_recipes (medical_history_id)
+SELECT
+  id
+FROM
+  medical_histories
+WHERE
+  recipe;
+
+ALTER TABLE medical_histories
+DROP COLUMN recipe;
+
+INSERT INTO
+  permissions (name, category, action_key, description)
+VALUES
+  ('Crear', 'prescriptions', 'prescriptions-create', 'Crear recetas'),
+  ('Ver', 'prescriptions', 'prescriptions-view', 'Ver recetas'),
+  ('Anular', 'prescriptions', 'prescriptions-delete', 'Anular recetas')
+ON CONFLICT (action_key) DO NOTHING;
+
+-- Roles that could already work with medical histories get the matching prescription permissions.
+INSERT INTO
+  role_permissions (role_id, permission_id)
+SELECT
+  rp.role_id,
+  np.id
+FROM
+  role_permissions rp
+  JOIN permissions p ON p.id = rp.permission_id
+  JOIN permissions np ON np.action_key = CASE p.action_key
+    WHEN 'medical_history-create' THEN 'prescriptions-create'
+    WHEN 'medical_history-view' THEN 'prescriptions-view'
+    WHEN 'medical_history-delete' THEN 'prescriptions-delete'
+  END
+ON CONFLICT DO NOTHING;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS clinical_record_exports;
@@ -0,0 +1,17 @@
+CREATE TABLE clinical_record_exports (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  business_id UUID NOT NULL REFERENCES businesses (id) ON DELETE CASCADE,
+  patient_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+  requested_by UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+  include_deleted BOOLEAN NOT NULL DEFAULT FALSE,
+  status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (
+    status IN ('pending', 'processing', 'completed', 'failed')
+  ),
+  storage_key VARCHAR(500),
+  error VARCHAR(500),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  completed_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_clinical_record_exports_business_patient ON clinical_record_exports (business_id, patient_id, created_at);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS medical_history_addenda;
+
+DROP TABLE IF EXISTS medical_history_revisions;
@@ -0,0 +1,59 @@
+-- Revisions intentionally have no foreign key to medical_histories so the
+-- trail survives a hard delete.
+CREATE TABLE medical_history_revisions (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  business_id UUID NOT NULL REFERENCES businesses (id) ON DELETE CASCADE,
+  medical_history_id UUID NOT NULL,
+  revision INT NOT NULL,
+  action VARCHAR(20) NOT NULL CHECK (
+    action IN (
+      'create',
+      'update',
+      'addendum',
+      'soft_delete',
+      'restore',
+      'delete'
+    )
+  ),
+  changed_by UUID NOT NULL,
+  changes JSONB NOT NULL DEFAULT '{}',
+  snapshot JSONB,
+  reason VARCHAR(500),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CONSTRAINT uq_mh_revisions_revision UNIQUE (medical_history_id, revision)
+);
+
+CREATE INDEX idx_mh_revisions_business_mh ON medical_history_revisions (business_id, medical_history_id);
+
+CREATE TABLE medical_history_addenda (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  business_id UUID NOT NULL REFERENCES businesses (id) ON DELETE CASCADE,
+  medical_history_id UUID NOT NULL REFERENCES medical_histories (id) ON DELETE CASCADE,
+  author_id UUID NOT NULL REFERENCES users (id) ON DELETE RESTRICT,
+  content VARCHAR NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_mh_addenda_business_mh ON medical_history_addenda (business_id, medical_history_id, created_at);
+
+-- Existing entries get a baseline revision so every history has a starting point.
+INSERT INTO
+  medical_history_revisions (
+    business_id,
+    medical_history_id,
+    revision,
+    action,
+    changed_by,
+    snapshot,
+    created_at
+  )
+SELECT
+  mh.business_id,
+  mh.id,
+  1,
+  'create',
+  mh.professional_id,
+  to_jsonb(mh),
+  mh.created_at
+FROM
+  medical_histories mh;
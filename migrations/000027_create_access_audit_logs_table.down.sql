@@ -0,0 +1,7 @@
+DELETE FROM permissions
+WHERE
+  action_key = 'audit-view';
+
+DROP TABLE IF EXISTS access_audit_logs;
+
+DROP FUNCTION IF EXISTS access_audit_logs_append_only ();
@@ -0,0 +1,56 @@
+-- Audit rows outlive the users, patients and businesses they mention, so the
+-- table has no foreign keys.
+CREATE TABLE access_audit_logs (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  business_id UUID NOT NULL,
+  user_id UUID NOT NULL,
+  role_id UUID NOT NULL,
+  resource VARCHAR(50) NOT NULL,
+  resource_id UUID,
+  patient_id UUID,
+  method VARCHAR(10) NOT NULL,
+  path VARCHAR(255) NOT NULL,
+  status_code INT NOT NULL,
+  ip_address VARCHAR(45) NOT NULL,
+  user_agent VARCHAR(500) NOT NULL DEFAULT '',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_access_audit_business_created ON access_audit_logs (business_id, created_at DESC);
+
+CREATE INDEX idx_access_audit_business_patient ON access_audit_logs (business_id, patient_id, created_at DESC);
+
+CREATE INDEX idx_access_audit_business_user ON access_audit_logs (business_id, user_id, created_at DESC);
+
+CREATE FUNCTION access_audit_logs_append_only () RETURNS trigger AS $$
+BEGIN
+  RAISE EXCEPTION 'access_audit_logs is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_access_audit_logs_no_update BEFORE
+UPDATE
+OR DELETE ON access_audit_logs FOR EACH ROW
+EXECUTE FUNCTION access_audit_logs_append_only ();
+
+CREATE TRIGGER trg_access_audit_logs_no_truncate BEFORE TRUNCATE ON access_audit_logs FOR EACH STATEMENT
+EXECUTE FUNCTION access_audit_logs_append_only ();
+
+INSERT INTO
+  permissions (name, category, action_key, description)
+VALUES
+  ('Ver', 'audit', 'audit-view', 'Ver registros de auditoría de accesos')
+ON CONFLICT (action_key) DO NOTHING;
+
+-- Administrators get the audit permission by default.
+INSERT INTO
+  role_permissions (role_id, permission_id)
+SELECT
+  r.id,
+  p.id
+FROM
+  roles r
+  JOIN permissions p ON p.action_key = 'audit-view'
+WHERE
+  r.value = 'admin'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,16 @@
+-- Per-business data encryption keys, wrapped with the application master key.
+-- The highest version is the active one; older versions are kept so existing
+-- ciphertexts stay readable until the re-encryption job rewrites them.
+CREATE TABLE business_data_keys (
+  business_id UUID NOT NULL REFERENCES businesses (id) ON DELETE CASCADE,
+  version INT NOT NULL,
+  wrapped_key BYTEA NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (business_id, version)
+);
+
+-- Ciphertexts are longer than the plaintext limits the columns used to enforce;
+-- the request validation keeps applying those limits.
+ALTER TABLE patient_profile
+ALTER COLUMN emergency_contact_name TYPE VARCHAR,
+ALTER COLUMN emergency_contact_phone TYPE VARCHAR;
@@ -0,0 +1,3 @@
+DELETE FROM permissions
+WHERE
+  action_key IN ('medical_history-all', 'events-all');
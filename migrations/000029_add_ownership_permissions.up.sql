@@ -0,0 +1,35 @@
+INSERT INTO
+  permissions (name, category, action_key, description)
+VALUES
+  (
+    'Acceso total',
+    'medical_history',
+    'medical_history-all',
+    'Ver y editar historias médicas de cualquier profesional'
+  ),
+  (
+    'Acceso total',
+    'events',
+    'events-all',
+    'Ver y editar turnos de cualquier profesional'
+  )
+ON CONFLICT (action_key) DO NOTHING;
+
+-- Every role except professionals keeps its current business-wide access; professionals
+-- are narrowed to their own histories and events.
+INSERT INTO
+  role_permissions (role_id, permission_id)
+SELECT
+  rp.role_id,
+  np.id
+FROM
+  role_permissions rp
+  JOIN roles r ON r.id = rp.role_id
+  JOIN permissions p ON p.id = rp.permission_id
+  JOIN permissions np ON np.action_key = CASE p.action_key
+    WHEN 'medical_history-view' THEN 'medical_history-all'
+    WHEN 'events-view' THEN 'events-all'
+  END
+WHERE
+  r.value <> 'professional'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,57 @@
+CREATE TABLE patient_allergies (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  business_id UUID NOT NULL REFERENCES businesses (id) ON DELETE CASCADE,
+  patient_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+  substance VARCHAR(150) NOT NULL,
+  reaction VARCHAR(255) NOT NULL DEFAULT '',
+  severity VARCHAR(20) NOT NULL CHECK (
+    severity IN ('mild', 'moderate', 'severe', 'life_threatening')
+  ),
+  created_by UUID REFERENCES users (id) ON DELETE SET NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  deleted_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_patient_allergies_patient ON patient_allergies (business_id, patient_id)
+WHERE
+  deleted_at IS NULL;
+
+CREATE TABLE patient_conditions (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  business_id UUID NOT NULL REFERENCES businesses (id) ON DELETE CASCADE,
+  patient_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+  name VARCHAR(150) NOT NULL,
+  code VARCHAR(20) NOT NULL DEFAULT '',
+  status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'resolved')),
+  onset_date DATE,
+  resolved_date DATE,
+  created_by UUID REFERENCES users (id) ON DELETE SET NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  deleted_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_patient_conditions_patient ON patient_conditions (business_id, patient_id)
+WHERE
+  deleted_at IS NULL;
+
+CREATE TABLE patient_medications (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  business_id UUID NOT NULL REFERENCES businesses (id) ON DELETE CASCADE,
+  patient_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+  name VARCHAR(150) NOT NULL,
+  dose VARCHAR(100) NOT NULL DEFAULT '',
+  frequency VARCHAR(100) NOT NULL DEFAULT '',
+  status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'discontinued')),
+  start_date DATE,
+  end_date DATE,
+  created_by UUID REFERENCES users (id) ON DELETE SET NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  deleted_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_patient_medications_patient ON patient_medications (business_id, patient_id)
+WHERE
+  deleted_at IS NULL;
@@ -0,0 +1,68 @@
+CREATE TABLE vital_signs (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  business_id UUID NOT NULL REFERENCES businesses (id) ON DELETE CASCADE,
+  patient_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+  medical_history_id UUID REFERENCES medical_histories (id) ON DELETE SET NULL,
+  event_id UUID REFERENCES events (id) ON DELETE SET NULL,
+  measured_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  weight_kg NUMERIC(5, 2) CHECK (weight_kg > 0),
+  height_cm NUMERIC(5, 1) CHECK (height_cm > 0),
+  bmi NUMERIC(5, 2) GENERATED ALWAYS AS (
+    CASE
+      WHEN weight_kg IS NOT NULL
+      AND height_cm IS NOT NULL THEN round(weight_kg / ((height_cm / 100) ^ 2), 2)
+    END
+  ) STORED,
+  systolic_bp SMALLINT CHECK (systolic_bp BETWEEN 30 AND 300),
+  diastolic_bp SMALLINT CHECK (diastolic_bp BETWEEN 20 AND 200),
+  heart_rate SMALLINT CHECK (heart_rate BETWEEN 20 AND 300),
+  temperature_c NUMERIC(4, 1) CHECK (temperature_c BETWEEN 25 AND 45),
+  spo2 SMALLINT CHECK (spo2 BETWEEN 50 AND 100),
+  notes TEXT NOT NULL DEFAULT '',
+  recorded_by UUID REFERENCES users (id) ON DELETE SET NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  deleted_at TIMESTAMPTZ,
+  CONSTRAINT chk_vital_signs_measurement CHECK (
+    num_nonnulls(
+      weight_kg,
+      height_cm,
+      systolic_bp,
+      diastolic_bp,
+      heart_rate,
+      temperature_c,
+      spo2
+    ) > 0
+  ),
+  CONSTRAINT chk_vital_signs_blood_pressure CHECK ((systolic_bp IS NULL) = (diastolic_bp IS NULL))
+);
+
+CREATE INDEX idx_vital_signs_patient_measured ON vital_signs (business_id, patient_id, measured_at DESC)
+WHERE
+  deleted_at IS NULL;
+
+-- Seed the series with the measurements already stored on the patient profiles.
+INSERT INTO
+  vital_signs (
+    business_id,
+    patient_id,
+    measured_at,
+    weight_kg,
+    height_cm
+  )
+SELECT
+  business_id,
+  user_id,
+  updated_at,
+  NULLIF(weight, 0),
+  NULLIF(height, 0)
+FROM
+  patient_profile
+WHERE
+  deleted_at IS NULL
+  AND (
+    weight > 0
+    OR height > 0
+  )
+  AND weight < 1000
+  AND height < 10000;
@@ -0,0 +1,3 @@
+DELETE FROM permissions
+WHERE
+  action_key = 'fhir-read';
@@ -0,0 +1,23 @@
+INSERT INTO
+  permissions (name, category, action_key, description)
+VALUES
+  (
+    'Ver',
+    'fhir',
+    'fhir-read',
+    'Consultar pacientes, profesionales, turnos e historias médicas vía FHIR'
+  )
+ON CONFLICT (action_key) DO NOTHING;
+
+-- Administrators get FHIR access by default; other roles are opted in per business.
+INSERT INTO
+  role_permissions (role_id, permission_id)
+SELECT
+  r.id,
+  p.id
+FROM
+  roles r
+  JOIN permissions p ON p.action_key = 'fhir-read'
+WHERE
+  r.value = 'admin'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,10 @@
+DELETE FROM permissions
+WHERE
+  action_key IN (
+    'medical_history_templates-create',
+    'medical_history_templates-view',
+    'medical_history_templates-update',
+    'medical_history_templates-delete'
+  );
+
+DROP TABLE IF EXISTS medical_history_templates;
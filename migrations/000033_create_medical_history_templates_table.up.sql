@@ -0,0 +1,68 @@
+CREATE TABLE medical_history_templates (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  business_id UUID NOT NULL REFERENCES businesses (id) ON DELETE CASCADE,
+  title VARCHAR(100) NOT NULL,
+  -- An empty specialty makes the template available to every professional.
+  specialty VARCHAR NOT NULL DEFAULT '',
+  reason VARCHAR(100) NOT NULL DEFAULT '',
+  comments TEXT NOT NULL DEFAULT '',
+  fields JSONB NOT NULL DEFAULT '[]',
+  created_by UUID REFERENCES users (id) ON DELETE SET NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  deleted_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX uq_mh_templates_business_title ON medical_history_templates (business_id, lower(title))
+WHERE
+  deleted_at IS NULL;
+
+CREATE INDEX idx_mh_templates_business_specialty ON medical_history_templates (business_id, specialty)
+WHERE
+  deleted_at IS NULL;
+
+INSERT INTO
+  permissions (name, category, action_key, description)
+VALUES
+  (
+    'Crear',
+    'medical_history_templates',
+    'medical_history_templates-create',
+    'Crear plantillas de historias médicas'
+  ),
+  (
+    'Ver',
+    'medical_history_templates',
+    'medical_history_templates-view',
+    'Ver plantillas de historias médicas'
+  ),
+  (
+    'Editar',
+    'medical_history_templates',
+    'medical_history_templates-update',
+    'Editar plantillas de historias médicas'
+  ),
+  (
+    'Eliminar',
+    'medical_history_templates',
+    'medical_history_templates-delete',
+    'Eliminar plantillas de historias médicas'
+  )
+ON CONFLICT (action_key) DO NOTHING;
+
+-- Roles that could already work with medical histories get the matching template permissions.
+INSERT INTO
+  role_permissions (role_id, permission_id)
+SELECT
+  rp.role_id,
+  np.id
+FROM
+  role_permissions rp
+  JOIN permissions p ON p.id = rp.permission_id
+  JOIN permissions np ON np.action_key = CASE p.action_key
+    WHEN 'medical_history-create' THEN 'medical_history_templates-create'
+    WHEN 'medical_history-view' THEN 'medical_history_templates-view'
+    WHEN 'medical_history-update' THEN 'medical_history_templates-update'
+    WHEN 'medical_history-delete' THEN 'medical_history_templates-delete'
+  END
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS medical_history_search_tokens;
+
+DROP INDEX IF EXISTS idx_mh_business_user_date;
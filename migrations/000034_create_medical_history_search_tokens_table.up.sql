@@ -0,0 +1,12 @@
+CREATE INDEX idx_mh_business_user_date ON medical_histories (business_id, user_id, date DESC, id DESC);
+
+-- Reason and comments are encrypted, so text search runs against keyed HMAC
+-- tokens of their words instead of the plaintext.
+CREATE TABLE medical_history_search_tokens (
+  medical_history_id UUID PRIMARY KEY REFERENCES medical_histories (id) ON DELETE CASCADE,
+  business_id UUID NOT NULL REFERENCES businesses (id) ON DELETE CASCADE,
+  tokens TEXT[] NOT NULL DEFAULT '{}',
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_mh_search_tokens_tokens ON medical_history_search_tokens USING GIN (tokens);
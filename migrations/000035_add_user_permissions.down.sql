@@ -0,0 +1,22 @@
+DELETE FROM permissions
+WHERE
+  action_key IN (
+    'users-admin-create',
+    'users-admin-view',
+    'users-admin-update',
+    'users-admin-delete',
+    'users-admin-restore',
+    'users-admin-delete-hard',
+    'patients-create',
+    'patients-view',
+    'patients-update',
+    'patients-delete',
+    'patients-restore',
+    'patients-delete-hard',
+    'professionals-create',
+    'professionals-view',
+    'professionals-update',
+    'professionals-delete',
+    'professionals-restore',
+    'professionals-delete-hard'
+  );
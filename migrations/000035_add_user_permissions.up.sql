@@ -0,0 +1,162 @@
+INSERT INTO
+  permissions (name, category, action_key, description)
+VALUES
+  (
+    'Crear',
+    'users-admin',
+    'users-admin-create',
+    'Crear administradores'
+  ),
+  (
+    'Ver',
+    'users-admin',
+    'users-admin-view',
+    'Ver administradores'
+  ),
+  (
+    'Editar',
+    'users-admin',
+    'users-admin-update',
+    'Editar administradores'
+  ),
+  (
+    'Eliminar',
+    'users-admin',
+    'users-admin-delete',
+    'Eliminar administradores'
+  ),
+  (
+    'Restaurar',
+    'users-admin',
+    'users-admin-restore',
+    'Restaurar administradores eliminados'
+  ),
+  (
+    'Eliminar definitivamente',
+    'users-admin',
+    'users-admin-delete-hard',
+    'Eliminar administradores de forma permanente'
+  ),
+  (
+    'Crear',
+    'patients',
+    'patients-create',
+    'Crear pacientes'
+  ),
+  (
+    'Ver',
+    'patients',
+    'patients-view',
+    'Ver pacientes'
+  ),
+  (
+    'Editar',
+    'patients',
+    'patients-update',
+    'Editar pacientes'
+  ),
+  (
+    'Eliminar',
+    'patients',
+    'patients-delete',
+    'Eliminar pacientes'
+  ),
+  (
+    'Restaurar',
+    'patients',
+    'patients-restore',
+    'Restaurar pacientes eliminados'
+  ),
+  (
+    'Eliminar definitivamente',
+    'patients',
+    'patients-delete-hard',
+    'Eliminar pacientes de forma permanente'
+  ),
+  (
+    'Crear',
+    'professionals',
+    'professionals-create',
+    'Crear profesionales'
+  ),
+  (
+    'Ver',
+    'professionals',
+    'professionals-view',
+    'Ver profesionales'
+  ),
+  (
+    'Editar',
+    'professionals',
+    'professionals-update',
+    'Editar profesionales'
+  ),
+  (
+    'Eliminar',
+    'professionals',
+    'professionals-delete',
+    'Eliminar profesionales'
+  ),
+  (
+    'Restaurar',
+    'professionals',
+    'professionals-restore',
+    'Restaurar profesionales eliminados'
+  ),
+  (
+    'Eliminar definitivamente',
+    'professionals',
+    'professionals-delete-hard',
+    'Eliminar profesionales de forma permanente'
+  )
+ON CONFLICT (action_key) DO NOTHING;
+
+-- Administrators manage every kind of user. Professionals register and follow
+-- up their patients and can look up colleagues.
+INSERT INTO
+  role_permissions (role_id, permission_id)
+SELECT
+  r.id,
+  p.id
+FROM
+  roles r
+  JOIN permissions p ON p.action_key IN (
+    'users-admin-create',
+    'users-admin-view',
+    'users-admin-update',
+    'users-admin-delete',
+    'users-admin-restore',
+    'users-admin-delete-hard',
+    'patients-create',
+    'patients-view',
+    'patients-update',
+    'patients-delete',
+    'patients-restore',
+    'patients-delete-hard',
+    'professionals-create',
+    'professionals-view',
+    'professionals-update',
+    'professionals-delete',
+    'professionals-restore',
+    'professionals-delete-hard'
+  )
+WHERE
+  r.value = 'admin'
+ON CONFLICT DO NOTHING;
+
+INSERT INTO
+  role_permissions (role_id, permission_id)
+SELECT
+  r.id,
+  p.id
+FROM
+  roles r
+  JOIN permissions p ON p.action_key IN (
+    'patients-create',
+    'patients-view',
+    'patients-update',
+    'professionals-view'
+  )
+WHERE
+  r.value = 'professional'
+ON CONFLICT DO NOTHING;
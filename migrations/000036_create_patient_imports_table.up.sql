@@ -0,0 +1,21 @@
+CREATE TABLE patient_imports (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  business_id UUID NOT NULL REFERENCES businesses (id) ON DELETE CASCADE,
+  requested_by UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+  file_name VARCHAR(255) NOT NULL,
+  storage_key VARCHAR(500) NOT NULL,
+  status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (
+    status IN ('pending', 'processing', 'completed', 'failed')
+  ),
+  total_rows INT NOT NULL,
+  processed_rows INT NOT NULL DEFAULT 0,
+  imported_rows INT NOT NULL DEFAULT 0,
+  failed_rows INT NOT NULL DEFAULT 0,
+  errors JSONB NOT NULL DEFAULT '[]',
+  error VARCHAR(500),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  completed_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_patient_imports_business_created ON patient_imports (business_id, created_at DESC);
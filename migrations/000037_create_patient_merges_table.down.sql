@@ -0,0 +1,9 @@
+DELETE FROM permissions
+WHERE
+  action_key = 'patients-merge';
+
+DROP TABLE IF EXISTS patient_merges;
+
+DROP INDEX IF EXISTS idx_users_business_phone;
+
+DROP INDEX IF EXISTS idx_users_full_name_trgm;
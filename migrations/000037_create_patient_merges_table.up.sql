@@ -0,0 +1,48 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Duplicate detection compares full names by trigram similarity and looks up
+-- matching phone numbers.
+CREATE INDEX idx_users_full_name_trgm ON users USING GIN (lower(first_name || ' ' || last_name) gin_trgm_ops);
+
+CREATE INDEX idx_users_business_phone ON users (business_id, phone_number);
+
+-- Merge rows outlive the users they mention, so the table has no foreign keys
+-- to them.
+CREATE TABLE patient_merges (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  business_id UUID NOT NULL REFERENCES businesses (id) ON DELETE CASCADE,
+  survivor_id UUID NOT NULL,
+  duplicate_id UUID NOT NULL,
+  merged_by UUID NOT NULL,
+  summary JSONB NOT NULL DEFAULT '{}',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_patient_merges_business_created ON patient_merges (business_id, created_at DESC);
+
+CREATE INDEX idx_patient_merges_business_survivor ON patient_merges (business_id, survivor_id);
+
+CREATE INDEX idx_patient_merges_business_duplicate ON patient_merges (business_id, duplicate_id);
+
+INSERT INTO
+  permissions (name, category, action_key, description)
+VALUES
+  (
+    'Fusionar',
+    'patients',
+    'patients-merge',
+    'Fusionar pacientes duplicados'
+  )
+ON CONFLICT (action_key) DO NOTHING;
+
+INSERT INTO
+  role_permissions (role_id, permission_id)
+SELECT
+  r.id,
+  p.id
+FROM
+  roles r
+  JOIN permissions p ON p.action_key = 'patients-merge'
+WHERE
+  r.value = 'admin'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,9 @@
+-- Medical histories a professional made visible to the patient in the portal.
+CREATE TABLE medical_history_shares (
+  medical_history_id UUID PRIMARY KEY REFERENCES medical_histories (id) ON DELETE CASCADE,
+  business_id UUID NOT NULL REFERENCES businesses (id) ON DELETE CASCADE,
+  shared_by UUID REFERENCES users (id) ON DELETE SET NULL,
+  shared_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_mh_shares_business ON medical_history_shares (business_id);
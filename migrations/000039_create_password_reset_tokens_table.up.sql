@@ -0,0 +1,9 @@
+-- One pending reset per user: requesting a new link replaces the previous one.
+-- Only the SHA-256 of the token is stored.
+CREATE TABLE password_reset_tokens (
+  user_id UUID PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+  business_id UUID NOT NULL REFERENCES businesses (id) ON DELETE CASCADE,
+  token_hash VARCHAR(64) NOT NULL UNIQUE,
+  expires_at TIMESTAMPTZ NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
@@ -0,0 +1,23 @@
+-- Staff invitations. A pending invitation past expires_at is reported as
+-- expired; it is marked so when the email is invited again.
+CREATE TABLE invitations (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  business_id UUID NOT NULL REFERENCES businesses (id) ON DELETE CASCADE,
+  email VARCHAR(100) NOT NULL,
+  role_id UUID NOT NULL REFERENCES roles (id),
+  invited_by UUID REFERENCES users (id) ON DELETE SET NULL,
+  token_hash VARCHAR(64) NOT NULL UNIQUE,
+  status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'revoked', 'expired')),
+  expires_at TIMESTAMPTZ NOT NULL,
+  accepted_user_id UUID REFERENCES users (id) ON DELETE SET NULL,
+  accepted_at TIMESTAMPTZ,
+  revoked_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX uq_invitations_pending_email ON invitations (business_id, email)
+WHERE
+  status = 'pending';
+
+CREATE INDEX idx_invitations_business_created ON invitations (business_id, created_at DESC);
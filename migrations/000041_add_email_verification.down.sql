@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS email_verification_tokens;
+
+ALTER TABLE businesses
+DROP COLUMN IF EXISTS require_verified_email_booking,
+DROP COLUMN IF EXISTS require_verified_email_login;
+
+ALTER TABLE users
+DROP COLUMN IF EXISTS email_verified_at;
@@ -0,0 +1,18 @@
+ALTER TABLE users
+ADD COLUMN email_verified_at TIMESTAMPTZ;
+
+ALTER TABLE businesses
+ADD COLUMN require_verified_email_login BOOLEAN NOT NULL DEFAULT false,
+ADD COLUMN require_verified_email_booking BOOLEAN NOT NULL DEFAULT false;
+
+-- One pending verification per user: resending replaces the token. send_count
+-- counts the emails sent since window_started_at to limit resends.
+CREATE TABLE email_verification_tokens (
+  user_id UUID PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+  business_id UUID NOT NULL REFERENCES businesses (id) ON DELETE CASCADE,
+  token_hash VARCHAR(64) NOT NULL UNIQUE,
+  expires_at TIMESTAMPTZ NOT NULL,
+  send_count INT NOT NULL DEFAULT 1,
+  window_started_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  last_sent_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
@@ -0,0 +1,8 @@
+DELETE FROM permissions
+WHERE
+  action_key IN ('sessions-view', 'sessions-delete');
+
+ALTER TABLE users
+ADD COLUMN refresh_token TEXT;
+
+DROP TABLE IF EXISTS user_sessions;
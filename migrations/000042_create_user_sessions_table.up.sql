@@ -0,0 +1,50 @@
+-- One row per logged in device. Only the SHA-256 of the current refresh token
+-- is stored; every refresh rotates it.
+CREATE TABLE user_sessions (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+  business_id UUID NOT NULL REFERENCES businesses (id) ON DELETE CASCADE,
+  token_hash VARCHAR(64) NOT NULL UNIQUE,
+  user_agent VARCHAR(255) NOT NULL DEFAULT '',
+  ip_address VARCHAR(45) NOT NULL DEFAULT '',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  last_used_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_user_sessions_user ON user_sessions (user_id, last_used_at DESC);
+
+CREATE INDEX idx_user_sessions_expires ON user_sessions (expires_at);
+
+-- Existing refresh tokens are dropped: users log in again once.
+ALTER TABLE users
+DROP COLUMN refresh_token;
+
+INSERT INTO
+  permissions (name, category, action_key, description)
+VALUES
+  (
+    'Ver',
+    'sessions',
+    'sessions-view',
+    'Ver las sesiones abiertas de otros usuarios'
+  ),
+  (
+    'Cerrar',
+    'sessions',
+    'sessions-delete',
+    'Cerrar las sesiones de otros usuarios'
+  )
+ON CONFLICT (action_key) DO NOTHING;
+
+INSERT INTO
+  role_permissions (role_id, permission_id)
+SELECT
+  r.id,
+  p.id
+FROM
+  roles r
+  JOIN permissions p ON p.action_key IN ('sessions-view', 'sessions-delete')
+WHERE
+  r.value = 'admin'
+ON CONFLICT DO NOTHING;
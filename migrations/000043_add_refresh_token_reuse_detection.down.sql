@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS security_events;
+
+DROP TABLE IF EXISTS user_session_rotated_tokens;
@@ -0,0 +1,24 @@
+-- Hashes of the refresh tokens a session already rotated away. Presenting one
+-- again means the token was copied, so the whole session is revoked.
+CREATE TABLE user_session_rotated_tokens (
+  token_hash VARCHAR(64) PRIMARY KEY,
+  session_id UUID NOT NULL REFERENCES user_sessions (id) ON DELETE CASCADE,
+  rotated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_user_session_rotated_tokens_session ON user_session_rotated_tokens (session_id);
+
+-- Like the access audit logs, security events outlive the users and sessions
+-- they mention, so the table has no foreign keys.
+CREATE TABLE security_events (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  business_id UUID NOT NULL,
+  user_id UUID NOT NULL,
+  session_id UUID,
+  event_type VARCHAR(50) NOT NULL,
+  ip_address VARCHAR(45) NOT NULL,
+  user_agent VARCHAR(255) NOT NULL DEFAULT '',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_security_events_business_created ON security_events (business_id, created_at DESC);
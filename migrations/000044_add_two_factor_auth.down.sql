@@ -0,0 +1,14 @@
+DELETE FROM permissions
+WHERE
+  action_key IN ('two-factor-view', 'two-factor-update', 'two-factor-reset');
+
+DROP TABLE IF EXISTS two_factor_challenges;
+
+DROP TABLE IF EXISTS user_recovery_codes;
+
+DROP TABLE IF EXISTS user_two_factor;
+
+DROP TABLE IF EXISTS business_two_factor_roles;
+
+ALTER TABLE businesses
+DROP COLUMN IF EXISTS require_two_factor;
@@ -0,0 +1,83 @@
+ALTER TABLE businesses
+ADD COLUMN require_two_factor BOOLEAN NOT NULL DEFAULT false;
+
+-- Roles whose users must log in with two-factor authentication, on top of
+-- businesses.require_two_factor which covers every staff role.
+CREATE TABLE business_two_factor_roles (
+  business_id UUID NOT NULL REFERENCES businesses (id) ON DELETE CASCADE,
+  role_id UUID NOT NULL REFERENCES roles (id) ON DELETE CASCADE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (business_id, role_id)
+);
+
+-- The TOTP secret is encrypted with the key of the user's business. A row
+-- without enabled_at is an enrollment waiting for its first code.
+-- last_used_step keeps a code from being used twice.
+CREATE TABLE user_two_factor (
+  user_id UUID PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+  business_id UUID NOT NULL REFERENCES businesses (id) ON DELETE CASCADE,
+  secret TEXT NOT NULL,
+  enabled_at TIMESTAMPTZ,
+  last_used_step BIGINT NOT NULL DEFAULT 0,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Single-use codes to log in without the phone. Only their SHA-256 is stored.
+CREATE TABLE user_recovery_codes (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID NOT NULL REFERENCES user_two_factor (user_id) ON DELETE CASCADE,
+  code_hash VARCHAR(64) NOT NULL,
+  used_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  UNIQUE (user_id, code_hash)
+);
+
+-- Second login step: the password was checked and the code is pending.
+CREATE TABLE two_factor_challenges (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+  business_id UUID NOT NULL REFERENCES businesses (id) ON DELETE CASCADE,
+  token_hash VARCHAR(64) NOT NULL UNIQUE,
+  is_super_admin BOOLEAN NOT NULL DEFAULT false,
+  attempts INT NOT NULL DEFAULT 0,
+  expires_at TIMESTAMPTZ NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_two_factor_challenges_expires ON two_factor_challenges (expires_at);
+
+INSERT INTO
+  permissions (name, category, action_key, description)
+VALUES
+  (
+    'Ver',
+    'two_factor',
+    'two-factor-view',
+    'Ver qué roles deben usar la verificación en dos pasos'
+  ),
+  (
+    'Editar',
+    'two_factor',
+    'two-factor-update',
+    'Definir qué roles deben usar la verificación en dos pasos'
+  ),
+  (
+    'Restablecer',
+    'two_factor',
+    'two-factor-reset',
+    'Desactivar la verificación en dos pasos de otros usuarios'
+  )
+ON CONFLICT (action_key) DO NOTHING;
+
+INSERT INTO
+  role_permissions (role_id, permission_id)
+SELECT
+  r.id,
+  p.id
+FROM
+  roles r
+  JOIN permissions p ON p.action_key IN ('two-factor-view', 'two-factor-update', 'two-factor-reset')
+WHERE
+  r.value = 'admin'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,17 @@
+-- Failed and throttled logins. Like the access audit logs, rows outlive the
+-- users and businesses they mention, so the table has no foreign keys.
+CREATE TABLE login_attempts (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  business_id UUID NOT NULL,
+  email VARCHAR(100) NOT NULL,
+  ip_address VARCHAR(45) NOT NULL,
+  user_agent VARCHAR(255) NOT NULL DEFAULT '',
+  reason VARCHAR(30) NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_login_attempts_business_created ON login_attempts (business_id, created_at DESC);
+
+CREATE INDEX idx_login_attempts_business_email ON login_attempts (business_id, email, created_at DESC);
+
+CREATE INDEX idx_login_attempts_created ON login_attempts (created_at);
@@ -0,0 +1,5 @@
+DELETE FROM permissions
+WHERE
+  action_key IN ('api-keys-view', 'api-keys-create', 'api-keys-delete');
+
+DROP TABLE IF EXISTS api_keys;
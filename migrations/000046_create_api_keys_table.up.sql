@@ -0,0 +1,53 @@
+-- Keys for server-to-server integrations. A key acts as the user who created
+-- it, limited to its scopes (permission action keys). Only the SHA-256 of the
+-- key is stored; prefix is the start of the key, kept to tell keys apart.
+CREATE TABLE api_keys (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  business_id UUID NOT NULL REFERENCES businesses (id) ON DELETE CASCADE,
+  user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+  name VARCHAR(100) NOT NULL,
+  prefix VARCHAR(16) NOT NULL,
+  key_hash VARCHAR(64) NOT NULL UNIQUE,
+  scopes TEXT[] NOT NULL,
+  expires_at TIMESTAMPTZ,
+  last_used_at TIMESTAMPTZ,
+  revoked_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_api_keys_business ON api_keys (business_id, created_at DESC);
+
+INSERT INTO
+  permissions (name, category, action_key, description)
+VALUES
+  (
+    'Ver',
+    'api_keys',
+    'api-keys-view',
+    'Ver las claves de API del negocio'
+  ),
+  (
+    'Crear',
+    'api_keys',
+    'api-keys-create',
+    'Crear claves de API para integraciones'
+  ),
+  (
+    'Revocar',
+    'api_keys',
+    'api-keys-delete',
+    'Revocar claves de API'
+  )
+ON CONFLICT (action_key) DO NOTHING;
+
+INSERT INTO
+  role_permissions (role_id, permission_id)
+SELECT
+  r.id,
+  p.id
+FROM
+  roles r
+  JOIN permissions p ON p.action_key IN ('api-keys-view', 'api-keys-create', 'api-keys-delete')
+WHERE
+  r.value = 'admin'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,5 @@
+ALTER TABLE security_events
+DROP COLUMN IF EXISTS impersonator_id;
+
+ALTER TABLE access_audit_logs
+DROP COLUMN IF EXISTS impersonator_id;
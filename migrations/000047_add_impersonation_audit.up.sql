@@ -0,0 +1,7 @@
+-- The superadmin acting as the user, for requests and events that happened
+-- during an impersonation.
+ALTER TABLE access_audit_logs
+ADD COLUMN impersonator_id UUID;
+
+ALTER TABLE security_events
+ADD COLUMN impersonator_id UUID;
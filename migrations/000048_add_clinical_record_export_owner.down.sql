@@ -0,0 +1,2 @@
+ALTER TABLE clinical_record_exports
+DROP COLUMN IF EXISTS owner_id;
@@ -0,0 +1,4 @@
+-- The ownership scope of the requester, so the worker renders only what they
+-- were allowed to see. NULL means the requester had no restriction.
+ALTER TABLE clinical_record_exports
+ADD COLUMN owner_id UUID;
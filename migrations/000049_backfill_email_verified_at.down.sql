@@ -0,0 +1,2 @@
+-- The backfilled timestamps cannot be told apart from real verifications, so
+-- they are kept.
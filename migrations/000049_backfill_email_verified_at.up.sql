@@ -0,0 +1,17 @@
+-- Accounts created before email verification existed were never sent a
+-- verification email, so requiring a verified email would lock them out. Treat
+-- them as verified since their creation. Accounts created since then always
+-- got a token, so an unverified account without one predates the feature.
+UPDATE users u
+SET
+  email_verified_at = u.created_at
+WHERE
+  u.email_verified_at IS NULL
+  AND NOT EXISTS (
+    SELECT
+      1
+    FROM
+      email_verification_tokens t
+    WHERE
+      t.user_id = u.id
+  );